@@ -12,6 +12,7 @@ import (
 	"github.com/checkmarx/ast-cli/internal/kicsshutdown"
 	"github.com/checkmarx/ast-cli/internal/logger"
 	"github.com/checkmarx/ast-cli/internal/params"
+	"github.com/checkmarx/ast-cli/internal/tracing"
 	"github.com/checkmarx/ast-cli/internal/wrappers"
 	"github.com/checkmarx/ast-cli/internal/wrappers/bitbucketserver"
 	"github.com/checkmarx/ast-cli/internal/wrappers/configuration"
@@ -86,6 +87,7 @@ func main() {
 	bitBucketWrapper := wrappers.NewBitbucketWrapper()
 	bitBucketServerWrapper := bitbucketserver.NewBitbucketServerWrapper()
 	gitLabWrapper := wrappers.NewGitLabWrapper()
+	giteaWrapper := wrappers.NewGiteaWrapper()
 	bflWrapper := wrappers.NewBflHTTPWrapper(bfl)
 	prWrapper := wrappers.NewHTTPPRWrapper(prDecorationGithubPath, prDecorationGitlabPath, bitbucketCloudPath, bitbucketServerPath, prDecorationAzurePath)
 	learnMoreWrapper := wrappers.NewHTTPLearnMoreWrapper(descriptionsPath)
@@ -127,6 +129,7 @@ func main() {
 		bitBucketWrapper,
 		bitBucketServerWrapper,
 		gitLabWrapper,
+		giteaWrapper,
 		bflWrapper,
 		prWrapper,
 		learnMoreWrapper,
@@ -146,6 +149,7 @@ func main() {
 	)
 	exitListener()
 	err = astCli.Execute()
+	tracing.EndCommand(err)
 	exitIfError(err)
 	os.Exit(successfulExitCode)
 }
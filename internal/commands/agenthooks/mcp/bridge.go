@@ -193,14 +193,35 @@ and connects automatically once you run 'cx auth login' — no restart needed.`,
 // Kerberos auth types — it reuses the CLI's proxy-aware client (wrappers.GetClient), so a single
 // `cx configure`-based proxy setup covers both the CLI and the MCP. Otherwise it keeps the default
 // transport, which already honors HTTPS_PROXY / HTTP_PROXY / NO_PROXY — so no existing env-var proxy
-// behavior is lost.
+// behavior is lost. The CLI's TLS settings (--ca-cert, --client-cert/--client-key, --insecure)
+// apply on both paths so the bridge can reach gateways that require a private CA or mTLS.
 func newBridgeClient() *http.Client {
 	if strings.TrimSpace(viper.GetString(commonParams.ProxyKey)) != "" {
 		c := wrappers.GetClient(uint(bridgeRequestTimeout / time.Second))
 		c.Timeout = bridgeRequestTimeout // preserve the exact bridge timeout
 		return c
 	}
-	return &http.Client{Timeout: bridgeRequestTimeout}
+	if !hasCustomTLS() {
+		return &http.Client{Timeout: bridgeRequestTimeout}
+	}
+	tlsConfig, err := wrappers.NewTLSConfig()
+	if err != nil {
+		// Never exit the bridge over a bad TLS setting; report it on STDERR and let the
+		// handshake fail if the server really requires it.
+		fmt.Fprintln(os.Stderr, "cx mcp bridge: ignoring TLS configuration: "+err.Error())
+		return &http.Client{Timeout: bridgeRequestTimeout}
+	}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = tlsConfig
+	return &http.Client{Timeout: bridgeRequestTimeout, Transport: transport}
+}
+
+// hasCustomTLS reports whether any CLI TLS setting differs from Go's defaults.
+func hasCustomTLS() bool {
+	return viper.GetBool(commonParams.InsecureFlag) ||
+		strings.TrimSpace(viper.GetString(commonParams.CACertKey)) != "" ||
+		strings.TrimSpace(viper.GetString(commonParams.ClientCertKey)) != "" ||
+		strings.TrimSpace(viper.GetString(commonParams.ClientKeyKey)) != ""
 }
 
 func runBridge(version, urlOverride string) error {
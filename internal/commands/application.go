@@ -0,0 +1,501 @@
+package commands
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/MakeNowJust/heredoc"
+	"github.com/checkmarx/ast-cli/internal/commands/util/printer"
+	errorConstants "github.com/checkmarx/ast-cli/internal/constants/errors"
+	commonParams "github.com/checkmarx/ast-cli/internal/params"
+	"github.com/checkmarx/ast-cli/internal/services"
+	"github.com/checkmarx/ast-cli/internal/wrappers"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+const (
+	failedGettingApplications = "Failed getting applications"
+	failedCreatingApplication = "Failed creating an application"
+	failedUpdatingApplication = "Failed updating an application"
+	failedDeletingApplication = "Failed deleting an application"
+	defaultCriticality        = 3
+)
+
+// applicationRuleTypes maps the short rule types accepted by --rule to the rule types of the applications API.
+// Tag rules with a value, tag=key:value, use the key-value rule type.
+var applicationRuleTypes = map[string]string{
+	"tag":           "project.tag.key.exists",
+	"name":          services.ApplicationRuleType,
+	"name-prefix":   "project.name.starts-with",
+	"name-contains": "project.name.contains",
+	"name-regex":    "project.name.regex",
+}
+
+const tagKeyValueRuleType = "project.tag.key-value.exists"
+
+var (
+	filterApplicationsListFlagUsage = fmt.Sprintf(
+		"Filter the list of applications. Use ';' as the delimeter for arrays. Available filters are: %s",
+		strings.Join(
+			[]string{
+				commonParams.LimitQueryParam,
+				commonParams.OffsetQueryParam,
+				commonParams.NameQueryParam,
+				commonParams.TagsKeyQueryParam,
+				commonParams.TagsValueQueryParam,
+			}, ",",
+		),
+	)
+	applicationRuleFlagUsage = "Rule associating projects with the application, in the <type>=<value> format. " +
+		"Available types are: tag (key or key:value), name (';' separated names), name-prefix, name-contains, name-regex"
+)
+
+func NewApplicationCommand(
+	applicationsWrapper wrappers.ApplicationsWrapper,
+	projectsWrapper wrappers.ProjectsWrapper,
+	featureFlagsWrapper wrappers.FeatureFlagsWrapper,
+	tenantWrapper wrappers.TenantConfigurationWrapper,
+) *cobra.Command {
+	applicationCmd := &cobra.Command{
+		Use:   "application",
+		Short: "Manage applications",
+		Long:  "The application command enables the ability to manage applications and the projects associated with them in Checkmarx One",
+	}
+
+	listApplicationsCmd := &cobra.Command{
+		Use:   "list",
+		Short: "List all applications in the system",
+		Example: heredoc.Doc(
+			`
+			$ cx application list --format json --filter name=payments
+		`,
+		),
+		RunE: runListApplicationsCommand(applicationsWrapper),
+	}
+	listApplicationsCmd.PersistentFlags().StringSlice(commonParams.FilterFlag, []string{}, filterApplicationsListFlagUsage)
+
+	showApplicationCmd := &cobra.Command{
+		Use:   "show",
+		Short: "Show information about an application",
+		Example: heredoc.Doc(
+			`
+			$ cx application show --application-name <Application Name>
+		`,
+		),
+		RunE: runShowApplicationCommand(applicationsWrapper),
+	}
+	addApplicationNameFlag(showApplicationCmd, "Name of the application to show")
+
+	createApplicationCmd := &cobra.Command{
+		Use:   "create",
+		Short: "Creates a new application",
+		Example: heredoc.Doc(
+			`
+			$ cx application create --application-name <Application Name> --criticality 4 --rule tag=team:payments --rule name-prefix=payments-
+		`,
+		),
+		RunE: runCreateApplicationCommand(applicationsWrapper),
+	}
+	addApplicationNameFlag(createApplicationCmd, "Name of the application to create")
+	createApplicationCmd.PersistentFlags().String(commonParams.ApplicationDescriptionFlag, "", "Description of the application")
+	createApplicationCmd.PersistentFlags().Int(commonParams.CriticalityFlag, defaultCriticality, "Criticality of the application, from 1 (lowest) to 5 (highest)")
+	createApplicationCmd.PersistentFlags().String(commonParams.TagList, "", "List of tags, ex: (tagA,tagB:val,etc)")
+	createApplicationCmd.PersistentFlags().StringArray(commonParams.ApplicationRuleFlag, []string{}, applicationRuleFlagUsage)
+
+	updateApplicationCmd := &cobra.Command{
+		Use:   "update",
+		Short: "Updates an application",
+		Long:  "The application update command changes only the fields of the given flags",
+		Example: heredoc.Doc(
+			`
+			$ cx application update --application-name <Application Name> --new-name <New Name> --add-rule tag=team:payments
+		`,
+		),
+		RunE: runUpdateApplicationCommand(applicationsWrapper),
+	}
+	addApplicationNameFlag(updateApplicationCmd, "Name of the application to update")
+	updateApplicationCmd.PersistentFlags().String(commonParams.NewApplicationNameFlag, "", "New name of the application")
+	updateApplicationCmd.PersistentFlags().String(commonParams.ApplicationDescriptionFlag, "", "Description of the application")
+	updateApplicationCmd.PersistentFlags().Int(commonParams.CriticalityFlag, 0, "Criticality of the application, from 1 (lowest) to 5 (highest)")
+	updateApplicationCmd.PersistentFlags().String(commonParams.AddTagsFlag, "", "List of tags to add or change, ex: (tagA,tagB:val,etc)")
+	updateApplicationCmd.PersistentFlags().String(commonParams.RemoveTagsFlag, "", "List of tags to remove, ex: (tagA,tagB:val,etc)")
+	updateApplicationCmd.PersistentFlags().StringArray(commonParams.AddApplicationRuleFlag, []string{}, applicationRuleFlagUsage)
+	updateApplicationCmd.PersistentFlags().StringArray(commonParams.RemoveApplicationRuleFlag, []string{},
+		"Rule to remove, given by its ID or in the same format as --add-rule")
+
+	deleteApplicationCmd := &cobra.Command{
+		Use:   "delete",
+		Short: "Delete an application",
+		Example: heredoc.Doc(
+			`
+			$ cx application delete --application-name <Application Name>
+		`,
+		),
+		RunE: runDeleteApplicationCommand(applicationsWrapper),
+	}
+	addApplicationNameFlag(deleteApplicationCmd, "Name of the application to delete")
+
+	addProjectCmd := &cobra.Command{
+		Use:   "add-project",
+		Short: "Associate a project with an application",
+		Example: heredoc.Doc(
+			`
+			$ cx application add-project --application-name <Application Name> --project-id <project_id>
+		`,
+		),
+		RunE: runAddProjectToApplicationCommand(applicationsWrapper, projectsWrapper, featureFlagsWrapper, tenantWrapper),
+	}
+	addApplicationNameFlag(addProjectCmd, "Name of the application")
+	addProjectIDFlag(addProjectCmd, "Project ID to associate with the application")
+
+	removeProjectCmd := &cobra.Command{
+		Use:   "remove-project",
+		Short: "Remove the association of a project with an application",
+		Example: heredoc.Doc(
+			`
+			$ cx application remove-project --application-name <Application Name> --project-id <project_id>
+		`,
+		),
+		RunE: runRemoveProjectFromApplicationCommand(applicationsWrapper, projectsWrapper, featureFlagsWrapper, tenantWrapper),
+	}
+	addApplicationNameFlag(removeProjectCmd, "Name of the application")
+	addProjectIDFlag(removeProjectCmd, "Project ID to remove from the application")
+
+	addFormatFlagToMultipleCommands(
+		[]*cobra.Command{listApplicationsCmd, showApplicationCmd, createApplicationCmd},
+		printer.FormatTable,
+		printer.FormatJSON,
+		printer.FormatList,
+	)
+	applicationCmd.AddCommand(listApplicationsCmd, showApplicationCmd, createApplicationCmd, updateApplicationCmd, deleteApplicationCmd,
+		addProjectCmd, removeProjectCmd)
+	return applicationCmd
+}
+
+func addApplicationNameFlag(cmd *cobra.Command, helpMsg string) {
+	cmd.PersistentFlags().String(commonParams.ApplicationName, "", helpMsg)
+	_ = cmd.MarkPersistentFlagRequired(commonParams.ApplicationName)
+}
+
+func runListApplicationsCommand(applicationsWrapper wrappers.ApplicationsWrapper) func(cmd *cobra.Command, args []string) error {
+	return func(cmd *cobra.Command, args []string) error {
+		params, err := getFilters(cmd)
+		if err != nil {
+			return errors.Wrapf(err, "%s", failedGettingApplications)
+		}
+		applications, err := applicationsWrapper.Get(params)
+		if err != nil {
+			return errors.Wrapf(err, "%s", failedGettingApplications)
+		}
+		if applications == nil {
+			return errors.Errorf("%s", failedGettingApplications)
+		}
+		return printByFormat(cmd, toApplicationViews(applications.Applications))
+	}
+}
+
+func runShowApplicationCommand(applicationsWrapper wrappers.ApplicationsWrapper) func(cmd *cobra.Command, args []string) error {
+	return func(cmd *cobra.Command, args []string) error {
+		application, err := getApplicationByName(cmd, applicationsWrapper)
+		if err != nil {
+			return err
+		}
+		return printByFormat(cmd, toApplicationView(application))
+	}
+}
+
+func runCreateApplicationCommand(applicationsWrapper wrappers.ApplicationsWrapper) func(cmd *cobra.Command, args []string) error {
+	return func(cmd *cobra.Command, args []string) error {
+		name, _ := cmd.Flags().GetString(commonParams.ApplicationName)
+		description, _ := cmd.Flags().GetString(commonParams.ApplicationDescriptionFlag)
+		criticality, _ := cmd.Flags().GetInt(commonParams.CriticalityFlag)
+		tags, _ := cmd.Flags().GetString(commonParams.TagList)
+		ruleSpecs, _ := cmd.Flags().GetStringArray(commonParams.ApplicationRuleFlag)
+		if strings.TrimSpace(name) == "" {
+			return errors.Errorf("%s: Please provide an application name", failedCreatingApplication)
+		}
+		if criticality < minCriticality || criticality > maxCriticality {
+			return errors.Errorf("%s: Criticality must be between %d and %d", failedCreatingApplication, minCriticality, maxCriticality)
+		}
+		rules, err := parseApplicationRules(ruleSpecs)
+		if err != nil {
+			return errors.Wrapf(err, "%s", failedCreatingApplication)
+		}
+
+		applicationModel := wrappers.ApplicationConfiguration{
+			Name:        name,
+			Description: description,
+			Criticality: criticality,
+			Rules:       rules,
+			Tags:        services.CreateTagMap(tags),
+		}
+		application, errorModel, err := applicationsWrapper.Create(&applicationModel)
+		if err != nil {
+			return errors.Wrapf(err, "%s", failedCreatingApplication)
+		}
+		if errorModel != nil {
+			return errors.Errorf(services.ErrorCodeFormat, failedCreatingApplication, errorModel.Code, errorModel.Message)
+		}
+		return printByFormat(cmd, toApplicationView(application))
+	}
+}
+
+func runUpdateApplicationCommand(applicationsWrapper wrappers.ApplicationsWrapper) func(cmd *cobra.Command, args []string) error {
+	return func(cmd *cobra.Command, args []string) error {
+		application, err := getApplicationByName(cmd, applicationsWrapper)
+		if err != nil {
+			return err
+		}
+		applicationModel, err := updatedApplicationConfiguration(cmd, application)
+		if err != nil {
+			return errors.Wrapf(err, "%s", failedUpdatingApplication)
+		}
+		errorModel, err := applicationsWrapper.Update(application.ID, applicationModel)
+		if err != nil {
+			return errors.Wrapf(err, "%s", failedUpdatingApplication)
+		}
+		if errorModel != nil {
+			return errors.Errorf(services.ErrorCodeFormat, failedUpdatingApplication, errorModel.Code, errorModel.Message)
+		}
+		fmt.Fprintf(cmd.OutOrStdout(), "Updated application %s (%s)\n", applicationModel.Name, application.ID)
+		return nil
+	}
+}
+
+func runDeleteApplicationCommand(applicationsWrapper wrappers.ApplicationsWrapper) func(cmd *cobra.Command, args []string) error {
+	return func(cmd *cobra.Command, args []string) error {
+		application, err := getApplicationByName(cmd, applicationsWrapper)
+		if err != nil {
+			return err
+		}
+		errorModel, err := applicationsWrapper.Delete(application.ID)
+		if err != nil {
+			return errors.Wrapf(err, "%s", failedDeletingApplication)
+		}
+		if errorModel != nil {
+			return errors.Errorf(services.ErrorCodeFormat, failedDeletingApplication, errorModel.Code, errorModel.Message)
+		}
+		fmt.Fprintf(cmd.OutOrStdout(), "Deleted application %s (%s)\n", application.Name, application.ID)
+		return nil
+	}
+}
+
+func runAddProjectToApplicationCommand(
+	applicationsWrapper wrappers.ApplicationsWrapper,
+	projectsWrapper wrappers.ProjectsWrapper,
+	featureFlagsWrapper wrappers.FeatureFlagsWrapper,
+	tenantWrapper wrappers.TenantConfigurationWrapper,
+) func(cmd *cobra.Command, args []string) error {
+	return func(cmd *cobra.Command, args []string) error {
+		application, err := getApplicationByName(cmd, applicationsWrapper)
+		if err != nil {
+			return err
+		}
+		project, err := getApplicationProject(cmd, projectsWrapper)
+		if err != nil {
+			return err
+		}
+		err = services.AddProjectToApplication(application.Name, project.Name, project.ID, applicationsWrapper, featureFlagsWrapper, tenantWrapper)
+		if err != nil {
+			return errors.Wrapf(err, "%s", failedUpdatingApplication)
+		}
+		fmt.Fprintf(cmd.OutOrStdout(), "Added project %s to application %s\n", project.Name, application.Name)
+		return nil
+	}
+}
+
+func runRemoveProjectFromApplicationCommand(
+	applicationsWrapper wrappers.ApplicationsWrapper,
+	projectsWrapper wrappers.ProjectsWrapper,
+	featureFlagsWrapper wrappers.FeatureFlagsWrapper,
+	tenantWrapper wrappers.TenantConfigurationWrapper,
+) func(cmd *cobra.Command, args []string) error {
+	return func(cmd *cobra.Command, args []string) error {
+		application, err := getApplicationByName(cmd, applicationsWrapper)
+		if err != nil {
+			return err
+		}
+		project, err := getApplicationProject(cmd, projectsWrapper)
+		if err != nil {
+			return err
+		}
+		if !slices.Contains(application.ProjectIds, project.ID) {
+			return errors.Errorf("%s: Project %s is not associated with the application %s", failedUpdatingApplication, project.Name, application.Name)
+		}
+		err = services.RemoveProjectFromApplication(application, project.Name, project.ID, applicationsWrapper, featureFlagsWrapper, tenantWrapper)
+		if err != nil {
+			return errors.Wrapf(err, "%s", failedUpdatingApplication)
+		}
+		fmt.Fprintf(cmd.OutOrStdout(), "Removed project %s from application %s\n", project.Name, application.Name)
+		return nil
+	}
+}
+
+func getApplicationByName(cmd *cobra.Command, applicationsWrapper wrappers.ApplicationsWrapper) (*wrappers.Application, error) {
+	name, _ := cmd.Flags().GetString(commonParams.ApplicationName)
+	application, err := services.GetApplication(name, applicationsWrapper)
+	if err != nil {
+		return nil, err
+	}
+	if application == nil {
+		return nil, errors.Errorf("%s: %s", errorConstants.ApplicationNotFound, name)
+	}
+	return application, nil
+}
+
+func getApplicationProject(cmd *cobra.Command, projectsWrapper wrappers.ProjectsWrapper) (*wrappers.ProjectResponseModel, error) {
+	projectID, _ := cmd.Flags().GetString(commonParams.ProjectIDFlag)
+	if strings.TrimSpace(projectID) == "" {
+		return nil, errors.Errorf("%s: Please provide a project ID", failedUpdatingApplication)
+	}
+	project, errorModel, err := projectsWrapper.GetByID(projectID)
+	if err != nil {
+		return nil, errors.Wrapf(err, "%s", services.FailedGettingProj)
+	}
+	if errorModel != nil {
+		return nil, errors.Errorf(services.ErrorCodeFormat, services.FailedGettingProj, errorModel.Code, errorModel.Message)
+	}
+	return project, nil
+}
+
+// updatedApplicationConfiguration returns the application with the changes of the given flags applied, keeping the
+// fields that were not passed.
+func updatedApplicationConfiguration(cmd *cobra.Command, application *wrappers.Application) (*wrappers.ApplicationConfiguration, error) {
+	applicationModel := &wrappers.ApplicationConfiguration{
+		Name:        application.Name,
+		Description: application.Description,
+		Type:        application.Type,
+		Criticality: application.Criticality,
+		Rules:       slices.Clone(application.Rules),
+		Tags:        maps.Clone(application.Tags),
+	}
+	if applicationModel.Tags == nil {
+		applicationModel.Tags = map[string]string{}
+	}
+	if cmd.Flags().Changed(commonParams.NewApplicationNameFlag) {
+		applicationModel.Name, _ = cmd.Flags().GetString(commonParams.NewApplicationNameFlag)
+		if strings.TrimSpace(applicationModel.Name) == "" {
+			return nil, errors.New("Please provide a new name for the application")
+		}
+	}
+	if cmd.Flags().Changed(commonParams.ApplicationDescriptionFlag) {
+		applicationModel.Description, _ = cmd.Flags().GetString(commonParams.ApplicationDescriptionFlag)
+	}
+	if cmd.Flags().Changed(commonParams.CriticalityFlag) {
+		applicationModel.Criticality, _ = cmd.Flags().GetInt(commonParams.CriticalityFlag)
+		if applicationModel.Criticality < minCriticality || applicationModel.Criticality > maxCriticality {
+			return nil, errors.Errorf("Criticality must be between %d and %d", minCriticality, maxCriticality)
+		}
+	}
+	removeTags, _ := cmd.Flags().GetString(commonParams.RemoveTagsFlag)
+	for key, value := range services.CreateTagMap(removeTags) {
+		if current, ok := applicationModel.Tags[key]; ok && (value == "" || value == current) {
+			delete(applicationModel.Tags, key)
+		}
+	}
+	addTags, _ := cmd.Flags().GetString(commonParams.AddTagsFlag)
+	for key, value := range services.CreateTagMap(addTags) {
+		applicationModel.Tags[key] = value
+	}
+
+	removeRules, _ := cmd.Flags().GetStringArray(commonParams.RemoveApplicationRuleFlag)
+	for _, spec := range removeRules {
+		index := slices.IndexFunc(applicationModel.Rules, func(rule wrappers.Rule) bool {
+			return rule.ID == spec
+		})
+		if index < 0 && strings.Contains(spec, "=") {
+			rule, err := parseApplicationRule(spec)
+			if err != nil {
+				return nil, err
+			}
+			index = slices.IndexFunc(applicationModel.Rules, func(existing wrappers.Rule) bool {
+				return existing.Type == rule.Type && existing.Value == rule.Value
+			})
+		}
+		if index < 0 {
+			return nil, errors.Errorf("The application has no rule %s", spec)
+		}
+		applicationModel.Rules = slices.Delete(applicationModel.Rules, index, index+1)
+	}
+	addRules, _ := cmd.Flags().GetStringArray(commonParams.AddApplicationRuleFlag)
+	rules, err := parseApplicationRules(addRules)
+	if err != nil {
+		return nil, err
+	}
+	applicationModel.Rules = append(applicationModel.Rules, rules...)
+	return applicationModel, nil
+}
+
+func parseApplicationRules(specs []string) ([]wrappers.Rule, error) {
+	var rules []wrappers.Rule
+	for _, spec := range specs {
+		rule, err := parseApplicationRule(spec)
+		if err != nil {
+			return nil, err
+		}
+		rules = append(rules, rule)
+	}
+	return rules, nil
+}
+
+// parseApplicationRule converts a <type>=<value> rule, where the type is either a short type of applicationRuleTypes or
+// a rule type of the applications API.
+func parseApplicationRule(spec string) (wrappers.Rule, error) {
+	ruleType, value, found := strings.Cut(spec, "=")
+	if !found || strings.TrimSpace(value) == "" {
+		return wrappers.Rule{}, errors.Errorf("Invalid rule %q, rules should be in the <type>=<value> format", spec)
+	}
+	ruleType = strings.TrimSpace(ruleType)
+	if ruleType == "tag" {
+		if key, tagValue, hasValue := strings.Cut(value, ":"); hasValue {
+			return wrappers.Rule{Type: tagKeyValueRuleType, Value: key + ";" + tagValue}, nil
+		}
+	}
+	if apiType, ok := applicationRuleTypes[ruleType]; ok {
+		return wrappers.Rule{Type: apiType, Value: value}, nil
+	}
+	if ruleType == tagKeyValueRuleType || slices.Contains(slices.Collect(maps.Values(applicationRuleTypes)), ruleType) {
+		return wrappers.Rule{Type: ruleType, Value: value}, nil
+	}
+	return wrappers.Rule{}, errors.Errorf("Invalid rule type %q. %s", ruleType, applicationRuleFlagUsage)
+}
+
+type applicationView struct {
+	ID          string `format:"name:Application ID"`
+	Name        string
+	Description string
+	Criticality int
+	Rules       []string
+	Tags        map[string]string
+	ProjectIds  []string  `format:"name:Project IDs"`
+	CreatedAt   time.Time `format:"name:Created at;time:01-02-06 15:04:05"`
+}
+
+func toApplicationViews(applications []wrappers.Application) []applicationView {
+	views := make([]applicationView, 0, len(applications))
+	for i := range applications {
+		views = append(views, toApplicationView(&applications[i]))
+	}
+	return views
+}
+
+func toApplicationView(application *wrappers.Application) applicationView {
+	rules := make([]string, 0, len(application.Rules))
+	for _, rule := range application.Rules {
+		rules = append(rules, rule.Type+"="+rule.Value)
+	}
+	return applicationView{
+		ID:          application.ID,
+		Name:        application.Name,
+		Description: application.Description,
+		Criticality: application.Criticality,
+		Rules:       rules,
+		Tags:        application.Tags,
+		ProjectIds:  application.ProjectIds,
+		CreatedAt:   application.CreatedAt,
+	}
+}
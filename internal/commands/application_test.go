@@ -0,0 +1,121 @@
+//go:build !integration
+
+package commands
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/checkmarx/ast-cli/internal/wrappers"
+	"github.com/checkmarx/ast-cli/internal/wrappers/mock"
+	"github.com/spf13/cobra"
+	"gotest.tools/assert"
+)
+
+func TestApplicationHelp(t *testing.T) {
+	execCmdNilAssertion(t, "help", "application")
+}
+
+func TestRunListApplicationsCommand(t *testing.T) {
+	buffer, err := executeRedirectedTestCommand("application", "list", "--format", "json", "--filter", "limit=10")
+	assert.NilError(t, err)
+	var views []applicationView
+	assert.NilError(t, json.Unmarshal(buffer.Bytes(), &views))
+	assert.Equal(t, len(views), 1)
+	assert.Equal(t, views[0].ID, "mockID")
+}
+
+func TestRunShowApplicationCommand(t *testing.T) {
+	buffer, err := executeRedirectedTestCommand("application", "show", "--application-name", "MOCK", "--format", "json")
+	assert.NilError(t, err)
+	assert.Assert(t, strings.Contains(buffer.String(), `"Name":"MOCK"`))
+
+	err = execCmdNotNilAssertion(t, "application", "show", "--application-name", "anyApplication")
+	assert.ErrorContains(t, err, "Application not found: anyApplication")
+}
+
+func TestRunCreateApplicationCommand(t *testing.T) {
+	buffer, err := executeRedirectedTestCommand("application", "create", "--application-name", "payments", "--criticality", "4",
+		"--tags", "team:payments", "--rule", "tag=team:payments", "--rule", "name-prefix=payments-", "--format", "json")
+	assert.NilError(t, err)
+	view := applicationView{}
+	assert.NilError(t, json.Unmarshal(buffer.Bytes(), &view))
+	assert.Equal(t, view.ID, "ID-payments")
+	assert.Equal(t, view.Criticality, 4)
+	assert.DeepEqual(t, view.Rules, []string{"project.tag.key-value.exists=team;payments", "project.name.starts-with=payments-"})
+
+	err = execCmdNotNilAssertion(t, "application", "create", "--application-name", "payments", "--rule", "owner=me")
+	assert.ErrorContains(t, err, `Invalid rule type "owner"`)
+	err = execCmdNotNilAssertion(t, "application", "create", "--application-name", "payments", "--criticality", "0")
+	assert.ErrorContains(t, err, "Criticality must be between 1 and 5")
+	err = execCmdNotNilAssertion(t, "application", "create", "--application-name", mock.FakeBadRequest400)
+	assert.ErrorContains(t, err, "invalid applicationBody")
+}
+
+func TestRunUpdateAndDeleteApplicationCommand(t *testing.T) {
+	buffer, err := executeRedirectedTestCommand("application", "update", "--application-name", "MOCK", "--new-name", "renamed",
+		"--add-rule", "name=payments")
+	assert.NilError(t, err)
+	assert.Equal(t, buffer.String(), "Updated application renamed (mockID)\n")
+
+	err = execCmdNotNilAssertion(t, "application", "update", "--application-name", "MOCK", "--remove-rule", "missing-rule-id")
+	assert.ErrorContains(t, err, "The application has no rule missing-rule-id")
+
+	buffer, err = executeRedirectedTestCommand("application", "delete", "--application-name", "MOCK")
+	assert.NilError(t, err)
+	assert.Equal(t, buffer.String(), "Deleted application MOCK (mockID)\n")
+}
+
+func TestRunApplicationProjectCommands(t *testing.T) {
+	mock.Flag = wrappers.FeatureFlagResponseModel{Name: wrappers.DirectAssociationEnabled, Status: true}
+	defer func() { mock.Flag = wrappers.FeatureFlagResponseModel{} }()
+
+	buffer, err := executeRedirectedTestCommand("application", "add-project", "--application-name", "MOCK", "--project-id", "new-project-id")
+	assert.NilError(t, err)
+	assert.Equal(t, buffer.String(), "Added project MOCK to application MOCK\n")
+
+	buffer, err = executeRedirectedTestCommand("application", "remove-project", "--application-name", "MOCK", "--project-id", "ProjectID1")
+	assert.NilError(t, err)
+	assert.Equal(t, buffer.String(), "Removed project MOCK from application MOCK\n")
+
+	err = execCmdNotNilAssertion(t, "application", "remove-project", "--application-name", "MOCK", "--project-id", "new-project-id")
+	assert.ErrorContains(t, err, "is not associated with the application MOCK")
+}
+
+func TestUpdatedApplicationConfiguration_KeepsFieldsNotPassed(t *testing.T) {
+	cmd := &cobra.Command{}
+	cmd.Flags().String("new-name", "", "")
+	cmd.Flags().String("description", "", "")
+	cmd.Flags().Int("criticality", 0, "")
+	cmd.Flags().String("add-tags", "", "")
+	cmd.Flags().String("remove-tags", "", "")
+	cmd.Flags().StringArray("add-rule", []string{}, "")
+	cmd.Flags().StringArray("remove-rule", []string{}, "")
+	assert.NilError(t, cmd.ParseFlags([]string{"--remove-rule", "tag=team", "--remove-rule", "r2", "--add-rule", "name-regex=^pay", "--add-tags", "tier:1"}))
+	application := &wrappers.Application{
+		ID:          "app-id",
+		Name:        "payments",
+		Description: "Payments services",
+		Criticality: 5,
+		Rules: []wrappers.Rule{
+			{ID: "r1", Type: "project.tag.key.exists", Value: "team"},
+			{ID: "r2", Type: "project.name.contains", Value: "pay"},
+			{ID: "r3", Type: "project.name.in", Value: "billing"},
+		},
+		Tags: map[string]string{"owner": "platform"},
+	}
+
+	applicationModel, err := updatedApplicationConfiguration(cmd, application)
+	assert.NilError(t, err)
+	assert.Equal(t, applicationModel.Name, "payments")
+	assert.Equal(t, applicationModel.Description, "Payments services")
+	assert.Equal(t, applicationModel.Criticality, 5)
+	assert.DeepEqual(t, applicationModel.Tags, map[string]string{"owner": "platform", "tier": "1"})
+	assert.DeepEqual(t, applicationModel.Rules, []wrappers.Rule{
+		{ID: "r3", Type: "project.name.in", Value: "billing"},
+		{Type: "project.name.regex", Value: "^pay"},
+	})
+	assert.Equal(t, len(application.Rules), 3)
+	assert.DeepEqual(t, application.Tags, map[string]string{"owner": "platform"})
+}
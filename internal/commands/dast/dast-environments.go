@@ -5,6 +5,7 @@ import (
 	"strings"
 
 	"github.com/MakeNowJust/heredoc"
+	"github.com/checkmarx/ast-cli/internal/commands/util"
 	"github.com/checkmarx/ast-cli/internal/commands/util/printer"
 	commonParams "github.com/checkmarx/ast-cli/internal/params"
 	"github.com/checkmarx/ast-cli/internal/services"
@@ -54,6 +55,7 @@ func NewDastEnvironmentsCommand(dastEnvironmentsWrapper wrappers.DastEnvironment
 			$ cx dast-environments list --format list
 			$ cx dast-environments list --filter "from=1,to=10"
 			$ cx dast-environments list --filter "search=production,sort=created"
+			$ cx dast-environments list --all --format ndjson
 		`,
 		),
 		Annotations: map[string]string{
@@ -66,12 +68,14 @@ func NewDastEnvironmentsCommand(dastEnvironmentsWrapper wrappers.DastEnvironment
 		RunE: runListDastEnvironmentsCommand(dastEnvironmentsWrapper),
 	}
 	listDastEnvironmentsCmd.PersistentFlags().StringSlice(commonParams.FilterFlag, []string{}, filterDastEnvironmentsListFlagUsage)
+	util.AddPaginationFlags(listDastEnvironmentsCmd)
 
 	addFormatFlagToMultipleCommands(
 		[]*cobra.Command{listDastEnvironmentsCmd},
 		printer.FormatTable,
 		printer.FormatJSON,
 		printer.FormatList,
+		printer.FormatNDJSON,
 	)
 
 	environmentsCmd.AddCommand(listDastEnvironmentsCmd)
@@ -87,6 +91,23 @@ func runListDastEnvironmentsCommand(dastEnvironmentsWrapper wrappers.DastEnviron
 		if err != nil {
 			return errors.Wrapf(err, "%s", failedGettingDastEnvironments)
 		}
+		all, pageSize, err := util.GetPagination(cmd, params)
+		if err != nil {
+			return errors.Wrapf(err, "%s", failedGettingDastEnvironments)
+		}
+		if all {
+			errorModel, err = util.PrintAllPages(cmd, wrappers.NewDastEnvironmentsPager(dastEnvironmentsWrapper, params, pageSize),
+				func(environments []wrappers.DastEnvironmentResponseModel) ([]environmentView, error) {
+					return toEnvironmentViews(environments), nil
+				})
+			if err != nil {
+				return errors.Wrapf(err, "%s\n", failedGettingDastEnvironments)
+			}
+			if errorModel != nil {
+				return errors.Errorf(services.ErrorCodeFormat, failedGettingDastEnvironments, errorModel.Code, errorModel.Message)
+			}
+			return nil
+		}
 
 		// The API expects: from, to, search, sort
 		// from and to are pagination parameters (e.g., from=1, to=10 for first page)
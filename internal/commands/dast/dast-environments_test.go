@@ -45,6 +45,16 @@ func TestDastEnvironmentsListWithSearch(t *testing.T) {
 	assert.NilError(t, err)
 }
 
+func TestDastEnvironmentsListAllPages(t *testing.T) {
+	err := createTestCommand("list", "--all", "--page-size", "20", "--format", "ndjson")
+	assert.NilError(t, err)
+}
+
+func TestDastEnvironmentsListPageSizeWithoutAll(t *testing.T) {
+	err := createTestCommand("list", "--page-size", "20")
+	assert.ErrorContains(t, err, "--page-size can only be used with --all")
+}
+
 func TestDastEnvironmentsListWithSort(t *testing.T) {
 	err := createTestCommand("list", "--filter", "sort=domain:asc")
 	assert.NilError(t, err)
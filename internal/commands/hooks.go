@@ -14,11 +14,12 @@ func NewHooksCommand(jwtWrapper wrappers.JWTWrapper, featureFlagsWrapper wrapper
 	hooksCmd := &cobra.Command{
 		Use:   "hooks",
 		Short: "Manage Git hooks and AI coding agent hooks",
-		Long:  "The hooks command manages Git hooks for secret detection and AI coding agent hooks for Claude, Cursor, Windsurf, Factory Droid, Gemini, and GitHub Copilot CLI.",
+		Long:  "The hooks command manages Git hooks for secret detection and realtime scans of staged files, and AI coding agent hooks for Claude, Cursor, Windsurf, Factory Droid, Gemini, and GitHub Copilot CLI.",
 		Example: heredoc.Doc(
 			`
 			$ cx hooks pre-commit secrets-install-git-hook
 			$ cx hooks pre-commit secrets-scan
+			$ cx hooks pre-commit install --engines secrets,asca,oss,iac
 			$ cx hooks pre-receive secrets-scan
 			$ cx hooks agenthooks install
 		`,
@@ -33,7 +34,7 @@ func NewHooksCommand(jwtWrapper wrappers.JWTWrapper, featureFlagsWrapper wrapper
 	}
 
 	// Add pre-commit, pre-receive, and agenthooks management subcommands
-	hooksCmd.AddCommand(PreCommitCommand(jwtWrapper, featureFlagsWrapper))
+	hooksCmd.AddCommand(PreCommitCommand(jwtWrapper, featureFlagsWrapper, realtimeScannerWrapper))
 	hooksCmd.AddCommand(PreReceiveCommand(jwtWrapper, featureFlagsWrapper))
 	hooksCmd.AddCommand(NewAgentHooksCommand())
 
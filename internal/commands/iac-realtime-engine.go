@@ -21,8 +21,13 @@ func RunScanIacRealtimeCommand(
 
 		ignoredFilePathFlag, _ := cmd.Flags().GetString(commonParams.IgnoredFilePathFlag)
 		engine, _ := cmd.Flags().GetString(commonParams.EngineFlag)
+		kicsBinary, _ := cmd.Flags().GetString(commonParams.KicsBinaryFlag)
 
-		iacRealtimeService := iacrealtime.NewIacRealtimeService(jwtWrapper, featureFlagWrapper, iacrealtime.NewContainerManager())
+		kicsManager, err := iacrealtime.NewKicsManager(kicsBinary, engine)
+		if err != nil {
+			return err
+		}
+		iacRealtimeService := iacrealtime.NewIacRealtimeService(jwtWrapper, featureFlagWrapper, kicsManager)
 
 		results, err := iacRealtimeService.RunIacRealtimeScan(fileSourceFlag, engine, ignoredFilePathFlag)
 		if err != nil {
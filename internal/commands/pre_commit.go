@@ -12,15 +12,22 @@ import (
 
 // PreCommitCommand creates the pre-commit subcommand
 
-func PreCommitCommand(jwtWrapper wrappers.JWTWrapper, featureFlagsWrapper wrappers.FeatureFlagsWrapper) *cobra.Command {
+func PreCommitCommand(
+	jwtWrapper wrappers.JWTWrapper,
+	featureFlagsWrapper wrappers.FeatureFlagsWrapper,
+	realtimeScannerWrapper wrappers.RealtimeScannerWrapper,
+) *cobra.Command {
 	preCommitCmd := &cobra.Command{
 		Use:   "pre-commit",
-		Short: "Manage pre-commit hooks and run secret detection scans",
-		Long:  "The pre-commit command enables the ability to manage Git pre-commit hooks for secret detection.",
+		Short: "Manage pre-commit hooks and run secret detection and realtime scans",
+		Long: "The pre-commit command enables the ability to manage Git pre-commit hooks for secret detection, " +
+			"and for scanning the staged files with the secrets, ASCA, OSS and IaC realtime engines.",
 		Example: heredoc.Doc(
 			`
             $ cx hooks pre-commit secrets-install-git-hook
             $ cx hooks pre-commit secrets-scan
+            $ cx hooks pre-commit install --engines secrets,asca,oss,iac
+            $ cx hooks pre-commit scan
         `,
 		),
 	}
@@ -32,6 +39,10 @@ func PreCommitCommand(jwtWrapper wrappers.JWTWrapper, featureFlagsWrapper wrappe
 	preCommitCmd.AddCommand(secretsScanCommand(jwtWrapper, featureFlagsWrapper))
 	preCommitCmd.AddCommand(secretsIgnoreCommand(jwtWrapper, featureFlagsWrapper))
 	preCommitCmd.AddCommand(secretsHelpCommand())
+	preCommitCmd.AddCommand(preCommitInstallCommand())
+	preCommitCmd.AddCommand(preCommitUninstallCommand())
+	preCommitCmd.AddCommand(preCommitScanCommand(jwtWrapper, featureFlagsWrapper, realtimeScannerWrapper))
+	preCommitCmd.AddCommand(preCommitIgnoreCommand())
 
 	return preCommitCmd
 }
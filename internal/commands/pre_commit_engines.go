@@ -0,0 +1,694 @@
+package commands
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/MakeNowJust/heredoc"
+	"github.com/checkmarx/ast-cli/internal/commands/util"
+	commonParams "github.com/checkmarx/ast-cli/internal/params"
+	"github.com/checkmarx/ast-cli/internal/services"
+	"github.com/checkmarx/ast-cli/internal/services/realtimeengine"
+	"github.com/checkmarx/ast-cli/internal/services/realtimeengine/iacrealtime"
+	"github.com/checkmarx/ast-cli/internal/services/realtimeengine/ignore"
+	"github.com/checkmarx/ast-cli/internal/services/realtimeengine/ossrealtime"
+	"github.com/checkmarx/ast-cli/internal/services/realtimeengine/secretsrealtime"
+	"github.com/checkmarx/ast-cli/internal/wrappers"
+	"github.com/checkmarx/ast-cli/internal/wrappers/grpcs"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	preCommitHookMarker    = "# Checkmarx One pre-commit hook"
+	preCommitHookGitPath   = "hooks/pre-commit"
+	preCommitLastScanFile  = "cx-pre-commit-last-scan.json"
+	preCommitConfigPath    = ".checkmarx/pre-commit.yaml"
+	preCommitIDLength      = 12
+	preCommitBinarySniff   = 8000
+	preCommitIacEngine     = "docker"
+	preCommitBlockLabel    = "BLOCK"
+	preCommitWarnLabel     = "WARN"
+	preCommitHookScript    = "#!/bin/sh\n" + preCommitHookMarker + ", installed by cx hooks pre-commit install\nexec \"%s\" hooks pre-commit scan%s\n"
+	invalidPreCommitEngine = "invalid engine %s, expected one of: %s"
+	preCommitHookExists    = "%s already exists and wasn't installed by cx, use --%s to replace it"
+	preCommitBlocked       = "commit blocked by %d finding(s). Fix them, or ignore them with: cx hooks pre-commit ignore --%s %s"
+	preCommitNoLastScan    = "no pre-commit findings to ignore, the last scan found none or cx hooks pre-commit scan hasn't run"
+	preCommitUnknownID     = "no finding %s in the last pre-commit scan"
+)
+
+var (
+	preCommitEngineNames = []string{ignore.ScanTypeSecrets, ignore.ScanTypeASCA, ignore.ScanTypeOSS, ignore.ScanTypeIaC}
+
+	// preCommitDefaultBlocking are the severities blocking a commit for the engines the configuration doesn't list
+	preCommitDefaultBlocking = []string{"critical", "high", "malicious"}
+
+	// preCommitASCAExtensions are the languages ASCA scans
+	preCommitASCAExtensions = []string{".java", ".js", ".jsx", ".ts", ".tsx", ".mjs", ".cjs", ".cs", ".go", ".py", ".pyw"}
+
+	// newPreCommitEngines returns the realtime engines scanning the staged files by name. Tests replace it.
+	newPreCommitEngines = realtimePreCommitEngines
+)
+
+// preCommitConfig is the per repository configuration of the pre-commit hook, read from .checkmarx/pre-commit.yaml
+type preCommitConfig struct {
+	Engines            []string            `yaml:"engines"`
+	BlockingSeverities map[string][]string `yaml:"blocking-severities"`
+}
+
+// preCommitFinding is a finding of an engine in a staged file
+type preCommitFinding struct {
+	ID       string `json:"id"`
+	Engine   string `json:"engine"`
+	File     string `json:"file"`
+	Line     int    `json:"line"`
+	Severity string `json:"severity"`
+	Title    string `json:"title"`
+	Blocking bool   `json:"blocking"`
+	// Data is the finding as reported by the engine, from which its ignore entry is built
+	Data json.RawMessage `json:"data"`
+}
+
+// preCommitEngine scans the files it supports with a realtime engine
+type preCommitEngine struct {
+	supports func(filePath string) bool
+	// scan scans the staged files, relative to stageDir, and returns their findings by file. The files it fails to
+	// scan are passed to warn, or an empty file when the whole scan failed.
+	scan func(stageDir string, files []string, ignoredFilePath string, warn func(file string, err error)) map[string][]preCommitFinding
+}
+
+func preCommitInstallCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "install",
+		Short: "Install the pre-commit hook scanning the staged files",
+		Long:  "Install a Git pre-commit hook scanning the staged files of the repository with the realtime engines.",
+		Example: heredoc.Doc(
+			`
+            $ cx hooks pre-commit install --engines secrets,asca,oss,iac
+            $ cx hooks pre-commit install --engines iac --iac-engine podman
+        `,
+		),
+		RunE: runPreCommitInstall,
+	}
+	cmd.Flags().String(commonParams.PreCommitEnginesFlag, "", fmt.Sprintf(commonParams.PreCommitEnginesFlagUsage, strings.Join(preCommitEngineNames, ", ")))
+	cmd.Flags().Bool(commonParams.PreCommitForceFlag, false, commonParams.PreCommitForceFlagUsage)
+	addPreCommitIacFlags(cmd)
+	return cmd
+}
+
+func preCommitUninstallCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "uninstall",
+		Short: "Uninstall the pre-commit hook scanning the staged files",
+		Long:  "Uninstall the Git pre-commit hook installed by cx hooks pre-commit install.",
+		Example: heredoc.Doc(
+			`
+            $ cx hooks pre-commit uninstall
+        `,
+		),
+		RunE: runPreCommitUninstall,
+	}
+}
+
+func preCommitScanCommand(
+	jwtWrapper wrappers.JWTWrapper,
+	featureFlagsWrapper wrappers.FeatureFlagsWrapper,
+	realtimeScannerWrapper wrappers.RealtimeScannerWrapper,
+) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "scan",
+		Short: "Scan the staged files with the realtime engines",
+		Long: "Scan the staged content of the files, not their working copy, with the realtime engines. " +
+			"The commit is blocked by findings of the blocking severities of .checkmarx/pre-commit.yaml, by default critical, high and malicious.",
+		Example: heredoc.Doc(
+			`
+            $ cx hooks pre-commit scan
+            $ cx hooks pre-commit scan --engines secrets,oss
+        `,
+		),
+		RunE: runPreCommitScan(jwtWrapper, featureFlagsWrapper, realtimeScannerWrapper),
+	}
+	cmd.Flags().String(commonParams.PreCommitEnginesFlag, "", fmt.Sprintf(commonParams.PreCommitEnginesFlagUsage, strings.Join(preCommitEngineNames, ", ")))
+	addPreCommitIacFlags(cmd)
+	return cmd
+}
+
+// addPreCommitIacFlags adds the flags choosing how the iac engine runs KICS, as scan iac-realtime does
+func addPreCommitIacFlags(cmd *cobra.Command) {
+	cmd.Flags().String(commonParams.PreCommitIacEngineFlag, preCommitIacEngine, commonParams.PreCommitIacEngineUsage)
+	cmd.Flags().String(commonParams.KicsBinaryFlag, "", util.KicsBinaryFlagUsage)
+}
+
+func preCommitIgnoreCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "ignore",
+		Short: "Ignore findings of the last pre-commit scan",
+		Long:  "Add findings of the last pre-commit scan to the realtime ignore file so they no longer block commits.",
+		Example: heredoc.Doc(
+			`
+            $ cx hooks pre-commit ignore --resultIds=a1b2c3d4e5f6,f1e2d3c4b5a6
+            $ cx hooks pre-commit ignore --all
+        `,
+		),
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			resultIDs, _ := cmd.Flags().GetString(commonParams.PreCommitResultIDsFlag)
+			all, _ := cmd.Flags().GetBool(commonParams.PreCommitAllFlag)
+			if all && len(resultIDs) > 0 {
+				return fmt.Errorf("--all cannot be used with --resultIds")
+			}
+			if !all && len(resultIDs) == 0 {
+				return fmt.Errorf("either --all or --resultIds must be specified")
+			}
+			return nil
+		},
+		RunE: runPreCommitIgnore,
+	}
+	cmd.Flags().String(commonParams.PreCommitResultIDsFlag, "", "Comma-separated IDs of findings to ignore (e.g., id1,id2,id3)")
+	cmd.Flags().Bool(commonParams.PreCommitAllFlag, false, "Ignore all findings of the last scan")
+	return cmd
+}
+
+func runPreCommitInstall(cmd *cobra.Command, _ []string) error {
+	engines, _ := cmd.Flags().GetString(commonParams.PreCommitEnginesFlag)
+	if _, err := parsePreCommitEngines(engines); err != nil {
+		return err
+	}
+	force, _ := cmd.Flags().GetBool(commonParams.PreCommitForceFlag)
+
+	root, err := gitRepositoryRoot()
+	if err != nil {
+		return err
+	}
+	hookPath, err := gitPath(root, preCommitHookGitPath)
+	if err != nil {
+		return err
+	}
+	if content, readErr := os.ReadFile(hookPath); readErr == nil && !bytes.Contains(content, []byte(preCommitHookMarker)) && !force {
+		return errors.Errorf(preCommitHookExists, hookPath, commonParams.PreCommitForceFlag)
+	}
+
+	// Run the same cx from the hook, which git GUIs may start without cx in their PATH
+	executable, err := os.Executable()
+	if err != nil {
+		executable = "cx"
+	}
+	scanArgs := ""
+	if engines != "" {
+		scanArgs = fmt.Sprintf(" --%s %s", commonParams.PreCommitEnginesFlag, engines)
+	}
+	if cmd.Flags().Changed(commonParams.PreCommitIacEngineFlag) {
+		iacEngine, _ := cmd.Flags().GetString(commonParams.PreCommitIacEngineFlag)
+		scanArgs += fmt.Sprintf(" --%s %s", commonParams.PreCommitIacEngineFlag, iacEngine)
+	}
+	if kicsBinary, _ := cmd.Flags().GetString(commonParams.KicsBinaryFlag); kicsBinary != "" {
+		scanArgs += fmt.Sprintf(" --%s \"%s\"", commonParams.KicsBinaryFlag, filepath.ToSlash(kicsBinary))
+	}
+	script := fmt.Sprintf(preCommitHookScript, filepath.ToSlash(executable), scanArgs)
+
+	if err = os.MkdirAll(filepath.Dir(hookPath), 0o755); err != nil {
+		return errors.Wrapf(err, "failed to create %s", filepath.Dir(hookPath))
+	}
+	if err = os.WriteFile(hookPath, []byte(script), 0o755); err != nil { //nolint:gosec // git only runs executable hooks
+		return errors.Wrapf(err, "failed to write %s", hookPath)
+	}
+	_, _ = fmt.Fprintf(cmd.OutOrStdout(), "Installed the pre-commit hook at %s\n", hookPath)
+	return nil
+}
+
+func runPreCommitUninstall(cmd *cobra.Command, _ []string) error {
+	root, err := gitRepositoryRoot()
+	if err != nil {
+		return err
+	}
+	hookPath, err := gitPath(root, preCommitHookGitPath)
+	if err != nil {
+		return err
+	}
+	content, err := os.ReadFile(hookPath)
+	if os.IsNotExist(err) {
+		_, _ = fmt.Fprintln(cmd.OutOrStdout(), "No pre-commit hook is installed")
+		return nil
+	}
+	if err != nil {
+		return errors.Wrapf(err, "failed to read %s", hookPath)
+	}
+	if !bytes.Contains(content, []byte(preCommitHookMarker)) {
+		return errors.Errorf("%s wasn't installed by cx hooks pre-commit install", hookPath)
+	}
+	if err = os.Remove(hookPath); err != nil {
+		return errors.Wrapf(err, "failed to remove %s", hookPath)
+	}
+	_, _ = fmt.Fprintf(cmd.OutOrStdout(), "Uninstalled the pre-commit hook from %s\n", hookPath)
+	return nil
+}
+
+func runPreCommitScan(
+	jwtWrapper wrappers.JWTWrapper,
+	featureFlagsWrapper wrappers.FeatureFlagsWrapper,
+	realtimeScannerWrapper wrappers.RealtimeScannerWrapper,
+) func(cmd *cobra.Command, args []string) error {
+	return func(cmd *cobra.Command, _ []string) error {
+		root, err := gitRepositoryRoot()
+		if err != nil {
+			return err
+		}
+		config, err := loadPreCommitConfig(root)
+		if err != nil {
+			return err
+		}
+		engineNames, err := preCommitEnginesToRun(cmd, config)
+		if err != nil {
+			return err
+		}
+
+		stageDir, err := os.MkdirTemp("", "cx-pre-commit-*")
+		if err != nil {
+			return errors.Wrap(err, "failed to create the staging directory")
+		}
+		defer func() { _ = os.RemoveAll(stageDir) }()
+		files, err := stageGitIndex(root, stageDir)
+		if err != nil {
+			return err
+		}
+
+		ignoredFilePath := ""
+		if _, statErr := os.Stat(ignore.PathFor(root)); statErr == nil {
+			ignoredFilePath = ignore.PathFor(root)
+		}
+		iacEngine, _ := cmd.Flags().GetString(commonParams.PreCommitIacEngineFlag)
+		kicsBinary, _ := cmd.Flags().GetString(commonParams.KicsBinaryFlag)
+		engines := newPreCommitEngines(jwtWrapper, featureFlagsWrapper, realtimeScannerWrapper, iacEngine, kicsBinary)
+
+		var findings []preCommitFinding
+		for _, engineName := range engineNames {
+			engine := engines[engineName]
+			var engineFiles []string
+			for _, file := range files {
+				if engine.supports(file) {
+					engineFiles = append(engineFiles, file)
+				}
+			}
+			if len(engineFiles) == 0 {
+				continue
+			}
+			// An engine failing, e.g. unlicensed or unable to parse a file, doesn't block the commit
+			warn := func(file string, scanErr error) {
+				if file == "" {
+					_, _ = fmt.Fprintf(cmd.ErrOrStderr(), "Warning: %s scan failed: %v\n", engineName, scanErr)
+					return
+				}
+				_, _ = fmt.Fprintf(cmd.ErrOrStderr(), "Warning: %s scan of %s failed: %v\n", engineName, file, scanErr)
+			}
+			results := engine.scan(stageDir, engineFiles, ignoredFilePath, warn)
+			for _, file := range engineFiles {
+				for _, finding := range results[file] {
+					finding.Engine = engineName
+					finding.File = file
+					finding.ID = preCommitFindingID(&finding)
+					finding.Blocking = config.isBlocking(engineName, finding.Severity)
+					findings = append(findings, finding)
+				}
+			}
+		}
+
+		if err = savePreCommitFindings(root, findings); err != nil {
+			return err
+		}
+		return reportPreCommitFindings(cmd.OutOrStdout(), findings)
+	}
+}
+
+func runPreCommitIgnore(cmd *cobra.Command, _ []string) error {
+	resultIDs, _ := cmd.Flags().GetString(commonParams.PreCommitResultIDsFlag)
+	all, _ := cmd.Flags().GetBool(commonParams.PreCommitAllFlag)
+
+	root, err := gitRepositoryRoot()
+	if err != nil {
+		return err
+	}
+	findings, err := loadPreCommitFindings(root)
+	if err != nil {
+		return err
+	}
+	if len(findings) == 0 {
+		return errors.New(preCommitNoLastScan)
+	}
+
+	selected := findings
+	if !all {
+		selected = nil
+		for _, id := range strings.Split(resultIDs, ",") {
+			id = strings.TrimSpace(id)
+			index := slices.IndexFunc(findings, func(finding preCommitFinding) bool { return finding.ID == id })
+			if index < 0 {
+				return errors.Errorf(preCommitUnknownID, id)
+			}
+			selected = append(selected, findings[index])
+		}
+	}
+
+	ignoredFilePath := ignore.PathFor(root)
+	list, err := ignore.Load(ignoredFilePath)
+	if err != nil {
+		return errors.Wrapf(err, "failed to read ignore file %s", ignoredFilePath)
+	}
+	for _, finding := range selected {
+		entries, buildErr := ignore.BuildEntries(finding.Engine, finding.Data)
+		if buildErr != nil {
+			return buildErr
+		}
+		for _, entry := range entries {
+			if list, _, err = ignore.Append(list, entry); err != nil {
+				return err
+			}
+		}
+	}
+	if err = ignore.Save(ignoredFilePath, list); err != nil {
+		return errors.Wrapf(err, "failed to write ignore file %s", ignoredFilePath)
+	}
+	_, _ = fmt.Fprintf(cmd.OutOrStdout(), "Ignored %d finding(s) in %s\n", len(selected), ignoredFilePath)
+	return nil
+}
+
+// realtimePreCommitEngines scans with the same realtime services as the IDE plugins, created once per hook run. The
+// iac engine runs KICS once on all the staged IaC files, with iacEngine or kicsBinary resolved like scan iac-realtime
+// does.
+func realtimePreCommitEngines(
+	jwtWrapper wrappers.JWTWrapper,
+	featureFlagsWrapper wrappers.FeatureFlagsWrapper,
+	realtimeScannerWrapper wrappers.RealtimeScannerWrapper,
+	iacEngine, kicsBinary string,
+) map[string]preCommitEngine {
+	secretsService := secretsrealtime.NewSecretsRealtimeService(jwtWrapper, featureFlagsWrapper)
+	ossService := ossrealtime.NewOssRealtimeService(jwtWrapper, featureFlagsWrapper, realtimeScannerWrapper)
+	ascaWrappers := services.AscaWrappersParam{
+		JwtWrapper:  jwtWrapper,
+		ASCAWrapper: grpcs.NewASCAGrpcWrapper(viper.GetInt(commonParams.ASCAPortKey)),
+	}
+	return map[string]preCommitEngine{
+		ignore.ScanTypeSecrets: {
+			supports: func(string) bool { return true },
+			scan: scanPreCommitFiles(func(filePath, ignoredFilePath string) ([]preCommitFinding, error) {
+				results, err := secretsService.RunSecretsRealtimeScan(filePath, ignoredFilePath)
+				if err != nil {
+					return nil, err
+				}
+				var findings []preCommitFinding
+				for i := range results {
+					findings = append(findings, newPreCommitFinding(results[i].Title, results[i].Severity, firstLine(results[i].Locations), results[i]))
+				}
+				return findings, nil
+			}),
+		},
+		ignore.ScanTypeASCA: {
+			supports: func(filePath string) bool {
+				return slices.Contains(preCommitASCAExtensions, strings.ToLower(filepath.Ext(filePath)))
+			},
+			scan: scanPreCommitFiles(func(filePath, ignoredFilePath string) ([]preCommitFinding, error) {
+				result, err := services.CreateASCAScanRequest(
+					services.AscaScanParams{
+						FilePath:        filePath,
+						IgnoredFilePath: ignoredFilePath,
+						VorpalLocation:  strings.TrimSpace(viper.GetString(commonParams.ASCALocationKey)),
+					},
+					ascaWrappers,
+				)
+				if err != nil {
+					return nil, err
+				}
+				if result.Error != nil {
+					return nil, errors.New(result.Error.Description)
+				}
+				var findings []preCommitFinding
+				for _, detail := range result.ScanDetails {
+					findings = append(findings, newPreCommitFinding(detail.RuleName, detail.Severity, int(detail.Line), detail))
+				}
+				return findings, nil
+			}),
+		},
+		ignore.ScanTypeOSS: {
+			supports: ossrealtime.IsSupportedManifestFile,
+			scan: scanPreCommitFiles(func(filePath, ignoredFilePath string) ([]preCommitFinding, error) {
+				results, err := ossService.RunOssRealtimeScan(filePath, ignoredFilePath)
+				if err != nil {
+					return nil, err
+				}
+				var findings []preCommitFinding
+				for i := range results.Packages {
+					pkg := results.Packages[i]
+					// OK and Unknown packages are clean, the status of the others is Malicious or their highest severity
+					if pkg.Status == "" || pkg.Status == "OK" || pkg.Status == "Unknown" {
+						continue
+					}
+					title := fmt.Sprintf("%s %s@%s", pkg.PackageManager, pkg.PackageName, pkg.PackageVersion)
+					findings = append(findings, newPreCommitFinding(title, pkg.Status, firstLine(pkg.Locations), pkg))
+				}
+				return findings, nil
+			}),
+		},
+		ignore.ScanTypeIaC: {
+			supports: func(filePath string) bool {
+				return slices.ContainsFunc(commonParams.KicsBaseFilters, func(filter string) bool {
+					return filter != "" && strings.Contains(filePath, filter)
+				})
+			},
+			scan: func(stageDir string, files []string, ignoredFilePath string, warn func(string, error)) map[string][]preCommitFinding {
+				kicsManager, err := iacrealtime.NewKicsManager(kicsBinary, iacEngine)
+				if err != nil {
+					warn("", err)
+					return nil
+				}
+				filePaths := make([]string, len(files))
+				for i, file := range files {
+					filePaths[i] = filepath.FromSlash(file)
+				}
+				results, err := iacrealtime.NewIacRealtimeService(jwtWrapper, featureFlagsWrapper, kicsManager).
+					RunIacRealtimeFilesScan(stageDir, filePaths, iacEngine, ignoredFilePath)
+				if err != nil {
+					warn("", err)
+					return nil
+				}
+				findings := map[string][]preCommitFinding{}
+				for file, fileResults := range results {
+					for i := range fileResults {
+						findings[file] = append(findings[file],
+							newPreCommitFinding(fileResults[i].Title, fileResults[i].Severity, firstLine(fileResults[i].Locations), fileResults[i]))
+					}
+				}
+				return findings
+			},
+		},
+	}
+}
+
+// scanPreCommitFiles scans the staged files one at a time with scanFile, for the engines scanning a single file
+func scanPreCommitFiles(
+	scanFile func(filePath, ignoredFilePath string) ([]preCommitFinding, error),
+) func(stageDir string, files []string, ignoredFilePath string, warn func(string, error)) map[string][]preCommitFinding {
+	return func(stageDir string, files []string, ignoredFilePath string, warn func(string, error)) map[string][]preCommitFinding {
+		findings := map[string][]preCommitFinding{}
+		for _, file := range files {
+			results, err := scanFile(filepath.Join(stageDir, filepath.FromSlash(file)), ignoredFilePath)
+			if err != nil {
+				warn(file, err)
+				continue
+			}
+			findings[file] = results
+		}
+		return findings
+	}
+}
+
+func newPreCommitFinding(title, severity string, line int, data any) preCommitFinding {
+	raw, _ := json.Marshal(data)
+	return preCommitFinding{Title: title, Severity: severity, Line: line, Data: raw}
+}
+
+func firstLine(locations []realtimeengine.Location) int {
+	if len(locations) == 0 {
+		return 0
+	}
+	return locations[0].Line
+}
+
+// preCommitFindingID identifies a finding across scans of the same staged content
+func preCommitFindingID(finding *preCommitFinding) string {
+	sum := sha256.Sum256([]byte(fmt.Sprintf("%s|%s|%d|%s", finding.Engine, finding.File, finding.Line, finding.Title)))
+	return hex.EncodeToString(sum[:])[:preCommitIDLength]
+}
+
+// isBlocking reports whether findings of severity block commits for engine
+func (c *preCommitConfig) isBlocking(engine, severity string) bool {
+	blocking, ok := c.BlockingSeverities[engine]
+	if !ok {
+		blocking = preCommitDefaultBlocking
+	}
+	return slices.ContainsFunc(blocking, func(blockingSeverity string) bool {
+		return strings.EqualFold(blockingSeverity, severity)
+	})
+}
+
+func loadPreCommitConfig(root string) (*preCommitConfig, error) {
+	config := &preCommitConfig{}
+	content, err := os.ReadFile(filepath.Join(root, filepath.FromSlash(preCommitConfigPath)))
+	if os.IsNotExist(err) {
+		return config, nil
+	}
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to read %s", preCommitConfigPath)
+	}
+	if err = yaml.Unmarshal(content, config); err != nil {
+		return nil, errors.Wrapf(err, "failed to parse %s", preCommitConfigPath)
+	}
+	for engine := range config.BlockingSeverities {
+		if !slices.Contains(preCommitEngineNames, engine) {
+			return nil, errors.Errorf(invalidPreCommitEngine, engine, strings.Join(preCommitEngineNames, ", "))
+		}
+	}
+	return config, nil
+}
+
+// preCommitEnginesToRun returns the engines of the --engines flag, else of the configuration, else all of them
+func preCommitEnginesToRun(cmd *cobra.Command, config *preCommitConfig) ([]string, error) {
+	engines, _ := cmd.Flags().GetString(commonParams.PreCommitEnginesFlag)
+	if engines == "" {
+		engines = strings.Join(config.Engines, ",")
+	}
+	return parsePreCommitEngines(engines)
+}
+
+func parsePreCommitEngines(engines string) ([]string, error) {
+	if strings.TrimSpace(engines) == "" {
+		return preCommitEngineNames, nil
+	}
+	var names []string
+	for _, engine := range strings.Split(engines, ",") {
+		engine = strings.ToLower(strings.TrimSpace(engine))
+		if !slices.Contains(preCommitEngineNames, engine) {
+			return nil, errors.Errorf(invalidPreCommitEngine, engine, strings.Join(preCommitEngineNames, ", "))
+		}
+		if !slices.Contains(names, engine) {
+			names = append(names, engine)
+		}
+	}
+	return names, nil
+}
+
+func gitRepositoryRoot() (string, error) {
+	root, err := runGit(".", "rev-parse", "--show-toplevel")
+	if err != nil {
+		return "", errors.Wrap(err, "the pre-commit hook runs in a git repository")
+	}
+	return strings.TrimSpace(root), nil
+}
+
+// gitPath resolves a path inside the git directory of root, honoring core.hooksPath and worktrees
+func gitPath(root, name string) (string, error) {
+	resolved, err := runGit(root, "rev-parse", "--git-path", name)
+	if err != nil {
+		return "", err
+	}
+	resolved = filepath.FromSlash(strings.TrimSpace(resolved))
+	if !filepath.IsAbs(resolved) {
+		resolved = filepath.Join(root, resolved)
+	}
+	return resolved, nil
+}
+
+// stageGitIndex writes the staged content of the added, copied, modified and renamed files of root to stageDir, so
+// the engines scan what is committed rather than the working copy. Binary files are skipped.
+func stageGitIndex(root, stageDir string) ([]string, error) {
+	diff, err := runGit(root, "diff", "--cached", "--name-only", "--diff-filter=ACMR", "-z")
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list the staged files")
+	}
+	var files []string
+	for _, file := range strings.Split(diff, "\x00") {
+		if file == "" || !filepath.IsLocal(filepath.FromSlash(file)) {
+			continue
+		}
+		content, showErr := runGit(root, "show", ":"+file)
+		if showErr != nil {
+			return nil, errors.Wrapf(showErr, "failed to read the staged content of %s", file)
+		}
+		if bytes.IndexByte([]byte(content[:min(len(content), preCommitBinarySniff)]), 0) >= 0 {
+			continue
+		}
+		stagedPath := filepath.Join(stageDir, filepath.FromSlash(file))
+		if err = os.MkdirAll(filepath.Dir(stagedPath), 0o700); err != nil {
+			return nil, err
+		}
+		if err = os.WriteFile(stagedPath, []byte(content), 0o600); err != nil {
+			return nil, err
+		}
+		files = append(files, file)
+	}
+	return files, nil
+}
+
+// savePreCommitFindings keeps the findings of the scan in the git directory for cx hooks pre-commit ignore
+func savePreCommitFindings(root string, findings []preCommitFinding) error {
+	lastScanPath, err := gitPath(root, preCommitLastScanFile)
+	if err != nil {
+		return err
+	}
+	content, err := json.Marshal(findings)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(lastScanPath, content, 0o600)
+}
+
+func loadPreCommitFindings(root string) ([]preCommitFinding, error) {
+	lastScanPath, err := gitPath(root, preCommitLastScanFile)
+	if err != nil {
+		return nil, err
+	}
+	content, err := os.ReadFile(lastScanPath)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var findings []preCommitFinding
+	if err = json.Unmarshal(content, &findings); err != nil {
+		return nil, errors.Wrapf(err, "failed to parse %s", lastScanPath)
+	}
+	return findings, nil
+}
+
+// reportPreCommitFindings prints the findings and fails when any of them blocks the commit
+func reportPreCommitFindings(out io.Writer, findings []preCommitFinding) error {
+	if len(findings) == 0 {
+		_, _ = fmt.Fprintln(out, "No findings in the staged files")
+		return nil
+	}
+	writer := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	var blockingIDs []string
+	for i := range findings {
+		label := preCommitWarnLabel
+		if findings[i].Blocking {
+			label = preCommitBlockLabel
+			blockingIDs = append(blockingIDs, findings[i].ID)
+		}
+		_, _ = fmt.Fprintf(writer, "%s\t%s\t%s\t%s\t%s:%d\t%s\n",
+			label, findings[i].ID, findings[i].Engine, findings[i].Severity, findings[i].File, findings[i].Line, findings[i].Title)
+	}
+	_ = writer.Flush()
+	if len(blockingIDs) > 0 {
+		return errors.Errorf(preCommitBlocked, len(blockingIDs), commonParams.PreCommitResultIDsFlag, strings.Join(blockingIDs, ","))
+	}
+	return nil
+}
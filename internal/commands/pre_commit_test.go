@@ -25,7 +25,8 @@ func TestNewHooksCommand(t *testing.T) {
 func TestPreCommitCommand(t *testing.T) {
 	mockJWT := &mock.JWTMockWrapper{}
 	mockFF := &mock.FeatureFlagsMockWrapper{}
-	cmd := PreCommitCommand(mockJWT, mockFF)
+	mockRealtime := &mock.RealtimeScannerMockWrapper{}
+	cmd := PreCommitCommand(mockJWT, mockFF, mockRealtime)
 
 	assert.NotNil(t, cmd)
 	assert.Equal(t, "pre-commit", cmd.Use)
@@ -43,6 +44,10 @@ func TestPreCommitCommand(t *testing.T) {
 		"secrets-scan",
 		"secrets-ignore",
 		"secrets-help",
+		"install",
+		"uninstall",
+		"scan",
+		"ignore",
 	}
 
 	for _, expectedCmd := range expectedSubCommands {
@@ -3,6 +3,7 @@ package commands
 import (
 	"encoding/json"
 	"fmt"
+	"slices"
 	"strings"
 	"time"
 
@@ -30,6 +31,9 @@ const (
 	mandatoryRepoURLError = "flag --repo-url is mandatory when --ssh-key is provided"
 	invalidRepoURL        = "provided repository url doesn't need a key. Make sure you are defining the right repository or remove the flag --ssh-key"
 	emptyTag              = "NONE"
+	failedUpdatingProj    = "Failed updating a project"
+	minCriticality        = 1
+	maxCriticality        = 5
 )
 
 var (
@@ -61,6 +65,7 @@ var (
 
 func NewProjectCommand(applicationsWrapper wrappers.ApplicationsWrapper, projectsWrapper wrappers.ProjectsWrapper, groupsWrapper wrappers.GroupsWrapper,
 	accessManagementWrapper wrappers.AccessManagementWrapper, featureFlagsWrapper wrappers.FeatureFlagsWrapper,
+	tenantWrapper wrappers.TenantConfigurationWrapper, scansWrapper wrappers.ScansWrapper,
 ) *cobra.Command {
 	projCmd := &cobra.Command{
 		Use:   "project",
@@ -101,12 +106,39 @@ func NewProjectCommand(applicationsWrapper wrappers.ApplicationsWrapper, project
 	createProjCmd.PersistentFlags().String(commonParams.RepoURLFlag, "", "Repository URL")
 	createProjCmd.PersistentFlags().String(commonParams.ApplicationName, "", "Name of the application to assign with the project")
 
+	updateProjCmd := &cobra.Command{
+		Use:   "update",
+		Short: "Updates a project",
+		Long: "The project update command enables the ability to update an existing project in Checkmarx One. " +
+			"Only the fields of the given flags are changed",
+		Example: heredoc.Doc(
+			`
+			$ cx project update --project-id <project_id> --project-name <New Name> --branch main
+			$ cx project update --project-id <project_id> --add-tags team:payments --remove-tags legacy --add-groups PowerUsers
+			$ cx project update --project-id <project_id> --criticality 5 --application-name <Application Name>
+		`,
+		),
+		RunE: runUpdateProjectCommand(applicationsWrapper, projectsWrapper, groupsWrapper, accessManagementWrapper, featureFlagsWrapper, tenantWrapper),
+	}
+	addProjectIDFlag(updateProjCmd, "Project ID to update")
+	updateProjCmd.PersistentFlags().String(commonParams.ProjectName, "", "New name of the project")
+	updateProjCmd.PersistentFlags().String(commonParams.MainBranchFlag, "", "Main branch")
+	updateProjCmd.PersistentFlags().String(commonParams.RepoURLFlag, "", "Repository URL")
+	updateProjCmd.PersistentFlags().String(commonParams.SSHKeyFlag, "", "Path to ssh private key")
+	updateProjCmd.PersistentFlags().String(commonParams.AddTagsFlag, "", "List of tags to add or change, ex: (tagA,tagB:val,etc)")
+	updateProjCmd.PersistentFlags().String(commonParams.RemoveTagsFlag, "", "List of tags to remove, ex: (tagA,tagB:val,etc)")
+	updateProjCmd.PersistentFlags().String(commonParams.AddGroupsFlag, "", "List of groups to add, ex: (PowerUsers,etc)")
+	updateProjCmd.PersistentFlags().String(commonParams.RemoveGroupsFlag, "", "List of groups to remove, ex: (PowerUsers,etc)")
+	updateProjCmd.PersistentFlags().Int(commonParams.CriticalityFlag, 0, "Criticality of the project, from 1 (lowest) to 5 (highest)")
+	updateProjCmd.PersistentFlags().String(commonParams.ApplicationName, "", "Name of the application to assign with the project")
+
 	listProjectsCmd := &cobra.Command{
 		Use:   "list",
 		Short: "List all projects in the system",
 		Example: heredoc.Doc(
 			`
 			$ cx project list --format list
+			$ cx project list --all --page-size 200 --format ndjson
 		`,
 		),
 		Annotations: map[string]string{
@@ -119,6 +151,8 @@ func NewProjectCommand(applicationsWrapper wrappers.ApplicationsWrapper, project
 		RunE: runListProjectsCommand(projectsWrapper),
 	}
 	listProjectsCmd.PersistentFlags().StringSlice(commonParams.FilterFlag, []string{}, filterProjectsListFlagUsage)
+	util.AddPaginationFlags(listProjectsCmd)
+	addFormatFlag(listProjectsCmd, printer.FormatTable, printer.FormatJSON, printer.FormatList, printer.FormatNDJSON)
 
 	showProjectCmd := &cobra.Command{
 		Use:   "show",
@@ -196,13 +230,18 @@ func NewProjectCommand(applicationsWrapper wrappers.ApplicationsWrapper, project
 		RunE: runGetProjectsTagsCommand(projectsWrapper),
 	}
 
+	applyProjCmd := projectApplySubCommand(applicationsWrapper, projectsWrapper, groupsWrapper, accessManagementWrapper, featureFlagsWrapper, tenantWrapper)
+	configProjCmd := projectConfigSubCommand(projectsWrapper)
+	pruneProjCmd := projectPruneSubCommand(projectsWrapper, scansWrapper)
+
 	addFormatFlagToMultipleCommands(
-		[]*cobra.Command{showProjectCmd, listProjectsCmd, createProjCmd},
+		[]*cobra.Command{showProjectCmd, createProjCmd, updateProjCmd},
 		printer.FormatTable,
 		printer.FormatJSON,
 		printer.FormatList,
 	)
-	projCmd.AddCommand(createProjCmd, projectBranchesCmd, showProjectCmd, listProjectsCmd, deleteProjCmd, tagsCmd)
+	projCmd.AddCommand(createProjCmd, updateProjCmd, projectBranchesCmd, showProjectCmd, listProjectsCmd, deleteProjCmd, tagsCmd, applyProjCmd, configProjCmd,
+		pruneProjCmd)
 	return projCmd
 }
 
@@ -301,6 +340,171 @@ func runCreateProjectCommand(
 	}
 }
 
+func runUpdateProjectCommand(
+	applicationsWrapper wrappers.ApplicationsWrapper,
+	projectsWrapper wrappers.ProjectsWrapper,
+	groupsWrapper wrappers.GroupsWrapper,
+	accessManagementWrapper wrappers.AccessManagementWrapper,
+	featureFlagsWrapper wrappers.FeatureFlagsWrapper,
+	tenantWrapper wrappers.TenantConfigurationWrapper,
+) func(cmd *cobra.Command, args []string) error {
+	return func(cmd *cobra.Command, args []string) error {
+		projectID, _ := cmd.Flags().GetString(commonParams.ProjectIDFlag)
+		if strings.TrimSpace(projectID) == "" {
+			return errors.Errorf("%s: Please provide a project ID", failedUpdatingProj)
+		}
+		projectFlags := []string{
+			commonParams.ProjectName, commonParams.MainBranchFlag, commonParams.AddTagsFlag, commonParams.RemoveTagsFlag,
+			commonParams.AddGroupsFlag, commonParams.RemoveGroupsFlag, commonParams.CriticalityFlag,
+		}
+		updateProject := false
+		for _, flag := range projectFlags {
+			updateProject = updateProject || cmd.Flags().Changed(flag)
+		}
+		if !updateProject && !cmd.Flags().Changed(commonParams.RepoURLFlag) && !cmd.Flags().Changed(commonParams.SSHKeyFlag) &&
+			!cmd.Flags().Changed(commonParams.ApplicationName) {
+			return errors.Errorf("%s: Please provide at least one field to update", failedUpdatingProj)
+		}
+		err := validateConfiguration(cmd)
+		if err != nil {
+			return err
+		}
+		criticality, _ := cmd.Flags().GetInt(commonParams.CriticalityFlag)
+		if cmd.Flags().Changed(commonParams.CriticalityFlag) && (criticality < minCriticality || criticality > maxCriticality) {
+			return errors.Errorf("%s: Criticality must be between %d and %d", failedUpdatingProj, minCriticality, maxCriticality)
+		}
+
+		project, errorModel, err := projectsWrapper.GetByID(projectID)
+		if err != nil {
+			return errors.Wrapf(err, "%s", failedUpdatingProj)
+		}
+		if errorModel != nil {
+			return errors.Errorf(services.ErrorCodeFormat, failedUpdatingProj, errorModel.Code, errorModel.Message)
+		}
+
+		var application *wrappers.Application
+		applicationName, _ := cmd.Flags().GetString(commonParams.ApplicationName)
+		if applicationName != "" {
+			application, err = services.GetApplication(applicationName, applicationsWrapper)
+			if err != nil {
+				return err
+			}
+			if application == nil {
+				return errors.Errorf(errorConstants.ApplicationDoesntExistOrNoPermission)
+			}
+		}
+
+		if updateProject {
+			err = updateProjectFields(cmd, project, projectsWrapper, groupsWrapper, accessManagementWrapper, featureFlagsWrapper)
+			if err != nil {
+				return err
+			}
+		}
+		if application != nil {
+			err = services.AddProjectToApplication(application.Name, project.Name, project.ID, applicationsWrapper, featureFlagsWrapper, tenantWrapper)
+			if err != nil {
+				return err
+			}
+		}
+		err = updateProjectConfigurationIfNeeded(cmd, projectsWrapper, project.ID)
+		if err != nil {
+			return err
+		}
+
+		updated, errorModel, err := projectsWrapper.GetByID(project.ID)
+		if err != nil {
+			return errors.Wrapf(err, "%s", services.FailedGettingProj)
+		}
+		if errorModel != nil {
+			return errors.Errorf(services.ErrorCodeFormat, services.FailedGettingProj, errorModel.Code, errorModel.Message)
+		}
+		return printByFormat(cmd, toProjectView(*updated))
+	}
+}
+
+// updateProjectFields sends the project with the changes of the given flags applied to its current state, so fields
+// that were not passed keep their values.
+func updateProjectFields(
+	cmd *cobra.Command,
+	project *wrappers.ProjectResponseModel,
+	projectsWrapper wrappers.ProjectsWrapper,
+	groupsWrapper wrappers.GroupsWrapper,
+	accessManagementWrapper wrappers.AccessManagementWrapper,
+	featureFlagsWrapper wrappers.FeatureFlagsWrapper,
+) error {
+	projModel := wrappers.Project{
+		Name:        project.Name,
+		MainBranch:  project.MainBranch,
+		RepoURL:     project.RepoURL,
+		Tags:        map[string]string{},
+		Groups:      project.Groups,
+		Criticality: project.Criticality,
+	}
+	for key, value := range project.Tags {
+		projModel.Tags[key] = value
+	}
+	if cmd.Flags().Changed(commonParams.ProjectName) {
+		projModel.Name, _ = cmd.Flags().GetString(commonParams.ProjectName)
+		if strings.TrimSpace(projModel.Name) == "" {
+			return errors.Errorf(errorConstants.ProjectNameIsRequired)
+		}
+	}
+	if cmd.Flags().Changed(commonParams.MainBranchFlag) {
+		projModel.MainBranch, _ = cmd.Flags().GetString(commonParams.MainBranchFlag)
+	}
+	if cmd.Flags().Changed(commonParams.CriticalityFlag) {
+		projModel.Criticality, _ = cmd.Flags().GetInt(commonParams.CriticalityFlag)
+	}
+	removeTags, _ := cmd.Flags().GetString(commonParams.RemoveTagsFlag)
+	for key, value := range services.CreateTagMap(removeTags) {
+		if current, ok := projModel.Tags[key]; ok && (value == "" || value == current) {
+			delete(projModel.Tags, key)
+		}
+	}
+	addTags, _ := cmd.Flags().GetString(commonParams.AddTagsFlag)
+	for key, value := range services.CreateTagMap(addTags) {
+		projModel.Tags[key] = value
+	}
+
+	removeGroupsStr, _ := cmd.Flags().GetString(commonParams.RemoveGroupsFlag)
+	removeGroups, err := services.CreateGroupsMap(removeGroupsStr, groupsWrapper)
+	if err != nil {
+		return errors.Errorf("%s: %v", failedUpdatingProj, err)
+	}
+	addGroupsStr, _ := cmd.Flags().GetString(commonParams.AddGroupsFlag)
+	addGroups, err := services.CreateGroupsMap(addGroupsStr, groupsWrapper)
+	if err != nil {
+		return errors.Errorf("%s: %v", failedUpdatingProj, err)
+	}
+	removed := map[string]bool{}
+	for _, group := range removeGroups {
+		removed[group.ID] = true
+	}
+	var groups []string
+	for _, groupID := range projModel.Groups {
+		if !removed[groupID] {
+			groups = append(groups, groupID)
+		}
+	}
+	for _, groupID := range services.GetGroupIds(addGroups) {
+		if !slices.Contains(groups, groupID) {
+			groups = append(groups, groupID)
+		}
+	}
+	projModel.Groups = groups
+
+	err = services.RemoveGroupsFromProjectNewAccessManagement(project.ID, project.Name, removeGroups, accessManagementWrapper, featureFlagsWrapper)
+	if err != nil {
+		return err
+	}
+	err = services.UpsertProjectGroups(&projModel, projectsWrapper, accessManagementWrapper, project.ID, projModel.Name, featureFlagsWrapper, addGroups)
+	if err != nil {
+		return err
+	}
+	project.Name = projModel.Name
+	return nil
+}
+
 func updateProjectConfigurationIfNeeded(cmd *cobra.Command, projectsWrapper wrappers.ProjectsWrapper, projectID string) error {
 	// Just update project configuration id a repository url is defined
 	if cmd.Flags().Changed(commonParams.RepoURLFlag) {
@@ -402,9 +606,27 @@ func runListProjectsCommand(projectsWrapper wrappers.ProjectsWrapper) func(cmd *
 		if err != nil {
 			return errors.Wrapf(err, "%s", failedGettingAll)
 		}
+		all, pageSize, err := util.GetPagination(cmd, params)
+		if err != nil {
+			return errors.Wrapf(err, "%s", failedGettingAll)
+		}
 
 		supportEmptyTags(params)
 
+		if all {
+			errorModel, err = util.PrintAllPages(cmd, wrappers.NewProjectsPager(projectsWrapper, params, pageSize),
+				func(projects []wrappers.ProjectResponseModel) ([]projectView, error) {
+					return toProjectViews(projects), nil
+				})
+			if err != nil {
+				return errors.Wrapf(err, "%s\n", failedGettingAll)
+			}
+			if errorModel != nil {
+				return errors.Errorf(services.ErrorCodeFormat, failedGettingAll, errorModel.Code, errorModel.Message)
+			}
+			return nil
+		}
+
 		allProjectsModel, errorModel, err = projectsWrapper.Get(params)
 		if err != nil {
 			return errors.Wrapf(err, "%s\n", failedGettingAll)
@@ -611,6 +833,7 @@ func toProjectView(model wrappers.ProjectResponseModel) projectView { //nolint:g
 		Groups:         model.Groups,
 		ApplicationIds: model.ApplicationIds,
 		MainBranch:     model.MainBranch,
+		Criticality:    model.Criticality,
 	}
 }
 
@@ -623,4 +846,5 @@ type projectView struct {
 	Tags           map[string]string
 	Groups         []string
 	ApplicationIds []string
+	Criticality    int
 }
@@ -0,0 +1,495 @@
+package commands
+
+import (
+	"fmt"
+	"io"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/MakeNowJust/heredoc"
+	"github.com/checkmarx/ast-cli/internal/commands/util"
+	errorConstants "github.com/checkmarx/ast-cli/internal/constants/errors"
+	commonParams "github.com/checkmarx/ast-cli/internal/params"
+	"github.com/checkmarx/ast-cli/internal/services"
+	"github.com/checkmarx/ast-cli/internal/wrappers"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	failedApplyingProjects = "Failed applying projects"
+	defaultSpecOwner       = "cx-project-apply"
+	managedByTag           = "managed-by"
+	createAction           = "create"
+	updateAction           = "update"
+	deleteAction           = "delete"
+)
+
+// projectsSpec is the declarative description of projects read by `project apply`, in YAML or JSON.
+// Projects created or updated by apply are tagged managed-by: <owner>, which scopes --prune to them.
+type projectsSpec struct {
+	Owner    string        `yaml:"owner"`
+	Projects []projectSpec `yaml:"projects"`
+}
+
+// projectSpec declares one project. Omitted fields are left as they are on the server; tags and groups,
+// when present, replace the current ones.
+type projectSpec struct {
+	Name        string            `yaml:"name"`
+	MainBranch  string            `yaml:"mainBranch"`
+	RepoURL     string            `yaml:"repoUrl"`
+	SSHKey      string            `yaml:"sshKey"`
+	Tags        map[string]string `yaml:"tags"`
+	Groups      []string          `yaml:"groups"`
+	Application string            `yaml:"application"`
+}
+
+type projectChange struct {
+	Field string
+	From  string
+	To    string
+}
+
+type projectAction struct {
+	Kind        string
+	Name        string
+	Spec        *projectSpec
+	Project     *wrappers.ProjectResponseModel
+	Changes     []projectChange
+	Tags        map[string]string
+	Groups      []*wrappers.Group
+	Application *wrappers.Application
+}
+
+func (a *projectAction) changed(field string) bool {
+	for _, change := range a.Changes {
+		if change.Field == field {
+			return true
+		}
+	}
+	return false
+}
+
+func projectApplySubCommand(
+	applicationsWrapper wrappers.ApplicationsWrapper,
+	projectsWrapper wrappers.ProjectsWrapper,
+	groupsWrapper wrappers.GroupsWrapper,
+	accessManagementWrapper wrappers.AccessManagementWrapper,
+	featureFlagsWrapper wrappers.FeatureFlagsWrapper,
+	tenantWrapper wrappers.TenantConfigurationWrapper,
+) *cobra.Command {
+	applyProjCmd := &cobra.Command{
+		Use:   "apply",
+		Short: "Creates and updates projects from a YAML or JSON specification",
+		Long: "The project apply command reconciles the projects declared in a specification file with Checkmarx One. " +
+			"It prints the planned changes first, then creates missing projects and updates the main branch, tags, groups, " +
+			"application and repository of existing ones",
+		Example: heredoc.Doc(
+			`
+			$ cx project apply -f projects.yaml
+			$ cx project apply -f projects.yaml --dry-run
+			$ cx project apply -f projects.yaml --prune --yes
+
+			# projects.yaml
+			owner: platform-team
+			projects:
+			  - name: payments-api
+			    mainBranch: main
+			    repoUrl: git@github.com:org/payments-api.git
+			    sshKey: ./keys/payments-api
+			    tags:
+			      team: payments
+			    groups: [PaymentsDevs]
+			    application: Payments
+		`,
+		),
+		RunE: runApplyProjectsCommand(applicationsWrapper, projectsWrapper, groupsWrapper, accessManagementWrapper, featureFlagsWrapper, tenantWrapper),
+	}
+	applyProjCmd.PersistentFlags().StringP(commonParams.SpecFileFlag, commonParams.SpecFileFlagSh, "", "Path to the YAML or JSON projects specification")
+	applyProjCmd.PersistentFlags().Bool(commonParams.PruneFlag, false,
+		fmt.Sprintf("Delete projects tagged as managed by the specification owner that are no longer declared, requires --%s", commonParams.YesFlag))
+	applyProjCmd.PersistentFlags().Bool(commonParams.DryRunFlag, false, "Print the planned changes without applying them")
+	applyProjCmd.PersistentFlags().Bool(commonParams.YesFlag, false, "Confirm the deletions planned by --prune")
+	_ = applyProjCmd.MarkPersistentFlagRequired(commonParams.SpecFileFlag)
+	return applyProjCmd
+}
+
+func runApplyProjectsCommand(
+	applicationsWrapper wrappers.ApplicationsWrapper,
+	projectsWrapper wrappers.ProjectsWrapper,
+	groupsWrapper wrappers.GroupsWrapper,
+	accessManagementWrapper wrappers.AccessManagementWrapper,
+	featureFlagsWrapper wrappers.FeatureFlagsWrapper,
+	tenantWrapper wrappers.TenantConfigurationWrapper,
+) func(cmd *cobra.Command, args []string) error {
+	return func(cmd *cobra.Command, args []string) error {
+		specPath, _ := cmd.Flags().GetString(commonParams.SpecFileFlag)
+		prune, _ := cmd.Flags().GetBool(commonParams.PruneFlag)
+		dryRun, _ := cmd.Flags().GetBool(commonParams.DryRunFlag)
+		yes, _ := cmd.Flags().GetBool(commonParams.YesFlag)
+		if prune && !dryRun && !yes {
+			return errors.Errorf("%s: --%s deletes projects, confirm it with --%s or preview it with --%s",
+				failedApplyingProjects, commonParams.PruneFlag, commonParams.YesFlag, commonParams.DryRunFlag)
+		}
+
+		spec, err := loadProjectsSpec(specPath)
+		if err != nil {
+			return errors.Wrapf(err, "%s", failedApplyingProjects)
+		}
+		groups, err := resolveSpecGroups(spec, groupsWrapper)
+		if err != nil {
+			return errors.Wrapf(err, "%s", failedApplyingProjects)
+		}
+		applications, err := resolveSpecApplications(spec, applicationsWrapper)
+		if err != nil {
+			return errors.Wrapf(err, "%s", failedApplyingProjects)
+		}
+		projects, err := getAllProjects(projectsWrapper, map[string]string{})
+		if err != nil {
+			return errors.Wrapf(err, "%s", failedApplyingProjects)
+		}
+
+		actions := planProjectsApply(spec, projects, groups, applications, prune)
+		printProjectsPlan(cmd.OutOrStdout(), actions, len(spec.Projects))
+		if dryRun {
+			return nil
+		}
+		for _, action := range actions {
+			err = applyProjectAction(cmd.OutOrStdout(), action, applicationsWrapper, projectsWrapper, accessManagementWrapper, featureFlagsWrapper, tenantWrapper)
+			if err != nil {
+				return errors.Wrapf(err, "%s: project %s", failedApplyingProjects, action.Name)
+			}
+		}
+		return nil
+	}
+}
+
+func loadProjectsSpec(path string) (*projectsSpec, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	spec := &projectsSpec{}
+	// YAML is a superset of JSON, so both formats are read by the same decoder
+	if err = yaml.Unmarshal(content, spec); err != nil {
+		return nil, errors.Wrapf(err, "Invalid projects specification %s", path)
+	}
+	if strings.TrimSpace(spec.Owner) == "" {
+		spec.Owner = defaultSpecOwner
+	}
+	names := map[string]bool{}
+	for i := range spec.Projects {
+		project := &spec.Projects[i]
+		project.Name = strings.TrimSpace(project.Name)
+		if project.Name == "" {
+			return nil, errors.Errorf("Project #%d in %s has no name", i+1, path)
+		}
+		if names[project.Name] {
+			return nil, errors.Errorf("Project %s is declared more than once in %s", project.Name, path)
+		}
+		names[project.Name] = true
+		if project.SSHKey != "" {
+			if project.RepoURL == "" {
+				return nil, errors.Errorf("Project %s declares an sshKey without a repoUrl", project.Name)
+			}
+			if !util.IsSSHURL(project.RepoURL) {
+				return nil, errors.Errorf("Project %s declares an sshKey but %s is not an SSH repository URL", project.Name, project.RepoURL)
+			}
+			if !filepath.IsAbs(project.SSHKey) {
+				project.SSHKey = filepath.Join(filepath.Dir(path), project.SSHKey)
+			}
+		}
+	}
+	return spec, nil
+}
+
+func resolveSpecGroups(spec *projectsSpec, groupsWrapper wrappers.GroupsWrapper) (map[string]*wrappers.Group, error) {
+	var names []string
+	for i := range spec.Projects {
+		for _, name := range spec.Projects[i].Groups {
+			if !slices.Contains(names, name) {
+				names = append(names, name)
+			}
+		}
+	}
+	groups := map[string]*wrappers.Group{}
+	if len(names) == 0 {
+		return groups, nil
+	}
+	groupsMap, err := services.CreateGroupsMap(strings.Join(names, ","), groupsWrapper)
+	if err != nil {
+		return nil, err
+	}
+	for _, group := range groupsMap {
+		groups[group.Name] = group
+	}
+	return groups, nil
+}
+
+func resolveSpecApplications(spec *projectsSpec, applicationsWrapper wrappers.ApplicationsWrapper) (map[string]*wrappers.Application, error) {
+	applications := map[string]*wrappers.Application{}
+	for i := range spec.Projects {
+		name := spec.Projects[i].Application
+		if name == "" || applications[name] != nil {
+			continue
+		}
+		application, err := services.GetApplication(name, applicationsWrapper)
+		if err != nil {
+			return nil, err
+		}
+		if application == nil {
+			return nil, errors.Errorf("%s: %s", errorConstants.ApplicationDoesntExistOrNoPermission, name)
+		}
+		applications[name] = application
+	}
+	return applications, nil
+}
+
+// planProjectsApply compares the specification with the existing projects and returns the actions needed
+// to reconcile them, creations first and deletions last. Projects already matching the spec get no action.
+func planProjectsApply(spec *projectsSpec, existing []wrappers.ProjectResponseModel, groups map[string]*wrappers.Group,
+	applications map[string]*wrappers.Application, prune bool) []*projectAction {
+	byName := map[string]*wrappers.ProjectResponseModel{}
+	for i := range existing {
+		byName[existing[i].Name] = &existing[i]
+	}
+
+	var creates, updates, deletes []*projectAction
+	for i := range spec.Projects {
+		declared := &spec.Projects[i]
+		action := &projectAction{Name: declared.Name, Spec: declared, Application: applications[declared.Application]}
+		for _, name := range declared.Groups {
+			action.Groups = append(action.Groups, groups[name])
+		}
+		project := byName[declared.Name]
+		action.Tags = map[string]string{}
+		if declared.Tags == nil && project != nil {
+			maps.Copy(action.Tags, project.Tags)
+		}
+		maps.Copy(action.Tags, declared.Tags)
+		action.Tags[managedByTag] = spec.Owner
+
+		if project == nil {
+			action.Kind = createAction
+			creates = append(creates, action)
+			continue
+		}
+		action.Kind = updateAction
+		action.Project = project
+		action.Changes = projectChanges(action, project)
+		if len(action.Changes) > 0 {
+			updates = append(updates, action)
+		}
+	}
+
+	if prune {
+		for i := range existing {
+			project := &existing[i]
+			if project.Tags[managedByTag] == spec.Owner && !slices.ContainsFunc(spec.Projects, func(p projectSpec) bool { return p.Name == project.Name }) {
+				deletes = append(deletes, &projectAction{Kind: deleteAction, Name: project.Name, Project: project})
+			}
+		}
+	}
+	return append(append(creates, updates...), deletes...)
+}
+
+func projectChanges(action *projectAction, project *wrappers.ProjectResponseModel) []projectChange {
+	var changes []projectChange
+	declared := action.Spec
+	if declared.MainBranch != "" && declared.MainBranch != project.MainBranch {
+		changes = append(changes, projectChange{Field: "mainBranch", From: project.MainBranch, To: declared.MainBranch})
+	}
+	if !maps.Equal(action.Tags, project.Tags) {
+		changes = append(changes, projectChange{Field: "tags", From: formatTags(project.Tags), To: formatTags(action.Tags)})
+	}
+	if declared.Groups != nil {
+		wanted := services.GetGroupIds(action.Groups)
+		current := slices.Clone(project.Groups)
+		sort.Strings(wanted)
+		sort.Strings(current)
+		if !slices.Equal(wanted, current) {
+			changes = append(changes, projectChange{Field: "groups", From: strings.Join(current, ","), To: strings.Join(declared.Groups, ",")})
+		}
+	}
+	if action.Application != nil && !slices.Contains(project.ApplicationIds, action.Application.ID) &&
+		!slices.Contains(action.Application.ProjectIds, project.ID) {
+		changes = append(changes, projectChange{Field: "application", To: action.Application.Name})
+	}
+	if declared.RepoURL != "" && declared.RepoURL != project.RepoURL {
+		changes = append(changes, projectChange{Field: "repoUrl", From: project.RepoURL, To: declared.RepoURL})
+	}
+	return changes
+}
+
+// getRemovedGroups returns the groups of the project that the spec no longer declares
+func getRemovedGroups(current []string, declared []*wrappers.Group) []*wrappers.Group {
+	wanted := services.GetGroupIds(declared)
+	var removed []*wrappers.Group
+	for _, groupID := range current {
+		if !slices.Contains(wanted, groupID) {
+			removed = append(removed, &wrappers.Group{ID: groupID})
+		}
+	}
+	return removed
+}
+
+func formatTags(tags map[string]string) string {
+	keys := slices.Sorted(maps.Keys(tags))
+	formatted := make([]string, 0, len(keys))
+	for _, key := range keys {
+		if tags[key] == "" {
+			formatted = append(formatted, key)
+		} else {
+			formatted = append(formatted, key+":"+tags[key])
+		}
+	}
+	return strings.Join(formatted, ",")
+}
+
+func printProjectsPlan(w io.Writer, actions []*projectAction, declared int) {
+	counts := map[string]int{}
+	for _, action := range actions {
+		counts[action.Kind]++
+	}
+	unchanged := declared - counts[createAction] - counts[updateAction]
+	_, _ = fmt.Fprintf(w, "Plan: %d to create, %d to update, %d to delete, %d unchanged.\n",
+		counts[createAction], counts[updateAction], counts[deleteAction], unchanged)
+	symbols := map[string]string{createAction: "+", updateAction: "~", deleteAction: "-"}
+	for _, action := range actions {
+		_, _ = fmt.Fprintf(w, "  %s %s\n", symbols[action.Kind], action.Name)
+		for _, change := range action.Changes {
+			_, _ = fmt.Fprintf(w, "      %s: %q -> %q\n", change.Field, change.From, change.To)
+		}
+	}
+}
+
+func applyProjectAction(
+	w io.Writer,
+	action *projectAction,
+	applicationsWrapper wrappers.ApplicationsWrapper,
+	projectsWrapper wrappers.ProjectsWrapper,
+	accessManagementWrapper wrappers.AccessManagementWrapper,
+	featureFlagsWrapper wrappers.FeatureFlagsWrapper,
+	tenantWrapper wrappers.TenantConfigurationWrapper,
+) error {
+	switch action.Kind {
+	case createAction:
+		projModel := wrappers.Project{
+			Name:       action.Name,
+			MainBranch: action.Spec.MainBranch,
+			Tags:       action.Tags,
+			Groups:     services.GetGroupIds(action.Groups),
+		}
+		if action.Application != nil {
+			projModel.ApplicationIds = []string{action.Application.ID}
+		}
+		project, errorModel, err := projectsWrapper.Create(&projModel)
+		if err != nil {
+			return err
+		}
+		if errorModel != nil {
+			return errors.Errorf(services.ErrorCodeFormat, services.FailedCreatingProj, errorModel.Code, errorModel.Message)
+		}
+		err = services.AssignGroupsToProjectNewAccessManagement(project.ID, project.Name, action.Groups, accessManagementWrapper, featureFlagsWrapper)
+		if err != nil {
+			return err
+		}
+		if err = updateRepositoryConfiguration(projectsWrapper, project.ID, action.Spec); err != nil {
+			return err
+		}
+		_, _ = fmt.Fprintf(w, "Created project %s (%s)\n", project.Name, project.ID)
+	case updateAction:
+		project := action.Project
+		projModel := updatedProjectModel(action)
+		var err error
+		if action.changed("groups") {
+			projModel.Groups = services.GetGroupIds(action.Groups)
+			err = services.RemoveGroupsFromProjectNewAccessManagement(project.ID, project.Name, getRemovedGroups(project.Groups, action.Groups),
+				accessManagementWrapper, featureFlagsWrapper)
+			if err != nil {
+				return err
+			}
+			err = services.UpsertProjectGroups(&projModel, projectsWrapper, accessManagementWrapper, project.ID, project.Name, featureFlagsWrapper, action.Groups)
+		} else if action.changed("mainBranch") || action.changed("tags") {
+			err = projectsWrapper.Update(project.ID, &projModel)
+		}
+		if err != nil {
+			return err
+		}
+		if action.changed("application") {
+			err = services.AddProjectToApplication(action.Application.Name, project.Name, project.ID, applicationsWrapper, featureFlagsWrapper, tenantWrapper)
+			if err != nil {
+				return err
+			}
+		}
+		if action.changed("repoUrl") {
+			if err = updateRepositoryConfiguration(projectsWrapper, project.ID, action.Spec); err != nil {
+				return err
+			}
+		}
+		_, _ = fmt.Fprintf(w, "Updated project %s (%s)\n", project.Name, project.ID)
+	case deleteAction:
+		errorModel, err := projectsWrapper.Delete(action.Project.ID)
+		if err != nil {
+			return err
+		}
+		if errorModel != nil {
+			return errors.Errorf(services.ErrorCodeFormat, failedDeletingProj, errorModel.Code, errorModel.Message)
+		}
+		_, _ = fmt.Fprintf(w, "Deleted project %s (%s)\n", action.Name, action.Project.ID)
+	}
+	return nil
+}
+
+// updatedProjectModel returns the project to send with the update. The update replaces the whole project,
+// so every field the spec does not manage is carried over from the current one, as updateProjectFields does.
+func updatedProjectModel(action *projectAction) wrappers.Project {
+	project := action.Project
+	projModel := wrappers.Project{
+		Name:           project.Name,
+		MainBranch:     project.MainBranch,
+		RepoURL:        project.RepoURL,
+		Origin:         project.Origin,
+		ScmRepoID:      project.ScmRepoID,
+		Tags:           action.Tags,
+		Groups:         project.Groups,
+		ApplicationIds: project.ApplicationIds,
+		Criticality:    project.Criticality,
+	}
+	if action.changed("mainBranch") {
+		projModel.MainBranch = action.Spec.MainBranch
+	}
+	return projModel
+}
+
+// updateRepositoryConfiguration sets the repository URL, and SSH key if declared, of the project.
+func updateRepositoryConfiguration(projectsWrapper wrappers.ProjectsWrapper, projectID string, declared *projectSpec) error {
+	if declared.RepoURL == "" {
+		return nil
+	}
+	configurations := []wrappers.ProjectConfiguration{
+		getProjectConfiguration(repoConfKey, "repository", git, projOriginLevel, declared.RepoURL, "String", true),
+	}
+	if declared.SSHKey != "" {
+		sshKey, err := util.ReadFileAsString(declared.SSHKey)
+		if err != nil {
+			return err
+		}
+		viper.Set(commonParams.SSHValue, sshKey)
+		configurations = append(configurations, getProjectConfiguration(sshConfKey, "sshKey", git, projOriginLevel, sshKey, "Secret", true))
+	}
+	errorModel, err := projectsWrapper.UpdateConfiguration(projectID, configurations)
+	if err != nil {
+		return err
+	}
+	if errorModel != nil {
+		return errors.Errorf(services.ErrorCodeFormat, "Failed updating the project repository", errorModel.Code, errorModel.Message)
+	}
+	return nil
+}
@@ -0,0 +1,128 @@
+//go:build !integration
+
+package commands
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/checkmarx/ast-cli/internal/services"
+	"github.com/checkmarx/ast-cli/internal/wrappers"
+	"gotest.tools/assert"
+)
+
+func writeProjectsSpec(t *testing.T, content string) string {
+	path := filepath.Join(t.TempDir(), "projects.yaml")
+	assert.NilError(t, os.WriteFile(path, []byte(content), 0600))
+	return path
+}
+
+const projectsSpecYAML = `
+owner: platform
+projects:
+  - name: MOCK
+    mainBranch: main
+    tags:
+      team: payments
+  - name: new-project
+    groups: [existsGroup1]
+    application: MOCK
+`
+
+func TestProjectApply_DryRun_PrintsPlan(t *testing.T) {
+	specPath := writeProjectsSpec(t, projectsSpecYAML)
+	buffer, err := executeRedirectedTestCommand("project", "apply", "-f", specPath, "--dry-run")
+	assert.NilError(t, err)
+	assert.Equal(t, buffer.String(), `Plan: 1 to create, 1 to update, 0 to delete, 0 unchanged.
+  + new-project
+  ~ MOCK
+      mainBranch: "" -> "main"
+      tags: "" -> "managed-by:platform,team:payments"
+`)
+}
+
+func TestProjectApply_CreatesAndUpdates(t *testing.T) {
+	specPath := writeProjectsSpec(t, projectsSpecYAML)
+	buffer, err := executeRedirectedTestCommand("project", "apply", "-f", specPath)
+	assert.NilError(t, err)
+	assert.Assert(t, strings.Contains(buffer.String(), "Created project new-project (ID-new-project)"))
+	assert.Assert(t, strings.Contains(buffer.String(), "Updated project MOCK (MOCK)"))
+}
+
+func TestProjectApply_PruneRequiresConfirmation(t *testing.T) {
+	specPath := writeProjectsSpec(t, projectsSpecYAML)
+	err := execCmdNotNilAssertion(t, "project", "apply", "-f", specPath, "--prune")
+	assert.ErrorContains(t, err, "--prune deletes projects, confirm it with --yes")
+
+	_, err = executeRedirectedTestCommand("project", "apply", "-f", specPath, "--prune", "--dry-run")
+	assert.NilError(t, err)
+}
+
+func TestProjectApply_JSONSpec(t *testing.T) {
+	specPath := writeProjectsSpec(t, `{"projects": [{"name": "json-project", "tags": {"team": "core"}}]}`)
+	buffer, err := executeRedirectedTestCommand("project", "apply", "--file", specPath, "--dry-run")
+	assert.NilError(t, err)
+	assert.Assert(t, strings.Contains(buffer.String(), "+ json-project"))
+}
+
+func TestProjectApply_InvalidSpec(t *testing.T) {
+	specPath := writeProjectsSpec(t, "projects:\n  - name: a\n  - name: a\n")
+	err := execCmdNotNilAssertion(t, "project", "apply", "-f", specPath)
+	assert.ErrorContains(t, err, "Project a is declared more than once")
+
+	specPath = writeProjectsSpec(t, "projects:\n  - name: a\n    sshKey: key\n    repoUrl: https://github.com/org/a\n")
+	err = execCmdNotNilAssertion(t, "project", "apply", "-f", specPath)
+	assert.ErrorContains(t, err, "is not an SSH repository URL")
+}
+
+func TestPlanProjectsApply_PruneOnlyOwnedProjects(t *testing.T) {
+	spec := &projectsSpec{Owner: "platform", Projects: []projectSpec{{Name: "kept", Groups: []string{"devs"}}}}
+	existing := []wrappers.ProjectResponseModel{
+		{ID: "1", Name: "kept", Tags: map[string]string{managedByTag: "platform"}, Groups: []string{"g1"}},
+		{ID: "2", Name: "removed", Tags: map[string]string{managedByTag: "platform"}},
+		{ID: "3", Name: "other-team", Tags: map[string]string{managedByTag: "security"}},
+		{ID: "4", Name: "unmanaged"},
+	}
+	groups := map[string]*wrappers.Group{"devs": {ID: "g1", Name: "devs"}}
+
+	actions := planProjectsApply(spec, existing, groups, nil, false)
+	assert.Equal(t, len(actions), 0)
+
+	actions = planProjectsApply(spec, existing, groups, nil, true)
+	assert.Equal(t, len(actions), 1)
+	assert.Equal(t, actions[0].Kind, deleteAction)
+	assert.Equal(t, actions[0].Project.ID, "2")
+}
+
+func TestUpdatedProjectModel_KeepsUnmanagedFields(t *testing.T) {
+	spec := &projectsSpec{Owner: "platform", Projects: []projectSpec{{Name: "payments", MainBranch: "main", Tags: map[string]string{"team": "payments"}}}}
+	existing := []wrappers.ProjectResponseModel{{
+		ID:             "1",
+		Name:           "payments",
+		MainBranch:     "master",
+		RepoURL:        "https://github.com/org/payments",
+		Groups:         []string{"g1"},
+		ApplicationIds: []string{"app1"},
+		Criticality:    5,
+	}}
+
+	actions := planProjectsApply(spec, existing, nil, nil, false)
+	assert.Equal(t, len(actions), 1)
+	projModel := updatedProjectModel(actions[0])
+
+	assert.Equal(t, projModel.MainBranch, "main")
+	assert.Equal(t, projModel.RepoURL, "https://github.com/org/payments")
+	assert.DeepEqual(t, projModel.Groups, []string{"g1"})
+	assert.DeepEqual(t, projModel.ApplicationIds, []string{"app1"})
+	assert.Equal(t, projModel.Criticality, 5)
+	assert.DeepEqual(t, projModel.Tags, map[string]string{"team": "payments", managedByTag: "platform"})
+}
+
+func TestGetRemovedGroups(t *testing.T) {
+	removed := getRemovedGroups([]string{"g1", "g2", "g3"}, []*wrappers.Group{{ID: "g2", Name: "devs"}})
+
+	assert.DeepEqual(t, services.GetGroupIds(removed), []string{"g1", "g3"})
+	assert.Assert(t, getRemovedGroups(nil, []*wrappers.Group{{ID: "g1"}}) == nil)
+}
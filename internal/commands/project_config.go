@@ -0,0 +1,404 @@
+package commands
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/MakeNowJust/heredoc"
+	"github.com/checkmarx/ast-cli/internal/commands/util/printer"
+	commonParams "github.com/checkmarx/ast-cli/internal/params"
+	"github.com/checkmarx/ast-cli/internal/services"
+	"github.com/checkmarx/ast-cli/internal/wrappers"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+const (
+	failedGettingProjConfig   = "Failed getting the project configuration"
+	failedUpdatingProjConfig  = "Failed updating the project configuration"
+	failedImportingProjConfig = "Failed importing the project configuration"
+	secretValueType           = "Secret"
+	maskedSecretValue         = "********"
+)
+
+type projectConfigView struct {
+	Key           string
+	Value         string
+	Origin        string
+	AllowOverride bool `format:"name:Allow override"`
+}
+
+func projectConfigSubCommand(projectsWrapper wrappers.ProjectsWrapper) *cobra.Command {
+	configCmd := &cobra.Command{
+		Use:   "config",
+		Short: "Manage the scan configuration of a project",
+		Long: "The project config command enables the ability to view and change the scan configuration of a project, " +
+			"such as presets, SAST filters, incremental scans, SCA resolver settings and KICS platforms. " +
+			"Keys that are not set on the project inherit the tenant defaults",
+	}
+
+	getCmd := &cobra.Command{
+		Use:   "get",
+		Short: "Show the effective configuration of a project",
+		Example: heredoc.Doc(
+			`
+			$ cx project config get --project-id <project_id>
+			$ cx project config get --project-id <project_id> --key scan.config.sast.presetName
+		`,
+		),
+		RunE: runGetProjectConfigCommand(projectsWrapper),
+	}
+	addProjectIDFlag(getCmd, "Project ID to show the configuration of")
+	getCmd.PersistentFlags().StringSlice(commonParams.ConfigKeyFlag, []string{}, "Configuration keys to show, defaults to all of them")
+	addFormatFlag(getCmd, printer.FormatTable, printer.FormatJSON, printer.FormatList)
+
+	setCmd := &cobra.Command{
+		Use:   "set",
+		Short: "Set configuration keys on a project",
+		Example: heredoc.Doc(
+			`
+			$ cx project config set --project-id <project_id> --key-value scan.config.sast.incremental=true --key-value "scan.config.sast.filter=!**/test/**"
+		`,
+		),
+		RunE: runSetProjectConfigCommand(projectsWrapper),
+	}
+	addProjectIDFlag(setCmd, "Project ID to configure")
+	setCmd.PersistentFlags().StringArray(commonParams.ConfigKeyValueFlag, []string{}, "Configuration key and value to set, in the key=value format")
+	_ = setCmd.MarkPersistentFlagRequired(commonParams.ConfigKeyValueFlag)
+
+	unsetCmd := &cobra.Command{
+		Use:   "unset",
+		Short: "Remove configuration keys from a project, restoring the tenant defaults",
+		Example: heredoc.Doc(
+			`
+			$ cx project config unset --project-id <project_id> --key scan.config.sast.incremental
+		`,
+		),
+		RunE: runUnsetProjectConfigCommand(projectsWrapper),
+	}
+	addProjectIDFlag(unsetCmd, "Project ID to configure")
+	unsetCmd.PersistentFlags().StringSlice(commonParams.ConfigKeyFlag, []string{}, "Configuration keys to remove")
+	_ = unsetCmd.MarkPersistentFlagRequired(commonParams.ConfigKeyFlag)
+
+	exportCmd := &cobra.Command{
+		Use:   "export",
+		Short: "Export the configuration set on a project",
+		Long: "The project config export command writes the keys set at project level as JSON, to be used by project config import. " +
+			"Secrets are not exported",
+		Example: heredoc.Doc(
+			`
+			$ cx project config export --project-id <project_id> --file config.json
+		`,
+		),
+		RunE: runExportProjectConfigCommand(projectsWrapper),
+	}
+	addProjectIDFlag(exportCmd, "Project ID to export the configuration of")
+	exportCmd.PersistentFlags().StringP(commonParams.SpecFileFlag, commonParams.SpecFileFlagSh, "", "Path of the file to write, defaults to the standard output")
+
+	importCmd := &cobra.Command{
+		Use:   "import",
+		Short: "Copy a configuration to one or more projects",
+		Long: "The project config import command sets the keys of an exported configuration, or the keys set on a source project, " +
+			"on every target project",
+		Example: heredoc.Doc(
+			`
+			$ cx project config import --file config.json --project-id <project_id>,<project_id>
+			$ cx project config import --source-project-id <project_id> --project-id <project_id>,<project_id>
+		`,
+		),
+		RunE: runImportProjectConfigCommand(projectsWrapper),
+	}
+	addProjectIDFlag(importCmd, "Comma separated list of the project IDs to configure")
+	importCmd.PersistentFlags().StringP(commonParams.SpecFileFlag, commonParams.SpecFileFlagSh, "", "Path of a configuration written by project config export")
+	importCmd.PersistentFlags().String(commonParams.SourceProjectIDFlag, "", "Project ID to copy the configuration from")
+	importCmd.MarkFlagsMutuallyExclusive(commonParams.SpecFileFlag, commonParams.SourceProjectIDFlag)
+	importCmd.MarkFlagsOneRequired(commonParams.SpecFileFlag, commonParams.SourceProjectIDFlag)
+
+	configCmd.AddCommand(getCmd, setCmd, unsetCmd, exportCmd, importCmd)
+	return configCmd
+}
+
+func runGetProjectConfigCommand(projectsWrapper wrappers.ProjectsWrapper) func(cmd *cobra.Command, args []string) error {
+	return func(cmd *cobra.Command, args []string) error {
+		projectID, err := getConfigProjectID(cmd, failedGettingProjConfig)
+		if err != nil {
+			return err
+		}
+		configuration, err := getProjectConfigurationByID(projectsWrapper, projectID)
+		if err != nil {
+			return err
+		}
+		keys, _ := cmd.Flags().GetStringSlice(commonParams.ConfigKeyFlag)
+		if len(keys) > 0 {
+			configuration, err = filterProjectConfiguration(configuration, keys)
+			if err != nil {
+				return errors.Wrapf(err, "%s", failedGettingProjConfig)
+			}
+		}
+		return printByFormat(cmd, toProjectConfigViews(configuration))
+	}
+}
+
+func runSetProjectConfigCommand(projectsWrapper wrappers.ProjectsWrapper) func(cmd *cobra.Command, args []string) error {
+	return func(cmd *cobra.Command, args []string) error {
+		projectID, err := getConfigProjectID(cmd, failedUpdatingProjConfig)
+		if err != nil {
+			return err
+		}
+		configuration, err := getProjectConfigurationByID(projectsWrapper, projectID)
+		if err != nil {
+			return err
+		}
+		values := map[string]string{}
+		var keys []string
+		entries, _ := cmd.Flags().GetStringArray(commonParams.ConfigKeyValueFlag)
+		for _, entry := range entries {
+			key, value, found := strings.Cut(entry, "=")
+			if !found || strings.TrimSpace(key) == "" {
+				return errors.Errorf("%s: %q is not in the key=value format", failedUpdatingProjConfig, entry)
+			}
+			key = strings.TrimSpace(key)
+			if _, duplicated := values[key]; !duplicated {
+				keys = append(keys, key)
+			}
+			values[key] = value
+		}
+		updates, err := overrideProjectConfiguration(configuration, keys, values)
+		if err != nil {
+			return errors.Wrapf(err, "%s", failedUpdatingProjConfig)
+		}
+		if err = updateProjectConfigurationByID(projectsWrapper, projectID, updates); err != nil {
+			return err
+		}
+		for _, key := range keys {
+			fmt.Fprintf(cmd.OutOrStdout(), "Set %s on project %s\n", key, projectID)
+		}
+		return nil
+	}
+}
+
+func runUnsetProjectConfigCommand(projectsWrapper wrappers.ProjectsWrapper) func(cmd *cobra.Command, args []string) error {
+	return func(cmd *cobra.Command, args []string) error {
+		projectID, err := getConfigProjectID(cmd, failedUpdatingProjConfig)
+		if err != nil {
+			return err
+		}
+		keys, _ := cmd.Flags().GetStringSlice(commonParams.ConfigKeyFlag)
+		errorModel, err := projectsWrapper.DeleteConfiguration(projectID, keys)
+		if err != nil {
+			return errors.Wrapf(err, "%s", failedUpdatingProjConfig)
+		}
+		if errorModel != nil {
+			return errors.Errorf(services.ErrorCodeFormat, failedUpdatingProjConfig, errorModel.Code, errorModel.Message)
+		}
+		for _, key := range keys {
+			fmt.Fprintf(cmd.OutOrStdout(), "Unset %s on project %s\n", key, projectID)
+		}
+		return nil
+	}
+}
+
+func runExportProjectConfigCommand(projectsWrapper wrappers.ProjectsWrapper) func(cmd *cobra.Command, args []string) error {
+	return func(cmd *cobra.Command, args []string) error {
+		projectID, err := getConfigProjectID(cmd, failedGettingProjConfig)
+		if err != nil {
+			return err
+		}
+		configuration, err := getProjectConfigurationByID(projectsWrapper, projectID)
+		if err != nil {
+			return err
+		}
+		content, err := json.MarshalIndent(exportableProjectConfiguration(configuration), "", "  ")
+		if err != nil {
+			return errors.Wrapf(err, "%s", failedGettingProjConfig)
+		}
+		content = append(content, '\n')
+
+		path, _ := cmd.Flags().GetString(commonParams.SpecFileFlag)
+		if path == "" {
+			_, err = cmd.OutOrStdout().Write(content)
+			return err
+		}
+		if err = os.WriteFile(path, content, 0600); err != nil {
+			return errors.Wrapf(err, "%s", failedGettingProjConfig)
+		}
+		return nil
+	}
+}
+
+func runImportProjectConfigCommand(projectsWrapper wrappers.ProjectsWrapper) func(cmd *cobra.Command, args []string) error {
+	return func(cmd *cobra.Command, args []string) error {
+		targets := getConfigTargetProjectIDs(cmd)
+		if len(targets) == 0 {
+			return errors.Errorf("%s: Please provide at least one project ID", failedImportingProjConfig)
+		}
+		configuration, err := loadImportedProjectConfiguration(cmd, projectsWrapper)
+		if err != nil {
+			return err
+		}
+		if len(configuration) == 0 {
+			return errors.Errorf("%s: The configuration has no project level keys", failedImportingProjConfig)
+		}
+
+		var failed []string
+		for _, projectID := range targets {
+			if err = updateProjectConfigurationByID(projectsWrapper, projectID, configuration); err != nil {
+				fmt.Fprintf(cmd.ErrOrStderr(), "%v\n", err)
+				failed = append(failed, projectID)
+				continue
+			}
+			fmt.Fprintf(cmd.OutOrStdout(), "Imported %d configuration keys to project %s\n", len(configuration), projectID)
+		}
+		if len(failed) > 0 {
+			return errors.Errorf("%s: Projects %s were not updated", failedImportingProjConfig, strings.Join(failed, ", "))
+		}
+		return nil
+	}
+}
+
+func getConfigProjectID(cmd *cobra.Command, failure string) (string, error) {
+	projectID, _ := cmd.Flags().GetString(commonParams.ProjectIDFlag)
+	if strings.TrimSpace(projectID) == "" {
+		return "", errors.Errorf("%s: Please provide a project ID", failure)
+	}
+	return strings.TrimSpace(projectID), nil
+}
+
+func getConfigTargetProjectIDs(cmd *cobra.Command) []string {
+	projectIDs, _ := cmd.Flags().GetString(commonParams.ProjectIDFlag)
+	var targets []string
+	for _, projectID := range strings.Split(projectIDs, ",") {
+		if projectID = strings.TrimSpace(projectID); projectID != "" {
+			targets = append(targets, projectID)
+		}
+	}
+	return targets
+}
+
+func getProjectConfigurationByID(projectsWrapper wrappers.ProjectsWrapper, projectID string) ([]wrappers.ProjectConfiguration, error) {
+	configuration, errorModel, err := projectsWrapper.GetConfiguration(projectID)
+	if err != nil {
+		return nil, errors.Wrapf(err, "%s", failedGettingProjConfig)
+	}
+	if errorModel != nil {
+		return nil, errors.Errorf(services.ErrorCodeFormat, failedGettingProjConfig, errorModel.Code, errorModel.Message)
+	}
+	sort.SliceStable(configuration, func(i, j int) bool {
+		return configuration[i].Key < configuration[j].Key
+	})
+	return configuration, nil
+}
+
+func updateProjectConfigurationByID(projectsWrapper wrappers.ProjectsWrapper, projectID string, configuration []wrappers.ProjectConfiguration) error {
+	errorModel, err := projectsWrapper.UpdateConfiguration(projectID, configuration)
+	if err != nil {
+		return errors.Wrapf(err, "%s %s", failedUpdatingProjConfig, projectID)
+	}
+	if errorModel != nil {
+		return errors.Errorf(services.ErrorCodeFormat, failedUpdatingProjConfig+" "+projectID, errorModel.Code, errorModel.Message)
+	}
+	return nil
+}
+
+func loadImportedProjectConfiguration(cmd *cobra.Command, projectsWrapper wrappers.ProjectsWrapper) ([]wrappers.ProjectConfiguration, error) {
+	sourceProjectID, _ := cmd.Flags().GetString(commonParams.SourceProjectIDFlag)
+	if sourceProjectID != "" {
+		configuration, err := getProjectConfigurationByID(projectsWrapper, sourceProjectID)
+		if err != nil {
+			return nil, err
+		}
+		return exportableProjectConfiguration(configuration), nil
+	}
+
+	path, _ := cmd.Flags().GetString(commonParams.SpecFileFlag)
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, errors.Wrapf(err, "%s", failedImportingProjConfig)
+	}
+	var configuration []wrappers.ProjectConfiguration
+	if err = json.Unmarshal(content, &configuration); err != nil {
+		return nil, errors.Wrapf(err, "%s: Invalid configuration file %s", failedImportingProjConfig, path)
+	}
+	for i := range configuration {
+		if configuration[i].Key == "" {
+			return nil, errors.Errorf("%s: Entry %d of %s has no key", failedImportingProjConfig, i+1, path)
+		}
+		configuration[i].OriginLevel = projOriginLevel
+	}
+	return configuration, nil
+}
+
+// overrideProjectConfiguration returns the entries to send for setting keys at project level. The name,
+// category and value type of each key are taken from its effective entry, which also rejects unknown keys.
+func overrideProjectConfiguration(configuration []wrappers.ProjectConfiguration, keys []string, values map[string]string) (
+	[]wrappers.ProjectConfiguration,
+	error,
+) {
+	var updates []wrappers.ProjectConfiguration
+	for _, key := range keys {
+		entry := findProjectConfiguration(configuration, key)
+		if entry == nil {
+			return nil, errors.Errorf("Unknown configuration key %s", key)
+		}
+		if !entry.AllowOverride && entry.OriginLevel != projOriginLevel {
+			return nil, errors.Errorf("Configuration key %s can't be overridden at project level", key)
+		}
+		update := *entry
+		update.Value = values[key]
+		update.OriginLevel = projOriginLevel
+		updates = append(updates, update)
+	}
+	return updates, nil
+}
+
+func filterProjectConfiguration(configuration []wrappers.ProjectConfiguration, keys []string) ([]wrappers.ProjectConfiguration, error) {
+	var filtered []wrappers.ProjectConfiguration
+	for _, key := range keys {
+		entry := findProjectConfiguration(configuration, key)
+		if entry == nil {
+			return nil, errors.Errorf("Unknown configuration key %s", key)
+		}
+		filtered = append(filtered, *entry)
+	}
+	return filtered, nil
+}
+
+func findProjectConfiguration(configuration []wrappers.ProjectConfiguration, key string) *wrappers.ProjectConfiguration {
+	for i := range configuration {
+		if configuration[i].Key == key {
+			return &configuration[i]
+		}
+	}
+	return nil
+}
+
+// exportableProjectConfiguration keeps the keys set at project level, leaving out secrets.
+func exportableProjectConfiguration(configuration []wrappers.ProjectConfiguration) []wrappers.ProjectConfiguration {
+	exported := []wrappers.ProjectConfiguration{}
+	for _, entry := range configuration {
+		if entry.OriginLevel == projOriginLevel && entry.ValueType != secretValueType {
+			exported = append(exported, entry)
+		}
+	}
+	return exported
+}
+
+func toProjectConfigViews(configuration []wrappers.ProjectConfiguration) []projectConfigView {
+	views := make([]projectConfigView, 0, len(configuration))
+	for _, entry := range configuration {
+		value := entry.Value
+		if entry.ValueType == secretValueType && value != "" {
+			value = maskedSecretValue
+		}
+		views = append(views, projectConfigView{
+			Key:           entry.Key,
+			Value:         value,
+			Origin:        entry.OriginLevel,
+			AllowOverride: entry.AllowOverride,
+		})
+	}
+	return views
+}
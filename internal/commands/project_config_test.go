@@ -0,0 +1,91 @@
+//go:build !integration
+
+package commands
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/checkmarx/ast-cli/internal/wrappers"
+	"gotest.tools/assert"
+)
+
+func TestProjectConfigGet_ShowsOriginAndMasksSecrets(t *testing.T) {
+	buffer, err := executeRedirectedTestCommand("project", "config", "get", "--project-id", "MOCK", "--format", "json")
+	assert.NilError(t, err)
+	var views []projectConfigView
+	assert.NilError(t, json.Unmarshal(buffer.Bytes(), &views))
+	assert.Equal(t, len(views), 5)
+	assert.Equal(t, views[0].Key, "scan.config.sast.filter")
+	assert.Equal(t, views[0].Origin, "Tenant")
+	for _, view := range views {
+		if view.Key == sshConfKey {
+			assert.Equal(t, view.Value, maskedSecretValue)
+		}
+	}
+}
+
+func TestProjectConfigGet_FiltersKeys(t *testing.T) {
+	buffer, err := executeRedirectedTestCommand("project", "config", "get", "--project-id", "MOCK", "--format", "json",
+		"--key", "scan.config.sast.presetName")
+	assert.NilError(t, err)
+	assert.Assert(t, strings.Contains(buffer.String(), "ASA Premium"))
+	assert.Assert(t, !strings.Contains(buffer.String(), "incremental"))
+
+	err = execCmdNotNilAssertion(t, "project", "config", "get", "--project-id", "MOCK", "--key", "scan.config.unknown")
+	assert.ErrorContains(t, err, "Unknown configuration key scan.config.unknown")
+}
+
+func TestProjectConfigSet(t *testing.T) {
+	buffer, err := executeRedirectedTestCommand("project", "config", "set", "--project-id", "MOCK",
+		"--key-value", "scan.config.sast.presetName=Checkmarx Default", "--key-value", "scan.config.sast.filter=!**/test/**")
+	assert.NilError(t, err)
+	assert.Equal(t, buffer.String(), "Set scan.config.sast.presetName on project MOCK\nSet scan.config.sast.filter on project MOCK\n")
+
+	err = execCmdNotNilAssertion(t, "project", "config", "set", "--project-id", "MOCK", "--key-value", "scan.config.sca.ExploitablePath=true")
+	assert.ErrorContains(t, err, "can't be overridden at project level")
+	err = execCmdNotNilAssertion(t, "project", "config", "set", "--project-id", "MOCK", "--key-value", "scan.config.sast.filter")
+	assert.ErrorContains(t, err, "is not in the key=value format")
+	err = execCmdNotNilAssertion(t, "project", "config", "set", "--key-value", "scan.config.sast.filter=a")
+	assert.ErrorContains(t, err, "Please provide a project ID")
+}
+
+func TestProjectConfigUnset(t *testing.T) {
+	buffer, err := executeRedirectedTestCommand("project", "config", "unset", "--project-id", "MOCK", "--key", "scan.config.sast.incremental")
+	assert.NilError(t, err)
+	assert.Equal(t, buffer.String(), "Unset scan.config.sast.incremental on project MOCK\n")
+}
+
+func TestProjectConfigExportImport(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.json")
+	execCmdNilAssertion(t, "project", "config", "export", "--project-id", "MOCK", "--file", path)
+	content, err := os.ReadFile(path)
+	assert.NilError(t, err)
+	var exported []wrappers.ProjectConfiguration
+	assert.NilError(t, json.Unmarshal(content, &exported))
+	assert.Equal(t, len(exported), 1)
+	assert.Equal(t, exported[0].Key, "scan.config.sast.incremental")
+
+	buffer, err := executeRedirectedTestCommand("project", "config", "import", "--file", path, "--project-id", "p1, p2")
+	assert.NilError(t, err)
+	assert.Equal(t, buffer.String(), "Imported 1 configuration keys to project p1\nImported 1 configuration keys to project p2\n")
+
+	buffer, err = executeRedirectedTestCommand("project", "config", "import", "--source-project-id", "MOCK", "--project-id", "p3")
+	assert.NilError(t, err)
+	assert.Equal(t, buffer.String(), "Imported 1 configuration keys to project p3\n")
+}
+
+func TestProjectConfigImport_InvalidInput(t *testing.T) {
+	err := execCmdNotNilAssertion(t, "project", "config", "import", "--source-project-id", "MOCK")
+	assert.ErrorContains(t, err, "Please provide at least one project ID")
+	err = execCmdNotNilAssertion(t, "project", "config", "import", "--project-id", "p1")
+	assert.ErrorContains(t, err, "at least one of the flags in the group")
+
+	path := filepath.Join(t.TempDir(), "config.json")
+	assert.NilError(t, os.WriteFile(path, []byte(`[{"value": "true"}]`), 0600))
+	err = execCmdNotNilAssertion(t, "project", "config", "import", "--file", path, "--project-id", "p1")
+	assert.ErrorContains(t, err, "has no key")
+}
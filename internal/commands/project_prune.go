@@ -0,0 +1,309 @@
+package commands
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/MakeNowJust/heredoc"
+	commonParams "github.com/checkmarx/ast-cli/internal/params"
+	"github.com/checkmarx/ast-cli/internal/services"
+	"github.com/checkmarx/ast-cli/internal/wrappers"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+const (
+	failedPruningProjects = "Failed pruning projects"
+	pruneDeleteAction     = "delete"
+	hoursPerDay           = 24
+)
+
+// pruneAuditEntry is a line of the JSON Lines audit log written by the prune commands.
+type pruneAuditEntry struct {
+	Time      string `json:"time"`
+	Action    string `json:"action"`
+	Resource  string `json:"resource"`
+	ID        string `json:"id"`
+	Name      string `json:"name,omitempty"`
+	ProjectID string `json:"projectId,omitempty"`
+	Reason    string `json:"reason"`
+	DryRun    bool   `json:"dryRun,omitempty"`
+	Error     string `json:"error,omitempty"`
+}
+
+type projectPruneCandidate struct {
+	Project      wrappers.ProjectResponseModel
+	LastActivity time.Time
+}
+
+func projectPruneSubCommand(projectsWrapper wrappers.ProjectsWrapper, scansWrapper wrappers.ScansWrapper) *cobra.Command {
+	pruneProjCmd := &cobra.Command{
+		Use:   "prune",
+		Short: "Deletes projects without scans in the given number of days",
+		Long: "The project prune command deletes the projects whose last scan or update is older than --inactive-days. " +
+			"Projects carrying the retain tag are never deleted. The planned deletions are printed, and only carried out with --yes. " +
+			"Every deletion is recorded in the audit log, the standard error unless --audit-log is given",
+		Example: heredoc.Doc(
+			`
+			$ cx project prune --inactive-days 180
+			$ cx project prune --inactive-days 180 --tag-filter team:payments --yes --audit-log prune-audit.jsonl
+		`,
+		),
+		RunE: runPruneProjectsCommand(projectsWrapper, scansWrapper),
+	}
+	pruneProjCmd.PersistentFlags().Int(commonParams.InactiveDaysFlag, 0, "Number of days without scans after which a project is deleted")
+	pruneProjCmd.PersistentFlags().String(commonParams.TagFilterFlag, "", "Only prune projects carrying all these tags, ex: (tagA,tagB:val,etc)")
+	addPruneFlags(pruneProjCmd)
+	_ = pruneProjCmd.MarkPersistentFlagRequired(commonParams.InactiveDaysFlag)
+	return pruneProjCmd
+}
+
+func addPruneFlags(cmd *cobra.Command) {
+	cmd.PersistentFlags().String(commonParams.RetainTagFlag, "",
+		fmt.Sprintf("Tag protecting projects and scans from deletion, defaults to the %s setting or %q", commonParams.RetainTagEnv, "retain"))
+	cmd.PersistentFlags().Bool(commonParams.DryRunFlag, false, "Print the planned deletions without deleting anything, the default without --yes")
+	cmd.PersistentFlags().Bool(commonParams.YesFlag, false, "Delete without confirmation, otherwise the planned deletions are only printed")
+	cmd.PersistentFlags().String(commonParams.AuditLogFlag, "",
+		"Path of a JSON Lines file to append an entry to for every deletion, defaults to the standard error")
+}
+
+// isPruneDryRun reports whether the prune commands only print their plan, which they do unless --yes confirms it
+func isPruneDryRun(cmd *cobra.Command) bool {
+	dryRun, _ := cmd.Flags().GetBool(commonParams.DryRunFlag)
+	yes, _ := cmd.Flags().GetBool(commonParams.YesFlag)
+	return dryRun || !yes
+}
+
+// printPruneConfirmation tells how to carry out a plan that was only printed
+func printPruneConfirmation(cmd *cobra.Command, count int, resource string) {
+	dryRun, _ := cmd.Flags().GetBool(commonParams.DryRunFlag)
+	if !dryRun && count > 0 {
+		_, _ = fmt.Fprintf(cmd.OutOrStdout(), "Nothing was deleted, run again with --%s to delete these %d %s.\n", commonParams.YesFlag, count, resource)
+	}
+}
+
+func runPruneProjectsCommand(projectsWrapper wrappers.ProjectsWrapper, scansWrapper wrappers.ScansWrapper) func(cmd *cobra.Command, args []string) error {
+	return func(cmd *cobra.Command, args []string) error {
+		inactiveDays, _ := cmd.Flags().GetInt(commonParams.InactiveDaysFlag)
+		tagFilter, _ := cmd.Flags().GetString(commonParams.TagFilterFlag)
+		dryRun := isPruneDryRun(cmd)
+		if inactiveDays <= 0 {
+			return errors.Errorf("%s: --%s must be greater than 0", failedPruningProjects, commonParams.InactiveDaysFlag)
+		}
+		audit, err := openPruneAuditLog(cmd)
+		if err != nil {
+			return errors.Wrapf(err, "%s", failedPruningProjects)
+		}
+		defer audit.Close()
+
+		projects, err := getAllProjects(projectsWrapper, map[string]string{})
+		if err != nil {
+			return errors.Wrapf(err, "%s", failedPruningProjects)
+		}
+		cutoff := time.Now().Add(-time.Duration(inactiveDays) * hoursPerDay * time.Hour)
+		candidates, err := planProjectsPrune(projects, scansWrapper, cutoff, services.CreateTagMap(tagFilter), getRetainTag(cmd))
+		if err != nil {
+			return errors.Wrapf(err, "%s", failedPruningProjects)
+		}
+
+		out := cmd.OutOrStdout()
+		_, _ = fmt.Fprintf(out, "Plan: %d of %d projects to delete.\n", len(candidates), len(projects))
+		for _, candidate := range candidates {
+			_, _ = fmt.Fprintf(out, "  - %s (%s), last activity %s\n", candidate.Project.Name, candidate.Project.ID, formatLastActivity(candidate.LastActivity))
+		}
+
+		var failed int
+		for _, candidate := range candidates {
+			entry := pruneAuditEntry{
+				Action:   pruneDeleteAction,
+				Resource: "project",
+				ID:       candidate.Project.ID,
+				Name:     candidate.Project.Name,
+				Reason:   fmt.Sprintf("no scans since %s", formatLastActivity(candidate.LastActivity)),
+				DryRun:   dryRun,
+			}
+			if !dryRun {
+				if deleteErr := deletePrunedProject(projectsWrapper, candidate.Project.ID); deleteErr != nil {
+					entry.Error = deleteErr.Error()
+					failed++
+					_, _ = fmt.Fprintf(cmd.ErrOrStderr(), "Failed deleting project %s (%s): %v\n", candidate.Project.Name, candidate.Project.ID, deleteErr)
+				} else {
+					_, _ = fmt.Fprintf(out, "Deleted project %s (%s)\n", candidate.Project.Name, candidate.Project.ID)
+				}
+			}
+			if err = audit.Write(&entry); err != nil {
+				return errors.Wrapf(err, "%s", failedPruningProjects)
+			}
+		}
+		if failed > 0 {
+			return errors.Errorf("%s: %d of %d projects were not deleted", failedPruningProjects, failed, len(candidates))
+		}
+		if dryRun {
+			printPruneConfirmation(cmd, len(candidates), "projects")
+		}
+		return nil
+	}
+}
+
+// planProjectsPrune returns the projects matching tagFilter whose last activity is before cutoff, leaving out the ones
+// carrying retainTag. The last activity of a project is the latest of its last scan, creation and update.
+func planProjectsPrune(
+	projects []wrappers.ProjectResponseModel,
+	scansWrapper wrappers.ScansWrapper,
+	cutoff time.Time,
+	tagFilter map[string]string,
+	retainTag string,
+) ([]projectPruneCandidate, error) {
+	var candidates []projectPruneCandidate
+	for i := range projects {
+		project := projects[i]
+		if _, retained := project.Tags[retainTag]; retained || !matchesTags(project.Tags, tagFilter) {
+			continue
+		}
+		lastActivity := project.CreatedAt
+		if project.UpdatedAt.After(lastActivity) {
+			lastActivity = project.UpdatedAt
+		}
+		scans, errorModel, err := scansWrapper.Get(map[string]string{
+			commonParams.ProjectIDQueryParam: project.ID,
+			commonParams.LimitQueryParam:     "1",
+			commonParams.SortQueryParam:      "-created_at",
+		})
+		if err != nil {
+			return nil, err
+		}
+		if errorModel != nil {
+			return nil, errors.Errorf(services.ErrorCodeFormat, failedGettingAll, errorModel.Code, errorModel.Message)
+		}
+		if len(scans.Scans) > 0 && scans.Scans[0].CreatedAt.After(lastActivity) {
+			lastActivity = scans.Scans[0].CreatedAt
+		}
+		if lastActivity.Before(cutoff) {
+			candidates = append(candidates, projectPruneCandidate{Project: project, LastActivity: lastActivity})
+		}
+	}
+	return candidates, nil
+}
+
+func matchesTags(tags, filter map[string]string) bool {
+	for key, value := range filter {
+		current, ok := tags[key]
+		if !ok || (value != "" && value != current) {
+			return false
+		}
+	}
+	return true
+}
+
+func deletePrunedProject(projectsWrapper wrappers.ProjectsWrapper, projectID string) error {
+	errorModel, err := projectsWrapper.Delete(projectID)
+	if err != nil {
+		return err
+	}
+	if errorModel != nil {
+		return errors.Errorf(services.ErrorCodeFormat, failedDeletingProj, errorModel.Code, errorModel.Message)
+	}
+	return nil
+}
+
+func formatLastActivity(lastActivity time.Time) string {
+	if lastActivity.IsZero() {
+		return "never"
+	}
+	return lastActivity.UTC().Format(time.DateOnly)
+}
+
+func getRetainTag(cmd *cobra.Command) string {
+	retainTag, _ := cmd.Flags().GetString(commonParams.RetainTagFlag)
+	if retainTag == "" {
+		retainTag = viper.GetString(commonParams.RetainTagKey)
+	}
+	return retainTag
+}
+
+// getAllProjects pages through the projects matching params.
+func getAllProjects(projectsWrapper wrappers.ProjectsWrapper, params map[string]string) ([]wrappers.ProjectResponseModel, error) {
+	projects, errorModel, err := wrappers.NewProjectsPager(projectsWrapper, params, wrappers.DefaultPageSize).All()
+	if err != nil {
+		return nil, err
+	}
+	if errorModel != nil {
+		return nil, errors.Errorf(services.ErrorCodeFormat, failedGettingAll, errorModel.Code, errorModel.Message)
+	}
+	return projects, nil
+}
+
+// getAllScans pages through the scans matching params.
+func getAllScans(scansWrapper wrappers.ScansWrapper, params map[string]string) ([]wrappers.ScanResponseModel, error) {
+	scans, errorModel, err := wrappers.NewScansPager(scansWrapper, params, wrappers.DefaultPageSize).All()
+	if err != nil {
+		return nil, err
+	}
+	if errorModel != nil {
+		return nil, errors.Errorf(services.ErrorCodeFormat, failedGettingAll, errorModel.Code, errorModel.Message)
+	}
+	return scans, nil
+}
+
+type pruneAuditLog struct {
+	writer io.WriteCloser
+}
+
+// openPruneAuditLog opens the --audit-log file, or the standard error when it isn't given
+func openPruneAuditLog(cmd *cobra.Command) (*pruneAuditLog, error) {
+	path, _ := cmd.Flags().GetString(commonParams.AuditLogFlag)
+	if path == "" {
+		return &pruneAuditLog{writer: nopWriteCloser{cmd.ErrOrStderr()}}, nil
+	}
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0600)
+	if err != nil {
+		return nil, errors.Wrapf(err, "Failed opening the audit log %s", path)
+	}
+	return &pruneAuditLog{writer: file}, nil
+}
+
+func (a *pruneAuditLog) Write(entry *pruneAuditEntry) error {
+	entry.Time = time.Now().UTC().Format(time.RFC3339)
+	line, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+	_, err = a.writer.Write(append(line, '\n'))
+	return err
+}
+
+func (a *pruneAuditLog) Close() {
+	_ = a.writer.Close()
+}
+
+// nopWriteCloser keeps the standard error open when the audit log is closed
+type nopWriteCloser struct {
+	io.Writer
+}
+
+func (nopWriteCloser) Close() error {
+	return nil
+}
+
+// parseRetentionAge parses an age given in days, as 90d, or as a Go duration, as 720h.
+func parseRetentionAge(value string) (time.Duration, error) {
+	value = strings.TrimSpace(value)
+	if days, found := strings.CutSuffix(value, "d"); found {
+		count, err := strconv.Atoi(days)
+		if err != nil || count < 0 {
+			return 0, errors.Errorf("Invalid age %q, use a number of days such as 90d or a duration such as 720h", value)
+		}
+		return time.Duration(count) * hoursPerDay * time.Hour, nil
+	}
+	age, err := time.ParseDuration(value)
+	if err != nil || age < 0 {
+		return 0, errors.Errorf("Invalid age %q, use a number of days such as 90d or a duration such as 720h", value)
+	}
+	return age, nil
+}
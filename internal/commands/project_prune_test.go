@@ -0,0 +1,99 @@
+//go:build !integration
+
+package commands
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/checkmarx/ast-cli/internal/wrappers"
+	"github.com/checkmarx/ast-cli/internal/wrappers/mock"
+	"gotest.tools/assert"
+)
+
+func TestProjectPrune_DryRunPrintsPlan(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit.jsonl")
+	buffer, err := executeRedirectedTestCommand("project", "prune", "--inactive-days", "180", "--dry-run", "--audit-log", path)
+	assert.NilError(t, err)
+	assert.Equal(t, buffer.String(), "Plan: 1 of 1 projects to delete.\n  - MOCK (MOCK), last activity never\n")
+
+	content, err := os.ReadFile(path)
+	assert.NilError(t, err)
+	var entry pruneAuditEntry
+	assert.NilError(t, json.Unmarshal(content, &entry))
+	assert.Equal(t, entry.Resource, "project")
+	assert.Equal(t, entry.ID, "MOCK")
+	assert.Assert(t, entry.DryRun)
+}
+
+func TestProjectPrune_Deletes(t *testing.T) {
+	buffer, err := executeRedirectedTestCommand("project", "prune", "--inactive-days", "30", "--yes")
+	assert.NilError(t, err)
+	assert.Assert(t, strings.HasSuffix(buffer.String(), "Deleted project MOCK (MOCK)\n"))
+}
+
+func TestProjectPrune_RequiresConfirmation(t *testing.T) {
+	buffer, err := executeRedirectedTestCommand("project", "prune", "--inactive-days", "30")
+	assert.NilError(t, err)
+	assert.Equal(t, buffer.String(), "Plan: 1 of 1 projects to delete.\n  - MOCK (MOCK), last activity never\n"+
+		"Nothing was deleted, run again with --yes to delete these 1 projects.\n")
+}
+
+func TestProjectPrune_AuditLogDefaultsToStderr(t *testing.T) {
+	cmd := createASTTestCommand()
+	stderr := bytes.NewBufferString("")
+	cmd.SetOut(bytes.NewBufferString(""))
+	cmd.SetErr(stderr)
+
+	assert.NilError(t, executeTestCommand(cmd, "project", "prune", "--inactive-days", "30", "--yes"))
+
+	var entry pruneAuditEntry
+	assert.NilError(t, json.Unmarshal(stderr.Bytes(), &entry))
+	assert.Equal(t, entry.ID, "MOCK")
+	assert.Assert(t, !entry.DryRun)
+}
+
+func TestProjectPrune_InvalidInactiveDays(t *testing.T) {
+	err := execCmdNotNilAssertion(t, "project", "prune", "--inactive-days", "0")
+	assert.ErrorContains(t, err, "--inactive-days must be greater than 0")
+	err = execCmdNotNilAssertion(t, "project", "prune")
+	assert.ErrorContains(t, err, "required flag(s) \"inactive-days\" not set")
+}
+
+func TestPlanProjectsPrune_RetainTagAndFilter(t *testing.T) {
+	old := time.Now().AddDate(-1, 0, 0)
+	projects := []wrappers.ProjectResponseModel{
+		{ID: "1", Name: "old", CreatedAt: old},
+		{ID: "2", Name: "retained", CreatedAt: old, Tags: map[string]string{"retain": ""}},
+		{ID: "3", Name: "other-team", CreatedAt: old, Tags: map[string]string{"team": "web"}},
+		{ID: "4", Name: "recent", CreatedAt: time.Now()},
+	}
+	cutoff := time.Now().AddDate(0, 0, -180)
+
+	candidates, err := planProjectsPrune(projects, &mock.ScansMockWrapper{}, cutoff, map[string]string{}, "retain")
+	assert.NilError(t, err)
+	assert.Equal(t, len(candidates), 2)
+	assert.Equal(t, candidates[0].Project.ID, "1")
+	assert.Equal(t, candidates[1].Project.ID, "3")
+
+	candidates, err = planProjectsPrune(projects, &mock.ScansMockWrapper{}, cutoff, map[string]string{"team": "web"}, "retain")
+	assert.NilError(t, err)
+	assert.Equal(t, len(candidates), 1)
+	assert.Equal(t, candidates[0].Project.ID, "3")
+}
+
+func TestParseRetentionAge(t *testing.T) {
+	age, err := parseRetentionAge("90d")
+	assert.NilError(t, err)
+	assert.Equal(t, age, 90*24*time.Hour)
+	age, err = parseRetentionAge("36h")
+	assert.NilError(t, err)
+	assert.Equal(t, age, 36*time.Hour)
+	_, err = parseRetentionAge("ninety days")
+	assert.ErrorContains(t, err, "Invalid age")
+}
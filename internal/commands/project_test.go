@@ -3,9 +3,12 @@
 package commands
 
 import (
+	"strings"
 	"testing"
 	"time"
 
+	"github.com/spf13/cobra"
+
 	asserts "github.com/stretchr/testify/assert"
 
 	errorConstants "github.com/checkmarx/ast-cli/internal/constants/errors"
@@ -111,6 +114,30 @@ func TestRunGetAllProjectsCommandWithOffset(t *testing.T) {
 	execCmdNilAssertion(t, "project", "list", "--filter", "offset=150")
 }
 
+func TestRunGetAllProjectsCommandAllPages(t *testing.T) {
+	buffer, err := executeRedirectedTestCommand("project", "list", "--all", "--page-size", "50", "--format", "ndjson")
+	assert.NilError(t, err)
+	lines := strings.Split(strings.TrimSpace(buffer.String()), "\n")
+	assert.Equal(t, len(lines), 1)
+	assert.Assert(t, strings.Contains(lines[0], `"MOCK"`))
+
+	execCmdNilAssertion(t, "project", "list", "--all", "--format", "json")
+}
+
+func TestRunGetAllProjectsCommandPageSizeWithoutAll(t *testing.T) {
+	err := execCmdNotNilAssertion(t, "project", "list", "--page-size", "50")
+	assert.ErrorContains(t, err, "--page-size can only be used with --all")
+	err = execCmdNotNilAssertion(t, "project", "list", "--all", "--page-size", "0")
+	assert.ErrorContains(t, err, "--page-size must be greater than 0")
+}
+
+func TestRunGetAllProjectsCommandAllPagesWithLimitFilter(t *testing.T) {
+	err := execCmdNotNilAssertion(t, "project", "list", "--all", "--filter", "limit=10")
+	assert.ErrorContains(t, err, "--all cannot be used with the limit filter")
+	err = execCmdNotNilAssertion(t, "project", "list", "--all", "--filter", "offset=150")
+	assert.ErrorContains(t, err, "--all cannot be used with the offset filter")
+}
+
 func TestRunGetProjectTagsCommand(t *testing.T) {
 	execCmdNilAssertion(t, "project", "tags")
 }
@@ -298,3 +325,62 @@ func TestToProjectView(t *testing.T) {
 	assert.DeepEqual(t, result.Groups, input.Groups)
 	assert.DeepEqual(t, result.ApplicationIds, input.ApplicationIds)
 }
+
+type updateRecordingProjectsWrapper struct {
+	mock.ProjectsMockWrapper
+	updated *wrappers.Project
+}
+
+func (p *updateRecordingProjectsWrapper) Update(_ string, model *wrappers.Project) error {
+	p.updated = model
+	return nil
+}
+
+func TestRunUpdateProjectCommand(t *testing.T) {
+	execCmdNilAssertion(t, "project", "update", "--project-id", "MOCK", "--project-name", "renamed", "--add-tags", "team:payments",
+		"--remove-tags", "a", "--add-groups", "existsGroup1", "--remove-groups", "existsGroup2", "--criticality", "4")
+	execCmdNilAssertion(t, "project", "update", "--project-id", "MOCK", "--application-name", "MOCK")
+	execCmdNilAssertion(t, "project", "update", "--project-id", "MOCK", "--repo-url", "git@github.com:org/repo.git")
+}
+
+func TestRunUpdateProjectCommand_InvalidInput(t *testing.T) {
+	err := execCmdNotNilAssertion(t, "project", "update", "--project-name", "renamed")
+	assert.ErrorContains(t, err, "Please provide a project ID")
+	err = execCmdNotNilAssertion(t, "project", "update", "--project-id", "MOCK")
+	assert.ErrorContains(t, err, "Please provide at least one field to update")
+	err = execCmdNotNilAssertion(t, "project", "update", "--project-id", "MOCK", "--criticality", "6")
+	assert.ErrorContains(t, err, "Criticality must be between 1 and 5")
+	err = execCmdNotNilAssertion(t, "project", "update", "--project-id", "MOCK", "--add-groups", "fake-group-error")
+	assert.ErrorContains(t, err, "Failed updating a project")
+	err = execCmdNotNilAssertion(t, "project", "update", "--project-id", "MOCK", "--application-name", mock.NoPermissionApp)
+	assert.ErrorContains(t, err, errorConstants.ApplicationDoesntExistOrNoPermission)
+}
+
+func TestUpdateProjectFields_KeepsFieldsNotPassed(t *testing.T) {
+	cmd := &cobra.Command{}
+	for _, flag := range []string{"project-name", "branch", "add-tags", "remove-tags", "add-groups", "remove-groups"} {
+		cmd.Flags().String(flag, "", "")
+	}
+	cmd.Flags().Int("criticality", 0, "")
+	assert.NilError(t, cmd.ParseFlags([]string{"--add-tags", "team:payments,env:prod", "--remove-tags", "legacy,env:dev",
+		"--remove-groups", "existsGroup1"}))
+	project := &wrappers.ProjectResponseModel{
+		ID:          "p1",
+		Name:        "payments",
+		MainBranch:  "main",
+		Tags:        map[string]string{"legacy": "", "env": "staging", "owner": "jane"},
+		Groups:      []string{"1", "2"},
+		Criticality: 4,
+	}
+	projectsWrapper := &updateRecordingProjectsWrapper{}
+
+	err := updateProjectFields(cmd, project, projectsWrapper, &mock.GroupsMockWrapper{}, &mock.AccessManagementMockWrapper{},
+		&mock.FeatureFlagsMockWrapper{})
+	assert.NilError(t, err)
+	assert.Equal(t, projectsWrapper.updated.Name, "payments")
+	assert.Equal(t, projectsWrapper.updated.MainBranch, "main")
+	assert.Equal(t, projectsWrapper.updated.Criticality, 4)
+	assert.DeepEqual(t, projectsWrapper.updated.Tags, map[string]string{"env": "prod", "owner": "jane", "team": "payments"})
+	assert.DeepEqual(t, projectsWrapper.updated.Groups, []string{"2"})
+	assert.DeepEqual(t, project.Tags, map[string]string{"legacy": "", "env": "staging", "owner": "jane"})
+}
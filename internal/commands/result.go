@@ -127,8 +127,6 @@ const (
 	sastEngineLabel           = "SAST"
 	kicsEngineLabel           = "KICS"
 	notAvailableValue         = "NA"
-	originCode                = "code"
-	originDocumentation       = "documentation"
 	statusCompleted           = "Completed"
 	statusPartial             = "Partial"
 	statusFailed              = "Failed"
@@ -735,7 +733,7 @@ func summaryReport(
 	resultsParams map[string]string,
 ) (*wrappers.ResultSummary, error) {
 	if summary.HasAPISecurity() {
-		apiSecFilterRisks, err := getFilterResultsForAPISecScanner(risksOverviewWrapper, summary.ScanID, resultsParams)
+		apiSecFilterRisks, err := util.GetFilterResultsForAPISecScanner(risksOverviewWrapper, summary.ScanID, resultsParams)
 		if err != nil {
 			return nil, err
 		}
@@ -1302,7 +1300,7 @@ func CreateScanReport(
 func countResult(summary *wrappers.ResultSummary, result *wrappers.ScanResult) {
 	engineType := strings.TrimSpace(result.Type)
 	severity := strings.ToLower(result.Severity)
-	if contains(summary.EnginesEnabled, engineType) && isExploitable(result.State) {
+	if contains(summary.EnginesEnabled, engineType) && util.IsExploitable(result.State) {
 		if engineType == commonParams.SastType {
 			summary.SastIssues++
 			summary.TotalIssues++
@@ -1645,7 +1643,7 @@ func enrichScaResults(
 		if err != nil {
 			return nil, errors.Wrapf(err, "%s", failedListingResults)
 		}
-		scaPackageModel := parseScaExportPackage(scaExportDetails.Packages)
+		scaPackageModel := util.ParseScaExportPackage(scaExportDetails.Packages)
 		scaTypeModel := parseExportScaVulnerability(scaExportDetails.ScaTypes)
 		if scaPackageModel != nil {
 			resultsModel = addPackageInformation(resultsModel, scaPackageModel, scaTypeModel)
@@ -1665,55 +1663,6 @@ func parseExportScaVulnerability(types []wrappers.ScaType) *[]wrappers.ScaTypeCo
 	return &scaTypes
 }
 
-func parseScaExportPackage(packages []wrappers.ScaPackage) *[]wrappers.ScaPackageCollection {
-	var scaPackages []wrappers.ScaPackageCollection
-	for _, pkg := range packages {
-		pkg := pkg
-		scaPackages = append(scaPackages, wrappers.ScaPackageCollection{
-			ID:                      pkg.ID,
-			Locations:               pkg.Locations,
-			DependencyPathArray:     parsePackagePathToDependencyPath(&pkg),
-			Outdated:                pkg.Outdated,
-			IsDirectDependency:      pkg.IsDirectDependency,
-			IsDevelopmentDependency: pkg.IsDevelopmentDependency,
-			IsTestDependency:        pkg.IsTestDependency,
-		})
-	}
-	return &scaPackages
-}
-
-func parsePackagePathToDependencyPath(pkg *wrappers.ScaPackage) [][]wrappers.DependencyPath {
-	var dependencyPathArray [][]wrappers.DependencyPath
-	for _, path := range pkg.PackagePathArray {
-		var dependencyPath []wrappers.DependencyPath
-		for _, dep := range path {
-			dependencyPath = append(dependencyPath, wrappers.DependencyPath{
-				ID:      dep.ID,
-				Name:    dep.Name,
-				Version: dep.Version,
-			})
-		}
-		dependencyPathArray = append(dependencyPathArray, dependencyPath)
-	}
-
-	// We are doing this to maintain the same structure that was in risk-management api response
-	// in risk-management, if the length of the dependency path array is 1, it will be the main package
-	// in export service, if there are no dependencies, the package path array will be empty
-	if len(dependencyPathArray) == 0 {
-		appendMainPackageToDependencyPath(&dependencyPathArray, pkg)
-	}
-	return dependencyPathArray
-}
-
-func appendMainPackageToDependencyPath(dependencyPathArray *[][]wrappers.DependencyPath, pkg *wrappers.ScaPackage) {
-	*dependencyPathArray = append(*dependencyPathArray, []wrappers.DependencyPath{{
-		ID:            pkg.ID,
-		Locations:     pkg.Locations,
-		Name:          pkg.Name,
-		IsDevelopment: pkg.IsDevelopmentDependency,
-	}})
-}
-
 func removeResultsByType(model *wrappers.ScanResultsCollection, resultType string) *wrappers.ScanResultsCollection {
 	var newResults []*wrappers.ScanResult
 	for _, result := range model.Results {
@@ -2923,9 +2872,9 @@ func updatePackages(
 		return
 	}
 
-	updateDependencyPaths(packages.DependencyPathArray, locationsByID)
+	util.UpdateDependencyPaths(packages.DependencyPathArray, locationsByID)
 	if !packages.SupportsQuickFix {
-		packages.SupportsQuickFix = hasQuickFix(packages.DependencyPathArray)
+		packages.SupportsQuickFix = util.HasQuickFix(packages.DependencyPathArray)
 	}
 
 	if packages.IsDirectDependency {
@@ -2938,16 +2887,6 @@ func updatePackages(
 	result.ScanResultData.ScaPackageCollection = &packages
 }
 
-func hasQuickFix(dependencyPaths [][]wrappers.DependencyPath) bool {
-	for i := range dependencyPaths {
-		head := &dependencyPaths[i][0]
-		if head.SupportsQuickFix {
-			return true
-		}
-	}
-	return false
-}
-
 func buildScaPackageMap(scaPackageModel []wrappers.ScaPackageCollection) map[string]wrappers.ScaPackageCollection {
 	scaPackageMap := make(map[string]wrappers.ScaPackageCollection)
 	for i := range scaPackageModel {
@@ -2956,18 +2895,6 @@ func buildScaPackageMap(scaPackageModel []wrappers.ScaPackageCollection) map[str
 	return scaPackageMap
 }
 
-func updateDependencyPaths(dependencyPaths [][]wrappers.DependencyPath, locationsByID map[string][]*string) {
-	for i := range dependencyPaths {
-		head := &dependencyPaths[i][0]
-		head.Locations = locationsByID[head.ID]
-		head.SupportsQuickFix = len(dependencyPaths[i]) == 1
-
-		for _, location := range locationsByID[head.ID] {
-			head.SupportsQuickFix = head.SupportsQuickFix && util.IsPackageFileSupported(*location)
-		}
-	}
-}
-
 func buildFixLink(result *wrappers.ScanResult) string {
 	if result.ID != "" {
 		return fmt.Sprint(fixLinkPrefix, result.ID)
@@ -3016,46 +2943,3 @@ func parseURI(summaryBaseURI string) (hostName string) {
 func printWarningIfIgnorePolicyOmiited() {
 	fmt.Printf("\n            Warning: The --ignore-policy flag was not implemented because you do not have the required permission.\n                     Only users with 'override-policy-management' permission can use this flag.                     \n\n")
 }
-
-func getFilterResultsForAPISecScanner(risksOverviewWrapper wrappers.RisksOverviewWrapper, scanID string, resultsParams map[string]string) (aPISecSeveritySummary *wrappers.APISecFilteredResult, err error) {
-	var apiSecRiskEntriesResult wrappers.APISecRiskEntriesResult
-	var errorModel *wrappers.WebError
-
-	apiSecRiskEntriesResult, errorModel, err = risksOverviewWrapper.GetFilterResultForAPISecByScanID(scanID, resultsParams)
-	if err != nil {
-		return nil, errors.Wrapf(err, "%s", failedListingResults)
-	}
-	if errorModel != nil {
-		return nil, errors.Errorf("%s: CODE: %d, %s", failedListingResults, errorModel.Code, errorModel.Message)
-	}
-	if len(apiSecRiskEntriesResult.Entries) > 0 {
-		entries := apiSecRiskEntriesResult.Entries
-		severityCount := make(map[string]int)
-		originCount := make(map[string]int)
-		totalRecords := 0
-		for i := range entries {
-			entry := &entries[i]
-			if !isExploitable(entry.State) {
-				continue
-			}
-			sev := strings.ToLower(entry.Severity)
-			severityCount[sev]++
-			orig := strings.ToLower(entry.Origin)
-			originCount[orig]++
-			totalRecords++
-		}
-		var riskDistribution []wrappers.RiskDistributionEntry
-		if originCount[originCode] > 0 {
-			riskDistribution = append(riskDistribution, wrappers.RiskDistributionEntry{Origin: originCode, Total: originCount[originCode]})
-		}
-		if originCount[originDocumentation] > 0 {
-			riskDistribution = append(riskDistribution, wrappers.RiskDistributionEntry{Origin: originDocumentation, Total: originCount[originDocumentation]})
-		}
-		return &wrappers.APISecFilteredResult{
-			SeverityCount:    severityCount,
-			RiskDistribution: riskDistribution,
-			TotalRisksCount:  totalRecords,
-		}, nil
-	}
-	return nil, nil
-}
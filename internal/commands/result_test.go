@@ -13,6 +13,7 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/checkmarx/ast-cli/internal/commands/util"
 	"github.com/checkmarx/ast-cli/internal/commands/util/printer"
 	errorConstants "github.com/checkmarx/ast-cli/internal/constants/errors"
 	"github.com/checkmarx/ast-cli/internal/params"
@@ -1709,7 +1710,7 @@ func TestGetFilterResultsForAPISecScanner(t *testing.T) {
 	scanID := "test-scan-id"
 	resultsParams := map[string]string{}
 
-	result, err := getFilterResultsForAPISecScanner(mockWrapper, scanID, resultsParams)
+	result, err := util.GetFilterResultsForAPISecScanner(mockWrapper, scanID, resultsParams)
 	assert.NilError(t, err)
 	assert.Assert(t, result == nil, "Expected nil result for empty entries")
 
@@ -1721,7 +1722,7 @@ func TestGetFilterResultsForAPISecScanner(t *testing.T) {
 		{Severity: "Critical", Origin: "code", State: "not_exploitable"},
 	}
 	mockWrapperWithEntries := &mock.RisksOverviewMockWrapperWithEntries{Entries: mockEntries}
-	result, err = getFilterResultsForAPISecScanner(mockWrapperWithEntries, scanID, resultsParams)
+	result, err = util.GetFilterResultsForAPISecScanner(mockWrapperWithEntries, scanID, resultsParams)
 	assert.NilError(t, err)
 	assert.Assert(t, result != nil, "Expected non-nil result for entries")
 	if result == nil {
@@ -5,6 +5,7 @@ import (
 
 	"io"
 	"log"
+	"net/http"
 	"os"
 	"path/filepath"
 	"strings"
@@ -16,6 +17,7 @@ import (
 	"github.com/checkmarx/ast-cli/internal/commands/util/printer"
 	"github.com/checkmarx/ast-cli/internal/logger"
 	"github.com/checkmarx/ast-cli/internal/params"
+	"github.com/checkmarx/ast-cli/internal/tracing"
 	"github.com/checkmarx/ast-cli/internal/wrappers/bitbucketserver"
 	"github.com/checkmarx/ast-cli/internal/wrappers/configuration"
 	"github.com/checkmarx/ast-cli/internal/wrappers/utils"
@@ -24,6 +26,7 @@ import (
 	"github.com/checkmarx/ast-cli/internal/wrappers"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
+	"go.opentelemetry.io/otel/attribute"
 )
 
 // NewAstCLI Return a Checkmarx One CLI root command to execute
@@ -51,6 +54,7 @@ func NewAstCLI(
 	bitBucketWrapper wrappers.BitBucketWrapper,
 	bitBucketServerWrapper bitbucketserver.Wrapper,
 	gitLabWrapper wrappers.GitLabWrapper,
+	giteaWrapper wrappers.GiteaWrapper,
 	bflWrapper wrappers.BflWrapper,
 	prWrapper wrappers.PRWrapper,
 	learnMoreWrapper wrappers.LearnMoreWrapper,
@@ -94,6 +98,9 @@ func NewAstCLI(
 	rootCmd.PersistentFlags().String(params.AccessKeyIDFlag, "", params.AccessKeyIDFlagUsage)
 	rootCmd.PersistentFlags().String(params.AccessKeySecretFlag, "", params.AccessKeySecretFlagUsage)
 	rootCmd.PersistentFlags().Bool(params.InsecureFlag, false, params.InsecureFlagUsage)
+	rootCmd.PersistentFlags().String(params.CACertFlag, "", params.CACertFlagUsage)
+	rootCmd.PersistentFlags().String(params.ClientCertFlag, "", params.ClientCertFlagUsage)
+	rootCmd.PersistentFlags().String(params.ClientKeyFlag, "", params.ClientKeyFlagUsage)
 	rootCmd.PersistentFlags().String(params.ProxyFlag, "", params.ProxyFlagUsage)
 	rootCmd.PersistentFlags().Bool(params.IgnoreProxyFlag, false, params.IgnoreProxyFlagUsage)
 	rootCmd.PersistentFlags().String(params.ProxyTypeFlag, "", params.ProxyTypeFlagUsage)
@@ -102,6 +109,7 @@ func NewAstCLI(
 	rootCmd.PersistentFlags().String(params.KerberosKrb5ConfFlag, "", params.KerberosKrb5ConfFlagUsage)
 	rootCmd.PersistentFlags().String(params.KerberosCcacheFlag, "", params.KerberosCcacheFlagUsage)
 	rootCmd.PersistentFlags().String(params.TimeoutFlag, "", params.TimeoutFlagUsage)
+	rootCmd.PersistentFlags().Float64(params.RequestsPerSecondFlag, 0, params.RequestsPerSecondFlagUsage)
 	rootCmd.PersistentFlags().String(params.BaseURIFlag, params.BaseURI, params.BaseURIFlagUsage)
 	rootCmd.PersistentFlags().String(params.BaseAuthURIFlag, params.BaseIAMURI, params.BaseAuthURIFlagUsage)
 	rootCmd.PersistentFlags().String(params.AstAPIKeyFlag, "", params.AstAPIKeyUsage)
@@ -117,6 +125,14 @@ func NewAstCLI(
 	_ = rootCmd.PersistentFlags().MarkHidden(params.ApikeyOverrideFlag)
 	rootCmd.PersistentFlags().String(params.LogFileFlag, "", params.LogFileUsage)
 	rootCmd.PersistentFlags().String(params.LogFileConsoleFlag, "", params.LogFileConsoleUsage)
+	rootCmd.PersistentFlags().String(params.LogFormatFlag, "", params.LogFormatUsage)
+	rootCmd.PersistentFlags().String(params.LogLevelFlag, "", params.LogLevelUsage)
+	rootCmd.PersistentFlags().Int(params.LogMaxSizeFlag, params.LogMaxSizeDefault, params.LogMaxSizeUsage)
+	rootCmd.PersistentFlags().Int(params.LogMaxBackupsFlag, params.LogMaxBackupsDefault, params.LogMaxBackupsUsage)
+	rootCmd.PersistentFlags().String(params.RecordHTTPFlag, "", params.RecordHTTPUsage)
+	rootCmd.PersistentFlags().String(params.ReplayHTTPFlag, "", params.ReplayHTTPUsage)
+	rootCmd.PersistentFlags().String(params.TraceFileFlag, "", params.TraceFileUsage)
+	rootCmd.PersistentFlags().String(params.TraceEndpointFlag, "", params.TraceEndpointUsage)
 
 	// This monitors and traps situations where "extra/garbage" commands
 	// are passed to Cobra.
@@ -130,6 +146,15 @@ func NewAstCLI(
 		if err != nil {
 			return err
 		}
+		setLogFields(cmd)
+		err = httpArchiveConfiguration()
+		if err != nil {
+			return err
+		}
+		err = tracingConfiguration(cmd)
+		if err != nil {
+			return err
+		}
 		PrintConfiguration()
 		err = configuration.LoadConfiguration()
 		if err != nil {
@@ -153,7 +178,11 @@ func NewAstCLI(
 	_ = viper.BindPFlag(params.ProxyKerberosSPNKey, rootCmd.PersistentFlags().Lookup(params.KerberosProxySPNFlag))
 	_ = viper.BindPFlag(params.ProxyKerberosKrb5ConfKey, rootCmd.PersistentFlags().Lookup(params.KerberosKrb5ConfFlag))
 	_ = viper.BindPFlag(params.ProxyKerberosCcacheKey, rootCmd.PersistentFlags().Lookup(params.KerberosCcacheFlag))
+	_ = viper.BindPFlag(params.CACertKey, rootCmd.PersistentFlags().Lookup(params.CACertFlag))
+	_ = viper.BindPFlag(params.ClientCertKey, rootCmd.PersistentFlags().Lookup(params.ClientCertFlag))
+	_ = viper.BindPFlag(params.ClientKeyKey, rootCmd.PersistentFlags().Lookup(params.ClientKeyFlag))
 	_ = viper.BindPFlag(params.ClientTimeoutKey, rootCmd.PersistentFlags().Lookup(params.TimeoutFlag))
+	_ = viper.BindPFlag(params.RequestsPerSecondKey, rootCmd.PersistentFlags().Lookup(params.RequestsPerSecondFlag))
 	_ = viper.BindPFlag(params.BaseAuthURIKey, rootCmd.PersistentFlags().Lookup(params.BaseAuthURIFlag))
 	_ = viper.BindPFlag(params.AstAPIKey, rootCmd.PersistentFlags().Lookup(params.AstAPIKeyFlag))
 	_ = viper.BindPFlag(params.AgentNameKey, rootCmd.PersistentFlags().Lookup(params.AgentFlag))
@@ -170,6 +199,14 @@ func NewAstCLI(
 	_ = viper.BindPFlag(params.ApikeyOverrideFlag, rootCmd.PersistentFlags().Lookup(params.ApikeyOverrideFlag))
 	_ = viper.BindPFlag(params.LogFileFlag, rootCmd.PersistentFlags().Lookup(params.LogFileFlag))
 	_ = viper.BindPFlag(params.LogFileConsoleFlag, rootCmd.PersistentFlags().Lookup(params.LogFileConsoleFlag))
+	_ = viper.BindPFlag(params.LogFormatKey, rootCmd.PersistentFlags().Lookup(params.LogFormatFlag))
+	_ = viper.BindPFlag(params.LogLevelKey, rootCmd.PersistentFlags().Lookup(params.LogLevelFlag))
+	_ = viper.BindPFlag(params.LogMaxSizeFlag, rootCmd.PersistentFlags().Lookup(params.LogMaxSizeFlag))
+	_ = viper.BindPFlag(params.LogMaxBackupsFlag, rootCmd.PersistentFlags().Lookup(params.LogMaxBackupsFlag))
+	_ = viper.BindPFlag(params.RecordHTTPFlag, rootCmd.PersistentFlags().Lookup(params.RecordHTTPFlag))
+	_ = viper.BindPFlag(params.ReplayHTTPFlag, rootCmd.PersistentFlags().Lookup(params.ReplayHTTPFlag))
+	_ = viper.BindPFlag(params.TraceFileFlag, rootCmd.PersistentFlags().Lookup(params.TraceFileFlag))
+	_ = viper.BindPFlag(params.TraceEndpointKey, rootCmd.PersistentFlags().Lookup(params.TraceEndpointFlag))
 	// Set help func
 	rootCmd.SetHelpFunc(
 		func(command *cobra.Command, args []string) {
@@ -202,7 +239,9 @@ func NewAstCLI(
 		realTimeWrapper,
 		tenantWrapper,
 	)
-	projectCmd := NewProjectCommand(applicationsWrapper, projectsWrapper, groupsWrapper, accessManagementWrapper, featureFlagsWrapper)
+	projectCmd := NewProjectCommand(applicationsWrapper, projectsWrapper, groupsWrapper, accessManagementWrapper, featureFlagsWrapper, tenantWrapper,
+		scansWrapper)
+	applicationCmd := NewApplicationCommand(applicationsWrapper, projectsWrapper, featureFlagsWrapper, tenantWrapper)
 	dastEnvironmentsCmd := dast.NewDastEnvironmentsCommand(dastEnvironmentsWrapper)
 
 	resultsCmd := NewResultsCommand(
@@ -230,6 +269,7 @@ func NewAstCLI(
 		bitBucketWrapper,
 		bitBucketServerWrapper,
 		gitLabWrapper,
+		giteaWrapper,
 		prWrapper,
 		learnMoreWrapper,
 		tenantWrapper,
@@ -244,6 +284,9 @@ func NewAstCLI(
 		applicationsWrapper,
 		byorWrapper,
 		featureFlagsWrapper,
+		resultsWrapper,
+		exportWrapper,
+		risksOverviewWrapper,
 	)
 
 	configCmd := util.NewConfigCommand()
@@ -260,6 +303,7 @@ func NewAstCLI(
 	rootCmd.AddCommand(
 		scanCmd,
 		projectCmd,
+		applicationCmd,
 		dastEnvironmentsCmd,
 		resultsCmd,
 		triageCmd,
@@ -387,6 +431,14 @@ func printByScanInfoFormat(cmd *cobra.Command, view interface{}) error {
 }
 
 func customLogConfiguration(cmd *cobra.Command) error {
+	logFormat := viper.GetString(params.LogFormatKey)
+	if logFormat != "" && !strings.EqualFold(logFormat, logger.FormatText) && !strings.EqualFold(logFormat, logger.FormatJSON) {
+		return errors.Errorf("Invalid value for --%s: %s. Allowed values: %s, %s", params.LogFormatFlag, logFormat, logger.FormatText, logger.FormatJSON)
+	}
+	logLevel := viper.GetString(params.LogLevelKey)
+	if _, ok := logger.ParseLevel(logLevel); logLevel != "" && !ok {
+		return errors.Errorf("Invalid value for --%s: %s. Allowed values: debug, info, warn, error", params.LogLevelFlag, logLevel)
+	}
 	if cmd.PersistentFlags().Changed(params.LogFileFlag) {
 		if err := setLogOutputFromFlag(params.LogFileFlag, viper.GetString(params.LogFileFlag)); err != nil {
 			return err
@@ -400,6 +452,51 @@ func customLogConfiguration(cmd *cobra.Command) error {
 	return nil
 }
 
+// setLogFields reports the command, and the scan and project it targets, in every structured log entry.
+func setLogFields(cmd *cobra.Command) {
+	logger.SetField(logger.FieldCommand, cmd.CommandPath())
+	if flag := cmd.Flags().Lookup(params.ScanIDFlag); flag != nil {
+		logger.SetField(logger.FieldScanID, flag.Value.String())
+	}
+	if flag := cmd.Flags().Lookup(params.ProjectIDFlag); flag != nil {
+		logger.SetField(logger.FieldProjectID, flag.Value.String())
+	}
+}
+
+func httpArchiveConfiguration() error {
+	recordPath := strings.TrimSpace(viper.GetString(params.RecordHTTPFlag))
+	replayPath := strings.TrimSpace(viper.GetString(params.ReplayHTTPFlag))
+	if recordPath != "" && replayPath != "" {
+		return errors.Errorf("--%s and --%s cannot be used together", params.RecordHTTPFlag, params.ReplayHTTPFlag)
+	}
+	if recordPath != "" {
+		return wrappers.StartHTTPRecording(recordPath)
+	}
+	if replayPath != "" {
+		return wrappers.StartHTTPReplay(replayPath)
+	}
+	return nil
+}
+
+// tracingConfiguration starts the command span when --trace-file or --trace-endpoint is set.
+// The span is ended by tracing.EndCommand once the command returns.
+func tracingConfiguration(cmd *cobra.Command) error {
+	endpoint := viper.GetString(params.TraceEndpointKey)
+	var exportClient *http.Client
+	if strings.TrimSpace(endpoint) != "" {
+		exportClient = wrappers.GetClient(viper.GetUint(params.ClientTimeoutKey))
+	}
+	err := tracing.Configure(endpoint, viper.GetString(params.TraceFileFlag), exportClient)
+	if err != nil {
+		return err
+	}
+	ctx := tracing.StartCommand(cmd.Context(), cmd.CommandPath(),
+		attribute.String("cx.agent", viper.GetString(params.AgentNameKey)),
+		attribute.String("cx.version", params.Version))
+	cmd.SetContext(ctx)
+	return nil
+}
+
 func setLogOutputFromFlag(flag, dirPath string) error {
 	if strings.TrimSpace(dirPath) == "" {
 		return errors.New("flag needs an argument: --" + flag)
@@ -420,10 +517,10 @@ func setLogOutputFromFlag(flag, dirPath string) error {
 	// Create full path for the log file
 	logFilePath := filepath.Join(dirPath, "ast-cli.log")
 
-	const defaultFilePermissions = 0666
-	// open the log file with write and append permissions
+	// open the log file with write and append permissions, rotating it once it reaches --log-max-size
 	// If file doesn't exist, it will be created. If permission is denied for directory path, return an error.
-	file, err := os.OpenFile(logFilePath, os.O_CREATE|os.O_WRONLY|os.O_APPEND, defaultFilePermissions)
+	const megabyte = 1024 * 1024
+	file, err := logger.OpenRotatingFile(logFilePath, viper.GetInt64(params.LogMaxSizeFlag)*megabyte, viper.GetInt(params.LogMaxBackupsFlag))
 	if err != nil {
 		if os.IsPermission(err) {
 			return fmt.Errorf("permission denied: cannot write to directory %s", dirPath)
@@ -58,6 +58,7 @@ func createASTTestCommand() *cobra.Command {
 	azureWrapper := &mock.AzureMockWrapper{}
 	bitBucketWrapper := &mock.BitBucketMockWrapper{}
 	gitLabWrapper := &mock.GitLabMockWrapper{}
+	giteaWrapper := &mock.GiteaMockWrapper{}
 	bflMockWrapper := &mock.BflMockWrapper{}
 	learnMoreMockWrapper := &mock.LearnMoreMockWrapper{}
 	prMockWrapper := &mock.PRMockWrapper{}
@@ -99,6 +100,7 @@ func createASTTestCommand() *cobra.Command {
 		bitBucketWrapper,
 		nil,
 		gitLabWrapper,
+		giteaWrapper,
 		bflMockWrapper,
 		prMockWrapper,
 		learnMoreMockWrapper,
@@ -31,6 +31,7 @@ import (
 	"github.com/checkmarx/ast-cli/internal/logger"
 	"github.com/checkmarx/ast-cli/internal/services"
 	"github.com/checkmarx/ast-cli/internal/services/osinstaller"
+	"github.com/checkmarx/ast-cli/internal/tracing"
 	"github.com/google/uuid"
 	"github.com/pkg/errors"
 
@@ -41,6 +42,7 @@ import (
 	"github.com/mssola/user_agent"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
+	"go.opentelemetry.io/otel/attribute"
 )
 
 const (
@@ -54,23 +56,17 @@ const (
 	thresholdMsgLog          = "Threshold check finished with status %s : %s"
 	mbBytes                  = 1024.0 * 1024.0
 	notExploitable           = "NOT_EXPLOITABLE"
-	ignored                  = "IGNORED"
 	minWindowsPathLength     = 3
 	containerImagesFlagError = "--container-images flag error"
 
 	git                                     = "git"
+	sshCredentials                          = "ssh"
 	invalidSSHSource                        = "provided source does not need a key. Make sure you are defining the right source or remove the flag --ssh-key"
 	errorUnzippingFile                      = "an error occurred while unzipping file. Reason: "
-	containerRun                            = "run"
-	containerVolumeFlag                     = "-v"
-	containerNameFlag                       = "--name"
-	containerRemove                         = "--rm"
-	containerImage                          = "checkmarx/kics@sha256:643071cf0c1657eaea695a48b49d2d61b7e625bb87c51505530e624e0c0a1ad1" // v2.1.20
 	containerScan                           = "scan"
 	containerScanPathFlag                   = "-p"
 	containerScanPath                       = "/path"
 	containerScanOutputFlag                 = "-o"
-	containerScanOutput                     = "/path"
 	containerScanFormatFlag                 = "--report-formats"
 	containerScanFormatOutput               = "json"
 	containerStarting                       = "Starting kics container"
@@ -82,7 +78,6 @@ const (
 	containerFileSourceIncompatible         = ". Provided file is not supported by kics"
 	containerFileSourceError                = " Error reading file"
 	containerResultsFileFormat              = "%s/results.json"
-	containerVolumeFormat                   = "%s:/path"
 	containerTempDirPattern                 = "kics"
 	kicsContainerPrefixName                 = "cli-kics-realtime-"
 	cleanupMaxRetries                       = 3
@@ -244,6 +239,16 @@ func NewScanCommand(
 
 	tagsCmd := scanTagsSubCommand(scansWrapper)
 
+	pruneScanCmd := scanPruneSubCommand(scansWrapper, projectsWrapper)
+
+	compareScanCmd := scanCompareSubCommand(scansWrapper, sastMetadataWrapper)
+
+	timelineScanCmd := scanTimelineSubCommand(scansWrapper)
+
+	topScanCmd := scanTopSubCommand(scansWrapper)
+
+	rerunScanCmd := scanRerunSubCommand(scansWrapper, uploadsWrapper, featureFlagsWrapper)
+
 	logsCmd := scanLogsSubCommand(logsWrapper)
 
 	kicsRealtimeCmd := scanRealtimeSubCommand()
@@ -259,7 +264,7 @@ func NewScanCommand(
 	iacRealtimeCmd := scanIacRealtimeSubCommand(jwtWrapper, featureFlagsWrapper)
 
 	addFormatFlagToMultipleCommands(
-		[]*cobra.Command{listScansCmd, showScanCmd, workflowScanCmd},
+		[]*cobra.Command{showScanCmd, workflowScanCmd},
 		printer.FormatTable, printer.FormatList, printer.FormatJSON,
 	)
 	addScanInfoFormatFlag(
@@ -270,8 +275,13 @@ func NewScanCommand(
 		scanASCACmd,
 		showScanCmd,
 		workflowScanCmd,
+		compareScanCmd,
+		timelineScanCmd,
+		topScanCmd,
+		rerunScanCmd,
 		listScansCmd,
 		deleteScanCmd,
+		pruneScanCmd,
 		cancelScanCmd,
 		tagsCmd,
 		logsCmd,
@@ -324,6 +334,7 @@ func scanRealtimeSubCommand() *cobra.Command {
 		"docker",
 		"Name in the $PATH for the container engine to run kics. Example:podman.",
 	)
+	realtimeScanCmd.PersistentFlags().String(commonParams.KicsBinaryFlag, "", util.KicsBinaryFlagUsage)
 	markFlagAsRequired(realtimeScanCmd, commonParams.KicsRealtimeFile)
 	return realtimeScanCmd
 }
@@ -583,6 +594,7 @@ func scanIacRealtimeSubCommand(
 		"docker",
 		"Name of the container engine to run IaC-Realtime. (ex. docker, podman)",
 	)
+	scanIacRealtimeCmd.Flags().String(commonParams.KicsBinaryFlag, "", util.KicsBinaryFlagUsage)
 
 	return scanIacRealtimeCmd
 }
@@ -655,6 +667,7 @@ func scanListSubCommand(scansWrapper wrappers.ScansWrapper, sastMetadataWrapper
 		Example: heredoc.Doc(
 			`
 			$ cx scan list
+			$ cx scan list --all --filter "project-id=<project_id>" --format ndjson
 		`,
 		),
 		Annotations: map[string]string{
@@ -667,6 +680,8 @@ func scanListSubCommand(scansWrapper wrappers.ScansWrapper, sastMetadataWrapper
 		RunE: runListScansCommand(scansWrapper, sastMetadataWrapper),
 	}
 	listScansCmd.PersistentFlags().StringSlice(commonParams.FilterFlag, []string{}, filterScanListFlagUsage)
+	util.AddPaginationFlags(listScansCmd)
+	addFormatFlag(listScansCmd, printer.FormatTable, printer.FormatList, printer.FormatJSON, printer.FormatNDJSON)
 	return listScansCmd
 }
 
@@ -913,6 +928,12 @@ func scanCreateSubCommand(
 	createScanCmd.PersistentFlags().String(commonParams.SCSRepoURLFlag, "", "The URL of the repo that you are scanning with scs (for scorecard scans)")
 	createScanCmd.PersistentFlags().String(commonParams.SCSEnginesFlag, "", "Specify which scs engines will run (default: all licensed engines)")
 	createScanCmd.PersistentFlags().String(commonParams.GitCommitHistoryFlag, "", commonParams.GitCommitHistoryFlagDescription)
+	createScanCmd.PersistentFlags().String(commonParams.DiffBaseFlag, "", commonParams.DiffBaseFlagUsage)
+	createScanCmd.PersistentFlags().String(
+		commonParams.DiffContextFlag,
+		diffContextModule,
+		fmt.Sprintf(commonParams.DiffContextFlagUsage, strings.Join(diffContexts, ", ")),
+	)
 	createScanCmd.PersistentFlags().Bool(commonParams.ScaHideDevAndTestDepFlag, false, scaHideDevAndTestDepFlagDescription)
 
 	// Container config flags
@@ -996,6 +1017,7 @@ func setupScanTypeProjectAndConfig(
 	if findProjectErr != nil {
 		return findProjectErr
 	}
+	logger.SetField(logger.FieldProjectID, projectID)
 
 	info["project"].(map[string]interface{})["id"] = projectID
 	// Handle the scan configuration
@@ -1641,7 +1663,7 @@ func scanTypeEnabled(scanType string) bool {
 	return false
 }
 
-func compressFolder(sourceDir, filter, userIncludeFilter, scaResolver string) (string, error) {
+func compressFolder(sourceDir, filter, userIncludeFilter, scaResolver string, diffPaths []diffScanPath) (string, error) {
 	scaToolPath := scaResolver
 	outputFile, err := os.CreateTemp(os.TempDir(), "cx-*.zip")
 	if err != nil {
@@ -1667,6 +1689,12 @@ func compressFolder(sourceDir, filter, userIncludeFilter, scaResolver string) (s
 		if err != nil {
 			return "", errors.Wrapf(err, "Cannot write to placeholder file")
 		}
+	} else if len(diffPaths) > 0 {
+		// Add only the changed files of a --diff-base scan with their context
+		err = addDiffScanFiles(zipWriter, sourceDir, diffPaths, getExcludeFilters(filter), getIncludeFilters(userIncludeFilter))
+		if err != nil {
+			return "", err
+		}
 	} else {
 		// Add directory files normally
 		err = addDirFiles(zipWriter, "", sourceDir, getExcludeFilters(filter), getIncludeFilters(userIncludeFilter))
@@ -2077,8 +2105,12 @@ func addScaResults(zipWriter *zip.Writer) error {
 	return nil
 }
 
-func getUploadURLFromSource(cmd *cobra.Command, uploadsWrapper wrappers.UploadsWrapper, featureFlagsWrapper wrappers.FeatureFlagsWrapper) (
-	url, zipFilePath string, err error) {
+func getUploadURLFromSource(
+	cmd *cobra.Command,
+	uploadsWrapper wrappers.UploadsWrapper,
+	featureFlagsWrapper wrappers.FeatureFlagsWrapper,
+	diffPaths []diffScanPath,
+) (url, zipFilePath string, err error) {
 	var preSignedURL string
 
 	sourceDirFilter, _ := cmd.Flags().GetString(commonParams.SourceDirFilterFlag)
@@ -2237,7 +2269,9 @@ func getUploadURLFromSource(cmd *cobra.Command, uploadsWrapper wrappers.UploadsW
 			}
 		} else {
 			if !isSbom {
-				zipFilePath, dirPathErr = compressFolder(directoryPath, sourceDirFilter, userIncludeFilter, scaResolver)
+				endPackage := tracing.StartPhase("scan.package")
+				zipFilePath, dirPathErr = compressFolder(directoryPath, sourceDirFilter, userIncludeFilter, scaResolver, diffPaths)
+				endPackage(dirPathErr)
 			}
 
 			// Clean up .checkmarx/containers directory after successful mixed scan (including containers) compression
@@ -2327,6 +2361,8 @@ func uploadZip(uploadsWrapper wrappers.UploadsWrapper, zipFilePath string, unzip
 	url, zipPath string,
 	err error,
 ) {
+	endUpload := tracing.StartPhase("scan.upload")
+	defer func() { endUpload(err) }()
 	var zipFilePathErr error
 	// Send a request to uploads service
 	var preSignedURL *string
@@ -2607,6 +2643,11 @@ func runCreateScanCommand(
 		if err != nil {
 			return err
 		}
+		// Limit the scan to the changes since --diff-base
+		diffPaths, err := prepareDiffScan(cmd)
+		if err != nil {
+			return err
+		}
 		ignorePolicy, _ := cmd.Flags().GetBool(commonParams.IgnorePolicyFlag)
 
 		// Check if the user has permission to override policy management if --ignore-policy is set
@@ -2626,11 +2667,12 @@ func runCreateScanCommand(
 			return errors.Errorf("--%s should be equal or higher than 0", commonParams.ScanTimeoutFlag)
 		}
 		threshold, _ := cmd.Flags().GetString(commonParams.Threshold)
-		thresholdMap := parseThreshold(threshold)
-		err = validateThresholds(thresholdMap)
+		thresholdMap := util.ParseThreshold(threshold)
+		err = util.ValidateThresholds(thresholdMap)
 		if err != nil {
 			return err
 		}
+		endPrepare := tracing.StartPhase("scan.prepare")
 		scanModel, zipFilePath, err := createScanModel(
 			cmd,
 			uploadsWrapper,
@@ -2642,7 +2684,9 @@ func runCreateScanCommand(
 			featureFlagsWrapper,
 			jwtWrapper,
 			tenantWrapper,
+			diffPaths,
 		)
+		endPrepare(err)
 
 		defer cleanUpTempZip(zipFilePath)
 		if err != nil {
@@ -2653,7 +2697,9 @@ func runCreateScanCommand(
 			return nil
 		}
 
+		endEnqueue := tracing.StartPhase("scan.enqueue")
 		scanResponseModel, errorModel, err := scansWrapper.Create(scanModel)
+		endEnqueue(err)
 		if err != nil {
 			return errors.Wrapf(err, "%s", failedCreating)
 		}
@@ -2661,6 +2707,8 @@ func runCreateScanCommand(
 		if errorModel != nil {
 			return errors.Errorf(services.ErrorCodeFormat, failedCreating, errorModel.Code, errorModel.Message)
 		} else if scanResponseModel != nil {
+			tracing.SetCommandAttributes(attribute.String("cx.scan.id", scanResponseModel.ID))
+			logger.SetField(logger.FieldScanID, scanResponseModel.ID)
 			scanResponseModel = enrichScanResponseModel(cmd, scanResponseModel)
 			err = printByScanInfoFormat(cmd, toScanView(scanResponseModel))
 			if err != nil {
@@ -2672,6 +2720,7 @@ func runCreateScanCommand(
 		policyResponseModel := &wrappers.PolicyResponseModel{}
 		if !AsyncFlag {
 			waitDelay, _ := cmd.Flags().GetInt(commonParams.WaitDelayFlag)
+			endPoll := tracing.StartPhase("scan.poll")
 			err = handleWait(
 				cmd,
 				scanResponseModel,
@@ -2687,31 +2736,40 @@ func runCreateScanCommand(
 				scanSummaryWrapper,
 				featureFlagsWrapper,
 				ignorePolicyFlagOmit)
+			endPoll(err)
 			if err != nil {
 				return err
 			}
 
 			agent, _ := cmd.Flags().GetString(commonParams.AgentFlag)
 			policyTimeout, _ := cmd.Flags().GetInt(commonParams.PolicyTimeoutFlag)
+			endPolicy := tracing.StartPhase("scan.policy")
 			policyResponseModel, err = services.HandlePolicyEvaluation(cmd, policyWrapper, scanResponseModel, ignorePolicy, agent, waitDelay, policyTimeout)
+			endPolicy(err)
 			if err != nil {
 				return err
 			}
 
+			endReports := tracing.StartPhase("scan.reports")
 			results, reportErr := createReportsAfterScan(cmd, scanResponseModel.ID, scansWrapper, exportWrapper, resultsPdfReportsWrapper, resultsJSONReportsWrapper,
 				resultsWrapper, risksOverviewWrapper, scsScanOverviewWrapper, scanSummaryWrapper, policyResponseModel, featureFlagsWrapper, ignorePolicyFlagOmit)
+			endReports(reportErr)
 			if reportErr != nil {
 				return reportErr
 			}
 
+			endThreshold := tracing.StartPhase("scan.threshold")
 			err = applyThreshold(cmd, scanResponseModel, thresholdMap, risksOverviewWrapper, results, featureFlagsWrapper)
+			endThreshold(err)
 
 			if err != nil {
 				return err
 			}
 		} else {
+			endReports := tracing.StartPhase("scan.reports")
 			_, err = createReportsAfterScan(cmd, scanResponseModel.ID, scansWrapper, exportWrapper, resultsPdfReportsWrapper, resultsJSONReportsWrapper, resultsWrapper,
 				risksOverviewWrapper, scsScanOverviewWrapper, scanSummaryWrapper, nil, featureFlagsWrapper, ignorePolicyFlagOmit)
+			endReports(err)
 			if err != nil {
 				return err
 			}
@@ -2748,6 +2806,7 @@ func createScanModel(
 	featureFlagsWrapper wrappers.FeatureFlagsWrapper,
 	jwtWrapper wrappers.JWTWrapper,
 	tenantWrapper wrappers.TenantConfigurationWrapper,
+	diffPaths []diffScanPath,
 ) (*wrappers.Scan, string, error) {
 	var input = []byte("{}")
 
@@ -2769,7 +2828,7 @@ func createScanModel(
 	}
 
 	// Set up the scan handler (either git or upload)
-	scanHandler, zipFilePath, err := setupScanHandler(cmd, uploadsWrapper, featureFlagsWrapper)
+	scanHandler, zipFilePath, err := setupScanHandler(cmd, uploadsWrapper, featureFlagsWrapper, diffPaths)
 	if err != nil {
 		return nil, zipFilePath, err
 	}
@@ -2796,7 +2855,12 @@ func getUploadType(cmd *cobra.Command) string {
 	return "upload"
 }
 
-func setupScanHandler(cmd *cobra.Command, uploadsWrapper wrappers.UploadsWrapper, featureFlagsWrapper wrappers.FeatureFlagsWrapper) (
+func setupScanHandler(
+	cmd *cobra.Command,
+	uploadsWrapper wrappers.UploadsWrapper,
+	featureFlagsWrapper wrappers.FeatureFlagsWrapper,
+	diffPaths []diffScanPath,
+) (
 	wrappers.ScanHandler,
 	string,
 	error,
@@ -2813,7 +2877,7 @@ func setupScanHandler(cmd *cobra.Command, uploadsWrapper wrappers.UploadsWrapper
 	} else {
 		var err error
 		var uploadURL string
-		uploadURL, zipFilePath, err = getUploadURLFromSource(cmd, uploadsWrapper, featureFlagsWrapper)
+		uploadURL, zipFilePath, err = getUploadURLFromSource(cmd, uploadsWrapper, featureFlagsWrapper, diffPaths)
 		if err != nil {
 			return scanHandler, zipFilePath, err
 		}
@@ -2848,20 +2912,30 @@ func setupScanHandler(cmd *cobra.Command, uploadsWrapper wrappers.UploadsWrapper
 }
 
 func defineSSHCredentials(sshKeyPath string, handler *wrappers.ScanHandler) error {
-	sshKey, err := util.ReadFileAsString(sshKeyPath)
+	credentials, err := getSSHCredentials(sshKeyPath)
 	if err != nil {
 		return err
 	}
+
+	handler.Credentials = credentials
+
+	return nil
+}
+
+// getSSHCredentials reads the ssh private key, which is then masked in the logs
+func getSSHCredentials(sshKeyPath string) (wrappers.GitCredentials, error) {
+	sshKey, err := util.ReadFileAsString(sshKeyPath)
+	if err != nil {
+		return wrappers.GitCredentials{}, err
+	}
 	viper.Set(commonParams.SSHValue, sshKey)
 
 	credentials := wrappers.GitCredentials{}
 
-	credentials.Type = "ssh"
+	credentials.Type = sshCredentials
 	credentials.Value = sshKey
 
-	handler.Credentials = credentials
-
-	return nil
+	return credentials, nil
 }
 
 func handleWait(
@@ -2996,7 +3070,7 @@ func applyThreshold(
 	if err != nil {
 		return err
 	}
-	summaryMap, err := getSummaryThresholdMap(scanResponseModel, risksOverviewWrapper, results, featureFlagsWrapper, resultsParams)
+	summaryMap, err := util.GetSummaryThresholdMap(scanResponseModel, risksOverviewWrapper, results, featureFlagsWrapper, resultsParams)
 
 	if err != nil {
 		return err
@@ -3030,96 +3104,6 @@ func applyThreshold(
 	return nil
 }
 
-func parseThreshold(threshold string) map[string]int {
-	if strings.TrimSpace(threshold) == "" {
-		return nil
-	}
-	thresholdMap := make(map[string]int)
-	if threshold != "" {
-		threshold = strings.ReplaceAll(strings.ReplaceAll(threshold, " ", ""), ",", ";")
-		thresholdLimits := strings.Split(strings.ToLower(threshold), ";")
-		for _, limits := range thresholdLimits {
-			engineName, intLimit, err := parseThresholdLimit(limits)
-			if err != nil {
-				log.Printf("%s", err)
-			} else {
-				thresholdMap[engineName] = intLimit
-			}
-		}
-	}
-
-	return thresholdMap
-}
-
-func validateThresholds(thresholdMap map[string]int) error {
-	var errMsgBuilder strings.Builder
-
-	for engineName, limit := range thresholdMap {
-		if limit < 1 {
-			errMsgBuilder.WriteString(errors.Errorf("Invalid value for threshold limit %s. Threshold should be greater or equal to 1.\n", engineName).Error())
-		}
-	}
-
-	errMsg := errMsgBuilder.String()
-	if errMsg != "" {
-		return errors.New(errMsg)
-	}
-	return nil
-}
-
-func parseThresholdLimit(limit string) (engineName string, intLimit int, err error) {
-	parts := strings.Split(limit, "=")
-	engineName = strings.Replace(parts[0], commonParams.KicsType, commonParams.IacType, 1)
-	if len(parts) <= 1 {
-		return engineName, 0, errors.New("Error parsing threshold limit: missing values\n")
-	}
-	intLimit, err = strconv.Atoi(parts[1])
-	if err != nil {
-		err = errors.Errorf("%s: Error parsing threshold limit: %v\n", engineName, err)
-	}
-	return engineName, intLimit, err
-}
-
-func getSummaryThresholdMap(
-	scan *wrappers.ScanResponseModel,
-	risksOverviewWrapper wrappers.RisksOverviewWrapper,
-	results *wrappers.ScanResultsCollection,
-	featureFlagsWrapper wrappers.FeatureFlagsWrapper,
-	resultsParam map[string]string,
-) (map[string]int, error) {
-	summaryMap := make(map[string]int)
-
-	for _, result := range results.Results {
-		if isExploitable(result.State) {
-			key := strings.ToLower(fmt.Sprintf("%s-%s", strings.Replace(result.Type, commonParams.KicsType, commonParams.IacType, 1), result.Severity))
-			summaryMap[key]++
-		}
-	}
-
-	if slices.Contains(scan.Engines, commonParams.APISecType) {
-		apiSecFilterRisks, err := getFilterResultsForAPISecScanner(risksOverviewWrapper, scan.ID, resultsParam)
-		if err != nil {
-			return nil, err
-		}
-		if apiSecFilterRisks != nil && apiSecFilterRisks.SeverityCount != nil {
-			summaryMap["api-security-high"] = apiSecFilterRisks.SeverityCount["high"]
-			summaryMap["api-security-medium"] = apiSecFilterRisks.SeverityCount["medium"]
-			summaryMap["api-security-low"] = apiSecFilterRisks.SeverityCount["low"]
-
-			flagResponse, _ := wrappers.GetSpecificFeatureFlag(featureFlagsWrapper, wrappers.CVSSV3Enabled)
-			criticalEnabled := flagResponse.Status
-			if criticalEnabled {
-				summaryMap["api-security-critical"] = apiSecFilterRisks.SeverityCount["critical"]
-			}
-		}
-	}
-	return summaryMap, nil
-}
-
-func isExploitable(state string) bool {
-	return !strings.EqualFold(state, notExploitable) && !strings.EqualFold(state, ignored)
-}
-
 func waitForScanCompletion(
 	scanResponseModel *wrappers.ScanResponseModel,
 	waitDelay,
@@ -3273,6 +3257,23 @@ func runListScansCommand(scansWrapper wrappers.ScansWrapper, sastMetadataWrapper
 		if err != nil {
 			return errors.Wrapf(err, "%s", failedGettingAll)
 		}
+		all, pageSize, err := util.GetPagination(cmd, params)
+		if err != nil {
+			return errors.Wrapf(err, "%s", failedGettingAll)
+		}
+		if all {
+			errorModel, err = util.PrintAllPages(cmd, wrappers.NewScansPager(scansWrapper, params, pageSize),
+				func(scans []wrappers.ScanResponseModel) ([]*scanView, error) {
+					return toScanViews(scans, sastMetadataWrapper)
+				})
+			if err != nil {
+				return errors.Wrapf(err, "%s\n", failedGettingAll)
+			}
+			if errorModel != nil {
+				return errors.Errorf(services.ErrorCodeFormat, failedGettingAll, errorModel.Code, errorModel.Message)
+			}
+			return nil
+		}
 		allScansModel, errorModel, err = scansWrapper.Get(params)
 		if err != nil {
 			return errors.Wrapf(err, "%s\n", failedGettingAll)
@@ -3430,17 +3431,17 @@ func runDownloadLogs(logsWrapper wrappers.LogsWrapper) func(*cobra.Command, []st
 
 func runKicksRealtime() func(*cobra.Command, []string) error {
 	return func(cmd *cobra.Command, _ []string) error {
-		// Create temp location and add it to container volumes
-		volumeMap, tempDir, err := createKicsScanEnv(cmd)
+		// Create temp location and add it to the KICS volumes
+		volume, err := createKicsScanEnv(cmd)
 		if err != nil {
 			return errors.Errorf("%s", err)
 		}
 
-		// Run kics container
-		err = runKicsScan(cmd, volumeMap, tempDir, aditionalParameters)
+		// Run kics
+		err = runKicsScan(cmd, volume, aditionalParameters)
 		// Removing temporary dir
 		logger.PrintIfVerbose(containerFolderRemoving)
-		removeErr := os.RemoveAll(tempDir)
+		removeErr := os.RemoveAll(volume.HostPath)
 		if removeErr != nil {
 			logger.PrintIfVerbose(removeErr.Error())
 		}
@@ -3532,30 +3533,29 @@ func toScanView(scan *wrappers.ScanResponseModel) *scanView {
 	}
 }
 
-func createKicsScanEnv(cmd *cobra.Command) (volumeMap, kicsDir string, err error) {
-	kicsDir, err = ioutil.TempDir("", containerTempDirPattern)
+func createKicsScanEnv(cmd *cobra.Command) (volume util.KicsVolume, err error) {
+	kicsDir, err := ioutil.TempDir("", containerTempDirPattern)
 	if err != nil {
-		return "", "", errors.New(containerCreateFolderError)
+		return util.KicsVolume{}, errors.New(containerCreateFolderError)
 	}
 	kicsFilePath, _ := cmd.Flags().GetString(commonParams.KicsRealtimeFile)
 	if len(kicsFilePath) < 1 {
-		return "", "", errors.New(containerFileSourceMissing)
+		return util.KicsVolume{}, errors.New(containerFileSourceMissing)
 	}
 	if !contains(commonParams.KicsBaseFilters, kicsFilePath) {
-		return "", "", errors.New(kicsFilePath + containerFileSourceIncompatible)
+		return util.KicsVolume{}, errors.New(kicsFilePath + containerFileSourceIncompatible)
 	}
 	kicsFile, err := ioutil.ReadFile(kicsFilePath)
 	if err != nil {
-		return "", "", errors.New(containerFileSourceError)
+		return util.KicsVolume{}, errors.New(containerFileSourceError)
 	}
 	_, file := filepath.Split(kicsFilePath)
 	destinationFile := fmt.Sprintf("%s/%s", kicsDir, file)
 	err = ioutil.WriteFile(destinationFile, kicsFile, 0666)
 	if err != nil {
-		return "", "", errors.New(containerWriteFolderError)
+		return util.KicsVolume{}, errors.New(containerWriteFolderError)
 	}
-	volumeMap = fmt.Sprintf(containerVolumeFormat, kicsDir)
-	return volumeMap, kicsDir, nil
+	return util.KicsVolume{HostPath: kicsDir, ContainerPath: containerScanPath}, nil
 }
 
 func contains(s []string, str string) bool {
@@ -3596,21 +3596,20 @@ func readKicsResultsFile(tempDir string) (wrappers.KicsResultsCollection, error)
 	return resultsModel, nil
 }
 
-func runKicsScan(cmd *cobra.Command, volumeMap, tempDir string, additionalParameters []string) error {
+func runKicsScan(cmd *cobra.Command, volume util.KicsVolume, additionalParameters []string) error {
 	var errs error
+	engine, _ := cmd.Flags().GetString(commonParams.KicsRealtimeEngine)
+	kicsBinary, _ := cmd.Flags().GetString(commonParams.KicsBinaryFlag)
+	runner, err := util.NewKicsRunner(kicsBinary, engine, viper.GetString(commonParams.KicsContainerNameKey))
+	if err != nil {
+		return err
+	}
 	kicsRunArgs := []string{
-		containerRun,
-		containerRemove,
-		containerVolumeFlag,
-		volumeMap,
-		containerNameFlag,
-		viper.GetString(commonParams.KicsContainerNameKey),
-		containerImage,
 		containerScan,
 		containerScanPathFlag,
-		containerScanPath,
+		runner.Path(volume),
 		containerScanOutputFlag,
-		containerScanOutput,
+		runner.Path(volume),
 		containerScanFormatFlag,
 		containerScanFormatOutput,
 	}
@@ -3620,8 +3619,7 @@ func runKicsScan(cmd *cobra.Command, volumeMap, tempDir string, additionalParame
 	}
 	logger.PrintIfVerbose(containerStarting)
 	logger.PrintIfVerbose(containerFormatInfo)
-	kicsCmd, _ := cmd.Flags().GetString(commonParams.KicsRealtimeEngine)
-	out, err := exec.Command(kicsCmd, kicsRunArgs...).CombinedOutput()
+	out, err := runner.Run(kicsRunArgs, volume)
 	logger.PrintIfVerbose(string(out))
 	var resultsModel wrappers.KicsResultsCollection
 	/* 	NOTE: the kics container returns 40 instead of 0 when successful!! This
@@ -3644,7 +3642,7 @@ func runKicsScan(cmd *cobra.Command, volumeMap, tempDir string, additionalParame
 		extractedErrorCode := errorMessage[strings.LastIndex(errorMessage, " ")+1:]
 
 		if contains(kicsErrorCodes, extractedErrorCode) {
-			resultsModel, errs = readKicsResultsFile(tempDir)
+			resultsModel, errs = readKicsResultsFile(volume.HostPath)
 			if errs != nil {
 				return errors.Errorf("%s", errs)
 			}
@@ -0,0 +1,260 @@
+package commands
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/MakeNowJust/heredoc"
+	"github.com/checkmarx/ast-cli/internal/commands/util/printer"
+	commonParams "github.com/checkmarx/ast-cli/internal/params"
+	"github.com/checkmarx/ast-cli/internal/services"
+	"github.com/checkmarx/ast-cli/internal/wrappers"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+const (
+	failedComparingScans = "Failed comparing scans"
+	scansToCompare       = 2
+	missingCompareValue  = "-"
+	scanSection          = "Scan"
+	configurationSection = "Configuration"
+	metadataSection      = "Metadata"
+	timingsSection       = "Timings"
+)
+
+type scanComparisonView struct {
+	Section string
+	Field   string
+	First   string `format:"name:First scan"`
+	Second  string `format:"name:Second scan"`
+	Changed bool
+}
+
+// scanComparisonInput gathers what is known about one of the compared scans. Metadata is nil for scans without SAST.
+type scanComparisonInput struct {
+	Scan     *wrappers.ScanResponseModel
+	Metadata *wrappers.Scans
+	Workflow []*wrappers.ScanTaskResponseModel
+}
+
+func scanCompareSubCommand(scansWrapper wrappers.ScansWrapper, sastMetadataWrapper wrappers.SastMetadataWrapper) *cobra.Command {
+	compareScanCmd := &cobra.Command{
+		Use:   "compare",
+		Short: "Compare the configuration and metadata of two scans",
+		Long: "The compare command shows side by side the configuration, the SAST metadata (LOC, file count, incremental, preset) " +
+			"and the workflow timings of two scans",
+		Example: heredoc.Doc(
+			`
+			$ cx scan compare --scan-id <scan_id_a> --scan-id <scan_id_b>
+			$ cx scan compare --scan-id <scan_id_a>,<scan_id_b> --changed-only --format json
+		`,
+		),
+		RunE: runCompareScansCommand(scansWrapper, sastMetadataWrapper),
+	}
+	compareScanCmd.PersistentFlags().StringSlice(commonParams.ScanIDFlag, []string{}, "IDs of the two scans to compare")
+	compareScanCmd.PersistentFlags().Bool(commonParams.ChangedOnlyFlag, false, "Only show the fields that differ between the scans")
+	addFormatFlag(compareScanCmd, printer.FormatTable, printer.FormatJSON, printer.FormatList)
+	return compareScanCmd
+}
+
+func runCompareScansCommand(scansWrapper wrappers.ScansWrapper, sastMetadataWrapper wrappers.SastMetadataWrapper) func(cmd *cobra.Command, args []string) error {
+	return func(cmd *cobra.Command, args []string) error {
+		scanIDs, _ := cmd.Flags().GetStringSlice(commonParams.ScanIDFlag)
+		changedOnly, _ := cmd.Flags().GetBool(commonParams.ChangedOnlyFlag)
+		if len(scanIDs) != scansToCompare {
+			return errors.Errorf("%s: Please provide exactly two scan IDs", failedComparingScans)
+		}
+		if scanIDs[0] == scanIDs[1] {
+			return errors.Errorf("%s: Please provide two different scan IDs", failedComparingScans)
+		}
+
+		metadata, err := sastMetadataWrapper.GetSastMetadataByIDs(map[string]string{
+			commonParams.ScanIDsQueryParam: strings.Join(scanIDs, ","),
+		})
+		if err != nil {
+			return errors.Wrapf(err, "%s", failedComparingScans)
+		}
+		inputs := make([]*scanComparisonInput, len(scanIDs))
+		for i, scanID := range scanIDs {
+			inputs[i], err = getScanComparisonInput(scansWrapper, scanID, metadata)
+			if err != nil {
+				return err
+			}
+		}
+
+		views := compareScans(inputs[0], inputs[1])
+		if changedOnly {
+			changed := []scanComparisonView{}
+			for _, view := range views {
+				if view.Changed {
+					changed = append(changed, view)
+				}
+			}
+			views = changed
+		}
+		return printByFormat(cmd, views)
+	}
+}
+
+func getScanComparisonInput(scansWrapper wrappers.ScansWrapper, scanID string, metadata *wrappers.SastMetadataModel) (*scanComparisonInput, error) {
+	scan, errorModel, err := scansWrapper.GetByID(scanID)
+	if err != nil {
+		return nil, errors.Wrapf(err, "%s", failedComparingScans)
+	}
+	if errorModel != nil {
+		return nil, errors.Errorf(services.ErrorCodeFormat, failedComparingScans, errorModel.Code, errorModel.Message)
+	}
+	workflow, errorModel, err := scansWrapper.GetWorkflowByID(scanID)
+	if err != nil {
+		return nil, errors.Wrapf(err, "%s", failedComparingScans)
+	}
+	if errorModel != nil {
+		return nil, errors.Errorf(services.ErrorCodeFormat, failedComparingScans, errorModel.Code, errorModel.Message)
+	}
+	input := &scanComparisonInput{Scan: scan, Workflow: workflow}
+	if metadata != nil {
+		for i := range metadata.Scans {
+			if metadata.Scans[i].ScanID == scanID {
+				input.Metadata = &metadata.Scans[i]
+			}
+		}
+	}
+	return input, nil
+}
+
+// compareScans returns a row for every scan field, configuration key, SAST metadata field and workflow timing of the
+// two scans. Values missing on one side are shown as "-".
+func compareScans(first, second *scanComparisonInput) []scanComparisonView {
+	var views []scanComparisonView
+	add := func(section, field, firstValue, secondValue string) {
+		views = append(views, scanComparisonView{
+			Section: section,
+			Field:   field,
+			First:   firstValue,
+			Second:  secondValue,
+			Changed: firstValue != secondValue,
+		})
+	}
+
+	for _, field := range []struct {
+		name  string
+		value func(scan *wrappers.ScanResponseModel) string
+	}{
+		{"ID", func(scan *wrappers.ScanResponseModel) string { return scan.ID }},
+		{"Project", func(scan *wrappers.ScanResponseModel) string { return scan.ProjectName }},
+		{"Branch", func(scan *wrappers.ScanResponseModel) string { return scan.Branch }},
+		{"Status", func(scan *wrappers.ScanResponseModel) string { return string(scan.Status) }},
+		{"Engines", func(scan *wrappers.ScanResponseModel) string { return strings.Join(scan.Engines, ",") }},
+		{"Source type", func(scan *wrappers.ScanResponseModel) string { return scan.SourceType }},
+		{"Source origin", func(scan *wrappers.ScanResponseModel) string { return scan.SourceOrigin }},
+		{"Initiator", func(scan *wrappers.ScanResponseModel) string { return scan.Initiator }},
+		{"Timeout", func(scan *wrappers.ScanResponseModel) string { return scan.Timeout }},
+	} {
+		add(scanSection, field.name, compareValue(field.value(first.Scan)), compareValue(field.value(second.Scan)))
+	}
+
+	firstConfig := flattenScanConfigs(first.Scan.Metadata.Configs)
+	secondConfig := flattenScanConfigs(second.Scan.Metadata.Configs)
+	for _, key := range sortedUnionKeys(firstConfig, secondConfig) {
+		add(configurationSection, key, compareValue(firstConfig[key]), compareValue(secondConfig[key]))
+	}
+
+	firstMetadata := flattenSastMetadata(first.Metadata)
+	secondMetadata := flattenSastMetadata(second.Metadata)
+	for _, field := range sastMetadataFields {
+		add(metadataSection, field, compareValue(firstMetadata[field]), compareValue(secondMetadata[field]))
+	}
+
+	firstTimings := scanTimings(first)
+	secondTimings := scanTimings(second)
+	for _, key := range sortedUnionKeys(firstTimings, secondTimings) {
+		add(timingsSection, key, compareValue(firstTimings[key]), compareValue(secondTimings[key]))
+	}
+	return views
+}
+
+var sastMetadataFields = []string{"Preset", "Incremental", "Incremental canceled", "Base scan", "LOC", "File count",
+	"Added files", "Changed files", "Deleted files"}
+
+func flattenSastMetadata(metadata *wrappers.Scans) map[string]string {
+	if metadata == nil {
+		return map[string]string{}
+	}
+	canceled := strconv.FormatBool(metadata.IsIncrementalCanceled)
+	if metadata.IncrementalCancelReason != "" {
+		canceled = fmt.Sprintf("%s (%s)", canceled, metadata.IncrementalCancelReason)
+	}
+	return map[string]string{
+		"Preset":               metadata.QueryPreset,
+		"Incremental":          strconv.FormatBool(metadata.IsIncremental),
+		"Incremental canceled": canceled,
+		"Base scan":            metadata.BaseID,
+		"LOC":                  strconv.Itoa(metadata.Loc),
+		"File count":           strconv.Itoa(metadata.FileCount),
+		"Added files":          strconv.Itoa(metadata.AddedFilesCount),
+		"Changed files":        strconv.Itoa(metadata.ChangedFilesCount),
+		"Deleted files":        strconv.Itoa(metadata.DeletedFilesCount),
+	}
+}
+
+func flattenScanConfigs(configs []wrappers.Config) map[string]string {
+	flattened := make(map[string]string)
+	for _, config := range configs {
+		for key, value := range config.Value {
+			flattened[config.Type+"."+key] = fmt.Sprint(value)
+		}
+	}
+	return flattened
+}
+
+// scanTimings returns the total duration of a scan and, for every workflow source, the time between its first and
+// last workflow entry.
+func scanTimings(input *scanComparisonInput) map[string]string {
+	timings := make(map[string]string)
+	if !input.Scan.CreatedAt.IsZero() && input.Scan.UpdatedAt.After(input.Scan.CreatedAt) {
+		timings["Total"] = input.Scan.UpdatedAt.Sub(input.Scan.CreatedAt).Round(time.Second).String()
+	}
+	starts := make(map[string]time.Time)
+	ends := make(map[string]time.Time)
+	for _, task := range input.Workflow {
+		timestamp, err := time.Parse(time.RFC3339Nano, task.Timestamp)
+		if err != nil {
+			continue
+		}
+		if start, ok := starts[task.Source]; !ok || timestamp.Before(start) {
+			starts[task.Source] = timestamp
+		}
+		if timestamp.After(ends[task.Source]) {
+			ends[task.Source] = timestamp
+		}
+	}
+	for source, start := range starts {
+		timings[source] = ends[source].Sub(start).Round(time.Second).String()
+	}
+	return timings
+}
+
+func sortedUnionKeys(first, second map[string]string) []string {
+	keys := make([]string, 0, len(first)+len(second))
+	for key := range first {
+		keys = append(keys, key)
+	}
+	for key := range second {
+		if _, ok := first[key]; !ok {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func compareValue(value string) string {
+	if value == "" {
+		return missingCompareValue
+	}
+	return value
+}
@@ -0,0 +1,76 @@
+//go:build !integration
+
+package commands
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/checkmarx/ast-cli/internal/wrappers"
+	"gotest.tools/assert"
+)
+
+func TestScanCompare(t *testing.T) {
+	buffer, err := executeRedirectedTestCommand("scan", "compare", "--scan-id", "scan1", "--scan-id", "scan2", "--format", "json")
+	assert.NilError(t, err)
+	var views []scanComparisonView
+	assert.NilError(t, json.Unmarshal(buffer.Bytes(), &views))
+	rows := make(map[string]scanComparisonView)
+	for _, view := range views {
+		rows[view.Section+"/"+view.Field] = view
+	}
+	assert.DeepEqual(t, rows["Metadata/LOC"], scanComparisonView{Section: "Metadata", Field: "LOC", First: "100", Second: "150", Changed: true})
+	assert.Equal(t, rows["Metadata/Incremental"].First, "true")
+	assert.Equal(t, rows["Metadata/Incremental"].Second, "false")
+	assert.Equal(t, rows["Timings/sast"].First, "5m0s")
+	assert.Equal(t, rows["Timings/sast"].Second, "20m0s")
+	assert.Assert(t, !rows["Scan/Engines"].Changed)
+}
+
+func TestScanCompare_ChangedOnly(t *testing.T) {
+	buffer, err := executeRedirectedTestCommand("scan", "compare", "--scan-id", "scan1,scan2", "--changed-only", "--format", "json")
+	assert.NilError(t, err)
+	var views []scanComparisonView
+	assert.NilError(t, json.Unmarshal(buffer.Bytes(), &views))
+	for _, view := range views {
+		assert.Assert(t, view.Changed, "%s/%s should not be listed", view.Section, view.Field)
+	}
+}
+
+func TestScanCompare_InvalidScanIDs(t *testing.T) {
+	err := execCmdNotNilAssertion(t, "scan", "compare", "--scan-id", "scan1")
+	assert.ErrorContains(t, err, "Please provide exactly two scan IDs")
+	err = execCmdNotNilAssertion(t, "scan", "compare", "--scan-id", "scan1", "--scan-id", "scan1")
+	assert.ErrorContains(t, err, "Please provide two different scan IDs")
+}
+
+func TestCompareScans_ConfigurationAndMissingMetadata(t *testing.T) {
+	created := time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC)
+	first := &scanComparisonInput{
+		Scan: &wrappers.ScanResponseModel{
+			ID: "a", CreatedAt: created, UpdatedAt: created.Add(time.Hour),
+			Metadata: wrappers.ScanResponseModelMetadata{Configs: []wrappers.Config{
+				{Type: "sast", Value: map[string]interface{}{"presetName": "ASA Premium", "incremental": "false"}},
+			}},
+		},
+		Metadata: &wrappers.Scans{ScanID: "a", QueryPreset: "ASA Premium", Loc: 10},
+	}
+	second := &scanComparisonInput{
+		Scan: &wrappers.ScanResponseModel{
+			ID: "b",
+			Metadata: wrappers.ScanResponseModelMetadata{Configs: []wrappers.Config{
+				{Type: "sast", Value: map[string]interface{}{"presetName": "Checkmarx Default"}},
+			}},
+		},
+	}
+	rows := make(map[string]scanComparisonView)
+	for _, view := range compareScans(first, second) {
+		rows[view.Section+"/"+view.Field] = view
+	}
+	assert.Equal(t, rows["Configuration/sast.presetName"].Second, "Checkmarx Default")
+	assert.Equal(t, rows["Configuration/sast.incremental"].Second, "-")
+	assert.Equal(t, rows["Metadata/Preset"].Second, "-")
+	assert.Equal(t, rows["Timings/Total"].First, "1h0m0s")
+	assert.Equal(t, rows["Timings/Total"].Second, "-")
+}
@@ -0,0 +1,357 @@
+package commands
+
+import (
+	"archive/zip"
+	"fmt"
+	"io/fs"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/checkmarx/ast-cli/internal/commands/util"
+	"github.com/checkmarx/ast-cli/internal/logger"
+	commonParams "github.com/checkmarx/ast-cli/internal/params"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+const (
+	diffContextFiles          = "files"
+	diffContextDirectory      = "directory"
+	diffContextModule         = "module"
+	diffBaseCommitTag         = "diff-base-commit"
+	diffHeadCommitTag         = "diff-head-commit"
+	invalidDiffContext        = "invalid value for --%s flag, expected one of: %s"
+	diffScanUnsupportedSource = "--%s requires --%s to be a local git repository"
+	diffScanOtherEngines      = "--%s scans only the changed files with SAST, but this scan also runs %s: set --%s %s"
+	failedDiffingBase         = "failed diffing HEAD against %s"
+	diffScanArtifactsDir      = ".checkmarx"
+)
+
+var (
+	diffContexts = []string{diffContextFiles, diffContextDirectory, diffContextModule}
+
+	// diffBuildFiles change how the whole source builds or resolves its dependencies, so a diff touching them is
+	// scanned in full
+	diffBuildFiles = map[string]bool{
+		"go.mod":                   true,
+		"go.sum":                   true,
+		"pom.xml":                  true,
+		"build.gradle":             true,
+		"build.gradle.kts":         true,
+		"settings.gradle":          true,
+		"settings.gradle.kts":      true,
+		"gradle.properties":        true,
+		"build.xml":                true,
+		"package.json":             true,
+		"package-lock.json":        true,
+		"yarn.lock":                true,
+		"pnpm-lock.yaml":           true,
+		"requirements.txt":         true,
+		"setup.py":                 true,
+		"setup.cfg":                true,
+		"pyproject.toml":           true,
+		"Pipfile":                  true,
+		"Pipfile.lock":             true,
+		"poetry.lock":              true,
+		"Gemfile":                  true,
+		"Gemfile.lock":             true,
+		"composer.json":            true,
+		"composer.lock":            true,
+		"Cargo.toml":               true,
+		"Cargo.lock":               true,
+		"Makefile":                 true,
+		"CMakeLists.txt":           true,
+		"packages.config":          true,
+		"nuget.config":             true,
+		"Directory.Build.props":    true,
+		"Directory.Packages.props": true,
+	}
+	diffBuildExtensions = []string{".csproj", ".vbproj", ".fsproj", ".sln", ".gradle"}
+
+	// diffModuleFiles mark the root directory of a module
+	diffModuleFiles = []string{
+		"go.mod", "pom.xml", "build.gradle", "build.gradle.kts", "package.json", "pyproject.toml", "setup.py",
+		"Cargo.toml", "composer.json", "Gemfile", "CMakeLists.txt",
+	}
+)
+
+// diffScanPath is a file, or a directory with its files, relative to the source directory
+type diffScanPath struct {
+	Path      string
+	Dir       bool
+	Recursive bool
+}
+
+// prepareDiffScan limits the scan to the files changed between --diff-base and HEAD, returning the paths to package
+// instead of the whole source. The scan is tagged with both commits and run incrementally, unless the diff touches
+// build files or is empty, in which case the whole source is scanned and no paths are returned. Engines other than
+// SAST need the whole source, so --diff-base is rejected when the scan runs them.
+func prepareDiffScan(cmd *cobra.Command) ([]diffScanPath, error) {
+	diffBase, _ := cmd.Flags().GetString(commonParams.DiffBaseFlag)
+	diffBase = strings.TrimSpace(diffBase)
+	if diffBase == "" {
+		return nil, nil
+	}
+	diffContext, _ := cmd.Flags().GetString(commonParams.DiffContextFlag)
+	if !slices.Contains(diffContexts, diffContext) {
+		return nil, errors.Errorf(invalidDiffContext, commonParams.DiffContextFlag, strings.Join(diffContexts, ", "))
+	}
+	if engines := getNonIncrementalEngines(); len(engines) > 0 {
+		return nil, errors.Errorf(diffScanOtherEngines, commonParams.DiffBaseFlag, strings.Join(engines, ", "),
+			commonParams.ScanTypes, commonParams.SastType)
+	}
+	source, _ := cmd.Flags().GetString(commonParams.SourcesFlag)
+	source = strings.TrimSpace(source)
+	if info, err := os.Stat(source); util.IsGitURL(source) || err != nil || !info.IsDir() {
+		return nil, errors.Errorf(diffScanUnsupportedSource, commonParams.DiffBaseFlag, commonParams.SourcesFlag)
+	}
+
+	baseCommit, err := runGit(source, "rev-parse", "--verify", diffBase+"^{commit}")
+	if err != nil {
+		return nil, errors.Wrapf(err, failedDiffingBase, diffBase)
+	}
+	headCommit, err := runGit(source, "rev-parse", "--verify", "HEAD")
+	if err != nil {
+		return nil, errors.Wrapf(err, failedDiffingBase, diffBase)
+	}
+	// Compare with the merge base, like a pull request, and leave out deleted files
+	diff, err := runGit(source, "diff", "--name-only", "--diff-filter=d", "--relative", "-z", diffBase+"...HEAD")
+	if err != nil {
+		return nil, errors.Wrapf(err, failedDiffingBase, diffBase)
+	}
+
+	err = addDiffScanTags(cmd, strings.TrimSpace(baseCommit), strings.TrimSpace(headCommit))
+	if err != nil {
+		return nil, err
+	}
+
+	var changedFiles []string
+	for _, file := range strings.Split(diff, "\x00") {
+		if file != "" {
+			changedFiles = append(changedFiles, file)
+		}
+	}
+	if len(changedFiles) == 0 {
+		logger.Print(fmt.Sprintf("No files changed since %s, running a full scan", diffBase))
+		return nil, nil
+	}
+	for _, file := range changedFiles {
+		if isDiffBuildFile(file) {
+			logger.Print(fmt.Sprintf("Build file %s changed since %s, running a full scan", file, diffBase))
+			return nil, nil
+		}
+	}
+
+	if !cmd.Flags().Changed(commonParams.IncrementalSast) {
+		err = cmd.Flags().Set(commonParams.IncrementalSast, trueString)
+		if err != nil {
+			return nil, errors.Wrapf(err, "Failed to set --%s flag", commonParams.IncrementalSast)
+		}
+	}
+	logger.Print(fmt.Sprintf("Scanning the %d files changed since %s with their %s context", len(changedFiles), diffBase, diffContext))
+	return getDiffScanPaths(source, changedFiles, diffContext), nil
+}
+
+func runGit(dir string, args ...string) (string, error) {
+	output, err := exec.Command("git", append([]string{"-C", dir}, args...)...).Output()
+	if err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) && len(exitErr.Stderr) > 0 {
+			return "", errors.New(strings.TrimSpace(string(exitErr.Stderr)))
+		}
+		return "", err
+	}
+	return string(output), nil
+}
+
+// addDiffScanTags appends the base and head commits to the tags of the scan
+func addDiffScanTags(cmd *cobra.Command, baseCommit, headCommit string) error {
+	tags, _ := cmd.Flags().GetString(commonParams.TagList)
+	diffTags := fmt.Sprintf("%s:%s,%s:%s", diffBaseCommitTag, baseCommit, diffHeadCommitTag, headCommit)
+	if strings.TrimSpace(tags) != "" {
+		diffTags = tags + "," + diffTags
+	}
+	err := cmd.Flags().Set(commonParams.TagList, diffTags)
+	if err != nil {
+		return errors.Wrapf(err, "Failed to set --%s flag", commonParams.TagList)
+	}
+	return nil
+}
+
+// getNonIncrementalEngines returns the scan types of the scan other than SAST, which need the whole source
+func getNonIncrementalEngines() []string {
+	var engines []string
+	for _, scanType := range strings.Split(actualScanTypes, ",") {
+		scanType = strings.TrimSpace(scanType)
+		if scanType != "" && scanType != commonParams.SastType {
+			engines = append(engines, scanType)
+		}
+	}
+	return engines
+}
+
+func isDiffBuildFile(file string) bool {
+	name := path.Base(file)
+	return diffBuildFiles[name] || slices.Contains(diffBuildExtensions, strings.ToLower(path.Ext(name)))
+}
+
+// getDiffScanPaths returns the changed files with their context: the files alone, the files of their directories or
+// their whole modules
+func getDiffScanPaths(source string, changedFiles []string, diffContext string) []diffScanPath {
+	var paths []diffScanPath
+	switch diffContext {
+	case diffContextFiles:
+		for _, file := range changedFiles {
+			paths = append(paths, diffScanPath{Path: file})
+		}
+	case diffContextDirectory:
+		directories := map[string]bool{}
+		for _, file := range changedFiles {
+			directories[path.Dir(file)] = true
+		}
+		for directory := range directories {
+			paths = append(paths, diffScanPath{Path: directory, Dir: true})
+		}
+	case diffContextModule:
+		modules := map[string]bool{}
+		for _, file := range changedFiles {
+			modules[getDiffModule(source, path.Dir(file))] = true
+		}
+		for module := range modules {
+			if !isInDiffModule(module, modules) {
+				paths = append(paths, diffScanPath{Path: module, Dir: true, Recursive: true})
+			}
+		}
+	}
+	sort.Slice(paths, func(i, j int) bool {
+		return paths[i].Path < paths[j].Path
+	})
+	return paths
+}
+
+// getDiffModule walks up from directory to the closest module root, the source directory when there is none
+func getDiffModule(source, directory string) string {
+	for directory != "." {
+		for _, moduleFile := range diffModuleFiles {
+			if _, err := os.Stat(filepath.Join(source, filepath.FromSlash(directory), moduleFile)); err == nil {
+				return directory
+			}
+		}
+		directory = path.Dir(directory)
+	}
+	return directory
+}
+
+// isInDiffModule reports whether module is nested in another module of modules, which already packages it
+func isInDiffModule(module string, modules map[string]bool) bool {
+	for parent := module; parent != "."; {
+		parent = path.Dir(parent)
+		if modules[parent] {
+			return true
+		}
+	}
+	return false
+}
+
+// addDiffScanFiles adds the diffPaths of sourceDir and the .checkmarx artifacts written by the CLI to the zip,
+// applying the same filters as a full scan
+func addDiffScanFiles(zipWriter *zip.Writer, sourceDir string, diffPaths []diffScanPath, filters, includeFilters []string) error {
+	err := addDiffScanArtifacts(zipWriter, sourceDir, diffPaths, filters, includeFilters)
+	if err != nil {
+		return err
+	}
+	for _, diffPath := range diffPaths {
+		if !diffPath.Dir {
+			baseDir := strings.TrimPrefix(path.Dir(diffPath.Path)+"/", "./")
+			fileInfo, err := os.Stat(sourceDir + diffPath.Path)
+			if err != nil {
+				return err
+			}
+			if err = handleFile(zipWriter, baseDir, sourceDir+baseDir, filters, includeFilters, fileInfo); err != nil {
+				return err
+			}
+			continue
+		}
+
+		baseDir := ""
+		if diffPath.Path != "." {
+			baseDir = diffPath.Path + "/"
+		}
+		parentDir := sourceDir + baseDir
+		if diffPath.Recursive {
+			if err := addDirFiles(zipWriter, baseDir, parentDir, filters, includeFilters); err != nil {
+				return err
+			}
+			continue
+		}
+		entries, err := os.ReadDir(parentDir)
+		if err != nil {
+			return err
+		}
+		for _, entry := range entries {
+			fileInfo, err := entry.Info()
+			if err != nil {
+				return err
+			}
+			if util.IsDirOrSymLinkToDir(parentDir, fileInfo) {
+				continue
+			}
+			if err = handleFile(zipWriter, baseDir, parentDir, filters, includeFilters, fileInfo); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// addDiffScanArtifacts adds the files of .checkmarx, like the resolution of the local container resolver, that aren't
+// already packaged by diffPaths
+func addDiffScanArtifacts(zipWriter *zip.Writer, sourceDir string, diffPaths []diffScanPath, filters, includeFilters []string) error {
+	artifactsDir := filepath.Join(sourceDir, diffScanArtifactsDir)
+	if info, err := os.Stat(artifactsDir); err != nil || !info.IsDir() {
+		return nil
+	}
+	return filepath.WalkDir(artifactsDir, func(filePath string, entry fs.DirEntry, err error) error {
+		if err != nil || entry.IsDir() {
+			return err
+		}
+		relativePath, err := filepath.Rel(sourceDir, filePath)
+		if err != nil {
+			return err
+		}
+		relativePath = filepath.ToSlash(relativePath)
+		if isInDiffScanPaths(relativePath, diffPaths) {
+			return nil
+		}
+		fileInfo, err := entry.Info()
+		if err != nil {
+			return err
+		}
+		baseDir := path.Dir(relativePath) + "/"
+		return handleFile(zipWriter, baseDir, sourceDir+baseDir, filters, includeFilters, fileInfo)
+	})
+}
+
+// isInDiffScanPaths reports whether file is packaged by one of diffPaths
+func isInDiffScanPaths(file string, diffPaths []diffScanPath) bool {
+	for _, diffPath := range diffPaths {
+		switch {
+		case !diffPath.Dir:
+			if diffPath.Path == file {
+				return true
+			}
+		case diffPath.Recursive:
+			if diffPath.Path == "." || strings.HasPrefix(file, diffPath.Path+"/") {
+				return true
+			}
+		case path.Dir(file) == diffPath.Path:
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,198 @@
+package commands
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	commonParams "github.com/checkmarx/ast-cli/internal/params"
+	"github.com/spf13/cobra"
+	"gotest.tools/assert"
+)
+
+func gitCommand(t *testing.T, dir string, args ...string) {
+	gitArgs := append([]string{"-C", dir, "-c", "user.name=test", "-c", "user.email=test@example.com"}, args...)
+	output, err := exec.Command("git", gitArgs...).CombinedOutput()
+	assert.NilError(t, err, string(output))
+}
+
+func writeDiffFiles(t *testing.T, dir string, files map[string]string) {
+	for name, content := range files {
+		filePath := filepath.Join(dir, name)
+		assert.NilError(t, os.MkdirAll(filepath.Dir(filePath), 0o755))
+		assert.NilError(t, os.WriteFile(filePath, []byte(content), 0o600))
+	}
+}
+
+// createDiffRepository creates a repository with a base branch and a feature branch changing lib/service/service.go
+// and the changes given
+func createDiffRepository(t *testing.T, changes map[string]string) string {
+	dir := t.TempDir()
+	gitCommand(t, dir, "init", "-q", "-b", "base")
+	writeDiffFiles(t, dir, map[string]string{
+		"main.go":                    "package main",
+		"lib/go.mod":                 "module lib",
+		"lib/lib.go":                 "package lib",
+		"lib/service/service.go":     "package service",
+		"lib/service/handler.go":     "package service",
+		"lib/service/store/store.go": "package store",
+		"docs/README.md":             "docs",
+	})
+	gitCommand(t, dir, "add", "-A")
+	gitCommand(t, dir, "commit", "-q", "-m", "base")
+	gitCommand(t, dir, "checkout", "-q", "-b", "feature")
+	changes["lib/service/service.go"] = "package service // changed"
+	writeDiffFiles(t, dir, changes)
+	gitCommand(t, dir, "add", "-A")
+	gitCommand(t, dir, "commit", "-q", "-m", "feature")
+	return dir
+}
+
+func newDiffScanCommand(t *testing.T, source, diffContext string) *cobra.Command {
+	scanTypes := actualScanTypes
+	actualScanTypes = commonParams.SastType
+	t.Cleanup(func() { actualScanTypes = scanTypes })
+	cmd := &cobra.Command{}
+	cmd.Flags().String(commonParams.SourcesFlag, "", "")
+	cmd.Flags().String(commonParams.DiffBaseFlag, "", "")
+	cmd.Flags().String(commonParams.DiffContextFlag, diffContextModule, "")
+	cmd.Flags().String(commonParams.TagList, "", "")
+	cmd.Flags().Bool(commonParams.IncrementalSast, false, "")
+	assert.NilError(t, cmd.Flags().Set(commonParams.SourcesFlag, source))
+	assert.NilError(t, cmd.Flags().Set(commonParams.DiffBaseFlag, "base"))
+	assert.NilError(t, cmd.Flags().Set(commonParams.DiffContextFlag, diffContext))
+	assert.NilError(t, cmd.Flags().Set(commonParams.TagList, "team:core"))
+	return cmd
+}
+
+func compressDiffScan(t *testing.T, source string, diffPaths []diffScanPath) string {
+	zipPath, err := compressFolder(sbomTestSourceDir(source), "", "", "", diffPaths)
+	assert.NilError(t, err)
+	t.Cleanup(func() { _ = os.Remove(zipPath) })
+	return zipPath
+}
+
+func TestPrepareDiffScanContexts(t *testing.T) {
+	testCases := []struct {
+		diffContext string
+		included    []string
+		excluded    []string
+	}{
+		{
+			diffContextFiles,
+			[]string{"lib/service/service.go"},
+			[]string{"lib/service/handler.go", "lib/lib.go", "main.go"},
+		},
+		{
+			diffContextDirectory,
+			[]string{"lib/service/service.go", "lib/service/handler.go"},
+			[]string{"lib/service/store/store.go", "lib/lib.go", "main.go"},
+		},
+		{
+			diffContextModule,
+			[]string{"lib/service/service.go", "lib/service/handler.go", "lib/service/store/store.go", "lib/lib.go", "lib/go.mod"},
+			[]string{"main.go", "docs/README.md"},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.diffContext, func(t *testing.T) {
+			source := createDiffRepository(t, map[string]string{})
+			cmd := newDiffScanCommand(t, source, tc.diffContext)
+
+			diffPaths, err := prepareDiffScan(cmd)
+			assert.NilError(t, err)
+
+			incremental, _ := cmd.Flags().GetBool(commonParams.IncrementalSast)
+			assert.Assert(t, incremental)
+			zipPath := compressDiffScan(t, source, diffPaths)
+			for _, file := range tc.included {
+				assert.Assert(t, zipContainsFile(t, zipPath, file), "%s should be in the zip", file)
+			}
+			for _, file := range tc.excluded {
+				assert.Assert(t, !zipContainsFile(t, zipPath, file), "%s should not be in the zip", file)
+			}
+		})
+	}
+}
+
+func TestPrepareDiffScanTags(t *testing.T) {
+	source := createDiffRepository(t, map[string]string{})
+	cmd := newDiffScanCommand(t, source, diffContextFiles)
+
+	_, err := prepareDiffScan(cmd)
+	assert.NilError(t, err)
+
+	baseCommit, _ := exec.Command("git", "-C", source, "rev-parse", "base").Output()
+	headCommit, _ := exec.Command("git", "-C", source, "rev-parse", "feature").Output()
+	tags, _ := cmd.Flags().GetString(commonParams.TagList)
+	assert.Equal(t, tags, "team:core,"+diffBaseCommitTag+":"+string(baseCommit[:len(baseCommit)-1])+","+
+		diffHeadCommitTag+":"+string(headCommit[:len(headCommit)-1]))
+}
+
+func TestPrepareDiffScanBuildFileFallsBackToFullScan(t *testing.T) {
+	source := createDiffRepository(t, map[string]string{"lib/go.mod": "module lib\n\ngo 1.22"})
+	cmd := newDiffScanCommand(t, source, diffContextFiles)
+
+	diffPaths, err := prepareDiffScan(cmd)
+	assert.NilError(t, err)
+
+	assert.Assert(t, diffPaths == nil)
+	incremental, _ := cmd.Flags().GetBool(commonParams.IncrementalSast)
+	assert.Assert(t, !incremental)
+	assert.Assert(t, zipContainsFile(t, compressDiffScan(t, source, diffPaths), "main.go"))
+}
+
+func TestPrepareDiffScanRejectsOtherEngines(t *testing.T) {
+	source := createDiffRepository(t, map[string]string{})
+	cmd := newDiffScanCommand(t, source, diffContextFiles)
+	actualScanTypes = "sast,sca,kics"
+
+	_, err := prepareDiffScan(cmd)
+
+	assert.ErrorContains(t, err, "--diff-base scans only the changed files with SAST, but this scan also runs sca, kics: set --scan-types sast")
+	tags, _ := cmd.Flags().GetString(commonParams.TagList)
+	assert.Equal(t, tags, "team:core")
+}
+
+func TestPrepareDiffScanIncludesCheckmarxArtifacts(t *testing.T) {
+	source := createDiffRepository(t, map[string]string{})
+	cmd := newDiffScanCommand(t, source, diffContextFiles)
+	diffPaths, err := prepareDiffScan(cmd)
+	assert.NilError(t, err)
+	writeDiffFiles(t, source, map[string]string{".checkmarx/containers/" + containerResolutionFileName: "{}"})
+
+	zipPath := compressDiffScan(t, source, diffPaths)
+
+	assert.Assert(t, zipContainsFile(t, zipPath, ".checkmarx/containers/"+containerResolutionFileName))
+	assert.Assert(t, zipContainsFile(t, zipPath, "lib/service/service.go"))
+	assert.Assert(t, !zipContainsFile(t, zipPath, "main.go"))
+}
+
+func TestPrepareDiffScanKeepsIncrementalFlag(t *testing.T) {
+	source := createDiffRepository(t, map[string]string{})
+	cmd := newDiffScanCommand(t, source, diffContextFiles)
+	assert.NilError(t, cmd.Flags().Set(commonParams.IncrementalSast, "false"))
+
+	_, err := prepareDiffScan(cmd)
+	assert.NilError(t, err)
+
+	incremental, _ := cmd.Flags().GetBool(commonParams.IncrementalSast)
+	assert.Assert(t, !incremental)
+}
+
+func TestPrepareDiffScanInvalid(t *testing.T) {
+	cmd := newDiffScanCommand(t, t.TempDir(), "package")
+	_, err := prepareDiffScan(cmd)
+	assert.ErrorContains(t, err, "invalid value for --diff-context flag")
+
+	cmd = newDiffScanCommand(t, "https://github.com/org/repo.git", diffContextFiles)
+	_, err = prepareDiffScan(cmd)
+	assert.ErrorContains(t, err, "--diff-base requires --file-source to be a local git repository")
+
+	source := createDiffRepository(t, map[string]string{})
+	cmd = newDiffScanCommand(t, source, diffContextFiles)
+	assert.NilError(t, cmd.Flags().Set(commonParams.DiffBaseFlag, "missing"))
+	_, err = prepareDiffScan(cmd)
+	assert.ErrorContains(t, err, "failed diffing HEAD against missing")
+}
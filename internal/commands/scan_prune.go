@@ -0,0 +1,182 @@
+package commands
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/MakeNowJust/heredoc"
+	commonParams "github.com/checkmarx/ast-cli/internal/params"
+	"github.com/checkmarx/ast-cli/internal/services"
+	"github.com/checkmarx/ast-cli/internal/wrappers"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+const failedPruningScans = "Failed pruning scans"
+
+type scanPruneCandidate struct {
+	Scan   wrappers.ScanResponseModel
+	Reason string
+}
+
+func scanPruneSubCommand(scansWrapper wrappers.ScansWrapper, projectsWrapper wrappers.ProjectsWrapper) *cobra.Command {
+	pruneScanCmd := &cobra.Command{
+		Use:   "prune",
+		Short: "Deletes old scans of projects",
+		Long: "The scan prune command deletes, for every project or the given one, the scans beyond the --keep-last most recent " +
+			"that are older than --older-than. Running scans and scans or projects carrying the retain tag are never deleted. " +
+			"The planned deletions are printed, and only carried out with --yes. " +
+			"Every deletion is recorded in the audit log, the standard error unless --audit-log is given",
+		Example: heredoc.Doc(
+			`
+			$ cx scan prune --keep-last 10
+			$ cx scan prune --keep-last 5 --older-than 90d --project-id <project_id> --yes --audit-log prune-audit.jsonl
+		`,
+		),
+		RunE: runPruneScansCommand(scansWrapper, projectsWrapper),
+	}
+	pruneScanCmd.PersistentFlags().Int(commonParams.KeepLastFlag, 0, "Number of most recent scans of each project to keep")
+	pruneScanCmd.PersistentFlags().String(commonParams.OlderThanFlag, "", "Only delete scans older than this age, ex: 90d or 720h")
+	addProjectIDFlag(pruneScanCmd, "Only prune the scans of this project")
+	addPruneFlags(pruneScanCmd)
+	return pruneScanCmd
+}
+
+func runPruneScansCommand(scansWrapper wrappers.ScansWrapper, projectsWrapper wrappers.ProjectsWrapper) func(cmd *cobra.Command, args []string) error {
+	return func(cmd *cobra.Command, args []string) error {
+		keepLast, _ := cmd.Flags().GetInt(commonParams.KeepLastFlag)
+		olderThan, _ := cmd.Flags().GetString(commonParams.OlderThanFlag)
+		projectID, _ := cmd.Flags().GetString(commonParams.ProjectIDFlag)
+		dryRun := isPruneDryRun(cmd)
+		if keepLast < 0 {
+			return errors.Errorf("%s: --%s can't be negative", failedPruningScans, commonParams.KeepLastFlag)
+		}
+		if keepLast == 0 && olderThan == "" {
+			return errors.Errorf("%s: Please provide --%s, --%s or both", failedPruningScans, commonParams.KeepLastFlag, commonParams.OlderThanFlag)
+		}
+		var cutoff time.Time
+		if olderThan != "" {
+			age, err := parseRetentionAge(olderThan)
+			if err != nil {
+				return errors.Wrapf(err, "%s", failedPruningScans)
+			}
+			cutoff = time.Now().Add(-age)
+		}
+		audit, err := openPruneAuditLog(cmd)
+		if err != nil {
+			return errors.Wrapf(err, "%s", failedPruningScans)
+		}
+		defer audit.Close()
+
+		projects, err := getPruneScanProjects(projectsWrapper, projectID)
+		if err != nil {
+			return errors.Wrapf(err, "%s", failedPruningScans)
+		}
+		retainTag := getRetainTag(cmd)
+		var candidates []scanPruneCandidate
+		for i := range projects {
+			if _, retained := projects[i].Tags[retainTag]; retained {
+				continue
+			}
+			scans, scansErr := getAllScans(scansWrapper, map[string]string{
+				commonParams.ProjectIDQueryParam: projects[i].ID,
+				commonParams.SortQueryParam:      "-created_at",
+			})
+			if scansErr != nil {
+				return errors.Wrapf(scansErr, "%s", failedPruningScans)
+			}
+			candidates = append(candidates, planScansPrune(scans, keepLast, cutoff, retainTag)...)
+		}
+
+		out := cmd.OutOrStdout()
+		_, _ = fmt.Fprintf(out, "Plan: %d scans to delete in %d projects.\n", len(candidates), len(projects))
+		for _, candidate := range candidates {
+			_, _ = fmt.Fprintf(out, "  - %s (project %s, branch %s, created %s)\n", candidate.Scan.ID, candidate.Scan.ProjectName,
+				candidate.Scan.Branch, formatLastActivity(candidate.Scan.CreatedAt))
+		}
+
+		var failed int
+		for _, candidate := range candidates {
+			entry := pruneAuditEntry{
+				Action:    pruneDeleteAction,
+				Resource:  "scan",
+				ID:        candidate.Scan.ID,
+				ProjectID: candidate.Scan.ProjectID,
+				Reason:    candidate.Reason,
+				DryRun:    dryRun,
+			}
+			if !dryRun {
+				if deleteErr := deletePrunedScan(scansWrapper, candidate.Scan.ID); deleteErr != nil {
+					entry.Error = deleteErr.Error()
+					failed++
+					_, _ = fmt.Fprintf(cmd.ErrOrStderr(), "Failed deleting scan %s: %v\n", candidate.Scan.ID, deleteErr)
+				} else {
+					_, _ = fmt.Fprintf(out, "Deleted scan %s\n", candidate.Scan.ID)
+				}
+			}
+			if err = audit.Write(&entry); err != nil {
+				return errors.Wrapf(err, "%s", failedPruningScans)
+			}
+		}
+		if failed > 0 {
+			return errors.Errorf("%s: %d of %d scans were not deleted", failedPruningScans, failed, len(candidates))
+		}
+		if dryRun {
+			printPruneConfirmation(cmd, len(candidates), "scans")
+		}
+		return nil
+	}
+}
+
+// planScansPrune returns the scans of a project beyond the keepLast most recent that were created before cutoff, when
+// set. Scans still running and scans carrying retainTag are kept.
+func planScansPrune(scans []wrappers.ScanResponseModel, keepLast int, cutoff time.Time, retainTag string) []scanPruneCandidate {
+	sort.SliceStable(scans, func(i, j int) bool {
+		return scans[i].CreatedAt.After(scans[j].CreatedAt)
+	})
+	var candidates []scanPruneCandidate
+	for i := range scans {
+		scan := scans[i]
+		if i < keepLast || (!cutoff.IsZero() && !scan.CreatedAt.Before(cutoff)) {
+			continue
+		}
+		if scan.Status == wrappers.ScanRunning || scan.Status == wrappers.ScanQueued {
+			continue
+		}
+		if _, retained := scan.Tags[retainTag]; retained {
+			continue
+		}
+		reason := fmt.Sprintf("beyond the %d most recent scans of the project", keepLast)
+		if !cutoff.IsZero() {
+			reason = fmt.Sprintf("%s and created before %s", reason, formatLastActivity(cutoff))
+		}
+		candidates = append(candidates, scanPruneCandidate{Scan: scan, Reason: reason})
+	}
+	return candidates
+}
+
+func getPruneScanProjects(projectsWrapper wrappers.ProjectsWrapper, projectID string) ([]wrappers.ProjectResponseModel, error) {
+	if projectID == "" {
+		return getAllProjects(projectsWrapper, map[string]string{})
+	}
+	project, errorModel, err := projectsWrapper.GetByID(projectID)
+	if err != nil {
+		return nil, err
+	}
+	if errorModel != nil {
+		return nil, errors.Errorf(services.ErrorCodeFormat, services.FailedGettingProj, errorModel.Code, errorModel.Message)
+	}
+	return []wrappers.ProjectResponseModel{*project}, nil
+}
+
+func deletePrunedScan(scansWrapper wrappers.ScansWrapper, scanID string) error {
+	errorModel, err := scansWrapper.Delete(scanID)
+	if err != nil {
+		return err
+	}
+	if errorModel != nil {
+		return errors.Errorf(services.ErrorCodeFormat, failedDeleting, errorModel.Code, errorModel.Message)
+	}
+	return nil
+}
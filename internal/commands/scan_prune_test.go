@@ -0,0 +1,63 @@
+//go:build !integration
+
+package commands
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/checkmarx/ast-cli/internal/wrappers"
+	"gotest.tools/assert"
+)
+
+func TestScanPrune_DryRunPrintsPlan(t *testing.T) {
+	buffer, err := executeRedirectedTestCommand("scan", "prune", "--older-than", "30d", "--project-id", "MOCK", "--dry-run")
+	assert.NilError(t, err)
+	assert.Equal(t, buffer.String(), "Plan: 1 scans to delete in 1 projects.\n  - MOCK (project , branch , created never)\n")
+}
+
+func TestScanPrune_RequiresConfirmation(t *testing.T) {
+	buffer, err := executeRedirectedTestCommand("scan", "prune", "--older-than", "30d", "--project-id", "MOCK")
+	assert.NilError(t, err)
+	assert.Assert(t, strings.HasSuffix(buffer.String(), "Nothing was deleted, run again with --yes to delete these 1 scans.\n"))
+
+	buffer, err = executeRedirectedTestCommand("scan", "prune", "--older-than", "30d", "--project-id", "MOCK", "--yes")
+	assert.NilError(t, err)
+	assert.Assert(t, strings.HasSuffix(buffer.String(), "Deleted scan MOCK\n"))
+}
+
+func TestScanPrune_KeepsLastScans(t *testing.T) {
+	buffer, err := executeRedirectedTestCommand("scan", "prune", "--keep-last", "1")
+	assert.NilError(t, err)
+	assert.Equal(t, buffer.String(), "Plan: 0 scans to delete in 1 projects.\n")
+}
+
+func TestScanPrune_InvalidInput(t *testing.T) {
+	err := execCmdNotNilAssertion(t, "scan", "prune")
+	assert.ErrorContains(t, err, "Please provide --keep-last, --older-than or both")
+	err = execCmdNotNilAssertion(t, "scan", "prune", "--keep-last", "-1")
+	assert.ErrorContains(t, err, "--keep-last can't be negative")
+	err = execCmdNotNilAssertion(t, "scan", "prune", "--older-than", "soon")
+	assert.ErrorContains(t, err, "Invalid age")
+}
+
+func TestPlanScansPrune(t *testing.T) {
+	now := time.Now()
+	scans := []wrappers.ScanResponseModel{
+		{ID: "oldest", Status: wrappers.ScanCompleted, CreatedAt: now.AddDate(0, -6, 0)},
+		{ID: "newest", Status: wrappers.ScanCompleted, CreatedAt: now},
+		{ID: "retained", Status: wrappers.ScanCompleted, CreatedAt: now.AddDate(0, -5, 0), Tags: map[string]string{"keep": ""}},
+		{ID: "running", Status: wrappers.ScanRunning, CreatedAt: now.AddDate(0, -4, 0)},
+		{ID: "recent", Status: wrappers.ScanFailed, CreatedAt: now.AddDate(0, 0, -1)},
+	}
+
+	candidates := planScansPrune(scans, 1, time.Time{}, "keep")
+	assert.Equal(t, len(candidates), 2)
+	assert.Equal(t, candidates[0].Scan.ID, "recent")
+	assert.Equal(t, candidates[1].Scan.ID, "oldest")
+
+	candidates = planScansPrune(scans, 0, now.AddDate(0, 0, -30), "keep")
+	assert.Equal(t, len(candidates), 1)
+	assert.Equal(t, candidates[0].Scan.ID, "oldest")
+}
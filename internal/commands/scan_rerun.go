@@ -0,0 +1,220 @@
+package commands
+
+import (
+	"encoding/json"
+	"os"
+	"strings"
+
+	"github.com/MakeNowJust/heredoc"
+	"github.com/checkmarx/ast-cli/internal/commands/util"
+	"github.com/checkmarx/ast-cli/internal/commands/util/printer"
+	commonParams "github.com/checkmarx/ast-cli/internal/params"
+	"github.com/checkmarx/ast-cli/internal/services"
+	"github.com/checkmarx/ast-cli/internal/wrappers"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+const (
+	failedRerunningScan = "Failed rerunning the scan"
+	gitScanType         = "git"
+	uploadScanType      = "upload"
+	scmTokenCredentials = "apiKey"
+	missingRerunCreds   = "Scan %s cloned %s with %s credentials, which Checkmarx One doesn't return. Please provide them with --%s or --%s"
+)
+
+func scanRerunSubCommand(
+	scansWrapper wrappers.ScansWrapper,
+	uploadsWrapper wrappers.UploadsWrapper,
+	featureFlagsWrapper wrappers.FeatureFlagsWrapper,
+) *cobra.Command {
+	rerunScanCmd := &cobra.Command{
+		Use:   "rerun",
+		Short: "Submit a new scan with the source, configuration and tags of a previous scan",
+		Long: "The rerun command submits a new scan of the same source as a previous scan, the same repository, branch and " +
+			"commit or the same uploaded sources, with the same engine configuration and tags",
+		Example: heredoc.Doc(
+			`
+			$ cx scan rerun --scan-id <scan_id>
+			$ cx scan rerun --scan-id <scan_id> --scan-types sast --branch main
+			$ cx scan rerun --scan-id <scan_id> --ssh-key <path to ssh private key>
+		`,
+		),
+		RunE: runRerunScanCommand(scansWrapper, uploadsWrapper, featureFlagsWrapper),
+	}
+	addScanIDFlag(rerunScanCmd, "ID of the scan to rerun")
+	rerunScanCmd.PersistentFlags().String(commonParams.ScanTypes, "", "Only rerun these engines of the scan, ex: sast,sca")
+	rerunScanCmd.PersistentFlags().String(commonParams.BranchFlag, "", "Branch of the new scan, git scans then scan the latest commit of this branch")
+	rerunScanCmd.PersistentFlags().String(commonParams.SSHKeyFlag, "", "Path to the ssh private key cloning the repository of a git scan")
+	rerunScanCmd.PersistentFlags().String(commonParams.RerunSCMTokenFlag, "", "SCM token cloning the repository of a git scan")
+	rerunScanCmd.MarkFlagsMutuallyExclusive(commonParams.SSHKeyFlag, commonParams.RerunSCMTokenFlag)
+	addFormatFlag(rerunScanCmd, printer.FormatList, printer.FormatTable, printer.FormatJSON)
+	return rerunScanCmd
+}
+
+func runRerunScanCommand(
+	scansWrapper wrappers.ScansWrapper,
+	uploadsWrapper wrappers.UploadsWrapper,
+	featureFlagsWrapper wrappers.FeatureFlagsWrapper,
+) func(cmd *cobra.Command, args []string) error {
+	return func(cmd *cobra.Command, args []string) error {
+		scanID, _ := cmd.Flags().GetString(commonParams.ScanIDFlag)
+		scanTypes, _ := cmd.Flags().GetString(commonParams.ScanTypes)
+		branch, _ := cmd.Flags().GetString(commonParams.BranchFlag)
+		if scanID == "" {
+			return errors.Errorf("%s: Please provide a scan ID", failedRerunningScan)
+		}
+		scan, errorModel, err := scansWrapper.GetByID(scanID)
+		if err != nil {
+			return errors.Wrapf(err, "%s", failedRerunningScan)
+		}
+		if errorModel != nil {
+			return errors.Errorf(services.ErrorCodeFormat, failedRerunningScan, errorModel.Code, errorModel.Message)
+		}
+		scan.ReplaceMicroEnginesWithSCS()
+
+		configs, err := getRerunConfigs(scan, scanTypes)
+		if err != nil {
+			return errors.Wrapf(err, "%s", failedRerunningScan)
+		}
+		scanModel := &wrappers.Scan{
+			Project: wrappers.ScanProject{ID: scan.ProjectID},
+			Config:  configs,
+			Tags:    scan.Tags,
+		}
+		credentials, err := getRerunCredentials(cmd)
+		if err != nil {
+			return errors.Wrapf(err, "%s", failedRerunningScan)
+		}
+		scanModel.Type, scanModel.Handler, err = getRerunSource(scan, branch, credentials, uploadsWrapper, featureFlagsWrapper)
+		if err != nil {
+			return errors.Wrapf(err, "%s", failedRerunningScan)
+		}
+
+		scanResponseModel, errorModel, err := scansWrapper.Create(scanModel)
+		if err != nil {
+			return errors.Wrapf(err, "%s", failedRerunningScan)
+		}
+		if errorModel != nil {
+			return errors.Errorf(services.ErrorCodeFormat, failedRerunningScan, errorModel.Code, errorModel.Message)
+		}
+		return printByFormat(cmd, toScanView(scanResponseModel))
+	}
+}
+
+// getRerunConfigs returns the engine configurations of scan, restricted to scanTypes when given. Engines of the scan
+// without a configuration get an empty one so they are enabled in the new scan.
+func getRerunConfigs(scan *wrappers.ScanResponseModel, scanTypes string) ([]wrappers.Config, error) {
+	engines := scan.Engines
+	if scanTypes != "" {
+		engines = nil
+		for _, scanType := range strings.Split(scanTypes, ",") {
+			scanType = strings.ToLower(strings.TrimSpace(scanType))
+			if !containsIgnoreCase(scan.Engines, scanType) {
+				return nil, errors.Errorf("Scan %s didn't run %s, it ran %s", scan.ID, scanType, strings.Join(scan.Engines, ","))
+			}
+			engines = append(engines, scanType)
+		}
+	}
+	configs := make([]wrappers.Config, 0, len(engines))
+	for _, engine := range engines {
+		configType := engine
+		if engine == commonParams.ScsType {
+			configType = commonParams.MicroEnginesType
+		}
+		config := wrappers.Config{Type: configType, Value: map[string]interface{}{}}
+		for _, scanConfig := range scan.Metadata.Configs {
+			if scanConfig.Type == configType {
+				config = scanConfig
+			}
+		}
+		configs = append(configs, config)
+	}
+	return configs, nil
+}
+
+// getRerunCredentials returns the git credentials of --ssh-key or --scm-token, nil when neither is given.
+func getRerunCredentials(cmd *cobra.Command) (*wrappers.GitCredentials, error) {
+	sshKeyPath, _ := cmd.Flags().GetString(commonParams.SSHKeyFlag)
+	scmToken, _ := cmd.Flags().GetString(commonParams.RerunSCMTokenFlag)
+	switch {
+	case strings.TrimSpace(sshKeyPath) != "":
+		credentials, err := getSSHCredentials(strings.TrimSpace(sshKeyPath))
+		return &credentials, err
+	case strings.TrimSpace(scmToken) != "":
+		// The logger masks the value of the token key in the requests it prints, as it does for the ssh key stored
+		// by getSSHCredentials
+		viper.Set(commonParams.SCMTokenFlag, strings.TrimSpace(scmToken))
+		return &wrappers.GitCredentials{Type: scmTokenCredentials, Value: strings.TrimSpace(scmToken)}, nil
+	default:
+		return nil, nil
+	}
+}
+
+// getRerunSource returns the handler of the new scan. Git scans point to the same repository and commit, or to the
+// latest commit of branch when given, and clone it with credentials, which are required when the scan had some since
+// Checkmarx One doesn't return their value. The sources of upload scans are downloaded and uploaded again since the
+// original upload URL can't be reused.
+func getRerunSource(
+	scan *wrappers.ScanResponseModel,
+	branch string,
+	credentials *wrappers.GitCredentials,
+	uploadsWrapper wrappers.UploadsWrapper,
+	featureFlagsWrapper wrappers.FeatureFlagsWrapper,
+) (scanType string, handler json.RawMessage, err error) {
+	source := scan.Metadata.Handler
+	switch {
+	case source != nil && source.GitHandler != nil:
+		gitHandler := *source.GitHandler
+		switch {
+		case credentials != nil && credentials.Type == sshCredentials && !util.IsSSHURL(gitHandler.RepoURL):
+			return "", nil, errors.Errorf("Scan %s cloned %s, which doesn't need an ssh key", scan.ID, gitHandler.RepoURL)
+		case credentials != nil:
+			gitHandler.Credentials = *credentials
+		case gitHandler.Credentials.Type != "" && gitHandler.Credentials.Value == "":
+			return "", nil, errors.Errorf(missingRerunCreds, scan.ID, gitHandler.RepoURL, gitHandler.Credentials.Type,
+				commonParams.SSHKeyFlag, commonParams.RerunSCMTokenFlag)
+		}
+		if branch != "" {
+			gitHandler.Branch = branch
+			gitHandler.Commit = ""
+			gitHandler.Tag = ""
+		}
+		handler, err = json.Marshal(gitHandler)
+		return gitScanType, handler, err
+	case source != nil && source.UploadHandler != nil:
+		var uploadURL string
+		uploadURL, err = reuploadScanSources(source.UploadHandler.UploadURL, uploadsWrapper, featureFlagsWrapper)
+		if err != nil {
+			return "", nil, errors.Wrapf(err, "The sources of scan %s can't be downloaded anymore, please use scan create", scan.ID)
+		}
+		uploadHandler := wrappers.ScanHandler{UploadURL: uploadURL, Branch: source.UploadHandler.Branch, RepoURL: source.UploadHandler.RepoURL}
+		if uploadHandler.Branch == "" {
+			uploadHandler.Branch = scan.Branch
+		}
+		if branch != "" {
+			uploadHandler.Branch = branch
+		}
+		handler, err = json.Marshal(uploadHandler)
+		return uploadScanType, handler, err
+	default:
+		return "", nil, errors.Errorf("Scan %s has no source information to rerun it from", scan.ID)
+	}
+}
+
+func reuploadScanSources(uploadURL string, uploadsWrapper wrappers.UploadsWrapper, featureFlagsWrapper wrappers.FeatureFlagsWrapper) (string, error) {
+	zipFile, err := os.CreateTemp("", "cx-rerun-*.zip")
+	if err != nil {
+		return "", err
+	}
+	_ = zipFile.Close()
+	defer func() {
+		_ = os.Remove(zipFile.Name())
+	}()
+	if err = uploadsWrapper.DownloadFile(uploadURL, zipFile.Name()); err != nil {
+		return "", err
+	}
+	url, _, err := uploadZip(uploadsWrapper, zipFile.Name(), false, true, featureFlagsWrapper)
+	return url, err
+}
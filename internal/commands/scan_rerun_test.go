@@ -0,0 +1,168 @@
+//go:build !integration
+
+package commands
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	commonParams "github.com/checkmarx/ast-cli/internal/params"
+	"github.com/checkmarx/ast-cli/internal/wrappers"
+	"github.com/checkmarx/ast-cli/internal/wrappers/mock"
+	"gotest.tools/assert"
+)
+
+// rerunScansWrapper returns scan as the scan to rerun and records the scan created from it.
+type rerunScansWrapper struct {
+	mock.ScansMockWrapper
+	scan    *wrappers.ScanResponseModel
+	created *wrappers.Scan
+}
+
+func (w *rerunScansWrapper) GetByID(string) (*wrappers.ScanResponseModel, *wrappers.ErrorModel, error) {
+	return w.scan, nil, nil
+}
+
+func (w *rerunScansWrapper) Create(scanModel *wrappers.Scan) (*wrappers.ScanResponseModel, *wrappers.ErrorModel, error) {
+	w.created = scanModel
+	return &wrappers.ScanResponseModel{ID: "rerun", Status: wrappers.ScanQueued}, nil, nil
+}
+
+func newRerunScan(handler *wrappers.ScanMetadataHandler) *wrappers.ScanResponseModel {
+	scan := &wrappers.ScanResponseModel{
+		ID:        "previous",
+		ProjectID: "project",
+		Branch:    "main",
+		Engines:   []string{commonParams.SastType, commonParams.MicroEnginesType},
+		Tags:      map[string]string{"release": "1.0"},
+	}
+	scan.Metadata.Handler = handler
+	scan.Metadata.Configs = []wrappers.Config{
+		{Type: commonParams.SastType, Value: map[string]interface{}{"incremental": "false", "presetName": "ASA Premium"}},
+	}
+	return scan
+}
+
+func executeRerunCommand(scansWrapper wrappers.ScansWrapper, args ...string) error {
+	cmd := scanRerunSubCommand(scansWrapper, &mock.UploadsMockWrapper{}, &mock.FeatureFlagsMockWrapper{})
+	cmd.SetArgs(args)
+	cmd.SilenceUsage = true
+	return cmd.Execute()
+}
+
+func TestScanRerun_GitScan(t *testing.T) {
+	scansWrapper := &rerunScansWrapper{scan: newRerunScan(&wrappers.ScanMetadataHandler{
+		GitHandler: &wrappers.GitProjectHandler{RepoURL: "https://github.com/org/repo", Branch: "main", Commit: "abc123"},
+	})}
+
+	err := executeRerunCommand(scansWrapper, "--scan-id", "previous")
+	assert.NilError(t, err)
+
+	created := scansWrapper.created
+	assert.Equal(t, created.Type, gitScanType)
+	assert.Equal(t, created.Project.ID, "project")
+	assert.DeepEqual(t, created.Tags, map[string]string{"release": "1.0"})
+	var handler wrappers.GitProjectHandler
+	assert.NilError(t, json.Unmarshal(created.Handler, &handler))
+	assert.Equal(t, handler.RepoURL, "https://github.com/org/repo")
+	assert.Equal(t, handler.Commit, "abc123")
+	assert.Equal(t, len(created.Config), 2)
+	assert.Equal(t, created.Config[0].Value["presetName"], "ASA Premium")
+	assert.Equal(t, created.Config[1].Type, commonParams.MicroEnginesType)
+}
+
+func TestScanRerun_GitScanOnBranch(t *testing.T) {
+	scansWrapper := &rerunScansWrapper{scan: newRerunScan(&wrappers.ScanMetadataHandler{
+		GitHandler: &wrappers.GitProjectHandler{RepoURL: "https://github.com/org/repo", Branch: "main", Commit: "abc123"},
+	})}
+
+	err := executeRerunCommand(scansWrapper, "--scan-id", "previous", "--branch", "develop", "--scan-types", "sast")
+	assert.NilError(t, err)
+
+	var handler wrappers.GitProjectHandler
+	assert.NilError(t, json.Unmarshal(scansWrapper.created.Handler, &handler))
+	assert.Equal(t, handler.Branch, "develop")
+	assert.Equal(t, handler.Commit, "")
+	assert.Equal(t, len(scansWrapper.created.Config), 1)
+	assert.Equal(t, scansWrapper.created.Config[0].Type, commonParams.SastType)
+}
+
+func TestScanRerun_GitScanCredentials(t *testing.T) {
+	sshKeyPath := filepath.Join(t.TempDir(), "id_rsa")
+	assert.NilError(t, os.WriteFile(sshKeyPath, []byte("private key"), 0o600))
+	scansWrapper := &rerunScansWrapper{scan: newRerunScan(&wrappers.ScanMetadataHandler{
+		GitHandler: &wrappers.GitProjectHandler{RepoURL: "git@github.com:org/repo.git", Branch: "main",
+			Credentials: wrappers.GitCredentials{Type: "ssh"}},
+	})}
+
+	err := executeRerunCommand(scansWrapper, "--scan-id", "previous")
+	assert.ErrorContains(t, err, "Scan previous cloned git@github.com:org/repo.git with ssh credentials, which Checkmarx One doesn't return")
+	assert.Assert(t, scansWrapper.created == nil)
+
+	err = executeRerunCommand(scansWrapper, "--scan-id", "previous", "--ssh-key", sshKeyPath)
+	assert.NilError(t, err)
+	var handler wrappers.GitProjectHandler
+	assert.NilError(t, json.Unmarshal(scansWrapper.created.Handler, &handler))
+	assert.DeepEqual(t, handler.Credentials, wrappers.GitCredentials{Type: "ssh", Value: "private key"})
+
+	err = executeRerunCommand(scansWrapper, "--scan-id", "previous", "--scm-token", "pat")
+	assert.NilError(t, err)
+	assert.NilError(t, json.Unmarshal(scansWrapper.created.Handler, &handler))
+	assert.DeepEqual(t, handler.Credentials, wrappers.GitCredentials{Type: "apiKey", Value: "pat"})
+}
+
+func TestScanRerun_SSHKeyForHTTPRepository(t *testing.T) {
+	sshKeyPath := filepath.Join(t.TempDir(), "id_rsa")
+	assert.NilError(t, os.WriteFile(sshKeyPath, []byte("private key"), 0o600))
+	scansWrapper := &rerunScansWrapper{scan: newRerunScan(&wrappers.ScanMetadataHandler{
+		GitHandler: &wrappers.GitProjectHandler{RepoURL: "https://github.com/org/repo", Branch: "main"},
+	})}
+
+	err := executeRerunCommand(scansWrapper, "--scan-id", "previous", "--ssh-key", sshKeyPath)
+	assert.ErrorContains(t, err, "Scan previous cloned https://github.com/org/repo, which doesn't need an ssh key")
+}
+
+func TestScanRerun_UploadScan(t *testing.T) {
+	scansWrapper := &rerunScansWrapper{scan: newRerunScan(&wrappers.ScanMetadataHandler{
+		UploadHandler: &wrappers.ScanHandler{UploadURL: "https://storage/sources.zip", RepoURL: "https://github.com/org/repo"},
+	})}
+
+	err := executeRerunCommand(scansWrapper, "--scan-id", "previous")
+	assert.NilError(t, err)
+
+	assert.Equal(t, scansWrapper.created.Type, uploadScanType)
+	var handler wrappers.ScanHandler
+	assert.NilError(t, json.Unmarshal(scansWrapper.created.Handler, &handler))
+	assert.Equal(t, handler.UploadURL, "singlePart/path/to/nowhere")
+	assert.Equal(t, handler.Branch, "main")
+	assert.Equal(t, handler.RepoURL, "https://github.com/org/repo")
+}
+
+func TestScanRerun_ExpiredUpload(t *testing.T) {
+	scansWrapper := &rerunScansWrapper{scan: newRerunScan(&wrappers.ScanMetadataHandler{
+		UploadHandler: &wrappers.ScanHandler{UploadURL: "https://storage/expired.zip"},
+	})}
+
+	err := executeRerunCommand(scansWrapper, "--scan-id", "previous")
+	assert.ErrorContains(t, err, "The sources of scan previous can't be downloaded anymore, please use scan create")
+	assert.Assert(t, scansWrapper.created == nil)
+}
+
+func TestScanRerun_NoSource(t *testing.T) {
+	scansWrapper := &rerunScansWrapper{scan: newRerunScan(nil)}
+
+	err := executeRerunCommand(scansWrapper, "--scan-id", "previous")
+	assert.ErrorContains(t, err, "Scan previous has no source information to rerun it from")
+}
+
+func TestScanRerun_MissingScanID(t *testing.T) {
+	err := executeRerunCommand(&rerunScansWrapper{})
+	assert.ErrorContains(t, err, "Please provide a scan ID")
+}
+
+func TestGetRerunConfigs_UnknownScanType(t *testing.T) {
+	_, err := getRerunConfigs(newRerunScan(nil), "kics")
+	assert.ErrorContains(t, err, "Scan previous didn't run kics, it ran sast,microengines")
+}
@@ -119,6 +119,14 @@ func TestRunGetAllCommandLimitList(t *testing.T) {
 	execCmdNilAssertion(t, "scan", "list", "--format", "list", "--filter", "limit=40")
 }
 
+func TestRunGetAllCommandAllPages(t *testing.T) {
+	buffer, err := executeRedirectedTestCommand("scan", "list", "--all", "--format", "ndjson")
+	assert.NilError(t, err)
+	assert.Equal(t, strings.Count(buffer.String(), "\n"), 1)
+
+	execCmdNilAssertion(t, "scan", "list", "--all", "--page-size", "10", "--format", "table")
+}
+
 func TestRunGetAllCommandOffsetList(t *testing.T) {
 	execCmdNilAssertion(t, "scan", "list", "--format", "list", "--filter", "offset=0")
 }
@@ -708,7 +716,7 @@ func Test_parseThresholdSuccess(t *testing.T) {
 	want := make(map[string]int)
 	want["iac-security-low"] = 1
 	threshold := " KICS - LoW=1"
-	if got := parseThreshold(threshold); !reflect.DeepEqual(got, want) {
+	if got := util.ParseThreshold(threshold); !reflect.DeepEqual(got, want) {
 		t.Errorf("parseThreshold() = %v, want %v", got, want)
 	}
 }
@@ -718,14 +726,14 @@ func Test_parseThresholdsSuccess(t *testing.T) {
 	want["sast-medium"] = 1
 	want["sca-high"] = 1
 	threshold := "sast-high=1; sast-medium=1; sca-high=1"
-	if got := parseThreshold(threshold); !reflect.DeepEqual(got, want) {
+	if got := util.ParseThreshold(threshold); !reflect.DeepEqual(got, want) {
 		t.Errorf("parseThreshold() = %v, want %v", got, want)
 	}
 }
 func Test_parseThresholdParseError(t *testing.T) {
 	want := make(map[string]int)
 	threshold := " KICS - LoW=error"
-	if got := parseThreshold(threshold); !reflect.DeepEqual(got, want) {
+	if got := util.ParseThreshold(threshold); !reflect.DeepEqual(got, want) {
 		t.Errorf("parseThreshold() = %v, want %v", got, want)
 	}
 }
@@ -2477,7 +2485,7 @@ func Test_parseThresholdLimit(t *testing.T) {
 	for _, tt := range tests {
 		tt := tt
 		t.Run(tt.name, func(t *testing.T) {
-			gotEngineName, gotIntLimit, err := parseThresholdLimit(tt.args.limit)
+			gotEngineName, gotIntLimit, err := util.ParseThresholdLimit(tt.args.limit)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("parseThresholdLimit() error = %v, wantErr %v", err, tt.wantErr)
 				return
@@ -2525,7 +2533,7 @@ func Test_validateThresholds(t *testing.T) {
 	for _, tt := range tests {
 		tt := tt
 		t.Run(tt.name, func(t *testing.T) {
-			err := validateThresholds(tt.thresholdMap)
+			err := util.ValidateThresholds(tt.thresholdMap)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("validateThresholds() error = %v, wantErr %v", err, tt.wantErr)
 			}
@@ -5343,7 +5351,7 @@ func TestSbomFileExcludedFromZip_WithCustomOutputName(t *testing.T) {
 	sbomAbsoluteExcludes = computeSbomExclusions(projectDir, "", sbomOutputName)
 	defer func() { sbomAbsoluteExcludes = nil }()
 
-	zipPath, err := compressFolder(sbomTestSourceDir(projectDir), "", "", "")
+	zipPath, err := compressFolder(sbomTestSourceDir(projectDir), "", "", "", nil)
 	assert.NilError(t, err)
 	defer func() { _ = os.Remove(zipPath) }()
 
@@ -5372,7 +5380,7 @@ func TestDefaultSbomFileAlwaysExcludedFromZip(t *testing.T) {
 	sbomAbsoluteExcludes = computeSbomExclusions(projectDir, sbomOutputPath, sbomOutputName)
 	defer func() { sbomAbsoluteExcludes = nil }()
 
-	zipPath, err := compressFolder(sbomTestSourceDir(projectDir), "", "", "")
+	zipPath, err := compressFolder(sbomTestSourceDir(projectDir), "", "", "", nil)
 	assert.NilError(t, err)
 	defer func() { _ = os.Remove(zipPath) }()
 
@@ -5403,7 +5411,7 @@ func TestSbomFileExcludedFromZip_InSubdirectory(t *testing.T) {
 	sbomAbsoluteExcludes = computeSbomExclusions(projectDir, "./out", sbomOutputName)
 	defer func() { sbomAbsoluteExcludes = nil }()
 
-	zipPath, err := compressFolder(sbomTestSourceDir(projectDir), "", "", "")
+	zipPath, err := compressFolder(sbomTestSourceDir(projectDir), "", "", "", nil)
 	assert.NilError(t, err)
 	defer func() { _ = os.Remove(zipPath) }()
 
@@ -5440,7 +5448,7 @@ func TestSbomFileExcludedFromZip_AbsoluteSubdirWithCustomName(t *testing.T) {
 	sbomAbsoluteExcludes = computeSbomExclusions(projectDir, sbomOutputPath, sbomOutputName)
 	defer func() { sbomAbsoluteExcludes = nil }()
 
-	zipPath, err := compressFolder(sbomTestSourceDir(projectDir), "", "", "")
+	zipPath, err := compressFolder(sbomTestSourceDir(projectDir), "", "", "", nil)
 	assert.NilError(t, err)
 	defer func() { _ = os.Remove(zipPath) }()
 
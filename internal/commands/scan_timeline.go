@@ -0,0 +1,344 @@
+package commands
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/MakeNowJust/heredoc"
+	"github.com/checkmarx/ast-cli/internal/commands/util/printer"
+	commonParams "github.com/checkmarx/ast-cli/internal/params"
+	"github.com/checkmarx/ast-cli/internal/services"
+	"github.com/checkmarx/ast-cli/internal/wrappers"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+const (
+	failedGettingTimeline = "Failed getting the scan timeline"
+	timelineFormatGantt   = "gantt"
+	timelineFormatTrace   = "chrome-trace"
+	ganttWidth            = 60
+	ganttBar              = "#"
+	ganttCriticalBar      = "="
+
+	phaseQueued    = "queued"
+	phaseUploading = "uploading"
+	phaseRunning   = "running"
+	phaseCompleted = "completed"
+)
+
+// phaseKeywords maps the words found in the workflow entries to the phase they start, in matching order.
+var phaseKeywords = []struct {
+	phase    string
+	keywords []string
+}{
+	{phaseCompleted, []string{"complete", "finish", "done", "fail", "cancel", "partial"}},
+	{phaseQueued, []string{"queue", "waiting", "pending"}},
+	{phaseUploading, []string{"upload", "clone", "fetch", "download", "source"}},
+	{phaseRunning, []string{"run", "start", "scan", "progress"}},
+}
+
+type timelinePhase struct {
+	Engine   string        `json:"engine"`
+	Phase    string        `json:"phase"`
+	Start    time.Time     `json:"start"`
+	End      time.Time     `json:"end"`
+	Duration time.Duration `json:"-"`
+	Critical bool          `json:"critical"`
+}
+
+type engineTimeline struct {
+	Engine   string          `json:"engine"`
+	Start    time.Time       `json:"start"`
+	End      time.Time       `json:"end"`
+	Duration time.Duration   `json:"-"`
+	Phases   []timelinePhase `json:"phases"`
+}
+
+type scanTimeline struct {
+	ScanID        string           `json:"scanId"`
+	Start         time.Time        `json:"start"`
+	End           time.Time        `json:"end"`
+	Duration      time.Duration    `json:"-"`
+	SlowestEngine string           `json:"slowestEngine"`
+	CriticalPath  []string         `json:"criticalPath"`
+	Engines       []engineTimeline `json:"engines"`
+}
+
+// MarshalJSON adds the durations in seconds, the time.Duration nanoseconds aren't meant to be read.
+func (p timelinePhase) MarshalJSON() ([]byte, error) {
+	type phase timelinePhase
+	return json.Marshal(struct {
+		phase
+		DurationSeconds float64 `json:"durationSeconds"`
+	}{phase(p), p.Duration.Seconds()})
+}
+
+func (e engineTimeline) MarshalJSON() ([]byte, error) {
+	type engine engineTimeline
+	return json.Marshal(struct {
+		engine
+		DurationSeconds float64 `json:"durationSeconds"`
+	}{engine(e), e.Duration.Seconds()})
+}
+
+func (s scanTimeline) MarshalJSON() ([]byte, error) {
+	type timeline scanTimeline
+	return json.Marshal(struct {
+		timeline
+		DurationSeconds float64 `json:"durationSeconds"`
+	}{timeline(s), s.Duration.Seconds()})
+}
+
+func scanTimelineSubCommand(scansWrapper wrappers.ScansWrapper) *cobra.Command {
+	timelineScanCmd := &cobra.Command{
+		Use:   "timeline",
+		Short: "Show the phases of a scan and their durations",
+		Long: "The timeline command turns the workflow of a scan into a per-engine timeline of its phases (queued, uploading, " +
+			"running, completed), and highlights the critical path and the slowest engine",
+		Example: heredoc.Doc(
+			`
+			$ cx scan timeline --scan-id <scan_id>
+			$ cx scan timeline --scan-id <scan_id> --format chrome-trace > trace.json
+		`,
+		),
+		RunE: runScanTimelineCommand(scansWrapper),
+	}
+	addScanIDFlag(timelineScanCmd, "ID of the scan to show the timeline of")
+	addFormatFlag(timelineScanCmd, timelineFormatGantt, printer.FormatJSON, timelineFormatTrace)
+	return timelineScanCmd
+}
+
+func runScanTimelineCommand(scansWrapper wrappers.ScansWrapper) func(cmd *cobra.Command, args []string) error {
+	return func(cmd *cobra.Command, args []string) error {
+		scanID, _ := cmd.Flags().GetString(commonParams.ScanIDFlag)
+		format, _ := cmd.Flags().GetString(commonParams.FormatFlag)
+		if scanID == "" {
+			return errors.Errorf("%s: Please provide a scan ID", failedGettingTimeline)
+		}
+		if !printer.IsFormat(format, timelineFormatGantt) && !printer.IsFormat(format, timelineFormatTrace) &&
+			!printer.IsFormat(format, printer.FormatJSON) {
+			return errors.Errorf("%s: Invalid format %s", failedGettingTimeline, format)
+		}
+		scan, errorModel, err := scansWrapper.GetByID(scanID)
+		if err != nil {
+			return errors.Wrapf(err, "%s", failedGettingTimeline)
+		}
+		if errorModel != nil {
+			return errors.Errorf(services.ErrorCodeFormat, failedGettingTimeline, errorModel.Code, errorModel.Message)
+		}
+		workflow, errorModel, err := scansWrapper.GetWorkflowByID(scanID)
+		if err != nil {
+			return errors.Wrapf(err, "%s", failedGettingTimeline)
+		}
+		if errorModel != nil {
+			return errors.Errorf(services.ErrorCodeFormat, failedGettingTimeline, errorModel.Code, errorModel.Message)
+		}
+
+		timeline := buildScanTimeline(scan, workflow)
+		switch {
+		case printer.IsFormat(format, timelineFormatTrace):
+			return printChromeTrace(cmd.OutOrStdout(), timeline)
+		case printer.IsFormat(format, printer.FormatJSON):
+			return printer.Print(cmd.OutOrStdout(), timeline, printer.FormatJSON)
+		default:
+			printGantt(cmd.OutOrStdout(), timeline)
+			return nil
+		}
+	}
+}
+
+// buildScanTimeline groups the workflow entries by source. Every entry starts a phase of its source that lasts until
+// the next entry of the same source, the last phase of a source ends with the scan.
+func buildScanTimeline(scan *wrappers.ScanResponseModel, workflow []*wrappers.ScanTaskResponseModel) *scanTimeline {
+	timeline := &scanTimeline{ScanID: scan.ID, Start: scan.CreatedAt, End: scan.UpdatedAt}
+	events := make(map[string][]*wrappers.ScanTaskResponseModel)
+	times := make(map[*wrappers.ScanTaskResponseModel]time.Time)
+	for _, task := range workflow {
+		timestamp, err := time.Parse(time.RFC3339Nano, task.Timestamp)
+		if err != nil {
+			continue
+		}
+		times[task] = timestamp
+		events[task.Source] = append(events[task.Source], task)
+		if timeline.Start.IsZero() || timestamp.Before(timeline.Start) {
+			timeline.Start = timestamp
+		}
+		if timestamp.After(timeline.End) {
+			timeline.End = timestamp
+		}
+	}
+
+	for source, tasks := range events {
+		sort.SliceStable(tasks, func(i, j int) bool { return times[tasks[i]].Before(times[tasks[j]]) })
+		engine := engineTimeline{Engine: source, Start: times[tasks[0]], End: times[tasks[len(tasks)-1]]}
+		for i, task := range tasks {
+			phase := timelinePhase{Engine: source, Phase: workflowPhase(task.Info), Start: times[task]}
+			if phase.Phase == phaseCompleted {
+				phase.End = phase.Start
+			} else if i+1 < len(tasks) {
+				phase.End = times[tasks[i+1]]
+			} else {
+				phase.End = timeline.End
+				engine.End = timeline.End
+			}
+			// Consecutive entries of the same phase extend it instead of starting a new one
+			if last := len(engine.Phases) - 1; last >= 0 && engine.Phases[last].Phase == phase.Phase {
+				engine.Phases[last].End = phase.End
+				engine.Phases[last].Duration = phase.End.Sub(engine.Phases[last].Start)
+				continue
+			}
+			phase.Duration = phase.End.Sub(phase.Start)
+			engine.Phases = append(engine.Phases, phase)
+		}
+		engine.Duration = engine.End.Sub(engine.Start)
+		timeline.Engines = append(timeline.Engines, engine)
+	}
+	sort.Slice(timeline.Engines, func(i, j int) bool {
+		if !timeline.Engines[i].Start.Equal(timeline.Engines[j].Start) {
+			return timeline.Engines[i].Start.Before(timeline.Engines[j].Start)
+		}
+		return timeline.Engines[i].Engine < timeline.Engines[j].Engine
+	})
+	if timeline.End.After(timeline.Start) {
+		timeline.Duration = timeline.End.Sub(timeline.Start)
+	}
+
+	var slowest time.Duration
+	for _, engine := range timeline.Engines {
+		if engine.Duration > slowest {
+			slowest = engine.Duration
+			timeline.SlowestEngine = engine.Engine
+		}
+	}
+	markCriticalPath(timeline)
+	return timeline
+}
+
+// markCriticalPath walks back from the phase ending last, each time to the latest phase ending before the current one
+// starts, since that is the phase the current one waited for.
+func markCriticalPath(timeline *scanTimeline) {
+	var phases []*timelinePhase
+	for i := range timeline.Engines {
+		for j := range timeline.Engines[i].Phases {
+			if timeline.Engines[i].Phases[j].Duration > 0 {
+				phases = append(phases, &timeline.Engines[i].Phases[j])
+			}
+		}
+	}
+	var current *timelinePhase
+	for _, phase := range phases {
+		if current == nil || phase.End.After(current.End) {
+			current = phase
+		}
+	}
+	var path []string
+	for current != nil {
+		current.Critical = true
+		path = append([]string{current.Engine + ":" + current.Phase}, path...)
+		var previous *timelinePhase
+		for _, phase := range phases {
+			if !phase.Critical && !phase.End.After(current.Start) && (previous == nil || phase.End.After(previous.End)) {
+				previous = phase
+			}
+		}
+		current = previous
+	}
+	timeline.CriticalPath = path
+}
+
+func workflowPhase(info string) string {
+	info = strings.ToLower(info)
+	for _, candidate := range phaseKeywords {
+		for _, keyword := range candidate.keywords {
+			if strings.Contains(info, keyword) {
+				return candidate.phase
+			}
+		}
+	}
+	return phaseRunning
+}
+
+func printGantt(w io.Writer, timeline *scanTimeline) {
+	_, _ = fmt.Fprintf(w, "Scan %s took %s, slowest engine: %s\n", timeline.ScanID, timeline.Duration.Round(time.Second),
+		valueOrNone(timeline.SlowestEngine))
+	if len(timeline.CriticalPath) > 0 {
+		_, _ = fmt.Fprintf(w, "Critical path: %s\n", strings.Join(timeline.CriticalPath, " -> "))
+	}
+	if timeline.Duration <= 0 {
+		return
+	}
+	nameWidth := 0
+	for _, engine := range timeline.Engines {
+		for _, phase := range engine.Phases {
+			if phase.Phase != phaseCompleted {
+				nameWidth = max(nameWidth, len(engine.Engine)+len(phase.Phase)+1)
+			}
+		}
+	}
+	_, _ = fmt.Fprintln(w)
+	for _, engine := range timeline.Engines {
+		for _, phase := range engine.Phases {
+			if phase.Phase == phaseCompleted {
+				continue
+			}
+			from := int(float64(ganttWidth) * phase.Start.Sub(timeline.Start).Seconds() / timeline.Duration.Seconds())
+			to := int(float64(ganttWidth) * phase.End.Sub(timeline.Start).Seconds() / timeline.Duration.Seconds())
+			bar := ganttBar
+			if phase.Critical {
+				bar = ganttCriticalBar
+			}
+			length := max(to-from, 1)
+			from = min(from, ganttWidth-length)
+			_, _ = fmt.Fprintf(w, "%-*s |%s%s%s| %s\n", nameWidth, engine.Engine+":"+phase.Phase, strings.Repeat(" ", from),
+				strings.Repeat(bar, length), strings.Repeat(" ", ganttWidth-from-length), phase.Duration.Round(time.Second))
+		}
+	}
+	_, _ = fmt.Fprintf(w, "\n%q marks the critical path\n", ganttCriticalBar)
+}
+
+type chromeTraceEvent struct {
+	Name      string            `json:"name"`
+	Category  string            `json:"cat"`
+	Phase     string            `json:"ph"`
+	Timestamp int64             `json:"ts"`
+	Duration  int64             `json:"dur"`
+	ProcessID int               `json:"pid"`
+	ThreadID  int               `json:"tid"`
+	Args      map[string]string `json:"args,omitempty"`
+}
+
+// printChromeTrace writes the timeline in the Trace Event Format, one thread per engine, which chrome://tracing and
+// Perfetto can open.
+func printChromeTrace(w io.Writer, timeline *scanTimeline) error {
+	events := []chromeTraceEvent{}
+	for i, engine := range timeline.Engines {
+		for _, phase := range engine.Phases {
+			event := chromeTraceEvent{
+				Name:      phase.Phase,
+				Category:  engine.Engine,
+				Phase:     "X",
+				Timestamp: phase.Start.Sub(timeline.Start).Microseconds(),
+				Duration:  phase.Duration.Microseconds(),
+				ProcessID: 1,
+				ThreadID:  i + 1,
+			}
+			if phase.Critical {
+				event.Args = map[string]string{"critical": "true"}
+			}
+			events = append(events, event)
+		}
+	}
+	return printer.Print(w, map[string]interface{}{"traceEvents": events, "displayTimeUnit": "ms"}, printer.FormatJSON)
+}
+
+func valueOrNone(value string) string {
+	if value == "" {
+		return "none"
+	}
+	return value
+}
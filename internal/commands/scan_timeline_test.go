@@ -0,0 +1,87 @@
+//go:build !integration
+
+package commands
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/checkmarx/ast-cli/internal/wrappers"
+	"gotest.tools/assert"
+)
+
+func timelineTestWorkflow() (*wrappers.ScanResponseModel, []*wrappers.ScanTaskResponseModel) {
+	scan := &wrappers.ScanResponseModel{
+		ID:        "scan-id",
+		CreatedAt: time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC),
+		UpdatedAt: time.Date(2024, 1, 1, 10, 30, 0, 0, time.UTC),
+	}
+	return scan, []*wrappers.ScanTaskResponseModel{
+		{Source: "sast", Timestamp: "2024-01-01T10:30:00Z", Info: "sast scan completed"},
+		{Source: "general", Timestamp: "2024-01-01T10:00:00Z", Info: "Scan queued"},
+		{Source: "general", Timestamp: "2024-01-01T10:02:00Z", Info: "Uploading sources"},
+		{Source: "general", Timestamp: "2024-01-01T10:04:00Z", Info: "Source upload done"},
+		{Source: "sast", Timestamp: "2024-01-01T10:04:00Z", Info: "sast scan started"},
+		{Source: "sca", Timestamp: "2024-01-01T10:04:00Z", Info: "sca scan started"},
+		{Source: "sca", Timestamp: "2024-01-01T10:10:00Z", Info: "sca scan finished"},
+		{Source: "sca", Timestamp: "not a timestamp", Info: "ignored"},
+	}
+}
+
+func TestBuildScanTimeline(t *testing.T) {
+	timeline := buildScanTimeline(timelineTestWorkflow())
+	assert.Equal(t, timeline.Duration, 30*time.Minute)
+	assert.Equal(t, timeline.SlowestEngine, "sast")
+	assert.DeepEqual(t, timeline.CriticalPath, []string{"general:queued", "general:uploading", "sast:running"})
+	assert.Equal(t, len(timeline.Engines), 3)
+	assert.Equal(t, timeline.Engines[0].Engine, "general")
+
+	general := timeline.Engines[0]
+	assert.Equal(t, len(general.Phases), 3)
+	assert.Equal(t, general.Phases[0].Phase, phaseQueued)
+	assert.Equal(t, general.Phases[0].Duration, 2*time.Minute)
+	assert.Equal(t, general.Phases[1].Phase, phaseUploading)
+	assert.Equal(t, general.Phases[2].Phase, phaseCompleted)
+
+	sca := timeline.Engines[2]
+	assert.Equal(t, sca.Engine, "sca")
+	assert.Equal(t, sca.Duration, 6*time.Minute)
+	assert.Assert(t, !sca.Phases[0].Critical)
+}
+
+func TestPrintChromeTrace(t *testing.T) {
+	buffer := &strings.Builder{}
+	assert.NilError(t, printChromeTrace(buffer, buildScanTimeline(timelineTestWorkflow())))
+	var trace struct {
+		TraceEvents []chromeTraceEvent `json:"traceEvents"`
+	}
+	assert.NilError(t, json.Unmarshal([]byte(buffer.String()), &trace))
+	assert.Equal(t, len(trace.TraceEvents), 7)
+	running := trace.TraceEvents[3]
+	assert.Equal(t, running.Category, "sast")
+	assert.Equal(t, running.Timestamp, (4 * time.Minute).Microseconds())
+	assert.Equal(t, running.Duration, (26 * time.Minute).Microseconds())
+	assert.Equal(t, running.Args["critical"], "true")
+}
+
+func TestScanTimeline(t *testing.T) {
+	buffer, err := executeRedirectedTestCommand("scan", "timeline", "--scan-id", "scan1")
+	assert.NilError(t, err)
+	assert.Assert(t, strings.HasPrefix(buffer.String(), "Scan scan1 took 5m0s, slowest engine: sast\nCritical path: sast:running\n"))
+	assert.Assert(t, strings.Contains(buffer.String(), "sast:running |"+strings.Repeat(ganttCriticalBar, ganttWidth)+"| 5m0s"), buffer.String())
+
+	buffer, err = executeRedirectedTestCommand("scan", "timeline", "--scan-id", "scan1", "--format", "json")
+	assert.NilError(t, err)
+	var timeline map[string]interface{}
+	assert.NilError(t, json.Unmarshal(buffer.Bytes(), &timeline))
+	assert.Equal(t, timeline["durationSeconds"], float64(300))
+}
+
+func TestScanTimeline_InvalidInput(t *testing.T) {
+	err := execCmdNotNilAssertion(t, "scan", "timeline")
+	assert.ErrorContains(t, err, "Please provide a scan ID")
+	err = execCmdNotNilAssertion(t, "scan", "timeline", "--scan-id", "scan1", "--format", "sarif")
+	assert.ErrorContains(t, err, "Invalid format sarif")
+}
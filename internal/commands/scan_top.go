@@ -0,0 +1,283 @@
+package commands
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/MakeNowJust/heredoc"
+	"github.com/checkmarx/ast-cli/internal/commands/util/printer"
+	commonParams "github.com/checkmarx/ast-cli/internal/params"
+	"github.com/checkmarx/ast-cli/internal/services"
+	"github.com/checkmarx/ast-cli/internal/wrappers"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+const (
+	failedMonitoringScans  = "Failed monitoring scans"
+	defaultRefreshInterval = 5
+	clearScreen            = "\033[H\033[2J"
+	topHelp                = "Commands: c <row or scan ID>... to cancel scans, r to refresh, q to quit"
+)
+
+// scanTopSnapshot is the state of the monitored scans at a point in time, as printed by --once --format json.
+type scanTopSnapshot struct {
+	Time         time.Time         `json:"time"`
+	StatusCounts map[string]int    `json:"statusCounts"`
+	ByProject    []scanTopGroup    `json:"byProject"`
+	ByEngine     []scanTopGroup    `json:"byEngine"`
+	Scans        []scanTopScanView `json:"scans"`
+}
+
+type scanTopGroup struct {
+	Name         string         `json:"name"`
+	StatusCounts map[string]int `json:"statusCounts"`
+}
+
+type scanTopScanView struct {
+	Row         int       `json:"row" format:"name:#"`
+	ID          string    `json:"id" format:"name:Scan ID"`
+	ProjectName string    `json:"projectName" format:"name:Project Name"`
+	ProjectID   string    `json:"projectId" format:"-"`
+	Branch      string    `json:"branch"`
+	Status      string    `json:"status"`
+	Engines     string    `json:"engines"`
+	CreatedAt   time.Time `json:"createdAt" format:"-"`
+	Age         string    `json:"-"`
+	AgeSeconds  int64     `json:"ageSeconds" format:"-"`
+}
+
+func scanTopSubCommand(scansWrapper wrappers.ScansWrapper) *cobra.Command {
+	topScanCmd := &cobra.Command{
+		Use:   "top",
+		Short: "Monitor the running and queued scans",
+		Long: "The top command shows a refreshing view of the scans in the given statuses, grouped by project, engine and age. " +
+			"Scans can be canceled from the view. Use --once to print the view a single time, ex: for monitoring scripts",
+		Example: heredoc.Doc(
+			`
+			$ cx scan top
+			$ cx scan top --status Queued --filter "project-id=<project_id>" --refresh-interval 10
+			$ cx scan top --once --format json
+		`,
+		),
+		RunE: runScanTopCommand(scansWrapper),
+	}
+	topScanCmd.PersistentFlags().StringSlice(commonParams.StatusFlag, []string{wrappers.ScanRunning, wrappers.ScanQueued},
+		"Statuses of the scans to show")
+	topScanCmd.PersistentFlags().StringSlice(commonParams.FilterFlag, []string{}, filterScanListFlagUsage)
+	topScanCmd.PersistentFlags().Bool(commonParams.OnceFlag, false, "Print the scans a single time and exit")
+	topScanCmd.PersistentFlags().Int(commonParams.RefreshIntervalFlag, defaultRefreshInterval, "Seconds between refreshes of the view")
+	addFormatFlag(topScanCmd, printer.FormatTable, printer.FormatJSON)
+	return topScanCmd
+}
+
+func runScanTopCommand(scansWrapper wrappers.ScansWrapper) func(cmd *cobra.Command, args []string) error {
+	return func(cmd *cobra.Command, args []string) error {
+		params, err := getFilters(cmd)
+		if err != nil {
+			return errors.Wrapf(err, "%s", failedMonitoringScans)
+		}
+		statuses, _ := cmd.Flags().GetStringSlice(commonParams.StatusFlag)
+		once, _ := cmd.Flags().GetBool(commonParams.OnceFlag)
+		interval, _ := cmd.Flags().GetInt(commonParams.RefreshIntervalFlag)
+		format, _ := cmd.Flags().GetString(commonParams.FormatFlag)
+		if interval <= 0 {
+			return errors.Errorf("%s: --%s must be greater than 0", failedMonitoringScans, commonParams.RefreshIntervalFlag)
+		}
+		if !once && !printer.IsFormat(format, printer.FormatTable) {
+			return errors.Errorf("%s: --%s %s can only be used with --%s", failedMonitoringScans, commonParams.FormatFlag, format,
+				commonParams.OnceFlag)
+		}
+		if len(statuses) > 0 {
+			params[commonParams.StatusesQueryParam] = strings.Join(statuses, ",")
+		}
+
+		snapshot, err := getScanTopSnapshot(scansWrapper, params)
+		if err != nil {
+			return err
+		}
+		if once {
+			if printer.IsFormat(format, printer.FormatJSON) {
+				return printer.Print(cmd.OutOrStdout(), snapshot, printer.FormatJSON)
+			}
+			return printScanTop(cmd.OutOrStdout(), snapshot, "")
+		}
+
+		// Reading the standard input can't be interrupted, so once the command returns the reader stays blocked until
+		// the next line or the end of the input, and only done stops it from sending. scan top is interactive and
+		// always runs until cx exits, which ends the reader with the process.
+		input := make(chan string)
+		done := make(chan struct{})
+		defer close(done)
+		go func() {
+			scanner := bufio.NewScanner(cmd.InOrStdin())
+			for scanner.Scan() {
+				select {
+				case input <- scanner.Text():
+				case <-done:
+					return
+				}
+			}
+		}()
+		ticker := time.NewTicker(time.Duration(interval) * time.Second)
+		defer ticker.Stop()
+		message := topHelp
+		for {
+			_, _ = fmt.Fprint(cmd.OutOrStdout(), clearScreen)
+			if err = printScanTop(cmd.OutOrStdout(), snapshot, message); err != nil {
+				return err
+			}
+			select {
+			case line := <-input:
+				var quit bool
+				message, quit = handleScanTopInput(scansWrapper, snapshot, line)
+				if quit {
+					return nil
+				}
+			case <-ticker.C:
+			}
+			if snapshot, err = getScanTopSnapshot(scansWrapper, params); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+func getScanTopSnapshot(scansWrapper wrappers.ScansWrapper, params map[string]string) (*scanTopSnapshot, error) {
+	scans, err := getAllScans(scansWrapper, params)
+	if err != nil {
+		return nil, errors.Wrapf(err, "%s", failedMonitoringScans)
+	}
+	return buildScanTopSnapshot(scans, time.Now()), nil
+}
+
+// buildScanTopSnapshot counts the scans by status overall, by project and by engine, and lists them from the oldest.
+func buildScanTopSnapshot(scans []wrappers.ScanResponseModel, now time.Time) *scanTopSnapshot {
+	snapshot := &scanTopSnapshot{Time: now, StatusCounts: map[string]int{}, Scans: []scanTopScanView{}}
+	projects := map[string]map[string]int{}
+	engines := map[string]map[string]int{}
+	sort.SliceStable(scans, func(i, j int) bool { return scans[i].CreatedAt.Before(scans[j].CreatedAt) })
+	for i := range scans {
+		scan := &scans[i]
+		status := string(scan.Status)
+		snapshot.StatusCounts[status]++
+		countScanTopGroup(projects, scan.ProjectName, status)
+		for _, engine := range scan.Engines {
+			countScanTopGroup(engines, engine, status)
+		}
+		view := scanTopScanView{
+			Row:         i + 1,
+			ID:          scan.ID,
+			ProjectName: scan.ProjectName,
+			ProjectID:   scan.ProjectID,
+			Branch:      scan.Branch,
+			Status:      status,
+			Engines:     strings.Join(scan.Engines, ","),
+			CreatedAt:   scan.CreatedAt,
+			Age:         missingCompareValue,
+		}
+		if !scan.CreatedAt.IsZero() {
+			age := now.Sub(scan.CreatedAt).Round(time.Second)
+			view.Age = age.String()
+			view.AgeSeconds = int64(age.Seconds())
+		}
+		snapshot.Scans = append(snapshot.Scans, view)
+	}
+	snapshot.ByProject = toScanTopGroups(projects)
+	snapshot.ByEngine = toScanTopGroups(engines)
+	return snapshot
+}
+
+func countScanTopGroup(groups map[string]map[string]int, name, status string) {
+	if groups[name] == nil {
+		groups[name] = map[string]int{}
+	}
+	groups[name][status]++
+}
+
+func toScanTopGroups(groups map[string]map[string]int) []scanTopGroup {
+	result := []scanTopGroup{}
+	for name, counts := range groups {
+		result = append(result, scanTopGroup{Name: name, StatusCounts: counts})
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Name < result[j].Name })
+	return result
+}
+
+func printScanTop(w io.Writer, snapshot *scanTopSnapshot, message string) error {
+	_, _ = fmt.Fprintf(w, "%s - %s\n", snapshot.Time.Format(time.TimeOnly), formatStatusCounts(snapshot.StatusCounts))
+	if message != "" {
+		_, _ = fmt.Fprintln(w, message)
+	}
+	_, _ = fmt.Fprintln(w, "\nBy project:")
+	for _, group := range snapshot.ByProject {
+		_, _ = fmt.Fprintf(w, "  %s: %s\n", valueOrNone(group.Name), formatStatusCounts(group.StatusCounts))
+	}
+	_, _ = fmt.Fprintln(w, "By engine:")
+	for _, group := range snapshot.ByEngine {
+		_, _ = fmt.Fprintf(w, "  %s: %s\n", group.Name, formatStatusCounts(group.StatusCounts))
+	}
+	return printer.Print(w, snapshot.Scans, printer.FormatTable)
+}
+
+func formatStatusCounts(counts map[string]int) string {
+	if len(counts) == 0 {
+		return "no scans"
+	}
+	statuses := make([]string, 0, len(counts))
+	for status := range counts {
+		statuses = append(statuses, status)
+	}
+	sort.Strings(statuses)
+	parts := make([]string, len(statuses))
+	for i, status := range statuses {
+		parts[i] = fmt.Sprintf("%s %d", status, counts[status])
+	}
+	return strings.Join(parts, ", ")
+}
+
+// handleScanTopInput runs a command typed in the interactive view and returns the message to show with the next refresh.
+// Scans to cancel are given by their row in the view or their ID.
+func handleScanTopInput(scansWrapper wrappers.ScansWrapper, snapshot *scanTopSnapshot, line string) (message string, quit bool) {
+	fields := strings.Fields(line)
+	if len(fields) == 0 {
+		return topHelp, false
+	}
+	switch strings.ToLower(fields[0]) {
+	case "q", "quit":
+		return "", true
+	case "r", "refresh":
+		return topHelp, false
+	case "c", "cancel":
+		if len(fields) == 1 {
+			return "Please provide the rows or IDs of the scans to cancel", false
+		}
+		var results []string
+		for _, selector := range fields[1:] {
+			scanID := selector
+			if row, err := strconv.Atoi(selector); err == nil && row >= 1 && row <= len(snapshot.Scans) {
+				scanID = snapshot.Scans[row-1].ID
+			}
+			results = append(results, cancelScanFromTop(scansWrapper, scanID))
+		}
+		return strings.Join(results, "\n"), false
+	default:
+		return fmt.Sprintf("Unknown command %q. %s", fields[0], topHelp), false
+	}
+}
+
+func cancelScanFromTop(scansWrapper wrappers.ScansWrapper, scanID string) string {
+	errorModel, err := scansWrapper.Cancel(scanID)
+	if err != nil {
+		return fmt.Sprintf("%s %s: %v", failedCanceling, scanID, err)
+	}
+	if errorModel != nil {
+		return fmt.Sprintf(services.ErrorCodeFormat, failedCanceling+" "+scanID, errorModel.Code, errorModel.Message)
+	}
+	return fmt.Sprintf("Canceled scan %s", scanID)
+}
@@ -0,0 +1,93 @@
+//go:build !integration
+
+package commands
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/checkmarx/ast-cli/internal/wrappers"
+	"github.com/checkmarx/ast-cli/internal/wrappers/mock"
+	"gotest.tools/assert"
+)
+
+type cancelRecordingScansWrapper struct {
+	mock.ScansMockWrapper
+	canceled []string
+}
+
+func (w *cancelRecordingScansWrapper) Cancel(scanID string) (*wrappers.ErrorModel, error) {
+	w.canceled = append(w.canceled, scanID)
+	if scanID == "finished" {
+		return &wrappers.ErrorModel{Code: 400, Message: "scan already finished"}, nil
+	}
+	return nil, nil
+}
+
+func TestBuildScanTopSnapshot(t *testing.T) {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	snapshot := buildScanTopSnapshot([]wrappers.ScanResponseModel{
+		{ID: "new", Status: wrappers.ScanQueued, ProjectName: "web", Engines: []string{"sast"}, CreatedAt: now.Add(-time.Minute)},
+		{ID: "old", Status: wrappers.ScanRunning, ProjectName: "web", Engines: []string{"sast", "sca"}, CreatedAt: now.Add(-time.Hour)},
+		{ID: "api", Status: wrappers.ScanRunning, ProjectName: "api", Engines: []string{"sca"}, CreatedAt: now.Add(-10 * time.Minute)},
+	}, now)
+
+	assert.DeepEqual(t, snapshot.StatusCounts, map[string]int{wrappers.ScanRunning: 2, wrappers.ScanQueued: 1})
+	assert.DeepEqual(t, snapshot.ByProject, []scanTopGroup{
+		{Name: "api", StatusCounts: map[string]int{wrappers.ScanRunning: 1}},
+		{Name: "web", StatusCounts: map[string]int{wrappers.ScanRunning: 1, wrappers.ScanQueued: 1}},
+	})
+	assert.DeepEqual(t, snapshot.ByEngine[1], scanTopGroup{Name: "sca", StatusCounts: map[string]int{wrappers.ScanRunning: 2}})
+	assert.Equal(t, snapshot.Scans[0].ID, "old")
+	assert.Equal(t, snapshot.Scans[0].Row, 1)
+	assert.Equal(t, snapshot.Scans[0].Age, "1h0m0s")
+	assert.Equal(t, snapshot.Scans[2].AgeSeconds, int64(60))
+}
+
+func TestHandleScanTopInput(t *testing.T) {
+	scansWrapper := &cancelRecordingScansWrapper{}
+	snapshot := &scanTopSnapshot{Scans: []scanTopScanView{{Row: 1, ID: "first"}, {Row: 2, ID: "second"}}}
+
+	message, quit := handleScanTopInput(scansWrapper, snapshot, "c 2 other-id finished")
+	assert.Assert(t, !quit)
+	assert.DeepEqual(t, scansWrapper.canceled, []string{"second", "other-id", "finished"})
+	assert.Assert(t, strings.Contains(message, "Canceled scan second\nCanceled scan other-id\n"))
+	assert.Assert(t, strings.Contains(message, "scan already finished"))
+
+	message, _ = handleScanTopInput(scansWrapper, snapshot, "cancel")
+	assert.Equal(t, message, "Please provide the rows or IDs of the scans to cancel")
+	message, _ = handleScanTopInput(scansWrapper, snapshot, "x")
+	assert.Assert(t, strings.HasPrefix(message, `Unknown command "x"`))
+	_, quit = handleScanTopInput(scansWrapper, snapshot, "q")
+	assert.Assert(t, quit)
+}
+
+func TestScanTopOnceJSON(t *testing.T) {
+	buffer, err := executeRedirectedTestCommand("scan", "top", "--once", "--format", "json")
+	assert.NilError(t, err)
+	var snapshot scanTopSnapshot
+	assert.NilError(t, json.Unmarshal(buffer.Bytes(), &snapshot))
+	assert.Equal(t, len(snapshot.Scans), 1)
+	assert.Equal(t, snapshot.Scans[0].ID, "MOCK")
+}
+
+func TestScanTopInteractiveQuit(t *testing.T) {
+	buffer := bytes.NewBufferString("")
+	cmd := createASTTestCommand()
+	cmd.SetArgs([]string{"scan", "top", "--status", "Running"})
+	cmd.SetIn(strings.NewReader("c 1\nq\n"))
+	cmd.SetOut(buffer)
+	assert.NilError(t, cmd.Execute())
+	assert.Equal(t, strings.Count(buffer.String(), clearScreen), 2)
+	assert.Assert(t, strings.Contains(buffer.String(), "Canceled scan MOCK"))
+}
+
+func TestScanTop_InvalidInput(t *testing.T) {
+	err := execCmdNotNilAssertion(t, "scan", "top", "--format", "json")
+	assert.ErrorContains(t, err, "--format json can only be used with --once")
+	err = execCmdNotNilAssertion(t, "scan", "top", "--once", "--refresh-interval", "0")
+	assert.ErrorContains(t, err, "--refresh-interval must be greater than 0")
+}
@@ -0,0 +1,58 @@
+package util
+
+import (
+	"strings"
+
+	"github.com/checkmarx/ast-cli/internal/wrappers"
+	"github.com/pkg/errors"
+)
+
+const (
+	failedListingResults = "Failed listing results"
+	originCode           = "code"
+	originDocumentation  = "documentation"
+)
+
+// GetFilterResultsForAPISecScanner counts the exploitable API security risks of the scan by severity and origin
+func GetFilterResultsForAPISecScanner(risksOverviewWrapper wrappers.RisksOverviewWrapper, scanID string, resultsParams map[string]string) (aPISecSeveritySummary *wrappers.APISecFilteredResult, err error) {
+	var apiSecRiskEntriesResult wrappers.APISecRiskEntriesResult
+	var errorModel *wrappers.WebError
+
+	apiSecRiskEntriesResult, errorModel, err = risksOverviewWrapper.GetFilterResultForAPISecByScanID(scanID, resultsParams)
+	if err != nil {
+		return nil, errors.Wrapf(err, "%s", failedListingResults)
+	}
+	if errorModel != nil {
+		return nil, errors.Errorf("%s: CODE: %d, %s", failedListingResults, errorModel.Code, errorModel.Message)
+	}
+	if len(apiSecRiskEntriesResult.Entries) > 0 {
+		entries := apiSecRiskEntriesResult.Entries
+		severityCount := make(map[string]int)
+		originCount := make(map[string]int)
+		totalRecords := 0
+		for i := range entries {
+			entry := &entries[i]
+			if !IsExploitable(entry.State) {
+				continue
+			}
+			sev := strings.ToLower(entry.Severity)
+			severityCount[sev]++
+			orig := strings.ToLower(entry.Origin)
+			originCount[orig]++
+			totalRecords++
+		}
+		var riskDistribution []wrappers.RiskDistributionEntry
+		if originCount[originCode] > 0 {
+			riskDistribution = append(riskDistribution, wrappers.RiskDistributionEntry{Origin: originCode, Total: originCount[originCode]})
+		}
+		if originCount[originDocumentation] > 0 {
+			riskDistribution = append(riskDistribution, wrappers.RiskDistributionEntry{Origin: originDocumentation, Total: originCount[originDocumentation]})
+		}
+		return &wrappers.APISecFilteredResult{
+			SeverityCount:    severityCount,
+			RiskDistribution: riskDistribution,
+			TotalRisksCount:  totalRecords,
+		}, nil
+	}
+	return nil, nil
+}
@@ -0,0 +1,482 @@
+package util
+
+import (
+	"bytes"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"text/template"
+
+	"github.com/Checkmarx/containers-images-extractor/pkg/imagesExtractor"
+	"github.com/MakeNowJust/heredoc"
+	"github.com/checkmarx/ast-cli/internal/logger"
+	"github.com/checkmarx/ast-cli/internal/params"
+	"github.com/checkmarx/ast-cli/internal/services/realtimeengine/ossrealtime"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+const (
+	ciGitHubActions        = "github-actions"
+	ciGitLab               = "gitlab-ci"
+	ciAzurePipelines       = "azure-pipelines"
+	ciJenkins              = "jenkins"
+	ciBitbucketPipelines   = "bitbucket-pipelines"
+	ciDefaultBranch        = "main"
+	ciLatestCLIURL         = "https://github.com/Checkmarx/ast-cli/releases/latest/download/ast-cli_linux_x64.tar.gz"
+	ciVersionCLIURL        = "https://github.com/Checkmarx/ast-cli/releases/download/%s/ast-cli_linux_x64.tar.gz"
+	ciDevVersion           = "dev"
+	ciMaxListedFiles       = 10
+	ciIaCMaxFileSize       = 512 * 1024
+	ciContainersThreshold  = "containers"
+	ciPipelineCreated      = "Created %s for %s with scan types %s and threshold %s\n"
+	invalidCIProvider      = "invalid provider %s, expected one of: %s"
+	ciPipelineExists       = "%s already exists, use --%s to overwrite it"
+	failedInspectingSource = "failed inspecting the source directory"
+	failedWritingPipeline  = "failed writing the pipeline definition"
+)
+
+var (
+	ciProviders = []string{ciGitHubActions, ciGitLab, ciAzurePipelines, ciJenkins, ciBitbucketPipelines}
+
+	// ciPipelineFiles are the locations each provider reads its pipeline definition from
+	ciPipelineFiles = map[string]string{
+		ciGitHubActions:      filepath.Join(".github", "workflows", "checkmarx.yml"),
+		ciGitLab:             ".gitlab-ci.yml",
+		ciAzurePipelines:     "azure-pipelines.yml",
+		ciJenkins:            "Jenkinsfile",
+		ciBitbucketPipelines: "bitbucket-pipelines.yml",
+	}
+
+	ciTemplates = map[string]string{
+		ciGitHubActions:      githubActionsTemplate,
+		ciGitLab:             gitlabCITemplate,
+		ciAzurePipelines:     azurePipelinesTemplate,
+		ciJenkins:            jenkinsTemplate,
+		ciBitbucketPipelines: bitbucketPipelinesTemplate,
+	}
+
+	ciLanguages = map[string]string{
+		".go":     "Go",
+		".java":   "Java",
+		".kt":     "Kotlin",
+		".kts":    "Kotlin",
+		".scala":  "Scala",
+		".groovy": "Groovy",
+		".js":     "JavaScript",
+		".jsx":    "JavaScript",
+		".mjs":    "JavaScript",
+		".cjs":    "JavaScript",
+		".ts":     "TypeScript",
+		".tsx":    "TypeScript",
+		".vue":    "Vue",
+		".py":     "Python",
+		".cs":     "C#",
+		".vb":     "VB.NET",
+		".rb":     "Ruby",
+		".php":    "PHP",
+		".swift":  "Swift",
+		".m":      "Objective-C",
+		".c":      "C/C++",
+		".cc":     "C/C++",
+		".cpp":    "C/C++",
+		".h":      "C/C++",
+		".rs":     "Rust",
+		".dart":   "Dart",
+		".apxc":   "Apex",
+		".cbl":    "COBOL",
+	}
+
+	ciIaCExtensions = map[string]bool{
+		".tf":         true,
+		".tfvars":     true,
+		".bicep":      true,
+		".bicepparam": true,
+	}
+
+	ciSkippedDirectories = map[string]bool{
+		".git":         true,
+		".idea":        true,
+		".vs":          true,
+		".vscode":      true,
+		"node_modules": true,
+		"vendor":       true,
+	}
+
+	// ciIaCContent recognizes CloudFormation templates and Kubernetes manifests among YAML and JSON files
+	ciIaCContent    = regexp.MustCompile(`AWSTemplateFormatVersion|(?m)^\s*"?apiVersion"?\s*:[\s\S]*^\s*"?kind"?\s*:`)
+	ciOriginRemote  = regexp.MustCompile(`(?m)^\[remote "origin"\][^\[]*?^\s*url\s*=\s*(\S+)`)
+	ciScmByHostname = map[string]string{
+		"github.com":    "github",
+		"bitbucket.org": "bitbucket",
+	}
+)
+
+// ciRepository is what the inspection of a source directory found to scan
+type ciRepository struct {
+	Languages       []string
+	Manifests       []string
+	ContainerFiles  []string
+	ContainerImages []string
+	IaCFiles        []string
+	// SCM, Namespace and RepoName are parsed from the origin remote, when it is a known SCM
+	SCM       string
+	Namespace string
+	RepoName  string
+}
+
+// ciPipeline is the data the pipeline templates are rendered with
+type ciPipeline struct {
+	Header        string
+	CLIURL        string
+	Branch        string
+	ScanTypes     string
+	Threshold     string
+	ReportFormats string
+	GLSca         bool
+	ProjectName   string
+	Decoration    string
+}
+
+func NewCICommand() *cobra.Command {
+	ciCmd := &cobra.Command{
+		Use:   "ci",
+		Short: "Generate CI pipeline definitions running Checkmarx One scans",
+		Example: heredoc.Doc(
+			`
+			$ cx utils ci init --provider github-actions
+		`,
+		),
+	}
+
+	initCmd := &cobra.Command{
+		Use:   "init",
+		Short: "Generate the recommended pipeline definition for the repository",
+		Long: "The init command inspects the languages, package manifests, container and IaC files of the repository and " +
+			"generates a pipeline definition scanning it, failing on the threshold, publishing the reports and decorating pull requests",
+		Example: heredoc.Doc(
+			`
+			$ cx utils ci init --provider gitlab-ci --file-source . --branch main
+		`,
+		),
+		RunE: runCIInit,
+	}
+	initCmd.Flags().String(params.CIProviderFlag, "", fmt.Sprintf(params.CIProviderFlagUsage, strings.Join(ciProviders, ", ")))
+	initCmd.Flags().StringP(params.SourcesFlag, params.SourcesFlagSh, ".", "Source directory of the repository to inspect")
+	initCmd.Flags().String(params.BranchFlag, ciDefaultBranch, "Default branch of the repository, scanned on every push")
+	initCmd.Flags().String(params.ScanTypes, "", "Scan types to run. Defaults to the engines matching the files of the repository")
+	initCmd.Flags().String(params.Threshold, "", "Threshold failing the pipeline. Defaults to a critical or high result of every engine scanned")
+	initCmd.Flags().String(params.CIOutputFileFlag, "", params.CIOutputFileFlagUsage)
+	initCmd.Flags().Bool(params.CIForceFlag, false, params.CIForceFlagUsage)
+	_ = initCmd.MarkFlagRequired(params.CIProviderFlag)
+
+	ciCmd.AddCommand(initCmd)
+	return ciCmd
+}
+
+func runCIInit(cmd *cobra.Command, _ []string) error {
+	provider, _ := cmd.Flags().GetString(params.CIProviderFlag)
+	source, _ := cmd.Flags().GetString(params.SourcesFlag)
+	branch, _ := cmd.Flags().GetString(params.BranchFlag)
+	scanTypes, _ := cmd.Flags().GetString(params.ScanTypes)
+	threshold, _ := cmd.Flags().GetString(params.Threshold)
+	outputFile, _ := cmd.Flags().GetString(params.CIOutputFileFlag)
+	force, _ := cmd.Flags().GetBool(params.CIForceFlag)
+
+	provider = strings.ToLower(strings.TrimSpace(provider))
+	pipelineTemplate, ok := ciTemplates[provider]
+	if !ok {
+		return errors.Errorf(invalidCIProvider, provider, strings.Join(ciProviders, ", "))
+	}
+	if outputFile == "" {
+		outputFile = filepath.Join(source, ciPipelineFiles[provider])
+	}
+	if _, err := os.Stat(outputFile); err == nil && !force {
+		return errors.Errorf(ciPipelineExists, outputFile, params.CIForceFlag)
+	}
+
+	repository, err := inspectCIRepository(source)
+	if err != nil {
+		return errors.Wrap(err, failedInspectingSource)
+	}
+
+	pipeline := newCIPipeline(provider, repository, branch, scanTypes, threshold)
+	content, err := renderCIPipeline(pipelineTemplate, pipeline)
+	if err != nil {
+		return err
+	}
+
+	if err = os.MkdirAll(filepath.Dir(outputFile), os.ModePerm); err != nil {
+		return errors.Wrap(err, failedWritingPipeline)
+	}
+	if err = os.WriteFile(outputFile, content, 0o644); err != nil { //nolint:gosec // pipeline definitions are committed to the repository
+		return errors.Wrap(err, failedWritingPipeline)
+	}
+
+	_, _ = fmt.Fprintf(cmd.OutOrStdout(), ciPipelineCreated, outputFile, provider, pipeline.ScanTypes, pipeline.Threshold)
+	return nil
+}
+
+// inspectCIRepository lists the languages, package manifests, IaC files, container files and images of source
+func inspectCIRepository(source string) (*ciRepository, error) {
+	repository := &ciRepository{}
+	languages := map[string]bool{}
+
+	err := filepath.WalkDir(source, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if entry.IsDir() {
+			if path != source && ciSkippedDirectories[entry.Name()] {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		relativePath, _ := filepath.Rel(source, path)
+		relativePath = filepath.ToSlash(relativePath)
+		extension := strings.ToLower(filepath.Ext(entry.Name()))
+
+		if language, ok := ciLanguages[extension]; ok {
+			languages[language] = true
+		}
+		if ossrealtime.IsSupportedManifestFile(path) {
+			repository.Manifests = append(repository.Manifests, relativePath)
+		}
+		if isCIIaCFile(path, extension, entry) {
+			repository.IaCFiles = append(repository.IaCFiles, relativePath)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for language := range languages {
+		repository.Languages = append(repository.Languages, language)
+	}
+	sort.Strings(repository.Languages)
+
+	inspectCIContainers(source, repository)
+	inspectCIOrigin(source, repository)
+	return repository, nil
+}
+
+func isCIIaCFile(path, extension string, entry fs.DirEntry) bool {
+	if ciIaCExtensions[extension] {
+		return true
+	}
+	if extension != ".yaml" && extension != ".yml" && extension != ".json" {
+		return false
+	}
+	info, err := entry.Info()
+	if err != nil || info.Size() > ciIaCMaxFileSize {
+		return false
+	}
+	content, err := os.ReadFile(path)
+	return err == nil && ciIaCContent.Match(content)
+}
+
+// inspectCIContainers lists the Dockerfiles, compose files and Helm charts of source with the extractor the
+// container resolver uses, and the images they reference.
+func inspectCIContainers(source string, repository *ciRepository) {
+	extractor := imagesExtractor.NewImagesExtractor()
+	files, envVars, _, err := extractor.ExtractFiles(source)
+	if err != nil {
+		logger.PrintIfVerbose(fmt.Sprintf("Failed extracting the container files: %v", err))
+		return
+	}
+	for _, file := range append(files.Dockerfile, files.DockerCompose...) {
+		repository.ContainerFiles = append(repository.ContainerFiles, filepath.ToSlash(file.RelativePath))
+	}
+	for _, chart := range files.Helm {
+		relativePath, relErr := filepath.Rel(source, chart.Directory)
+		if relErr != nil {
+			relativePath = chart.Directory
+		}
+		repository.ContainerFiles = append(repository.ContainerFiles, filepath.ToSlash(relativePath))
+	}
+
+	images, err := extractor.ExtractAndMergeImagesFromFiles(files, nil, envVars)
+	if err != nil {
+		logger.PrintIfVerbose(fmt.Sprintf("Failed extracting the container images: %v", err))
+		return
+	}
+	for _, image := range images {
+		repository.ContainerImages = append(repository.ContainerImages, image.Name)
+	}
+	sort.Strings(repository.ContainerImages)
+}
+
+// inspectCIOrigin reads the SCM, namespace and repository of the origin remote from the git configuration of source
+func inspectCIOrigin(source string, repository *ciRepository) {
+	config, err := os.ReadFile(filepath.Join(source, ".git", "config"))
+	if err != nil {
+		return
+	}
+	match := ciOriginRemote.FindSubmatch(config)
+	if match == nil {
+		return
+	}
+	originURL := strings.TrimSuffix(string(match[1]), ".git")
+	for hostname, scm := range ciScmByHostname {
+		if strings.Contains(originURL, hostname) {
+			repository.SCM = scm
+		}
+	}
+	segments := strings.FieldsFunc(originURL, func(r rune) bool {
+		return r == '/' || r == ':'
+	})
+	if len(segments) >= 2 {
+		repository.Namespace = segments[len(segments)-2]
+		repository.RepoName = segments[len(segments)-1]
+	}
+}
+
+func newCIPipeline(provider string, repository *ciRepository, branch, scanTypes, threshold string) *ciPipeline {
+	var engines []string
+	if len(repository.Languages) > 0 {
+		engines = append(engines, params.SastType)
+	}
+	if len(repository.Manifests) > 0 {
+		engines = append(engines, params.ScaType)
+	}
+	// KICS scans the Dockerfiles and Helm charts as well
+	if len(repository.IaCFiles) > 0 || len(repository.ContainerFiles) > 0 {
+		engines = append(engines, params.IacType)
+	}
+	if len(repository.ContainerFiles) > 0 {
+		engines = append(engines, params.ContainersTypeFlag)
+	}
+	if scanTypes == "" {
+		if len(engines) == 0 {
+			engines = []string{params.SastType, params.ScaType}
+		}
+		scanTypes = strings.Join(engines, ",")
+	}
+	if threshold == "" {
+		threshold = ciThreshold(strings.Split(scanTypes, ","))
+	}
+
+	pipeline := &ciPipeline{
+		Header:        repository.header(ciCommentPrefix(provider)),
+		CLIURL:        ciCLIURL(),
+		Branch:        branch,
+		ScanTypes:     scanTypes,
+		Threshold:     threshold,
+		ReportFormats: "json,sarif,summaryConsole",
+		GLSca:         strings.Contains(scanTypes, params.ScaType),
+	}
+	switch provider {
+	case ciGitLab:
+		pipeline.ReportFormats = "json,gl-sast,summaryConsole"
+		if pipeline.GLSca {
+			pipeline.ReportFormats = "json,gl-sast,gl-sca,summaryConsole"
+		}
+	case ciJenkins:
+		pipeline.ReportFormats = "json,sarif,summaryHTML,summaryConsole"
+		pipeline.ProjectName = "${JOB_NAME%/*}"
+		if repository.Namespace != "" {
+			pipeline.ProjectName = repository.Namespace + "/" + repository.RepoName
+		}
+		pipeline.Decoration = ciJenkinsDecoration(repository, threshold)
+	}
+	return pipeline
+}
+
+// ciThreshold fails on a critical or high result of every engine of scanTypes
+func ciThreshold(scanTypes []string) string {
+	var limits []string
+	for _, scanType := range scanTypes {
+		engine := strings.TrimSpace(scanType)
+		if engine == params.ContainersTypeFlag {
+			engine = ciContainersThreshold
+		}
+		if engine == params.SastType || engine == params.ScaType || engine == params.IacType || engine == ciContainersThreshold {
+			limits = append(limits, engine+"-critical=1", engine+"-high=1")
+		}
+	}
+	return strings.Join(limits, ";")
+}
+
+// ciJenkinsDecoration returns the utils pr command decorating the pull requests of a Jenkins multibranch pipeline,
+// which only knows the SCM of the repository from its origin remote.
+func ciJenkinsDecoration(repository *ciRepository, threshold string) string {
+	switch repository.SCM {
+	case "github":
+		return fmt.Sprintf(
+			`./cx utils pr github --scan-id "$SCAN_ID" --token "$SCM_TOKEN" --namespace "%s" --repo-name "%s" `+
+				`--pr-number "$CHANGE_ID" --inline-comments --status --threshold "%s"`,
+			repository.Namespace, repository.RepoName, threshold,
+		)
+	case "bitbucket":
+		return fmt.Sprintf(
+			`./cx utils pr bitbucket --scan-id "$SCAN_ID" --token "$SCM_TOKEN" --namespace "%s" --repo-name "%s" `+
+				`--pr-id "$CHANGE_ID" --inline-comments --status --threshold "%s"`,
+			repository.Namespace, repository.RepoName, threshold,
+		)
+	}
+	return ""
+}
+
+func ciCommentPrefix(provider string) string {
+	if provider == ciJenkins {
+		return "//"
+	}
+	return "#"
+}
+
+// ciCLIURL pins the CLI installed by the pipeline to the version generating it
+func ciCLIURL() string {
+	if params.Version == "" || params.Version == ciDevVersion {
+		return ciLatestCLIURL
+	}
+	return fmt.Sprintf(ciVersionCLIURL, params.Version)
+}
+
+// header describes what the inspection found as comment lines
+func (r *ciRepository) header(prefix string) string {
+	lines := []string{"Checkmarx One pipeline generated by cx utils ci init"}
+	details := []struct {
+		title  string
+		values []string
+	}{
+		{"Languages", r.Languages},
+		{"Package manifests", r.Manifests},
+		{"Container files", r.ContainerFiles},
+		{"Container images", r.ContainerImages},
+		{"IaC files", r.IaCFiles},
+	}
+	for _, detail := range details {
+		if len(detail.values) > 0 {
+			lines = append(lines, fmt.Sprintf("%s: %s", detail.title, listCIValues(detail.values)))
+		}
+	}
+	for i, line := range lines {
+		lines[i] = prefix + " " + line
+	}
+	return strings.Join(lines, "\n")
+}
+
+func listCIValues(values []string) string {
+	if len(values) <= ciMaxListedFiles {
+		return strings.Join(values, ", ")
+	}
+	return fmt.Sprintf("%s and %d more", strings.Join(values[:ciMaxListedFiles], ", "), len(values)-ciMaxListedFiles)
+}
+
+// renderCIPipeline renders a pipeline template, delimited by [[ ]] not to clash with the expressions of the providers
+func renderCIPipeline(pipelineTemplate string, pipeline *ciPipeline) ([]byte, error) {
+	tmpl, err := template.New("pipeline").Delims("[[", "]]").Parse(pipelineTemplate)
+	if err != nil {
+		return nil, err
+	}
+	var content bytes.Buffer
+	if err = tmpl.Execute(&content, pipeline); err != nil {
+		return nil, err
+	}
+	return content.Bytes(), nil
+}
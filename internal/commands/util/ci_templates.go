@@ -0,0 +1,265 @@
+package util
+
+// The pipeline templates are delimited by [[ ]] not to clash with the ${{ }} expressions of GitHub Actions
+
+const githubActionsTemplate = `[[.Header]]
+name: Checkmarx One
+
+on:
+  push:
+    branches: [ "[[.Branch]]" ]
+  pull_request:
+    branches: [ "[[.Branch]]" ]
+
+permissions:
+  contents: read
+  security-events: write
+  pull-requests: write
+  statuses: write
+
+jobs:
+  checkmarx:
+    runs-on: ubuntu-latest
+    env:
+      CX_BASE_URI: ${{ secrets.CX_BASE_URI }}
+      CX_TENANT: ${{ secrets.CX_TENANT }}
+      CX_CLIENT_ID: ${{ secrets.CX_CLIENT_ID }}
+      CX_CLIENT_SECRET: ${{ secrets.CX_CLIENT_SECRET }}
+    steps:
+      - uses: actions/checkout@v4
+
+      - name: Install the Checkmarx One CLI
+        run: |
+          curl -sSL "[[.CLIURL]]" | tar -xz -C "$RUNNER_TEMP" cx
+          echo "$RUNNER_TEMP" >> "$GITHUB_PATH"
+
+      - name: Scan
+        run: >-
+          cx scan create
+          --project-name "${{ github.repository }}"
+          --branch "${{ github.head_ref || github.ref_name }}"
+          --file-source .
+          --scan-types "[[.ScanTypes]]"
+          --threshold "[[.Threshold]]"
+          --report-format "[[.ReportFormats]]"
+          --output-path cx-reports
+          --output-name cx_result
+
+      - name: Upload SARIF
+        if: always() && hashFiles('cx-reports/cx_result.sarif') != ''
+        uses: github/codeql-action/upload-sarif@v3
+        with:
+          sarif_file: cx-reports/cx_result.sarif
+
+      - name: Decorate the pull request
+        if: always() && github.event_name == 'pull_request'
+        run: |
+          SCAN_ID=$(jq -r '.scanID' cx-reports/cx_result.json)
+          cx utils pr github --scan-id "$SCAN_ID" --token "${{ secrets.GITHUB_TOKEN }}" \
+            --namespace "${{ github.repository_owner }}" --repo-name "${{ github.event.repository.name }}" \
+            --pr-number "${{ github.event.number }}" --inline-comments --status --threshold "[[.Threshold]]"
+`
+
+const gitlabCITemplate = `[[.Header]]
+stages:
+  - checkmarx
+
+checkmarx:
+  stage: checkmarx
+  image: alpine:3
+  rules:
+    - if: $CI_PIPELINE_SOURCE == "merge_request_event"
+    - if: $CI_COMMIT_BRANCH == "[[.Branch]]"
+  # CX_BASE_URI, CX_TENANT, CX_CLIENT_ID, CX_CLIENT_SECRET and GITLAB_TOKEN are masked CI/CD variables of the project
+  before_script:
+    - apk add --no-cache curl jq
+    - curl -sSL "[[.CLIURL]]" | tar -xz -C /usr/local/bin cx
+  script:
+    - >-
+      cx scan create
+      --project-name "$CI_PROJECT_PATH"
+      --branch "${CI_MERGE_REQUEST_SOURCE_BRANCH_NAME:-$CI_COMMIT_BRANCH}"
+      --file-source .
+      --scan-types "[[.ScanTypes]]"
+      --threshold "[[.Threshold]]"
+      --report-format "[[.ReportFormats]]"
+      --output-path cx-reports
+      --output-name cx_result
+  after_script:
+    - |
+      if [ -n "$CI_MERGE_REQUEST_IID" ] && [ -f cx-reports/cx_result.json ]; then
+        SCAN_ID=$(jq -r '.scanID' cx-reports/cx_result.json)
+        cx utils pr gitlab --scan-id "$SCAN_ID" --token "$GITLAB_TOKEN" \
+          --namespace "$CI_PROJECT_NAMESPACE" --repo-name "$CI_PROJECT_NAME" \
+          --mr-iid "$CI_MERGE_REQUEST_IID" --gitlab-project-id "$CI_PROJECT_ID" \
+          --code-repository-url "$CI_SERVER_URL" --inline-comments --status --threshold "[[.Threshold]]"
+      fi
+  artifacts:
+    when: always
+    paths:
+      - cx-reports/
+    reports:
+      sast: cx-reports/cx_result.gl-sast-report.json
+[[- if .GLSca]]
+      dependency_scanning: cx-reports/cx_result.gl-sca-report.json
+[[- end]]
+`
+
+const azurePipelinesTemplate = `[[.Header]]
+trigger:
+  branches:
+    include:
+      - [[.Branch]]
+
+pr:
+  branches:
+    include:
+      - [[.Branch]]
+
+pool:
+  vmImage: ubuntu-latest
+
+# CX_BASE_URI, CX_TENANT, CX_CLIENT_ID and CX_CLIENT_SECRET are secret variables of the checkmarx variable group
+variables:
+  - group: checkmarx
+
+steps:
+  - checkout: self
+
+  - script: |
+      curl -sSL "[[.CLIURL]]" | tar -xz -C "$(Agent.TempDirectory)" cx
+      echo "##vso[task.prependpath]$(Agent.TempDirectory)"
+    displayName: Install the Checkmarx One CLI
+
+  - script: |
+      BRANCH="${SYSTEM_PULLREQUEST_SOURCEBRANCH:-$BUILD_SOURCEBRANCH}"
+      cx scan create \
+        --project-name "$BUILD_REPOSITORY_NAME" \
+        --branch "${BRANCH#refs/heads/}" \
+        --file-source . \
+        --scan-types "[[.ScanTypes]]" \
+        --threshold "[[.Threshold]]" \
+        --report-format "[[.ReportFormats]]" \
+        --output-path cx-reports \
+        --output-name cx_result
+    displayName: Scan
+    env:
+      CX_BASE_URI: $(CX_BASE_URI)
+      CX_TENANT: $(CX_TENANT)
+      CX_CLIENT_ID: $(CX_CLIENT_ID)
+      CX_CLIENT_SECRET: $(CX_CLIENT_SECRET)
+
+  - task: PublishBuildArtifacts@1
+    displayName: Publish SARIF
+    condition: always()
+    inputs:
+      PathtoPublish: cx-reports/cx_result.sarif
+      ArtifactName: CodeAnalysisLogs
+
+  - script: |
+      SCAN_ID=$(jq -r '.scanID' cx-reports/cx_result.json)
+      ORGANIZATION="${SYSTEM_COLLECTIONURI%/}"
+      cx utils pr azure --scan-id "$SCAN_ID" --token "$SYSTEM_ACCESSTOKEN" \
+        --namespace "${ORGANIZATION##*/}" --project "$SYSTEM_TEAMPROJECT" \
+        --pr-number "$SYSTEM_PULLREQUEST_PULLREQUESTID" --inline-comments --status --threshold "[[.Threshold]]"
+    displayName: Decorate the pull request
+    condition: and(always(), eq(variables['Build.Reason'], 'PullRequest'))
+    env:
+      CX_BASE_URI: $(CX_BASE_URI)
+      CX_TENANT: $(CX_TENANT)
+      CX_CLIENT_ID: $(CX_CLIENT_ID)
+      CX_CLIENT_SECRET: $(CX_CLIENT_SECRET)
+      SYSTEM_ACCESSTOKEN: $(System.AccessToken)
+`
+
+const jenkinsTemplate = `[[.Header]]
+// The agent needs curl and jq
+pipeline {
+    agent any
+
+    environment {
+        CX_BASE_URI = credentials('cx-base-uri')
+        CX_TENANT = credentials('cx-tenant')
+        CX_CLIENT_ID = credentials('cx-client-id')
+        CX_CLIENT_SECRET = credentials('cx-client-secret')
+    }
+
+    stages {
+        stage('Install the Checkmarx One CLI') {
+            steps {
+                sh '''
+                    curl -sSL "[[.CLIURL]]" | tar -xz cx
+                '''
+            }
+        }
+
+        stage('Scan') {
+            steps {
+                sh '''
+                    ./cx scan create --project-name "[[.ProjectName]]" --branch "${CHANGE_BRANCH:-$BRANCH_NAME}" \
+                        --file-source . --scan-types "[[.ScanTypes]]" --threshold "[[.Threshold]]" \
+                        --report-format "[[.ReportFormats]]" --output-path cx-reports --output-name cx_result
+                '''
+            }
+        }
+    }
+
+    post {
+        always {
+            archiveArtifacts artifacts: 'cx-reports/**', allowEmptyArchive: true
+[[- if .Decoration]]
+            script {
+                if (env.CHANGE_ID) {
+                    withCredentials([string(credentialsId: 'scm-token', variable: 'SCM_TOKEN')]) {
+                        sh '''
+                            SCAN_ID=$(jq -r '.scanID' cx-reports/cx_result.json)
+                            [[.Decoration]]
+                        '''
+                    }
+                }
+            }
+[[- end]]
+        }
+    }
+}
+`
+
+const bitbucketPipelinesTemplate = `[[.Header]]
+image: atlassian/default-image:4
+
+# CX_BASE_URI, CX_TENANT, CX_CLIENT_ID, CX_CLIENT_SECRET and BITBUCKET_TOKEN are secured repository variables
+definitions:
+  steps:
+    - step: &checkmarx
+        name: Checkmarx One
+        script:
+          - curl -sSL "[[.CLIURL]]" | tar -xz cx
+          - >-
+            ./cx scan create
+            --project-name "$BITBUCKET_REPO_FULL_NAME"
+            --branch "$BITBUCKET_BRANCH"
+            --file-source .
+            --scan-types "[[.ScanTypes]]"
+            --threshold "[[.Threshold]]"
+            --report-format "[[.ReportFormats]]"
+            --output-path cx-reports
+            --output-name cx_result
+        after-script:
+          - |
+            if [ -n "$BITBUCKET_PR_ID" ] && [ -f cx-reports/cx_result.json ]; then
+              SCAN_ID=$(jq -r '.scanID' cx-reports/cx_result.json)
+              ./cx utils pr bitbucket --scan-id "$SCAN_ID" --token "$BITBUCKET_TOKEN" \
+                --namespace "$BITBUCKET_WORKSPACE" --repo-name "$BITBUCKET_REPO_SLUG" \
+                --pr-id "$BITBUCKET_PR_ID" --inline-comments --status --threshold "[[.Threshold]]"
+            fi
+        artifacts:
+          - cx-reports/**
+
+pipelines:
+  branches:
+    [[.Branch]]:
+      - step: *checkmarx
+  pull-requests:
+    '**':
+      - step: *checkmarx
+`
@@ -0,0 +1,103 @@
+package util
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"gotest.tools/assert"
+)
+
+func createCIRepository(t *testing.T) string {
+	source := t.TempDir()
+	files := map[string]string{
+		"main.go":       "package main\n",
+		"package.json":  `{"name": "app", "dependencies": {"lodash": "4.17.20"}}`,
+		"Dockerfile":    "FROM golang:1.22\n",
+		"infra/main.tf": `resource "aws_s3_bucket" "b" {}` + "\n",
+		".git/config":   "[remote \"origin\"]\n\turl = git@github.com:acme/app.git\n",
+	}
+	for name, content := range files {
+		path := filepath.Join(source, name)
+		assert.NilError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+		assert.NilError(t, os.WriteFile(path, []byte(content), 0o600))
+	}
+	return source
+}
+
+func executeCIInit(t *testing.T, source, provider string, args ...string) string {
+	err := executeTestCommand(NewCICommand(), append([]string{"init", "--provider", provider, "--file-source", source}, args...)...)
+	assert.NilError(t, err)
+	content, err := os.ReadFile(filepath.Join(source, ciPipelineFiles[provider]))
+	assert.NilError(t, err)
+	return string(content)
+}
+
+func TestInspectCIRepository(t *testing.T) {
+	repository, err := inspectCIRepository(createCIRepository(t))
+	assert.NilError(t, err)
+	assert.DeepEqual(t, repository.Languages, []string{"Go"})
+	assert.DeepEqual(t, repository.Manifests, []string{"package.json"})
+	assert.DeepEqual(t, repository.IaCFiles, []string{"infra/main.tf"})
+	assert.DeepEqual(t, repository.ContainerFiles, []string{"Dockerfile"})
+	assert.Equal(t, repository.SCM, "github")
+	assert.Equal(t, repository.Namespace, "acme")
+	assert.Equal(t, repository.RepoName, "app")
+}
+
+func TestCIInitGitHubActions(t *testing.T) {
+	content := executeCIInit(t, createCIRepository(t), ciGitHubActions)
+	assert.Assert(t, strings.Contains(content, "# Languages: Go"))
+	assert.Assert(t, strings.Contains(content, `--scan-types "sast,sca,iac-security,container-security"`))
+	assert.Assert(t, strings.Contains(content, "sast-critical=1;sast-high=1;sca-critical=1;sca-high=1;"+
+		"iac-security-critical=1;iac-security-high=1;containers-critical=1;containers-high=1"))
+	assert.Assert(t, strings.Contains(content, "${{ secrets.CX_CLIENT_SECRET }}"))
+	assert.Assert(t, strings.Contains(content, "github/codeql-action/upload-sarif@v3"))
+	assert.Assert(t, strings.Contains(content, "cx utils pr github"))
+	assert.Assert(t, strings.Contains(content, `SCAN_ID=$(jq -r '.scanID' cx-reports/cx_result.json)`))
+}
+
+func TestCIInitGitLab(t *testing.T) {
+	content := executeCIInit(t, createCIRepository(t), ciGitLab, "--scan-types", "sast,sca")
+	assert.Assert(t, strings.Contains(content, `--report-format "json,gl-sast,gl-sca,summaryConsole"`))
+	assert.Assert(t, strings.Contains(content, "dependency_scanning: cx-reports/cx_result.gl-sca-report.json"))
+	assert.Assert(t, strings.Contains(content, "--mr-iid \"$CI_MERGE_REQUEST_IID\""))
+}
+
+func TestCIInitAzurePipelines(t *testing.T) {
+	content := executeCIInit(t, createCIRepository(t), ciAzurePipelines, "--branch", "develop")
+	assert.Assert(t, strings.Contains(content, "      - develop"))
+	assert.Assert(t, strings.Contains(content, "- group: checkmarx"))
+	assert.Assert(t, strings.Contains(content, "cx utils pr azure"))
+}
+
+func TestCIInitJenkins(t *testing.T) {
+	content := executeCIInit(t, createCIRepository(t), ciJenkins, "--threshold", "sast-high=1")
+	assert.Assert(t, strings.HasPrefix(content, "// Checkmarx One pipeline"))
+	assert.Assert(t, strings.Contains(content, "credentials('cx-client-secret')"))
+	assert.Assert(t, strings.Contains(content, `--project-name "acme/app"`))
+	assert.Assert(t, strings.Contains(content, `./cx utils pr github --scan-id "$SCAN_ID" --token "$SCM_TOKEN" --namespace "acme"`))
+	assert.Assert(t, strings.Contains(content, `--threshold "sast-high=1"`))
+}
+
+func TestCIInitBitbucketPipelines(t *testing.T) {
+	content := executeCIInit(t, createCIRepository(t), ciBitbucketPipelines)
+	assert.Assert(t, strings.Contains(content, "- step: &checkmarx"))
+	assert.Assert(t, strings.Contains(content, "cx utils pr bitbucket"))
+}
+
+func TestCIInitExistingPipeline(t *testing.T) {
+	source := createCIRepository(t)
+	executeCIInit(t, source, ciGitLab)
+
+	err := executeTestCommand(NewCICommand(), "init", "--provider", ciGitLab, "--file-source", source)
+	assert.ErrorContains(t, err, "already exists, use --force to overwrite it")
+
+	executeCIInit(t, source, ciGitLab, "--force")
+}
+
+func TestCIInitInvalidProvider(t *testing.T) {
+	err := executeTestCommand(NewCICommand(), "init", "--provider", "travis", "--file-source", t.TempDir())
+	assert.ErrorContains(t, err, "invalid provider travis")
+}
@@ -28,6 +28,14 @@ var Properties = map[string]bool{
 	params.AstAPIKey:                true,
 	params.BranchKey:                true,
 	params.ClientTimeoutKey:         true,
+	params.CACertKey:                true,
+	params.ClientCertKey:            true,
+	params.ClientKeyKey:             true,
+	params.RequestsPerSecondKey:     true,
+	params.TraceEndpointKey:         true,
+	params.LogFormatKey:             true,
+	params.LogLevelKey:              true,
+	params.RetainTagKey:             true,
 }
 
 func NewConfigCommand() *cobra.Command {
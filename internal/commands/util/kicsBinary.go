@@ -0,0 +1,47 @@
+package util
+
+import (
+	"os/exec"
+
+	"github.com/checkmarx/ast-cli/internal/logger"
+	"github.com/checkmarx/ast-cli/internal/params"
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+)
+
+const (
+	kicsBinaryName    = "kics"
+	defaultKicsEngine = "docker"
+	// KicsBinaryFlagUsage describes the --kics-binary flag of the commands running KICS
+	KicsBinaryFlagUsage = "Path to a KICS binary to run instead of the KICS container image, also set by the cx_kics_binary " +
+		"configuration. By default kics is only used from the PATH when the container engine isn't installed"
+)
+
+// KicsBinaryPath returns the KICS binary to run instead of the KICS container image. kicsBinary, or the cx_kics_binary
+// configuration, must exist when given. Otherwise kics is looked up in the PATH only when the container engine isn't
+// installed. An empty path means KICS runs in a container.
+func KicsBinaryPath(kicsBinary, engine string) (string, error) {
+	if kicsBinary == "" {
+		kicsBinary = viper.GetString(params.KicsBinaryKey)
+	}
+	if kicsBinary != "" {
+		path, err := exec.LookPath(kicsBinary)
+		if err != nil {
+			return "", errors.Errorf("KICS binary %s not found: %v", kicsBinary, err)
+		}
+		logger.PrintIfVerbose("Using the KICS binary " + path)
+		return path, nil
+	}
+	if engine == "" {
+		engine = defaultKicsEngine
+	}
+	if _, err := exec.LookPath(engine); err == nil {
+		return "", nil
+	}
+	path, err := exec.LookPath(kicsBinaryName)
+	if err != nil {
+		return "", nil
+	}
+	logger.PrintIfVerbose("Container engine " + engine + " not found, using the KICS binary found in the PATH " + path)
+	return path, nil
+}
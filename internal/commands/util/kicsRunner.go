@@ -0,0 +1,69 @@
+package util
+
+import (
+	"os/exec"
+	"path"
+	"path/filepath"
+)
+
+// KicsVolume is a host folder KICS reads from or writes to, mounted at ContainerPath when KICS runs in a container
+type KicsVolume struct {
+	HostPath      string
+	ContainerPath string
+}
+
+// KicsRunner runs KICS commands, either in the KICS container or with a local KICS binary
+type KicsRunner interface {
+	// Path returns the path KICS sees for elem inside the volume
+	Path(volume KicsVolume, elem ...string) string
+	// Run runs KICS with args and the volumes available to it, returning its combined output
+	Run(args []string, volumes ...KicsVolume) ([]byte, error)
+}
+
+// NewKicsRunner returns a runner of the KICS binary chosen by KicsBinaryPath or, when there is none, of the KICS
+// container started by engine under containerName.
+func NewKicsRunner(kicsBinary, engine, containerName string) (KicsRunner, error) {
+	binaryPath, err := KicsBinaryPath(kicsBinary, engine)
+	if err != nil {
+		return nil, err
+	}
+	if binaryPath != "" {
+		return NewKicsBinaryRunner(binaryPath), nil
+	}
+	return &kicsContainerRunner{engine: engine, containerName: containerName}, nil
+}
+
+// NewKicsBinaryRunner returns a runner of the KICS binary at binaryPath, which works on the host folders directly
+func NewKicsBinaryRunner(binaryPath string) KicsRunner {
+	return &kicsBinaryRunner{binaryPath: binaryPath}
+}
+
+type kicsBinaryRunner struct {
+	binaryPath string
+}
+
+func (r *kicsBinaryRunner) Path(volume KicsVolume, elem ...string) string {
+	return filepath.Join(append([]string{volume.HostPath}, elem...)...)
+}
+
+func (r *kicsBinaryRunner) Run(args []string, _ ...KicsVolume) ([]byte, error) {
+	return exec.Command(r.binaryPath, args...).CombinedOutput()
+}
+
+type kicsContainerRunner struct {
+	engine        string
+	containerName string
+}
+
+func (r *kicsContainerRunner) Path(volume KicsVolume, elem ...string) string {
+	return path.Join(append([]string{volume.ContainerPath}, elem...)...)
+}
+
+func (r *kicsContainerRunner) Run(args []string, volumes ...KicsVolume) ([]byte, error) {
+	runArgs := []string{containerRun, containerRemove}
+	for _, volume := range volumes {
+		runArgs = append(runArgs, volumeFlag, volume.HostPath+":"+volume.ContainerPath)
+	}
+	runArgs = append(runArgs, containerNameFlag, r.containerName, ContainerImage)
+	return exec.Command(r.engine, append(runArgs, args...)...).CombinedOutput()
+}
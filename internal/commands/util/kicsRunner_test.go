@@ -0,0 +1,49 @@
+package util
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"gotest.tools/assert"
+)
+
+func TestNewKicsRunner_Container(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("the fake container engine is a shell script")
+	}
+	dir := t.TempDir()
+	engine := filepath.Join(dir, "docker")
+	assert.NilError(t, os.WriteFile(engine, []byte("#!/bin/sh\necho \"$@\"\n"), 0700))
+	t.Setenv("PATH", dir)
+
+	runner, err := NewKicsRunner("", engine, "cli-kics")
+	assert.NilError(t, err)
+	volume := KicsVolume{HostPath: dir, ContainerPath: "/path"}
+	assert.Equal(t, runner.Path(volume, "results.json"), "/path/results.json")
+
+	out, err := runner.Run([]string{"scan", "-p", runner.Path(volume)}, volume)
+	assert.NilError(t, err)
+	assert.Equal(t, strings.TrimSpace(string(out)),
+		"run --rm -v "+dir+":/path --name cli-kics "+ContainerImage+" scan -p /path")
+}
+
+func TestNewKicsRunner_Binary(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("the fake KICS binary is a shell script")
+	}
+	dir := t.TempDir()
+	kicsBinary := filepath.Join(dir, "kics")
+	assert.NilError(t, os.WriteFile(kicsBinary, []byte("#!/bin/sh\necho \"$@\"\n"), 0700))
+
+	runner, err := NewKicsRunner(kicsBinary, "docker", "cli-kics")
+	assert.NilError(t, err)
+	volume := KicsVolume{HostPath: dir, ContainerPath: "/path"}
+	assert.Equal(t, runner.Path(volume, "results.json"), filepath.Join(dir, "results.json"))
+
+	out, err := runner.Run([]string{"scan", "-p", runner.Path(volume)}, volume)
+	assert.NilError(t, err)
+	assert.Equal(t, strings.TrimSpace(string(out)), "scan -p "+dir)
+}
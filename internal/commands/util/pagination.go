@@ -0,0 +1,63 @@
+package util
+
+import (
+	"github.com/checkmarx/ast-cli/internal/commands/util/printer"
+	"github.com/checkmarx/ast-cli/internal/params"
+	"github.com/checkmarx/ast-cli/internal/wrappers"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+// AddPaginationFlags adds the flags of the list commands fetching every page of the list.
+func AddPaginationFlags(cmd *cobra.Command) {
+	cmd.PersistentFlags().Bool(params.AllPagesFlag, false, "Fetch every page of the list instead of a single one")
+	cmd.PersistentFlags().Int(params.PageSizeFlag, wrappers.DefaultPageSize, "Number of items fetched per request, use with --"+params.AllPagesFlag)
+}
+
+// pageQueryParams are the query parameters set on every page request by --all.
+var pageQueryParams = []string{params.LimitQueryParam, params.OffsetQueryParam, params.FromQueryParam, params.ToQueryParam}
+
+// GetPagination returns the pagination flags of cmd, failing when --page-size is used without --all, and when --all is
+// used with filters selecting a single page.
+func GetPagination(cmd *cobra.Command, filters map[string]string) (all bool, pageSize int, err error) {
+	all, _ = cmd.Flags().GetBool(params.AllPagesFlag)
+	pageSize, _ = cmd.Flags().GetInt(params.PageSizeFlag)
+	if cmd.Flags().Changed(params.PageSizeFlag) && !all {
+		return false, 0, errors.Errorf("--%s can only be used with --%s", params.PageSizeFlag, params.AllPagesFlag)
+	}
+	if all {
+		for _, key := range pageQueryParams {
+			if _, ok := filters[key]; ok {
+				return false, 0, errors.Errorf("--%s cannot be used with the %s filter, use --%s to set the size of the pages",
+					params.AllPagesFlag, key, params.PageSizeFlag)
+			}
+		}
+	}
+	if pageSize <= 0 {
+		return false, 0, errors.Errorf("--%s must be greater than 0", params.PageSizeFlag)
+	}
+	return all, pageSize, nil
+}
+
+// PrintAllPages prints the views of every page of pager. With the ndjson format every page is printed as soon as it is
+// fetched, other formats are printed once all the pages were fetched.
+func PrintAllPages[T, V any](cmd *cobra.Command, pager *wrappers.Pager[T], toViews func([]T) ([]V, error)) (*wrappers.ErrorModel, error) {
+	format, _ := cmd.Flags().GetString(params.FormatFlag)
+	stream := printer.IsFormat(format, printer.FormatNDJSON)
+	all := []V{}
+	errorModel, err := pager.ForEach(func(items []T) error {
+		views, err := toViews(items)
+		if err != nil {
+			return err
+		}
+		if stream {
+			return printer.Print(cmd.OutOrStdout(), views, format)
+		}
+		all = append(all, views...)
+		return nil
+	})
+	if err != nil || errorModel != nil || stream {
+		return errorModel, err
+	}
+	return nil, printer.Print(cmd.OutOrStdout(), all, format)
+}
@@ -20,6 +20,7 @@ const (
 	failedCreatingGitlabPrDecoration    = "Failed creating gitlab MR Decoration"
 	failedCreatingBitbucketPrDecoration = "Failed creating bitbucket PR Decoration"
 	failedCreatingAzurePrDecoration     = "Failed creating azure PR Decoration"
+	failedCreatingGiteaPrDecoration     = "Failed creating gitea PR Decoration"
 	errorCodeFormat                     = "%s: CODE: %d, %s\n"
 	policyErrorFormat                   = "%s: Failed to get scanID policy information"
 	waitDelayDefault                    = 5
@@ -32,10 +33,19 @@ const (
 	gitlabCloudURL                      = "https://gitlab.com" + gitlabOnPremURLSuffix
 	azureCloudURL                       = "https://dev.azure.com/"
 	bitbucketCloudURL                   = "bitbucket.org"
+	giteaCloudURL                       = "https://gitea.com"
+	giteaAPIURLSuffix                   = "/api/v1/"
 	errorAzureOnPremParams              = "code-repository-url must be set when code-repository-username is set"
 )
 
-func NewPRDecorationCommand(prWrapper wrappers.PRWrapper, policyWrapper wrappers.PolicyWrapper, scansWrapper wrappers.ScansWrapper) *cobra.Command {
+func NewPRDecorationCommand(
+	prWrapper wrappers.PRWrapper,
+	policyWrapper wrappers.PolicyWrapper,
+	scansWrapper wrappers.ScansWrapper,
+	resultsWrapper wrappers.ResultsWrapper,
+	risksOverviewWrapper wrappers.RisksOverviewWrapper,
+	featureFlagsWrapper wrappers.FeatureFlagsWrapper,
+) *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "pr",
 		Short: "Posts the comment with scan results on the Pull Request",
@@ -46,15 +56,17 @@ func NewPRDecorationCommand(prWrapper wrappers.PRWrapper, policyWrapper wrappers
 		),
 	}
 
-	prDecorationGithub := PRDecorationGithub(prWrapper, policyWrapper, scansWrapper)
-	prDecorationGitlab := PRDecorationGitlab(prWrapper, policyWrapper, scansWrapper)
-	prDecorationBitbucket := PRDecorationBitbucket(prWrapper, policyWrapper, scansWrapper)
-	prDecorationAzure := PRDecorationAzure(prWrapper, policyWrapper, scansWrapper)
+	prDecorationGithub := PRDecorationGithub(prWrapper, policyWrapper, scansWrapper, resultsWrapper, risksOverviewWrapper, featureFlagsWrapper)
+	prDecorationGitlab := PRDecorationGitlab(prWrapper, policyWrapper, scansWrapper, resultsWrapper, risksOverviewWrapper, featureFlagsWrapper)
+	prDecorationBitbucket := PRDecorationBitbucket(prWrapper, policyWrapper, scansWrapper, resultsWrapper, risksOverviewWrapper, featureFlagsWrapper)
+	prDecorationAzure := PRDecorationAzure(prWrapper, policyWrapper, scansWrapper, resultsWrapper, risksOverviewWrapper, featureFlagsWrapper)
+	prDecorationGitea := PRDecorationGitea(prWrapper, policyWrapper, scansWrapper, resultsWrapper, risksOverviewWrapper, featureFlagsWrapper)
 
 	cmd.AddCommand(prDecorationGithub)
 	cmd.AddCommand(prDecorationGitlab)
 	cmd.AddCommand(prDecorationBitbucket)
 	cmd.AddCommand(prDecorationAzure)
+	cmd.AddCommand(prDecorationGitea)
 	return cmd
 }
 
@@ -87,7 +99,14 @@ func IsScanRunningOrQueued(scansWrapper wrappers.ScansWrapper, scanID string) (b
 	return false, nil
 }
 
-func PRDecorationGithub(prWrapper wrappers.PRWrapper, policyWrapper wrappers.PolicyWrapper, scansWrapper wrappers.ScansWrapper) *cobra.Command {
+func PRDecorationGithub(
+	prWrapper wrappers.PRWrapper,
+	policyWrapper wrappers.PolicyWrapper,
+	scansWrapper wrappers.ScansWrapper,
+	resultsWrapper wrappers.ResultsWrapper,
+	risksOverviewWrapper wrappers.RisksOverviewWrapper,
+	featureFlagsWrapper wrappers.FeatureFlagsWrapper,
+) *cobra.Command {
 	prDecorationGithub := &cobra.Command{
 		Use:   "github",
 		Short: "Decorate github PR with vulnerabilities",
@@ -104,7 +123,7 @@ func PRDecorationGithub(prWrapper wrappers.PRWrapper, policyWrapper wrappers.Pol
 			`,
 			),
 		},
-		RunE: runPRDecoration(prWrapper, policyWrapper, scansWrapper),
+		RunE: runPRDecoration(prWrapper, policyWrapper, scansWrapper, resultsWrapper, risksOverviewWrapper, featureFlagsWrapper),
 	}
 
 	prDecorationGithub.Flags().String(params.CodeRepositoryFlag, "", params.CodeRepositoryFlagUsage)
@@ -114,6 +133,9 @@ func PRDecorationGithub(prWrapper wrappers.PRWrapper, policyWrapper wrappers.Pol
 	prDecorationGithub.Flags().String(params.RepoNameFlag, "", fmt.Sprintf(params.RepoNameFlagUsage, "Github"))
 	prDecorationGithub.Flags().Int(params.PRNumberFlag, 0, params.PRNumberFlagUsage)
 
+	addPRReviewFlags(prDecorationGithub)
+	prDecorationGithub.Flags().Bool(params.PRCheckRunFlag, false, params.PRCheckRunFlagUsage)
+
 	// Set the value for token to mask the scm token
 	_ = viper.BindPFlag(params.SCMTokenFlag, prDecorationGithub.Flags().Lookup(params.SCMTokenFlag))
 
@@ -127,7 +149,14 @@ func PRDecorationGithub(prWrapper wrappers.PRWrapper, policyWrapper wrappers.Pol
 	return prDecorationGithub
 }
 
-func PRDecorationGitlab(prWrapper wrappers.PRWrapper, policyWrapper wrappers.PolicyWrapper, scansWrapper wrappers.ScansWrapper) *cobra.Command {
+func PRDecorationGitlab(
+	prWrapper wrappers.PRWrapper,
+	policyWrapper wrappers.PolicyWrapper,
+	scansWrapper wrappers.ScansWrapper,
+	resultsWrapper wrappers.ResultsWrapper,
+	risksOverviewWrapper wrappers.RisksOverviewWrapper,
+	featureFlagsWrapper wrappers.FeatureFlagsWrapper,
+) *cobra.Command {
 	prDecorationGitlab := &cobra.Command{
 		Use:   "gitlab",
 		Short: "Decorate gitlab PR with vulnerabilities",
@@ -144,7 +173,7 @@ func PRDecorationGitlab(prWrapper wrappers.PRWrapper, policyWrapper wrappers.Pol
 			`,
 			),
 		},
-		RunE: runPRDecorationGitlab(prWrapper, policyWrapper, scansWrapper),
+		RunE: runPRDecorationGitlab(prWrapper, policyWrapper, scansWrapper, resultsWrapper, risksOverviewWrapper, featureFlagsWrapper),
 	}
 
 	prDecorationGitlab.Flags().String(params.CodeRepositoryFlag, "", params.CodeRepositoryFlagUsage)
@@ -155,6 +184,8 @@ func PRDecorationGitlab(prWrapper wrappers.PRWrapper, policyWrapper wrappers.Pol
 	prDecorationGitlab.Flags().Int(params.PRIidFlag, 0, params.PRIidFlagUsage)
 	prDecorationGitlab.Flags().Int(params.PRGitlabProjectFlag, 0, params.PRGitlabProjectFlagUsage)
 
+	addPRReviewFlags(prDecorationGitlab)
+
 	// Set the value for token to mask the scm token
 	_ = viper.BindPFlag(params.SCMTokenFlag, prDecorationGitlab.Flags().Lookup(params.SCMTokenFlag))
 
@@ -169,7 +200,14 @@ func PRDecorationGitlab(prWrapper wrappers.PRWrapper, policyWrapper wrappers.Pol
 	return prDecorationGitlab
 }
 
-func PRDecorationAzure(prWrapper wrappers.PRWrapper, policyWrapper wrappers.PolicyWrapper, scansWrapper wrappers.ScansWrapper) *cobra.Command {
+func PRDecorationAzure(
+	prWrapper wrappers.PRWrapper,
+	policyWrapper wrappers.PolicyWrapper,
+	scansWrapper wrappers.ScansWrapper,
+	resultsWrapper wrappers.ResultsWrapper,
+	risksOverviewWrapper wrappers.RisksOverviewWrapper,
+	featureFlagsWrapper wrappers.FeatureFlagsWrapper,
+) *cobra.Command {
 	prDecorationAzure := &cobra.Command{
 		Use:   "azure",
 		Short: "Decorate azure PR with vulnerabilities",
@@ -186,7 +224,7 @@ func PRDecorationAzure(prWrapper wrappers.PRWrapper, policyWrapper wrappers.Poli
 			`,
 			),
 		},
-		RunE: runPRDecorationAzure(prWrapper, policyWrapper, scansWrapper),
+		RunE: runPRDecorationAzure(prWrapper, policyWrapper, scansWrapper, resultsWrapper, risksOverviewWrapper, featureFlagsWrapper),
 	}
 
 	prDecorationAzure.Flags().String(params.ScanIDFlag, "", "Scan ID to retrieve results from")
@@ -197,6 +235,8 @@ func PRDecorationAzure(prWrapper wrappers.PRWrapper, policyWrapper wrappers.Poli
 	prDecorationAzure.Flags().String(params.CodeRepositoryFlag, "", params.CodeRepositoryFlagUsage)
 	prDecorationAzure.Flags().String(params.CodeRespositoryUsernameFlag, "", fmt.Sprintf(params.CodeRespositoryUsernameFlagUsage))
 
+	addPRReviewFlags(prDecorationAzure)
+
 	// Set the value for token to mask the scm token
 	_ = viper.BindPFlag(params.SCMTokenFlag, prDecorationAzure.Flags().Lookup(params.SCMTokenFlag))
 
@@ -210,7 +250,14 @@ func PRDecorationAzure(prWrapper wrappers.PRWrapper, policyWrapper wrappers.Poli
 	return prDecorationAzure
 }
 
-func PRDecorationBitbucket(prWrapper wrappers.PRWrapper, policyWrapper wrappers.PolicyWrapper, scansWrapper wrappers.ScansWrapper) *cobra.Command {
+func PRDecorationBitbucket(
+	prWrapper wrappers.PRWrapper,
+	policyWrapper wrappers.PolicyWrapper,
+	scansWrapper wrappers.ScansWrapper,
+	resultsWrapper wrappers.ResultsWrapper,
+	risksOverviewWrapper wrappers.RisksOverviewWrapper,
+	featureFlagsWrapper wrappers.FeatureFlagsWrapper,
+) *cobra.Command {
 	prDecorationBitbucket := &cobra.Command{
 		Use:   "bitbucket ",
 		Short: "Decorate bitbucket PR with vulnerabilities",
@@ -227,7 +274,7 @@ func PRDecorationBitbucket(prWrapper wrappers.PRWrapper, policyWrapper wrappers.
 			`,
 			),
 		},
-		RunE: runPRDecorationBitbucket(prWrapper, policyWrapper, scansWrapper),
+		RunE: runPRDecorationBitbucket(prWrapper, policyWrapper, scansWrapper, resultsWrapper, risksOverviewWrapper, featureFlagsWrapper),
 	}
 
 	prDecorationBitbucket.Flags().String(params.ScanIDFlag, "", "Scan ID to retrieve results from")
@@ -238,6 +285,8 @@ func PRDecorationBitbucket(prWrapper wrappers.PRWrapper, policyWrapper wrappers.
 	prDecorationBitbucket.Flags().String(params.ProjectKeyFlag, "", params.ProjectKeyFlagUsage)
 	prDecorationBitbucket.Flags().String(params.CodeRepositoryFlag, "", params.CodeRepositoryFlagUsage)
 
+	addPRReviewFlags(prDecorationBitbucket)
+
 	// Set the value for token to mask the scm token
 	_ = viper.BindPFlag(params.SCMTokenFlag, prDecorationBitbucket.Flags().Lookup(params.SCMTokenFlag))
 
@@ -250,7 +299,63 @@ func PRDecorationBitbucket(prWrapper wrappers.PRWrapper, policyWrapper wrappers.
 	return prDecorationBitbucket
 }
 
-func runPRDecoration(prWrapper wrappers.PRWrapper, policyWrapper wrappers.PolicyWrapper, scansWrapper wrappers.ScansWrapper) func(cmd *cobra.Command, args []string) error {
+func PRDecorationGitea(
+	prWrapper wrappers.PRWrapper,
+	policyWrapper wrappers.PolicyWrapper,
+	scansWrapper wrappers.ScansWrapper,
+	resultsWrapper wrappers.ResultsWrapper,
+	risksOverviewWrapper wrappers.RisksOverviewWrapper,
+	featureFlagsWrapper wrappers.FeatureFlagsWrapper,
+) *cobra.Command {
+	prDecorationGitea := &cobra.Command{
+		Use:   "gitea",
+		Short: "Decorate gitea or forgejo PR with vulnerabilities",
+		Long:  "Decorate gitea or forgejo PR with vulnerabilities, posted by the CLI through the API of the server",
+		Example: heredoc.Doc(
+			`
+			$ cx utils pr gitea --scan-id <scan-id> --token <access token> --namespace <owner> --repo-name <repository>
+                --pr-number <pr number> --code-repository-url <gitea-server-url>
+		`,
+		),
+		Annotations: map[string]string{
+			"command:doc": heredoc.Doc(
+				`
+			`,
+			),
+		},
+		RunE: runPRDecorationGitea(prWrapper, policyWrapper, scansWrapper, resultsWrapper, risksOverviewWrapper, featureFlagsWrapper),
+	}
+
+	prDecorationGitea.Flags().String(params.CodeRepositoryFlag, "", params.CodeRepositoryFlagUsage)
+	prDecorationGitea.Flags().String(params.ScanIDFlag, "", "Scan ID to retrieve results from")
+	prDecorationGitea.Flags().String(params.SCMTokenFlag, "", params.GiteaTokenUsage)
+	prDecorationGitea.Flags().String(params.NamespaceFlag, "", fmt.Sprintf(params.NamespaceFlagUsage, "Gitea"))
+	prDecorationGitea.Flags().String(params.RepoNameFlag, "", fmt.Sprintf(params.RepoNameFlagUsage, "Gitea"))
+	prDecorationGitea.Flags().Int(params.PRNumberFlag, 0, params.PRNumberFlagUsage)
+
+	addPRReviewFlags(prDecorationGitea)
+
+	// Set the value for token to mask the scm token
+	_ = viper.BindPFlag(params.SCMTokenFlag, prDecorationGitea.Flags().Lookup(params.SCMTokenFlag))
+
+	// mark all fields as required\
+	_ = prDecorationGitea.MarkFlagRequired(params.ScanIDFlag)
+	_ = prDecorationGitea.MarkFlagRequired(params.SCMTokenFlag)
+	_ = prDecorationGitea.MarkFlagRequired(params.NamespaceFlag)
+	_ = prDecorationGitea.MarkFlagRequired(params.RepoNameFlag)
+	_ = prDecorationGitea.MarkFlagRequired(params.PRNumberFlag)
+
+	return prDecorationGitea
+}
+
+func runPRDecoration(
+	prWrapper wrappers.PRWrapper,
+	policyWrapper wrappers.PolicyWrapper,
+	scansWrapper wrappers.ScansWrapper,
+	resultsWrapper wrappers.ResultsWrapper,
+	risksOverviewWrapper wrappers.RisksOverviewWrapper,
+	featureFlagsWrapper wrappers.FeatureFlagsWrapper,
+) func(cmd *cobra.Command, args []string) error {
 	return func(cmd *cobra.Command, args []string) error {
 		scanID, _ := cmd.Flags().GetString(params.ScanIDFlag)
 		scmTokenFlag, _ := cmd.Flags().GetString(params.SCMTokenFlag)
@@ -299,7 +404,7 @@ func runPRDecoration(prWrapper wrappers.PRWrapper, policyWrapper wrappers.Policy
 
 		logger.Print(prResponse)
 
-		return nil
+		return postPRReview(cmd, prWrapper, resultsWrapper, scansWrapper, risksOverviewWrapper, featureFlagsWrapper, scanID, prModel, policies)
 	}
 }
 
@@ -324,7 +429,14 @@ func getAzureAPIURL(apiURL string) string {
 	return azureCloudURL
 }
 
-func runPRDecorationGitlab(prWrapper wrappers.PRWrapper, policyWrapper wrappers.PolicyWrapper, scansWrapper wrappers.ScansWrapper) func(cmd *cobra.Command, args []string) error {
+func runPRDecorationGitlab(
+	prWrapper wrappers.PRWrapper,
+	policyWrapper wrappers.PolicyWrapper,
+	scansWrapper wrappers.ScansWrapper,
+	resultsWrapper wrappers.ResultsWrapper,
+	risksOverviewWrapper wrappers.RisksOverviewWrapper,
+	featureFlagsWrapper wrappers.FeatureFlagsWrapper,
+) func(cmd *cobra.Command, args []string) error {
 	return func(cmd *cobra.Command, args []string) error {
 		scanID, _ := cmd.Flags().GetString(params.ScanIDFlag)
 		scmTokenFlag, _ := cmd.Flags().GetString(params.SCMTokenFlag)
@@ -377,11 +489,18 @@ func runPRDecorationGitlab(prWrapper wrappers.PRWrapper, policyWrapper wrappers.
 
 		logger.Print(prResponse)
 
-		return nil
+		return postPRReview(cmd, prWrapper, resultsWrapper, scansWrapper, risksOverviewWrapper, featureFlagsWrapper, scanID, prModel, policies)
 	}
 }
 
-func runPRDecorationBitbucket(prWrapper wrappers.PRWrapper, policyWrapper wrappers.PolicyWrapper, scansWrapper wrappers.ScansWrapper) func(cmd *cobra.Command, args []string) error {
+func runPRDecorationBitbucket(
+	prWrapper wrappers.PRWrapper,
+	policyWrapper wrappers.PolicyWrapper,
+	scansWrapper wrappers.ScansWrapper,
+	resultsWrapper wrappers.ResultsWrapper,
+	risksOverviewWrapper wrappers.RisksOverviewWrapper,
+	featureFlagsWrapper wrappers.FeatureFlagsWrapper,
+) func(cmd *cobra.Command, args []string) error {
 	return func(cmd *cobra.Command, args []string) error {
 		scanID, _ := cmd.Flags().GetString(params.ScanIDFlag)
 		scmTokenFlag, _ := cmd.Flags().GetString(params.SCMTokenFlag)
@@ -424,11 +543,19 @@ func runPRDecorationBitbucket(prWrapper wrappers.PRWrapper, policyWrapper wrappe
 		}
 
 		logger.Print(prResponse)
-		return nil
+
+		return postPRReview(cmd, prWrapper, resultsWrapper, scansWrapper, risksOverviewWrapper, featureFlagsWrapper, scanID, prModel, policies)
 	}
 }
 
-func runPRDecorationAzure(prWrapper wrappers.PRWrapper, policyWrapper wrappers.PolicyWrapper, scansWrapper wrappers.ScansWrapper) func(cmd *cobra.Command, args []string) error {
+func runPRDecorationAzure(
+	prWrapper wrappers.PRWrapper,
+	policyWrapper wrappers.PolicyWrapper,
+	scansWrapper wrappers.ScansWrapper,
+	resultsWrapper wrappers.ResultsWrapper,
+	risksOverviewWrapper wrappers.RisksOverviewWrapper,
+	featureFlagsWrapper wrappers.FeatureFlagsWrapper,
+) func(cmd *cobra.Command, args []string) error {
 	return func(cmd *cobra.Command, args []string) error {
 		scanID, _ := cmd.Flags().GetString(params.ScanIDFlag)
 		scmTokenFlag, _ := cmd.Flags().GetString(params.SCMTokenFlag)
@@ -484,8 +611,75 @@ func runPRDecorationAzure(prWrapper wrappers.PRWrapper, policyWrapper wrappers.P
 
 		logger.Print(prResponse)
 
-		return nil
+		return postPRReview(cmd, prWrapper, resultsWrapper, scansWrapper, risksOverviewWrapper, featureFlagsWrapper, scanID, prModel, policies)
+	}
+}
+
+// runPRDecorationGitea builds the decoration from the scan results, the PR decoration service doesn't support Gitea
+func runPRDecorationGitea(
+	prWrapper wrappers.PRWrapper,
+	policyWrapper wrappers.PolicyWrapper,
+	scansWrapper wrappers.ScansWrapper,
+	resultsWrapper wrappers.ResultsWrapper,
+	risksOverviewWrapper wrappers.RisksOverviewWrapper,
+	featureFlagsWrapper wrappers.FeatureFlagsWrapper,
+) func(cmd *cobra.Command, args []string) error {
+	return func(cmd *cobra.Command, args []string) error {
+		scanID, _ := cmd.Flags().GetString(params.ScanIDFlag)
+		scmTokenFlag, _ := cmd.Flags().GetString(params.SCMTokenFlag)
+		namespaceFlag, _ := cmd.Flags().GetString(params.NamespaceFlag)
+		repoNameFlag, _ := cmd.Flags().GetString(params.RepoNameFlag)
+		prNumberFlag, _ := cmd.Flags().GetInt(params.PRNumberFlag)
+		apiURL, _ := cmd.Flags().GetString(params.CodeRepositoryFlag)
+
+		scanRunningOrQueued, err := IsScanRunningOrQueued(scansWrapper, scanID)
+
+		if err != nil {
+			return err
+		}
+
+		if scanRunningOrQueued {
+			log.Println(noPRDecorationCreated)
+			return nil
+		}
+
+		// Retrieve policies related to the scan and project to include in the PR decoration
+		policies, policyError := getScanViolatedPolicies(scansWrapper, policyWrapper, scanID, cmd)
+		if policyError != nil {
+			return errors.Errorf(policyErrorFormat, failedCreatingGiteaPrDecoration)
+		}
+
+		prModel := &wrappers.GiteaPRModel{
+			ScanID:    scanID,
+			ScmToken:  scmTokenFlag,
+			Namespace: namespaceFlag,
+			RepoName:  repoNameFlag,
+			PrNumber:  prNumberFlag,
+			Policies:  policies,
+			APIURL:    getGiteaAPIURL(apiURL),
+		}
+		pr, err := getPRScan(prWrapper, resultsWrapper, scansWrapper, scanID, prModel)
+		if err != nil {
+			return err
+		}
+		prModel.Comment = prDecorationComment(pr, policies)
+
+		prResponse, _, err := prWrapper.PostPRDecoration(prModel)
+		if err != nil {
+			return errors.Wrapf(err, "%s", failedCreatingGiteaPrDecoration)
+		}
+
+		logger.Print(prResponse)
+
+		return postPRScanReview(cmd, prWrapper, risksOverviewWrapper, featureFlagsWrapper, pr, prModel, policies)
+	}
+}
+
+func getGiteaAPIURL(apiURL string) string {
+	if apiURL == "" {
+		apiURL = giteaCloudURL
 	}
+	return strings.TrimSuffix(apiURL, "/") + giteaAPIURLSuffix
 }
 
 func validateAzureOnPremParameters(apiURL, codeRepositoryUserName string) error {
@@ -0,0 +1,78 @@
+package util
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/checkmarx/ast-cli/internal/params"
+	"github.com/checkmarx/ast-cli/internal/wrappers"
+)
+
+// prDecorationFindingsLimit is the number of new vulnerabilities listed by the decoration comment.
+const prDecorationFindingsLimit = 25
+
+var prDecorationSeverities = []string{"CRITICAL", "HIGH", "MEDIUM", "LOW", "INFO"}
+
+// prDecorationComment returns the markdown decoration of a pull request posted by the CLI, for the SCMs the PR
+// decoration service doesn't support. It summarizes the results of the scan, the violated policies and lists the new
+// vulnerabilities.
+func prDecorationComment(pr *prScan, policies []wrappers.PrPolicy) string {
+	newCounts, totalCounts := make(map[string]int), make(map[string]int)
+	var newFindings []prFinding
+	for _, result := range pr.results {
+		if result == nil || strings.EqualFold(result.State, params.NotExploitable) {
+			continue
+		}
+		severity := strings.ToUpper(result.Severity)
+		totalCounts[severity]++
+		if result.Status == newResultStatus {
+			newCounts[severity]++
+			finding := newPRFinding(result, pr)
+			finding.Path, finding.Line = resultLocation(result)
+			newFindings = append(newFindings, finding)
+		}
+	}
+
+	var comment strings.Builder
+	comment.WriteString("### Checkmarx One scan results\n\n")
+	comment.WriteString(fmt.Sprintf("Scan [%s](%s) found %d new vulnerabilities.\n\n", pr.scan.ID, scanResultsLink(pr), len(newFindings)))
+	comment.WriteString("| Severity | New | Total |\n|---|---|---|\n")
+	for _, severity := range prDecorationSeverities {
+		comment.WriteString(fmt.Sprintf("| %s | %d | %d |\n", severity, newCounts[severity], totalCounts[severity]))
+	}
+	if len(policies) > 0 {
+		comment.WriteString("\n#### Violated policies\n\n")
+		for _, policy := range policies {
+			breakBuild := ""
+			if policy.BreakBuild {
+				breakBuild = " (breaks the build)"
+			}
+			comment.WriteString(fmt.Sprintf("- **%s**%s: %s\n", policy.Name, breakBuild, strings.Join(policy.RulesNames, ", ")))
+		}
+	}
+	if len(newFindings) > 0 {
+		comment.WriteString("\n#### New vulnerabilities\n\n| Severity | Vulnerability | Location |\n|---|---|---|\n")
+		for i, finding := range newFindings {
+			if i == prDecorationFindingsLimit {
+				comment.WriteString(fmt.Sprintf("\nAnd %d more, see the [scan results](%s).\n", len(newFindings)-i, scanResultsLink(pr)))
+				break
+			}
+			location := finding.Path
+			if finding.Line > 0 {
+				location = fmt.Sprintf("%s:%d", finding.Path, finding.Line)
+			}
+			comment.WriteString(fmt.Sprintf("| %s | [%s](%s) | %s |\n", finding.Severity, finding.Title, finding.Link, location))
+		}
+	}
+	comment.WriteString("\n" + wrappers.PRDecorationMarker)
+	return comment.String()
+}
+
+// resultLocation returns the file of a result with its line, the first node of a SAST result.
+func resultLocation(result *wrappers.ScanResult) (path string, line int) {
+	data := result.ScanResultData
+	if len(data.Nodes) > 0 && data.Nodes[0] != nil {
+		return strings.TrimLeft(data.Nodes[0].FileName, "/"), int(data.Nodes[0].Line)
+	}
+	return strings.TrimLeft(data.Filename, "/"), int(data.Line)
+}
@@ -0,0 +1,47 @@
+package util
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/checkmarx/ast-cli/internal/wrappers"
+	"github.com/checkmarx/ast-cli/internal/wrappers/mock"
+	"gotest.tools/assert"
+)
+
+func TestPRDecorationComment(t *testing.T) {
+	pr := &prScan{
+		scan:       &wrappers.ScanResponseModel{ID: "scan", ProjectID: "project"},
+		results:    prReviewResults(),
+		resultsURL: "https://ast.example.com/projects/project/overview",
+	}
+	policies := []wrappers.PrPolicy{{Name: "No High", RulesNames: []string{"High SAST"}, BreakBuild: true}}
+
+	comment := prDecorationComment(pr, policies)
+
+	assert.Assert(t, strings.HasPrefix(comment, "### Checkmarx One scan results\n\n"+
+		"Scan [scan](https://ast.example.com/projects/project/scans?id=scan&branch=) found 4 new vulnerabilities."))
+	assert.Assert(t, strings.Contains(comment, "| HIGH | 2 | 2 |\n| MEDIUM | 1 | 1 |\n| LOW | 1 | 2 |"))
+	assert.Assert(t, strings.Contains(comment, "- **No High** (breaks the build): High SAST\n"))
+	assert.Assert(t, strings.Contains(comment, "| HIGH | [SQL_Injection (SAST)]"+
+		"(https://ast.example.com/results/scan/project/sast/description/89/42) | src/db.js:3 |"))
+	assert.Assert(t, strings.HasSuffix(comment, wrappers.PRDecorationMarker))
+}
+
+func TestPRDecorationGitea(t *testing.T) {
+	prWrapper := &prReviewSpyWrapper{}
+	cmd := PRDecorationGitea(prWrapper, &mock.PolicyMockWrapper{}, &mock.ScansMockWrapper{}, prReviewResultsWrapper{},
+		mock.RisksOverviewMockWrapper{}, &mock.FeatureFlagsMockWrapper{})
+
+	err := executeTestCommand(cmd, "--scan-id", "MOCK", "--token", token, "--namespace", "org", "--repo-name", "repo",
+		"--pr-number", "3", "--code-repository-url", "https://gitea.example.com", "--inline-comments", "--status")
+
+	assert.NilError(t, err)
+	assert.Equal(t, len(prWrapper.models), 1)
+	model := prWrapper.models[0].(*wrappers.GiteaPRModel)
+	assert.Equal(t, model.APIURL, "https://gitea.example.com/api/v1/")
+	assert.Equal(t, model.PrNumber, 3)
+	assert.Assert(t, strings.Contains(model.Comment, wrappers.PRDecorationMarker))
+	assert.Equal(t, len(prWrapper.created), 2)
+	assert.Equal(t, len(prWrapper.statuses), 1)
+}
@@ -0,0 +1,346 @@
+package util
+
+import (
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+
+	"github.com/checkmarx/ast-cli/internal/logger"
+	"github.com/checkmarx/ast-cli/internal/params"
+	"github.com/checkmarx/ast-cli/internal/wrappers"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+const (
+	failedPostingInlineComments = "Failed posting the PR inline review comments"
+	failedGettingScanResults    = "Failed getting the scan results"
+	failedGettingPRDiff         = "Failed getting the PR changes"
+	inlineCommentMarkerFormat   = "[//]: # (checkmarx-one-finding %s %s)"
+	inlineCommentOpen           = "open"
+	inlineCommentResolved       = "resolved"
+	newResultStatus             = "NEW"
+)
+
+// inlineCommentMarkerRegex matches the marker identifying the finding of a comment. It is a markdown comment, hidden
+// when the comment is rendered.
+var inlineCommentMarkerRegex = regexp.MustCompile(`\[//\]: # \(checkmarx-one-finding (\S+) (open|resolved)\)`)
+
+// prFinding is a new result of a scan on a line added by the pull request.
+type prFinding struct {
+	Key         string
+	Path        string
+	Line        int
+	Severity    string
+	Title       string
+	Description string
+	Link        string
+}
+
+// prScan is what the review comments and the status of a pull request need from its scan.
+type prScan struct {
+	scan       *wrappers.ScanResponseModel
+	results    []*wrappers.ScanResult
+	resultsURL string
+	diff       *wrappers.PRDiff
+}
+
+// inlineCommentsPlan is what posting the findings of a scan changes in the review comments of a pull request.
+type inlineCommentsPlan struct {
+	Create  []*wrappers.PRReviewComment
+	Update  []*wrappers.PRReviewComment
+	Resolve []*wrappers.PRReviewComment
+}
+
+func addPRReviewFlags(cmd *cobra.Command) {
+	cmd.Flags().Bool(params.PRInlineCommentsFlag, false, params.PRInlineCommentsFlagUsage)
+	cmd.Flags().Bool(params.PRStatusFlag, false, params.PRStatusFlagUsage)
+	cmd.Flags().String(params.Threshold, "", params.PRThresholdFlagUsage)
+}
+
+// postPRReview posts the inline review comments and the status of the pull request of model when requested.
+func postPRReview(
+	cmd *cobra.Command,
+	prWrapper wrappers.PRWrapper,
+	resultsWrapper wrappers.ResultsWrapper,
+	scansWrapper wrappers.ScansWrapper,
+	risksOverviewWrapper wrappers.RisksOverviewWrapper,
+	featureFlagsWrapper wrappers.FeatureFlagsWrapper,
+	scanID string,
+	model interface{},
+	policies []wrappers.PrPolicy,
+) error {
+	inlineComments, _ := cmd.Flags().GetBool(params.PRInlineCommentsFlag)
+	status, _ := cmd.Flags().GetBool(params.PRStatusFlag)
+	if !inlineComments && !status {
+		return nil
+	}
+	pr, err := getPRScan(prWrapper, resultsWrapper, scansWrapper, scanID, model)
+	if err != nil {
+		return err
+	}
+	return postPRScanReview(cmd, prWrapper, risksOverviewWrapper, featureFlagsWrapper, pr, model, policies)
+}
+
+// postPRScanReview posts the inline review comments and the status of the pull request of model from a scan already
+// fetched.
+func postPRScanReview(
+	cmd *cobra.Command,
+	prWrapper wrappers.PRWrapper,
+	risksOverviewWrapper wrappers.RisksOverviewWrapper,
+	featureFlagsWrapper wrappers.FeatureFlagsWrapper,
+	pr *prScan,
+	model interface{},
+	policies []wrappers.PrPolicy,
+) error {
+	var err error
+	inlineComments, _ := cmd.Flags().GetBool(params.PRInlineCommentsFlag)
+	status, _ := cmd.Flags().GetBool(params.PRStatusFlag)
+	if inlineComments {
+		if err = postInlineComments(prWrapper, pr, model); err != nil {
+			return errors.Wrapf(err, "%s", failedPostingInlineComments)
+		}
+	}
+	if status {
+		threshold, _ := cmd.Flags().GetString(params.Threshold)
+		checkRun, _ := cmd.Flags().GetBool(params.PRCheckRunFlag)
+		thresholdMap := ParseThreshold(threshold)
+		if err = ValidateThresholds(thresholdMap); err != nil {
+			return err
+		}
+		var summaryMap map[string]int
+		if len(thresholdMap) > 0 {
+			results := &wrappers.ScanResultsCollection{Results: pr.results}
+			summaryMap, err = GetSummaryThresholdMap(pr.scan, risksOverviewWrapper, results, featureFlagsWrapper, map[string]string{})
+			if err != nil {
+				return errors.Wrapf(err, "%s", failedPostingPRStatus)
+			}
+		}
+		if err = postPRStatus(prWrapper, pr, model, policies, thresholdMap, summaryMap, checkRun); err != nil {
+			return errors.Wrapf(err, "%s", failedPostingPRStatus)
+		}
+	}
+	return nil
+}
+
+func getPRScan(
+	prWrapper wrappers.PRWrapper,
+	resultsWrapper wrappers.ResultsWrapper,
+	scansWrapper wrappers.ScansWrapper,
+	scanID string,
+	model interface{},
+) (*prScan, error) {
+	scan, errorModel, err := scansWrapper.GetByID(scanID)
+	if err != nil {
+		return nil, errors.Wrapf(err, "%s", failedGettingScanError)
+	}
+	if errorModel != nil {
+		return nil, errors.Errorf(errorCodeFormat, failedGettingScanError, errorModel.Code, errorModel.Message)
+	}
+	results, webErr, err := resultsWrapper.GetAllResultsByScanID(map[string]string{params.ScanIDQueryParam: scanID})
+	if err != nil {
+		return nil, errors.Wrapf(err, "%s", failedGettingScanResults)
+	}
+	if webErr != nil {
+		return nil, errors.Errorf(errorCodeFormat, failedGettingScanResults, webErr.Code, webErr.Message)
+	}
+	resultsURL, err := resultsWrapper.GetResultsURL(scan.ProjectID)
+	if err != nil {
+		return nil, errors.Wrapf(err, "%s", failedGettingScanResults)
+	}
+	diff, err := prWrapper.GetPRDiff(model)
+	if err != nil {
+		return nil, errors.Wrapf(err, "%s", failedGettingPRDiff)
+	}
+	return &prScan{scan: scan, results: results.Results, resultsURL: resultsURL, diff: diff}, nil
+}
+
+// postInlineComments posts the new findings of the scan as review comments on the lines of the pull request of model
+// they are found on. The comments of a previous scan are updated, and resolved once their finding is gone.
+func postInlineComments(prWrapper wrappers.PRWrapper, pr *prScan, model interface{}) error {
+	comments, err := prWrapper.GetPRReviewComments(model, pr.diff)
+	if err != nil {
+		return err
+	}
+	plan := planInlineComments(newPRFindings(pr), comments, pr.scan.ID)
+	for _, comment := range plan.Create {
+		if err = prWrapper.CreatePRReviewComment(model, pr.diff, comment); err != nil {
+			return err
+		}
+	}
+	for _, comment := range plan.Update {
+		if err = prWrapper.UpdatePRReviewComment(model, pr.diff, comment); err != nil {
+			return err
+		}
+	}
+	for _, comment := range plan.Resolve {
+		if err = prWrapper.ResolvePRReviewComment(model, pr.diff, comment); err != nil {
+			return err
+		}
+	}
+	logger.Print(fmt.Sprintf("Inline review comments: %d created, %d updated, %d resolved",
+		len(plan.Create), len(plan.Update), len(plan.Resolve)))
+	return nil
+}
+
+// newPRFindings returns the new results located on a line added by the pull request, a SAST result on the first node
+// of its flow the pull request added.
+func newPRFindings(pr *prScan) []prFinding {
+	var findings []prFinding
+	keys := make(map[string]bool)
+	for _, result := range pr.results {
+		if result == nil || result.Status != newResultStatus || result.State == params.NotExploitable {
+			continue
+		}
+		key := findingKey(result)
+		path, line, found := findingLocation(result, pr.diff)
+		if !found || keys[key] {
+			continue
+		}
+		keys[key] = true
+		finding := newPRFinding(result, pr)
+		finding.Key, finding.Path, finding.Line = key, path, line
+		findings = append(findings, finding)
+	}
+	return findings
+}
+
+// planInlineComments matches the findings to the comments of previous scans by the marker of their finding. A comment
+// at the same place is updated when its content changed, the comment of a finding gone or moved is resolved.
+func planInlineComments(findings []prFinding, comments []wrappers.PRReviewComment, scanID string) inlineCommentsPlan {
+	plan := inlineCommentsPlan{}
+	open := make(map[string]*wrappers.PRReviewComment)
+	for i := range comments {
+		comment := &comments[i]
+		key, state := inlineCommentMarker(comment.Body)
+		if key == "" || comment.Resolved || state != inlineCommentOpen {
+			continue
+		}
+		if _, duplicated := open[key]; duplicated {
+			plan.Resolve = append(plan.Resolve, resolvedComment(comment, key, scanID))
+			continue
+		}
+		open[key] = comment
+	}
+	for _, finding := range findings {
+		comment, found := open[finding.Key]
+		body := finding.comment()
+		if found && comment.Path == finding.Path && comment.Line == finding.Line {
+			delete(open, finding.Key)
+			if comment.Body != body {
+				comment.Body = body
+				plan.Update = append(plan.Update, comment)
+			}
+			continue
+		}
+		plan.Create = append(plan.Create, &wrappers.PRReviewComment{Path: finding.Path, Line: finding.Line, Body: body})
+	}
+	for i := range comments {
+		comment := &comments[i]
+		key, _ := inlineCommentMarker(comment.Body)
+		if open[key] == comment {
+			plan.Resolve = append(plan.Resolve, resolvedComment(comment, key, scanID))
+		}
+	}
+	return plan
+}
+
+// findingKey identifies a finding across scans.
+func findingKey(result *wrappers.ScanResult) string {
+	id := result.SimilarityID
+	if id == "" {
+		id = result.ID
+	}
+	return result.Type + ":" + id
+}
+
+func findingLocation(result *wrappers.ScanResult, diff *wrappers.PRDiff) (path string, line int, found bool) {
+	type location struct {
+		path string
+		line uint
+	}
+	var locations []location
+	if result.Type == params.SastType {
+		for _, node := range result.ScanResultData.Nodes {
+			if node != nil {
+				locations = append(locations, location{node.FileName, node.Line})
+			}
+		}
+	} else if result.ScanResultData.Filename != "" {
+		locations = append(locations, location{result.ScanResultData.Filename, result.ScanResultData.Line})
+	}
+	for _, l := range locations {
+		path = strings.TrimLeft(strings.ReplaceAll(l.path, `\`, "/"), "/")
+		if diff.Files[path].HasLine(int(l.line)) {
+			return path, int(l.line), true
+		}
+	}
+	return "", 0, false
+}
+
+func newPRFinding(result *wrappers.ScanResult, pr *prScan) prFinding {
+	finding := prFinding{
+		Severity:    strings.ToUpper(result.Severity),
+		Title:       result.ScanResultData.QueryName,
+		Description: result.Description,
+		Link:        remediationLink(result, pr),
+	}
+	if finding.Title == "" {
+		finding.Title = result.ScanResultData.RuleName
+	}
+	if finding.Description == "" {
+		finding.Description = result.ScanResultData.Description
+	}
+	if finding.Description == "" {
+		finding.Description = result.ScanResultData.RuleDescription
+	}
+	finding.Title = fmt.Sprintf("%s (%s)", finding.Title, strings.ToUpper(result.Type))
+	finding.Description = strings.TrimSpace(finding.Description)
+	return finding
+}
+
+// remediationLink returns the description of the query of a SAST result, otherwise the remediation link of the result
+// or the results of the scan.
+func remediationLink(result *wrappers.ScanResult, pr *prScan) string {
+	host := ""
+	if parsedURL, err := url.Parse(pr.resultsURL); err == nil && parsedURL.Host != "" {
+		host = fmt.Sprintf("%s://%s", parsedURL.Scheme, parsedURL.Host)
+	}
+	switch {
+	case result.Type == params.SastType:
+		return fmt.Sprintf("%s/results/%s/%s/sast/description/%v/%v",
+			host, pr.scan.ID, pr.scan.ProjectID, result.VulnerabilityDetails.CweID, result.ScanResultData.QueryID)
+	case result.ScanResultData.RemediationLink != "":
+		return result.ScanResultData.RemediationLink
+	default:
+		return scanResultsLink(pr)
+	}
+}
+
+func scanResultsLink(pr *prScan) string {
+	return strings.Replace(pr.resultsURL, "overview",
+		fmt.Sprintf("scans?id=%s&branch=%s", pr.scan.ID, url.QueryEscape(pr.scan.Branch)), 1)
+}
+
+func (f *prFinding) comment() string {
+	return fmt.Sprintf("**%s** %s\n\n%s\n\n[Remediation](%s)\n\n%s",
+		f.Severity, f.Title, f.Description, f.Link, fmt.Sprintf(inlineCommentMarkerFormat, f.Key, inlineCommentOpen))
+}
+
+// resolvedComment returns comment with its title struck out and a marker of resolved finding.
+func resolvedComment(comment *wrappers.PRReviewComment, key, scanID string) *wrappers.PRReviewComment {
+	title := strings.SplitN(comment.Body, "\n", 2)[0]
+	comment.Body = fmt.Sprintf("~~%s~~\n\nResolved, scan %s doesn't find it anymore.\n\n%s",
+		title, scanID, fmt.Sprintf(inlineCommentMarkerFormat, key, inlineCommentResolved))
+	return comment
+}
+
+// inlineCommentMarker returns the finding key and state of a comment posted by the CLI, an empty key for other comments.
+func inlineCommentMarker(body string) (key, state string) {
+	matches := inlineCommentMarkerRegex.FindStringSubmatch(body)
+	if matches == nil {
+		return "", ""
+	}
+	return matches[1], matches[2]
+}
@@ -0,0 +1,195 @@
+package util
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/checkmarx/ast-cli/internal/wrappers"
+	"github.com/checkmarx/ast-cli/internal/wrappers/mock"
+	"gotest.tools/assert"
+)
+
+type prReviewResultsWrapper struct {
+	mock.ResultsMockWrapper
+}
+
+func (prReviewResultsWrapper) GetAllResultsByScanID(map[string]string) (*wrappers.ScanResultsCollection, *wrappers.WebError, error) {
+	return &wrappers.ScanResultsCollection{Results: prReviewResults()}, nil, nil
+}
+
+func (prReviewResultsWrapper) GetResultsURL(projectID string) (string, error) {
+	return "https://ast.example.com/projects/" + projectID + "/overview", nil
+}
+
+// prReviewSpyWrapper returns a pull request adding lines 10 to 12 of src/app.js and main.tf, and records the comments
+type prReviewSpyWrapper struct {
+	mock.PRMockWrapper
+	comments []wrappers.PRReviewComment
+	created  []*wrappers.PRReviewComment
+	updated  []*wrappers.PRReviewComment
+	resolved []*wrappers.PRReviewComment
+	statuses []*wrappers.PRStatus
+	models   []interface{}
+}
+
+func (w *prReviewSpyWrapper) PostPRDecoration(model interface{}) (string, *wrappers.WebError, error) {
+	w.models = append(w.models, model)
+	return w.PRMockWrapper.PostPRDecoration(model)
+}
+
+func (w *prReviewSpyWrapper) GetPRDiff(interface{}) (*wrappers.PRDiff, error) {
+	lines := map[int]bool{10: true, 11: true, 12: true}
+	return &wrappers.PRDiff{HeadSHA: "head", Files: map[string]*wrappers.PRDiffFile{
+		"src/app.js": {AddedLines: lines},
+		"main.tf":    {AddedLines: lines},
+	}}, nil
+}
+
+func (w *prReviewSpyWrapper) GetPRReviewComments(interface{}, *wrappers.PRDiff) ([]wrappers.PRReviewComment, error) {
+	return w.comments, nil
+}
+
+func (w *prReviewSpyWrapper) CreatePRReviewComment(_ interface{}, _ *wrappers.PRDiff, comment *wrappers.PRReviewComment) error {
+	w.created = append(w.created, comment)
+	return nil
+}
+
+func (w *prReviewSpyWrapper) UpdatePRReviewComment(_ interface{}, _ *wrappers.PRDiff, comment *wrappers.PRReviewComment) error {
+	w.updated = append(w.updated, comment)
+	return nil
+}
+
+func (w *prReviewSpyWrapper) ResolvePRReviewComment(_ interface{}, _ *wrappers.PRDiff, comment *wrappers.PRReviewComment) error {
+	w.resolved = append(w.resolved, comment)
+	return nil
+}
+
+func (w *prReviewSpyWrapper) PostPRStatus(_ interface{}, _ *wrappers.PRDiff, status *wrappers.PRStatus) error {
+	w.statuses = append(w.statuses, status)
+	return nil
+}
+
+func prReviewResults() []*wrappers.ScanResult {
+	return []*wrappers.ScanResult{
+		{
+			Type: "sast", SimilarityID: "111", Status: "NEW", Severity: "HIGH", Description: "Unsanitized input",
+			ScanResultData: wrappers.ScanResultData{QueryName: "SQL_Injection", QueryID: 42, Nodes: []*wrappers.ScanResultNode{
+				{FileName: "/src/db.js", Line: 3},
+				{FileName: "/src/app.js", Line: 11},
+			}},
+			VulnerabilityDetails: wrappers.VulnerabilityDetails{CweID: 89},
+		},
+		{
+			Type: "kics", SimilarityID: "222", Status: "NEW", Severity: "MEDIUM", Description: "Bucket is public",
+			ScanResultData: wrappers.ScanResultData{QueryName: "Public Bucket", Filename: "main.tf", Line: 12},
+		},
+		{
+			Type: "kics", SimilarityID: "333", Status: "RECURRENT", Severity: "LOW",
+			ScanResultData: wrappers.ScanResultData{QueryName: "Old Issue", Filename: "main.tf", Line: 10},
+		},
+		{
+			Type: "kics", SimilarityID: "444", Status: "NEW", Severity: "LOW",
+			ScanResultData: wrappers.ScanResultData{QueryName: "Outside Diff", Filename: "main.tf", Line: 30},
+		},
+		{Type: "sca", SimilarityID: "555", Status: "NEW", Severity: "HIGH"},
+	}
+}
+
+func prReviewFindings() []prFinding {
+	diff, _ := (&prReviewSpyWrapper{}).GetPRDiff(nil)
+	return newPRFindings(&prScan{
+		scan:       &wrappers.ScanResponseModel{ID: "scan", ProjectID: "project"},
+		results:    prReviewResults(),
+		resultsURL: "https://ast.example.com/projects/project/overview",
+		diff:       diff,
+	})
+}
+
+func TestNewPRFindings(t *testing.T) {
+	findings := prReviewFindings()
+
+	assert.Equal(t, len(findings), 2)
+	assert.Equal(t, findings[0].Key, "sast:111")
+	assert.Equal(t, findings[0].Path, "src/app.js")
+	assert.Equal(t, findings[0].Line, 11)
+	assert.Equal(t, findings[0].comment(), "**HIGH** SQL_Injection (SAST)\n\nUnsanitized input\n\n"+
+		"[Remediation](https://ast.example.com/results/scan/project/sast/description/89/42)\n\n"+
+		"[//]: # (checkmarx-one-finding sast:111 open)")
+	assert.Equal(t, findings[1].Key, "kics:222")
+	assert.Equal(t, findings[1].Line, 12)
+	assert.Assert(t, strings.Contains(findings[1].comment(), "[Remediation](https://ast.example.com/projects/project/scans?id=scan&branch=)"))
+}
+
+func TestPlanInlineComments(t *testing.T) {
+	findings := prReviewFindings()
+	comments := []wrappers.PRReviewComment{
+		// Same finding at the same place with an outdated body
+		{ID: "1", Path: "src/app.js", Line: 11, Body: "old\n\n[//]: # (checkmarx-one-finding sast:111 open)"},
+		// Same finding moved to another line
+		{ID: "2", Path: "main.tf", Line: 10, Body: "Public Bucket\n\n[//]: # (checkmarx-one-finding kics:222 open)"},
+		// Finding not found anymore
+		{ID: "3", Path: "main.tf", Line: 11, Body: "**LOW** Gone (KICS)\n\n[//]: # (checkmarx-one-finding kics:999 open)"},
+		// Already resolved and other comments
+		{ID: "4", Path: "main.tf", Line: 11, Body: "[//]: # (checkmarx-one-finding kics:888 resolved)"},
+		{ID: "5", Path: "main.tf", Line: 11, Body: "[//]: # (checkmarx-one-finding kics:777 open)", Resolved: true},
+		{ID: "6", Path: "main.tf", Line: 11, Body: "Looks good to me"},
+	}
+
+	plan := planInlineComments(findings, comments, "scan")
+
+	assert.Equal(t, len(plan.Create), 1)
+	assert.Equal(t, plan.Create[0].Line, 12)
+	assert.Equal(t, len(plan.Update), 1)
+	assert.Equal(t, plan.Update[0].ID, "1")
+	assert.Equal(t, plan.Update[0].Body, findings[0].comment())
+	assert.Equal(t, len(plan.Resolve), 2)
+	assert.Equal(t, plan.Resolve[0].ID, "2")
+	assert.Equal(t, plan.Resolve[1].ID, "3")
+	assert.Equal(t, plan.Resolve[1].Body, "~~**LOW** Gone (KICS)~~\n\nResolved, scan scan doesn't find it anymore.\n\n"+
+		"[//]: # (checkmarx-one-finding kics:999 resolved)")
+}
+
+func TestPlanInlineCommentsUnchanged(t *testing.T) {
+	findings := prReviewFindings()
+	comments := []wrappers.PRReviewComment{
+		{ID: "1", Path: findings[0].Path, Line: findings[0].Line, Body: findings[0].comment()},
+		{ID: "2", Path: findings[1].Path, Line: findings[1].Line, Body: findings[1].comment()},
+		{ID: "3", Path: findings[1].Path, Line: findings[1].Line, Body: findings[1].comment()},
+	}
+
+	plan := planInlineComments(findings, comments, "scan")
+
+	assert.Equal(t, len(plan.Create), 0)
+	assert.Equal(t, len(plan.Update), 0)
+	// The duplicated comment of a finding is resolved
+	assert.Equal(t, len(plan.Resolve), 1)
+	assert.Equal(t, plan.Resolve[0].ID, "3")
+}
+
+func TestPRDecorationGithubInlineComments(t *testing.T) {
+	prWrapper := &prReviewSpyWrapper{comments: []wrappers.PRReviewComment{
+		{ID: "9", Path: "main.tf", Line: 11, Body: "[//]: # (checkmarx-one-finding kics:999 open)"},
+	}}
+	cmd := PRDecorationGithub(prWrapper, &mock.PolicyMockWrapper{}, &mock.ScansMockWrapper{}, prReviewResultsWrapper{},
+		mock.RisksOverviewMockWrapper{}, &mock.FeatureFlagsMockWrapper{})
+
+	err := executeTestCommand(cmd, "--scan-id", "MOCK", "--token", token, "--namespace", "org", "--repo-name", "repo",
+		"--pr-number", "1", "--inline-comments")
+
+	assert.NilError(t, err)
+	assert.Equal(t, len(prWrapper.created), 2)
+	assert.Equal(t, len(prWrapper.updated), 0)
+	assert.Equal(t, len(prWrapper.resolved), 1)
+}
+
+func TestPRDecorationGithubWithoutInlineComments(t *testing.T) {
+	prWrapper := &prReviewSpyWrapper{}
+	cmd := PRDecorationGithub(prWrapper, &mock.PolicyMockWrapper{}, &mock.ScansMockWrapper{}, prReviewResultsWrapper{},
+		mock.RisksOverviewMockWrapper{}, &mock.FeatureFlagsMockWrapper{})
+
+	err := executeTestCommand(cmd, "--scan-id", "MOCK", "--token", token, "--namespace", "org", "--repo-name", "repo",
+		"--pr-number", "1")
+
+	assert.NilError(t, err)
+	assert.Equal(t, len(prWrapper.created), 0)
+}
@@ -0,0 +1,92 @@
+package util
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/checkmarx/ast-cli/internal/logger"
+	"github.com/checkmarx/ast-cli/internal/wrappers"
+)
+
+const (
+	failedPostingPRStatus   = "Failed posting the PR status"
+	prStatusName            = "Checkmarx One"
+	prStatusPassed          = "Passed: no violated policy breaks the build and no threshold is reached"
+	prStatusDescriptionSize = 140
+)
+
+// postPRStatus publishes the status of the commit scanned for the pull request of model. It fails when a violated
+// policy breaks the build or a threshold limit is reached.
+func postPRStatus(
+	prWrapper wrappers.PRWrapper,
+	pr *prScan,
+	model interface{},
+	policies []wrappers.PrPolicy,
+	thresholdMap,
+	summaryMap map[string]int,
+	checkRun bool,
+) error {
+	failures := prStatusFailures(summaryMap, policies, thresholdMap)
+	status := &wrappers.PRStatus{
+		CommitSHA:   prStatusCommit(pr),
+		Name:        prStatusName,
+		Passed:      len(failures) == 0,
+		Description: prStatusPassed,
+		TargetURL:   scanResultsLink(pr),
+		CheckRun:    checkRun,
+	}
+	if !status.Passed {
+		status.Description = "Failed: " + strings.Join(failures, ", ")
+	}
+	if len(status.Description) > prStatusDescriptionSize {
+		status.Description = status.Description[:prStatusDescriptionSize-3] + "..."
+	}
+	if checkRun {
+		for _, finding := range newPRFindings(pr) {
+			status.Annotations = append(status.Annotations, wrappers.PRAnnotation{
+				Path:     finding.Path,
+				Line:     finding.Line,
+				Severity: finding.Severity,
+				Title:    finding.Title,
+				Message:  finding.Description,
+			})
+		}
+	}
+	if err := prWrapper.PostPRStatus(model, pr.diff, status); err != nil {
+		return err
+	}
+	logger.Print(fmt.Sprintf("PR status of commit %s: %s", status.CommitSHA, status.Description))
+	return nil
+}
+
+// prStatusCommit returns the commit the scan ran on, otherwise the head of the pull request.
+func prStatusCommit(pr *prScan) string {
+	handler := pr.scan.Metadata.Handler
+	if handler != nil && handler.GitHandler != nil && handler.GitHandler.Commit != "" {
+		return handler.GitHandler.Commit
+	}
+	return pr.diff.HeadSHA
+}
+
+// prStatusFailures returns the violated policies breaking the build and the threshold limits reached by the counts of
+// summaryMap.
+func prStatusFailures(summaryMap map[string]int, policies []wrappers.PrPolicy, thresholdMap map[string]int) []string {
+	var failures []string
+	for _, policy := range policies {
+		if policy.BreakBuild {
+			failures = append(failures, fmt.Sprintf("policy %s", policy.Name))
+		}
+	}
+	keys := make([]string, 0, len(thresholdMap))
+	for key := range thresholdMap {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		if summaryMap[key] >= thresholdMap[key] {
+			failures = append(failures, fmt.Sprintf("%s %d >= %d", key, summaryMap[key], thresholdMap[key]))
+		}
+	}
+	return failures
+}
@@ -0,0 +1,87 @@
+package util
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/checkmarx/ast-cli/internal/params"
+	"github.com/checkmarx/ast-cli/internal/wrappers"
+	"github.com/checkmarx/ast-cli/internal/wrappers/mock"
+	"gotest.tools/assert"
+)
+
+func TestPRStatusFailures(t *testing.T) {
+	policies := []wrappers.PrPolicy{{Name: "Audit"}, {Name: "No High", BreakBuild: true}}
+
+	summaryMap, err := GetSummaryThresholdMap(&wrappers.ScanResponseModel{}, mock.RisksOverviewMockWrapper{},
+		&wrappers.ScanResultsCollection{Results: prReviewResults()}, &mock.FeatureFlagsMockWrapper{}, map[string]string{})
+	assert.NilError(t, err)
+
+	failures := prStatusFailures(summaryMap, policies, map[string]int{"iac-security-low": 2, "sast-high": 2})
+
+	assert.DeepEqual(t, failures, []string{"policy No High", "iac-security-low 2 >= 2"})
+}
+
+func TestPRStatusFailuresAPISecurity(t *testing.T) {
+	risksOverviewWrapper := &mock.RisksOverviewMockWrapperWithEntries{Entries: []wrappers.APISecRiskEntry{
+		{Severity: "High", Origin: "code", State: "to_verify"},
+		{Severity: "High", Origin: "code", State: "not_exploitable"},
+	}}
+	summaryMap, err := GetSummaryThresholdMap(&wrappers.ScanResponseModel{ID: "MOCK", Engines: []string{params.APISecType}},
+		risksOverviewWrapper, &wrappers.ScanResultsCollection{}, &mock.FeatureFlagsMockWrapper{}, map[string]string{})
+	assert.NilError(t, err)
+
+	failures := prStatusFailures(summaryMap, nil, map[string]int{"api-security-high": 1})
+
+	assert.DeepEqual(t, failures, []string{"api-security-high 1 >= 1"})
+}
+
+func TestPRStatusFailuresWithoutThreshold(t *testing.T) {
+	failures := prStatusFailures(nil, []wrappers.PrPolicy{{Name: "Audit"}}, nil)
+
+	assert.Equal(t, len(failures), 0)
+}
+
+func TestPRDecorationGithubStatus(t *testing.T) {
+	prWrapper := &prReviewSpyWrapper{}
+	cmd := PRDecorationGithub(prWrapper, &mock.PolicyMockWrapper{}, &mock.ScansMockWrapper{}, prReviewResultsWrapper{},
+		mock.RisksOverviewMockWrapper{}, &mock.FeatureFlagsMockWrapper{})
+
+	err := executeTestCommand(cmd, "--scan-id", "MOCK", "--token", token, "--namespace", "org", "--repo-name", "repo",
+		"--pr-number", "1", "--status", "--threshold", "sast-high=1", "--check-run")
+
+	assert.NilError(t, err)
+	assert.Equal(t, len(prWrapper.created), 0)
+	assert.Equal(t, len(prWrapper.statuses), 1)
+	status := prWrapper.statuses[0]
+	assert.Equal(t, status.CommitSHA, "head")
+	assert.Equal(t, status.Passed, false)
+	assert.Assert(t, strings.Contains(status.Description, "sast-high 1 >= 1"))
+	assert.Equal(t, status.CheckRun, true)
+	assert.Equal(t, len(status.Annotations), 2)
+	assert.Equal(t, status.Annotations[0].Path, "src/app.js")
+}
+
+func TestPRDecorationGithubStatusPassed(t *testing.T) {
+	prWrapper := &prReviewSpyWrapper{}
+	cmd := PRDecorationGithub(prWrapper, &mock.PolicyMockWrapper{}, &mock.ScansMockWrapper{}, prReviewResultsWrapper{},
+		mock.RisksOverviewMockWrapper{}, &mock.FeatureFlagsMockWrapper{})
+
+	err := executeTestCommand(cmd, "--scan-id", "MOCK", "--token", token, "--namespace", "org", "--repo-name", "repo",
+		"--pr-number", "1", "--status", "--threshold", "sast-critical=1")
+
+	assert.NilError(t, err)
+	assert.Equal(t, len(prWrapper.statuses), 1)
+	assert.Equal(t, prWrapper.statuses[0].Passed, true)
+	assert.Equal(t, len(prWrapper.statuses[0].Annotations), 0)
+}
+
+func TestPRDecorationGithubStatusInvalidThreshold(t *testing.T) {
+	cmd := PRDecorationGithub(&prReviewSpyWrapper{}, &mock.PolicyMockWrapper{}, &mock.ScansMockWrapper{}, prReviewResultsWrapper{},
+		mock.RisksOverviewMockWrapper{}, &mock.FeatureFlagsMockWrapper{})
+
+	err := executeTestCommand(cmd, "--scan-id", "MOCK", "--token", token, "--namespace", "org", "--repo-name", "repo",
+		"--pr-number", "1", "--status", "--threshold", "sast-high=0")
+
+	assert.ErrorContains(t, err, "Invalid value for threshold limit sast-high")
+}
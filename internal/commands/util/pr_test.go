@@ -14,7 +14,7 @@ const (
 )
 
 func TestNewGithubPRDecorationCommandMustExist(t *testing.T) {
-	cmd := PRDecorationGithub(nil, nil, nil)
+	cmd := PRDecorationGithub(nil, nil, nil, nil, nil, nil)
 	assert.Assert(t, cmd != nil, "PR decoration command must exist")
 
 	err := cmd.Execute()
@@ -22,7 +22,7 @@ func TestNewGithubPRDecorationCommandMustExist(t *testing.T) {
 }
 
 func TestNewGitlabMRDecorationCommandMustExist(t *testing.T) {
-	cmd := PRDecorationGitlab(nil, nil, nil)
+	cmd := PRDecorationGitlab(nil, nil, nil, nil, nil, nil)
 	assert.Assert(t, cmd != nil, "MR decoration command must exist")
 
 	err := cmd.Execute()
@@ -30,7 +30,15 @@ func TestNewGitlabMRDecorationCommandMustExist(t *testing.T) {
 }
 
 func TestNewAzurePRDecorationCommandMustExist(t *testing.T) {
-	cmd := PRDecorationAzure(nil, nil, nil)
+	cmd := PRDecorationAzure(nil, nil, nil, nil, nil, nil)
+	assert.Assert(t, cmd != nil, "PR decoration command must exist")
+
+	err := cmd.Execute()
+	assert.ErrorContains(t, err, "scan-id")
+}
+
+func TestNewGiteaPRDecorationCommandMustExist(t *testing.T) {
+	cmd := PRDecorationGitea(nil, nil, nil, nil, nil, nil)
 	assert.Assert(t, cmd != nil, "PR decoration command must exist")
 
 	err := cmd.Execute()
@@ -209,6 +217,16 @@ func TestGetAzureAPIURL_whenAPIURLIsNotSet_ShouldReturnCloudAPIURL(t *testing.T)
 	asserts.Equal(t, azureCloudURL, cloudAPIURL)
 }
 
+func TestGetGiteaAPIURL_whenAPIURLIsSet_ShouldUpdateAPIURL(t *testing.T) {
+	updatedAPIURL := getGiteaAPIURL("https://gitea.example.com/")
+	asserts.Equal(t, "https://gitea.example.com/api/v1/", updatedAPIURL)
+}
+
+func TestGetGiteaAPIURL_whenAPIURLIsNotSet_ShouldReturnCloudAPIURL(t *testing.T) {
+	cloudAPIURL := getGiteaAPIURL("")
+	asserts.Equal(t, giteaCloudURL+giteaAPIURLSuffix, cloudAPIURL)
+}
+
 func TestUpdateScmTokenForAzureOnPrem_whenUserNameIsSet_ShouldUpdateToken(t *testing.T) {
 	username := "username"
 	expectedToken := username + ":" + token
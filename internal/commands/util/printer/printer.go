@@ -1,6 +1,7 @@
 package printer
 
 import (
+	"encoding/csv"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -15,6 +16,7 @@ import (
 const (
 	FormatJSON            = "json"
 	FormatJSONv2          = "json-v2"
+	FormatNDJSON          = "ndjson"
 	FormatIndentedJSON    = "indented-json"
 	FormatSarif           = "sarif"
 	FormatSonar           = "sonar"
@@ -23,6 +25,7 @@ const (
 	FormatSummaryConsole  = "summaryConsole"
 	FormatList            = "list"
 	FormatTable           = "table"
+	FormatCSV             = "csv"
 	FormatHTML            = "html"
 	FormatPDF             = "pdf"
 	FormatMarkdown        = "md"
@@ -46,18 +49,45 @@ func Print(w io.Writer, view interface{}, format string) error {
 			return err
 		}
 		_, _ = fmt.Fprintln(w, string(viewJSON))
+	} else if IsFormat(format, FormatNDJSON) {
+		return printNDJSON(w, view)
 	} else if IsFormat(format, FormatList) {
 		entities := toEntities(view)
 		printList(w, entities)
 	} else if IsFormat(format, FormatTable) {
 		entities := toEntities(view)
 		printTable(w, entities)
+	} else if IsFormat(format, FormatCSV) {
+		return printCSV(w, toEntities(view))
 	} else {
 		return errors.Errorf("Invalid format %s", format)
 	}
 	return nil
 }
 
+// printNDJSON writes every element of a slice view, or a single view, as a JSON line.
+func printNDJSON(w io.Writer, view interface{}) error {
+	viewVal := reflect.ValueOf(view)
+	if viewVal.Kind() != reflect.Slice {
+		return printJSONLine(w, view)
+	}
+	for i := 0; i < viewVal.Len(); i++ {
+		if err := printJSONLine(w, viewVal.Index(i).Interface()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func printJSONLine(w io.Writer, view interface{}) error {
+	viewJSON, err := json.Marshal(view)
+	if err != nil {
+		return err
+	}
+	_, _ = fmt.Fprintln(w, string(viewJSON))
+	return nil
+}
+
 func IsFormat(val, format string) bool {
 	return strings.EqualFold(val, format)
 }
@@ -143,6 +173,28 @@ func printTable(w io.Writer, entities []*entity) {
 	_, _ = fmt.Fprintln(w)
 }
 
+// printCSV writes a header row with the property keys of the first entity followed by a row per entity.
+func printCSV(w io.Writer, entities []*entity) error {
+	if len(entities) == 0 {
+		return nil
+	}
+	writer := csv.NewWriter(w)
+	header := make([]string, len(entities[0].Properties))
+	for i, p := range entities[0].Properties {
+		header[i] = p.Key
+	}
+	_ = writer.Write(header)
+	for _, e := range entities {
+		row := make([]string, len(e.Properties))
+		for i, p := range e.Properties {
+			row[i] = p.Value
+		}
+		_ = writer.Write(row)
+	}
+	writer.Flush()
+	return writer.Error()
+}
+
 func pad(width int, key string) string {
 	padLen := width - len(key) + 1
 	const nonBreakingSpace = string('\u00A0')
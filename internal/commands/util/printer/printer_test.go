@@ -1,6 +1,7 @@
 package printer
 
 import (
+	"bytes"
 	"fmt"
 	"os"
 	"testing"
@@ -26,6 +27,18 @@ func TestPrintJson(t *testing.T) {
 	assert.Assert(t, err.Error() == "json: unsupported type: chan int")
 }
 
+func TestPrintNDJSON(t *testing.T) {
+	buffer := bytes.NewBufferString("")
+	err := Print(buffer, []map[string]int{{"a": 1}, {"b": 2}}, FormatNDJSON)
+	assert.NilError(t, err)
+	assert.Equal(t, buffer.String(), "{\"a\":1}\n{\"b\":2}\n")
+
+	buffer.Reset()
+	err = Print(buffer, map[string]int{"a": 1}, FormatNDJSON)
+	assert.NilError(t, err)
+	assert.Equal(t, buffer.String(), "{\"a\":1}\n")
+}
+
 func TestPrintList(t *testing.T) {
 	err := Print(os.Stdout, nil, FormatList)
 	assert.NilError(t, err, "list print must run well")
@@ -48,6 +61,22 @@ func TestPrintTable(t *testing.T) {
 	assert.NilError(t, err, "table print must run well")
 }
 
+func TestPrintCSV(t *testing.T) {
+	type view struct {
+		Name  string
+		Count int
+	}
+	buffer := bytes.NewBufferString("")
+	err := Print(buffer, []view{{Name: "org/repo", Count: 2}, {Name: "a, b", Count: 1}}, FormatCSV)
+	assert.NilError(t, err)
+	assert.Equal(t, buffer.String(), "Name,Count\norg/repo,2\n\"a, b\",1\n")
+
+	buffer.Reset()
+	err = Print(buffer, []view{}, FormatCSV)
+	assert.NilError(t, err)
+	assert.Equal(t, buffer.String(), "")
+}
+
 func TestGetFormatter(t *testing.T) {
 	tests := []struct {
 		name          string
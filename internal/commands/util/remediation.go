@@ -19,13 +19,11 @@ import (
 )
 
 const (
-	npmPackageFilename        = "package.json"
 	permission                = 0644
 	permission0666            = 0666
 	containerStarting         = "Starting kics container"
-	filesContainerLocation    = "/files/"
-	filesContainerVolume      = ":/files"
-	resultsContainerLocation  = "/kics/"
+	filesContainerLocation    = "/files"
+	resultsContainerLocation  = "/kics"
 	containerRemove           = "--rm"
 	// ContainerImage is the KICS container image with pinned SHA256 digest.
 	ContainerImage            = "checkmarx/kics@sha256:643071cf0c1657eaea695a48b49d2d61b7e625bb87c51505530e624e0c0a1ad1" // v2.1.20
@@ -54,7 +52,11 @@ var (
 	kicsErrorCodes       = []string{"70"}
 )
 
-func NewRemediationCommand() *cobra.Command {
+func NewRemediationCommand(
+	resultsWrapper wrappers.ResultsWrapper,
+	exportWrapper wrappers.ExportWrapper,
+	featureFlagsWrapper wrappers.FeatureFlagsWrapper,
+) *cobra.Command {
 	remediationCmd := &cobra.Command{
 		Use:   "remediation",
 		Short: "Remediate vulnerabilities",
@@ -73,6 +75,7 @@ func NewRemediationCommand() *cobra.Command {
 		},
 	}
 	scaRemediationCmd := RemediationScaCommand()
+	scaRemediationCmd.AddCommand(RemediationScaAutoCommand(resultsWrapper, exportWrapper, featureFlagsWrapper))
 	kicsRemediationCmd := RemediationKicsCommand()
 	remediationCmd.AddCommand(scaRemediationCmd, kicsRemediationCmd)
 	return remediationCmd
@@ -82,12 +85,15 @@ func RemediationScaCommand() *cobra.Command {
 	scaRemediateCmd := &cobra.Command{
 		Use:   "sca",
 		Short: "Remediate sca vulnerabilities",
-		Long: `To remediate package files vulnerabilities detected by the sca engine
+		Long: `To remediate package files vulnerabilities detected by the sca engine. Supported package files are
+package.json, pom.xml, build.gradle, build.gradle.kts, *.versions.toml, requirements*.txt, pyproject.toml, go.mod,
+.csproj, .fsproj, .vbproj and Directory.Packages.props
 	`,
 		RunE: runRemediationScaCmd(),
 		Example: heredoc.Doc(
 			`
 			$ cx utils remediation sca --package <package> --package-files <package-files> --package-version <package-version>
+			$ cx utils remediation sca auto --scan-id <scan-id> --source .
 		`,
 		),
 		Annotations: map[string]string{
@@ -97,20 +103,21 @@ func RemediationScaCommand() *cobra.Command {
 			),
 		},
 	}
-	scaRemediateCmd.PersistentFlags().StringSlice(
+	// Local flags, the auto subcommand doesn't take a package
+	scaRemediateCmd.Flags().StringSlice(
 		commonParams.RemediationFiles,
 		[]string{},
 		"Path to input package files to remediate the package version",
 	)
-	scaRemediateCmd.PersistentFlags().String(commonParams.RemediationPackage, "", "Name of the package to be replaced")
-	scaRemediateCmd.PersistentFlags().String(
+	scaRemediateCmd.Flags().String(commonParams.RemediationPackage, "", "Name of the package to be replaced")
+	scaRemediateCmd.Flags().String(
 		commonParams.RemediationPackageVersion,
 		"",
 		"Version of the package to be replaced",
 	)
-	_ = scaRemediateCmd.MarkPersistentFlagRequired(commonParams.RemediationFiles)
-	_ = scaRemediateCmd.MarkPersistentFlagRequired(commonParams.RemediationPackage)
-	_ = scaRemediateCmd.MarkPersistentFlagRequired(commonParams.RemediationPackageVersion)
+	_ = scaRemediateCmd.MarkFlagRequired(commonParams.RemediationFiles)
+	_ = scaRemediateCmd.MarkFlagRequired(commonParams.RemediationPackage)
+	_ = scaRemediateCmd.MarkFlagRequired(commonParams.RemediationPackageVersion)
 	return scaRemediateCmd
 }
 
@@ -156,6 +163,7 @@ func RemediationKicsCommand() *cobra.Command {
 		"docker",
 		"Name in the $PATH for the container engine to run kics. Example:podman.",
 	)
+	kicsRemediateCmd.PersistentFlags().String(commonParams.KicsBinaryFlag, "", KicsBinaryFlagUsage)
 	_ = kicsRemediateCmd.MarkPersistentFlagRequired(commonParams.KicsRemediationFile)
 	_ = kicsRemediateCmd.MarkPersistentFlagRequired(commonParams.KicsProjectFile)
 	return kicsRemediateCmd
@@ -176,11 +184,7 @@ func runRemediationScaCmd() func(cmd *cobra.Command, args []string) error {
 					return fileErr
 				}
 				// Call the parser for each specific package manager
-				p := remediation.PackageContentJSON{
-					FileContent:       fileContent,
-					PackageIdentifier: packageName,
-					PackageVersion:    packageVersion,
-				}
+				p := remediation.NewPackage(filePath, fileContent, packageName, packageVersion)
 				parserOutput, fileErr := p.Parser()
 				if fileErr != nil {
 					return fileErr
@@ -216,18 +220,26 @@ func writePackageFile(filename, output string) error {
 }
 
 func IsPackageFileSupported(filename string) bool {
-	return strings.Contains(filename, npmPackageFilename)
+	return remediation.IsPackageFileSupported(filename)
 }
 
 func runRemediationKicsCmd() func(cmd *cobra.Command, args []string) error {
 	return func(cmd *cobra.Command, args []string) error {
-		// Create temp location, add it to container volumes and copy the results inside it
-		volumeMap, tempDir, err := createKicsRemediateEnv(cmd)
+		kicsBinary, _ := cmd.Flags().GetString(commonParams.KicsBinaryFlag)
+		engine, _ := cmd.Flags().GetString(commonParams.KicsRealtimeEngine)
+		runner, err := NewKicsRunner(kicsBinary, engine, containerName)
+		if err != nil {
+			return err
+		}
+		kicsFilesPath, _ := cmd.Flags().GetString(commonParams.KicsProjectFile)
+		filesVolume := KicsVolume{HostPath: kicsFilesPath, ContainerPath: filesContainerLocation}
+		// Create temp location, add it to the KICS volumes and copy the results inside it
+		resultsVolume, err := createKicsRemediateEnv(cmd, runner, filesVolume)
 		if err != nil {
 			return errors.Errorf("%s", err)
 		}
-		// Run kics container
-		err = runKicsRemediation(cmd, volumeMap, tempDir)
+		// Run kics
+		err = runKicsRemediation(cmd, runner, resultsVolume, filesVolume)
 		if err != nil {
 			return errors.Errorf("%s", err)
 		}
@@ -235,23 +247,14 @@ func runRemediationKicsCmd() func(cmd *cobra.Command, args []string) error {
 	}
 }
 
-func runKicsRemediation(cmd *cobra.Command, volumeMap, tempDir string) error {
-	kicsFilesPath, _ := cmd.Flags().GetString(commonParams.KicsProjectFile)
+func runKicsRemediation(cmd *cobra.Command, runner KicsRunner, resultsVolume, filesVolume KicsVolume) error {
 	kicsResultsPath, _ := cmd.Flags().GetString(commonParams.KicsRemediationFile)
 	_, file := filepath.Split(kicsResultsPath)
+	tempDir := resultsVolume.HostPath
 	kicsRunArgs := []string{
-		containerRun,
-		containerRemove,
-		volumeFlag,
-		volumeMap,
-		volumeFlag,
-		kicsFilesPath + filesContainerVolume,
-		containerNameFlag,
-		containerName,
-		ContainerImage,
 		remediateCommand,
 		resultsFlag,
-		resultsContainerLocation + file,
+		runner.Path(resultsVolume, file),
 		kicsVerboseFlag,
 	}
 	if len(kicsSimilarityFilter) > 0 {
@@ -260,8 +263,7 @@ func runKicsRemediation(cmd *cobra.Command, volumeMap, tempDir string) error {
 		kicsRunArgs = append(kicsRunArgs, kicsSimilarityFilterString)
 	}
 	logger.PrintIfVerbose(containerStarting)
-	kicsCmd, _ := cmd.Flags().GetString(commonParams.KicsRealtimeEngine)
-	out, err := exec.Command(kicsCmd, kicsRunArgs...).CombinedOutput()
+	out, err := runner.Run(kicsRunArgs, resultsVolume, filesVolume)
 	logger.PrintIfVerbose(string(out))
 	/* 	NOTE: the kics container returns 40 instead of 0 when successful!! This
 	definitely an incorrect behavior but the following check gets past it.
@@ -298,35 +300,34 @@ func runKicsRemediation(cmd *cobra.Command, volumeMap, tempDir string) error {
 	return nil
 }
 
-func createKicsRemediateEnv(cmd *cobra.Command) (volume, kicsDir string, err error) {
-	kicsDir, err = os.MkdirTemp("", "kics")
+func createKicsRemediateEnv(cmd *cobra.Command, runner KicsRunner, filesVolume KicsVolume) (resultsVolume KicsVolume, err error) {
+	kicsDir, err := os.MkdirTemp("", "kics")
 	if err != nil {
-		return "", "", errors.New(directoryError)
+		return KicsVolume{}, errors.New(directoryError)
 	}
 	kicsResultsPath, _ := cmd.Flags().GetString(commonParams.KicsRemediationFile)
 	_, file := filepath.Split(kicsResultsPath)
 	if file == "" {
-		return "", "", errors.New(" No results file was provided")
+		return KicsVolume{}, errors.New(" No results file was provided")
 	}
 	kicsFile, err := os.ReadFile(kicsResultsPath)
 	if err != nil {
-		return "", "", err
+		return KicsVolume{}, err
 	}
-	// transform the file_name attribute to match container location
-	kicsFile, err = filenameMatcher(kicsFile)
+	// transform the file_name attribute to match the files location as KICS sees it
+	kicsFile, err = filenameMatcher(kicsFile, func(file string) string { return runner.Path(filesVolume, file) })
 	if err != nil {
-		return "", "", err
+		return KicsVolume{}, err
 	}
 	destinationFile := fmt.Sprintf("%s/%s", kicsDir, file)
 	err = os.WriteFile(destinationFile, kicsFile, permission0666)
 	if err != nil {
-		return "", "", errors.New(containerWriteFolderError)
+		return KicsVolume{}, errors.New(containerWriteFolderError)
 	}
-	volume = fmt.Sprintf("%s:/kics", kicsDir)
-	return volume, kicsDir, nil
+	return KicsVolume{HostPath: kicsDir, ContainerPath: resultsContainerLocation}, nil
 }
 
-func filenameMatcher(kicsFile []byte) (kicsFileUpdated []byte, err error) {
+func filenameMatcher(kicsFile []byte, filesLocation func(file string) string) (kicsFileUpdated []byte, err error) {
 	model := wrappers.KicsResultsCollection{}
 	err = json.Unmarshal(kicsFile, &model)
 	if err != nil {
@@ -335,7 +336,7 @@ func filenameMatcher(kicsFile []byte) (kicsFileUpdated []byte, err error) {
 	for indexResults := range model.Results {
 		for indexLocations := range model.Results[indexResults].Locations {
 			file := filepath.Base(model.Results[indexResults].Locations[indexLocations].Filename)
-			model.Results[indexResults].Locations[indexLocations].Filename = filesContainerLocation + file
+			model.Results[indexResults].Locations[indexLocations].Filename = filesLocation(file)
 		}
 	}
 	kicsFileUpdated, err = json.Marshal(model)
@@ -0,0 +1,332 @@
+package util
+
+import (
+	"fmt"
+	"io"
+	"path"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/MakeNowJust/heredoc"
+	"github.com/checkmarx/ast-cli/internal/commands/util/printer"
+	"github.com/checkmarx/ast-cli/internal/logger"
+	commonParams "github.com/checkmarx/ast-cli/internal/params"
+	"github.com/checkmarx/ast-cli/internal/services"
+	"github.com/checkmarx/ast-cli/internal/wrappers"
+	"github.com/checkmarx/ast-cli/internal/wrappers/remediation"
+	"github.com/pkg/errors"
+	"github.com/pmezard/go-difflib/difflib"
+	"github.com/spf13/cobra"
+)
+
+const (
+	failedScaAutoRemediation = "Failed remediating the SCA results"
+	scaAutoFixed             = "Fixed"
+	scaAutoNotFixable        = "Not fixable"
+	patchContextLines        = 3
+)
+
+// scaAutoFix is the upgrade of a vulnerable package to the newest version recommended by its results. Reason explains
+// why the package can't be upgraded automatically.
+type scaAutoFix struct {
+	ID                 string
+	Name               string
+	CurrentVersion     string
+	RecommendedVersion string
+	Locations          []string
+	Reason             string
+}
+
+type scaAutoFixView struct {
+	Package        string `json:"package"`
+	CurrentVersion string `json:"currentVersion" format:"name:Current version"`
+	FixedVersion   string `json:"fixedVersion" format:"name:Fixed version"`
+	File           string `json:"file"`
+	Status         string `json:"status"`
+	Reason         string `json:"reason,omitempty"`
+}
+
+// scaAutoFileChange is the content of a package manager file before and after its packages were upgraded.
+type scaAutoFileChange struct {
+	Original string
+	Updated  string
+}
+
+func RemediationScaAutoCommand(
+	resultsWrapper wrappers.ResultsWrapper,
+	exportWrapper wrappers.ExportWrapper,
+	featureFlagsWrapper wrappers.FeatureFlagsWrapper,
+) *cobra.Command {
+	scaAutoCmd := &cobra.Command{
+		Use:   "auto",
+		Short: "Upgrade every vulnerable direct dependency found by a scan",
+		Long: `To upgrade the direct dependencies with SCA results of a scan to their recommended version, in every package
+manager file of the source directory. By default a patch is printed, use --apply to change the files in place
+	`,
+		RunE: runRemediationScaAutoCmd(resultsWrapper, exportWrapper, featureFlagsWrapper),
+		Example: heredoc.Doc(
+			`
+			$ cx utils remediation sca auto --scan-id <scan-id> --source . > sca-fixes.patch
+			$ cx utils remediation sca auto --scan-id <scan-id> --source . --apply
+		`,
+		),
+	}
+	scaAutoCmd.PersistentFlags().String(commonParams.ScanIDFlag, "", "ID of the scan with the SCA results to remediate")
+	scaAutoCmd.PersistentFlags().String(commonParams.RemediationSourceFlag, ".", "Directory of the sources that were scanned")
+	scaAutoCmd.PersistentFlags().Bool(commonParams.RemediationApplyFlag, false, "Change the package manager files instead of printing a patch")
+	scaAutoCmd.PersistentFlags().String(
+		commonParams.FormatFlag,
+		printer.FormatTable,
+		fmt.Sprintf(commonParams.FormatFlagUsageFormat, []string{printer.FormatTable, printer.FormatJSON, printer.FormatList}),
+	)
+	_ = scaAutoCmd.MarkPersistentFlagRequired(commonParams.ScanIDFlag)
+	return scaAutoCmd
+}
+
+func runRemediationScaAutoCmd(
+	resultsWrapper wrappers.ResultsWrapper,
+	exportWrapper wrappers.ExportWrapper,
+	featureFlagsWrapper wrappers.FeatureFlagsWrapper,
+) func(cmd *cobra.Command, args []string) error {
+	return func(cmd *cobra.Command, args []string) error {
+		scanID, _ := cmd.Flags().GetString(commonParams.ScanIDFlag)
+		source, _ := cmd.Flags().GetString(commonParams.RemediationSourceFlag)
+		apply, _ := cmd.Flags().GetBool(commonParams.RemediationApplyFlag)
+		format, _ := cmd.Flags().GetString(commonParams.FormatFlag)
+
+		results, webErr, err := resultsWrapper.GetAllResultsByScanID(map[string]string{commonParams.ScanIDQueryParam: scanID})
+		if err != nil {
+			return errors.Wrapf(err, "%s", failedScaAutoRemediation)
+		}
+		if webErr != nil {
+			return errors.Errorf("%s: CODE: %d, %s", failedScaAutoRemediation, webErr.Code, webErr.Message)
+		}
+		export, err := services.GetExportPackage(exportWrapper, scanID, false, featureFlagsWrapper)
+		if err != nil {
+			return errors.Wrapf(err, "%s", failedScaAutoRemediation)
+		}
+
+		fixes := planScaAutoFixes(results.Results, export.Packages)
+		changes, views := applyScaAutoFixes(source, fixes)
+		summary := cmd.OutOrStdout()
+		if apply {
+			for _, file := range sortedChangedFiles(changes) {
+				if err = writePackageFile(filepath.Join(source, file), changes[file].Updated); err != nil {
+					return errors.Wrapf(err, "%s", failedScaAutoRemediation)
+				}
+			}
+		} else {
+			if err = writeScaAutoPatch(cmd.OutOrStdout(), changes); err != nil {
+				return errors.Wrapf(err, "%s", failedScaAutoRemediation)
+			}
+			// Keep the standard output a valid patch
+			summary = cmd.ErrOrStderr()
+		}
+		return printer.Print(summary, views, format)
+	}
+}
+
+// planScaAutoFixes returns a fix for every package with SCA results, upgrading it to the newest version recommended by
+// its results. Only the packages supporting a quick fix, like in the results, are fixable: a transitive dependency is
+// upgraded through the package that brings it.
+func planScaAutoFixes(results []*wrappers.ScanResult, packages []wrappers.ScaPackage) []scaAutoFix {
+	names := make(map[string]string, len(packages))
+	locationsByID := make(map[string][]*string, len(packages))
+	for i := range packages {
+		names[packages[i].ID] = packages[i].Name
+		locationsByID[packages[i].ID] = packages[i].Locations
+	}
+	scaPackages := *ParseScaExportPackage(packages)
+	packagesByID := make(map[string]*wrappers.ScaPackageCollection, len(scaPackages))
+	for i := range scaPackages {
+		UpdateDependencyPaths(scaPackages[i].DependencyPathArray, locationsByID)
+		scaPackages[i].SupportsQuickFix = HasQuickFix(scaPackages[i].DependencyPathArray)
+		packagesByID[scaPackages[i].ID] = &scaPackages[i]
+	}
+	recommended := make(map[string]string)
+	for _, result := range results {
+		if result == nil || result.Type != commonParams.ScaType {
+			continue
+		}
+		id := result.ScanResultData.PackageIdentifier
+		version := ""
+		if result.ScanResultData.RecommendedVersion != nil {
+			version = fmt.Sprint(result.ScanResultData.RecommendedVersion)
+		}
+		if current, found := recommended[id]; !found || isNewerVersion(version, current) {
+			recommended[id] = version
+		}
+	}
+
+	ids := make([]string, 0, len(recommended))
+	for id := range recommended {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	fixes := make([]scaAutoFix, 0, len(ids))
+	for _, id := range ids {
+		fix := scaAutoFix{ID: id, Name: id, RecommendedVersion: recommended[id]}
+		pkg, found := packagesByID[id]
+		if found {
+			fix.Name = names[id]
+			fix.CurrentVersion = directDependencyVersion(pkg)
+			for _, location := range pkg.Locations {
+				if location != nil {
+					fix.Locations = append(fix.Locations, *location)
+				}
+			}
+		}
+		switch {
+		case !found:
+			fix.Reason = "The package isn't in the SCA packages of the scan"
+		case !pkg.IsDirectDependency:
+			fix.Reason = "Transitive dependency, upgrade the direct dependency that brings it"
+		case fix.RecommendedVersion == "":
+			fix.Reason = "No fixed version available"
+		case len(fix.Locations) == 0:
+			fix.Reason = "No package manager file declares it"
+		case !pkg.SupportsQuickFix:
+			fix.Reason = "Declared in a package manager file that isn't supported"
+		}
+		fixes = append(fixes, fix)
+	}
+	return fixes
+}
+
+func directDependencyVersion(pkg *wrappers.ScaPackageCollection) string {
+	for _, dependencyPath := range pkg.DependencyPathArray {
+		if len(dependencyPath) == 1 {
+			return dependencyPath[0].Version
+		}
+	}
+	return ""
+}
+
+// applyScaAutoFixes upgrades the packages of fixes in the package manager files under source and returns the changed
+// files, by path relative to source, with a view of every fix per file.
+func applyScaAutoFixes(source string, fixes []scaAutoFix) (changes map[string]*scaAutoFileChange, views []scaAutoFixView) {
+	changes = make(map[string]*scaAutoFileChange)
+	views = []scaAutoFixView{}
+	for i := range fixes {
+		fix := &fixes[i]
+		view := scaAutoFixView{Package: fix.Name, CurrentVersion: fix.CurrentVersion, FixedVersion: fix.RecommendedVersion}
+		if fix.Reason != "" {
+			view.File = strings.Join(fix.Locations, ",")
+			view.Status = scaAutoNotFixable
+			view.Reason = fix.Reason
+			views = append(views, view)
+			continue
+		}
+		for _, location := range fix.Locations {
+			file, reason := sourcePackageFile(source, location)
+			view.File = file
+			if reason == "" {
+				reason = upgradePackageFile(source, file, fix, changes)
+			}
+			if reason != "" {
+				view.Status = scaAutoNotFixable
+				view.Reason = reason
+			} else {
+				view.Status = scaAutoFixed
+				view.Reason = ""
+			}
+			views = append(views, view)
+		}
+	}
+	return changes, views
+}
+
+// sourcePackageFile returns the location of a package manager file relative to source, or why it can't be changed when
+// it's outside source
+func sourcePackageFile(source, location string) (file, reason string) {
+	file = path.Clean(strings.TrimLeft(filepath.ToSlash(location), "/"))
+	if !filepath.IsLocal(filepath.FromSlash(file)) {
+		return filepath.ToSlash(location), "The package manager file is outside the source directory"
+	}
+	absSource, err := filepath.Abs(source)
+	if err != nil {
+		return file, err.Error()
+	}
+	absFile, err := filepath.Abs(filepath.Join(source, filepath.FromSlash(file)))
+	if err != nil {
+		return file, err.Error()
+	}
+	if relative, err := filepath.Rel(absSource, absFile); err != nil || !filepath.IsLocal(relative) {
+		return file, "The package manager file is outside the source directory"
+	}
+	return file, ""
+}
+
+// upgradePackageFile upgrades the package of fix in the file, on top of the previous upgrades of the same file. It
+// returns why the file couldn't be upgraded, empty when it was.
+func upgradePackageFile(source, file string, fix *scaAutoFix, changes map[string]*scaAutoFileChange) string {
+	if !remediation.IsPackageFileSupported(file) {
+		return "Unsupported package manager file"
+	}
+	change, found := changes[file]
+	if !found {
+		content, err := readPackageFile(filepath.Join(source, file))
+		if err != nil {
+			return err.Error()
+		}
+		change = &scaAutoFileChange{Original: content, Updated: content}
+	}
+	updated, err := remediation.NewPackage(file, change.Updated, fix.Name, fix.RecommendedVersion).Parser()
+	if err != nil {
+		return err.Error()
+	}
+	logger.PrintIfVerbose(fmt.Sprintf("Upgraded %s to %s in %s", fix.Name, fix.RecommendedVersion, file))
+	change.Updated = updated
+	changes[file] = change
+	return ""
+}
+
+func writeScaAutoPatch(w io.Writer, changes map[string]*scaAutoFileChange) error {
+	for _, file := range sortedChangedFiles(changes) {
+		diff := difflib.UnifiedDiff{
+			A:        difflib.SplitLines(changes[file].Original),
+			B:        difflib.SplitLines(changes[file].Updated),
+			FromFile: "a/" + file,
+			ToFile:   "b/" + file,
+			Context:  patchContextLines,
+		}
+		if err := difflib.WriteUnifiedDiff(w, diff); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func sortedChangedFiles(changes map[string]*scaAutoFileChange) []string {
+	files := make([]string, 0, len(changes))
+	for file, change := range changes {
+		if change.Original != change.Updated {
+			files = append(files, file)
+		}
+	}
+	sort.Strings(files)
+	return files
+}
+
+// isNewerVersion compares the numeric parts of two versions, ex: 1.10.0 is newer than 1.9.2.
+func isNewerVersion(version, other string) bool {
+	versionParts := versionNumbers(version)
+	otherParts := versionNumbers(other)
+	for i := 0; i < len(versionParts) && i < len(otherParts); i++ {
+		if versionParts[i] != otherParts[i] {
+			return versionParts[i] > otherParts[i]
+		}
+	}
+	return len(versionParts) > len(otherParts)
+}
+
+func versionNumbers(version string) []int {
+	var numbers []int
+	for _, part := range strings.FieldsFunc(version, func(r rune) bool { return r < '0' || r > '9' }) {
+		number, _ := strconv.Atoi(part)
+		numbers = append(numbers, number)
+	}
+	return numbers
+}
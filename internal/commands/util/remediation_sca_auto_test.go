@@ -0,0 +1,182 @@
+package util
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/checkmarx/ast-cli/internal/wrappers"
+	"github.com/checkmarx/ast-cli/internal/wrappers/mock"
+	"gotest.tools/assert"
+)
+
+const (
+	scaAutoPackageJSON = `{
+  "dependencies": {
+    "lodash": "4.17.15"
+  }
+}`
+	scaAutoRequirements = `# web
+flask==1.0
+requests==2.19.0  # http
+`
+)
+
+type scaAutoResultsWrapper struct {
+	mock.ResultsMockWrapper
+}
+
+func (scaAutoResultsWrapper) GetAllResultsByScanID(map[string]string) (*wrappers.ScanResultsCollection, *wrappers.WebError, error) {
+	return &wrappers.ScanResultsCollection{Results: scaAutoResults()}, nil, nil
+}
+
+type scaAutoExportWrapper struct {
+	mock.ExportMockWrapper
+}
+
+func (*scaAutoExportWrapper) GetScaPackageCollectionExport(string, bool) (*wrappers.ScaPackageCollectionExport, error) {
+	return &wrappers.ScaPackageCollectionExport{Packages: scaAutoPackages()}, nil
+}
+
+func scaAutoResults() []*wrappers.ScanResult {
+	result := func(id, packageID string, recommended interface{}) *wrappers.ScanResult {
+		return &wrappers.ScanResult{
+			Type:           "sca",
+			ID:             id,
+			ScanResultData: wrappers.ScanResultData{PackageIdentifier: packageID, RecommendedVersion: recommended},
+		}
+	}
+	return []*wrappers.ScanResult{
+		result("CVE-1", "Python-requests-2.19.0", "2.20.0"),
+		result("CVE-2", "Python-requests-2.19.0", "2.31.0"),
+		result("CVE-3", "Npm-lodash-4.17.15", "4.17.21"),
+		result("CVE-4", "Npm-minimist-0.0.8", "1.2.6"),
+		result("CVE-5", "Maven-log4j-2.14", "2.17.1"),
+		{Type: "sast", ID: "sast"},
+	}
+}
+
+func scaAutoPackages() []wrappers.ScaPackage {
+	location := func(path string) []*string { return []*string{&path} }
+	return []wrappers.ScaPackage{
+		{
+			ID: "Python-requests-2.19.0", Name: "requests", IsDirectDependency: true, Locations: location("/requirements.txt"),
+			PackagePathArray: [][]wrappers.PackagePath{{{ID: "Python-requests-2.19.0", Version: "2.19.0"}}},
+		},
+		{
+			ID: "Npm-lodash-4.17.15", Name: "lodash", IsDirectDependency: true, Locations: location("web/package.json"),
+			PackagePathArray: [][]wrappers.PackagePath{{{ID: "Npm-lodash-4.17.15", Version: "4.17.15"}}},
+		},
+		{ID: "Npm-minimist-0.0.8", Name: "minimist", Locations: location("web/package.json")},
+	}
+}
+
+func writeScaAutoSources(t *testing.T) string {
+	source := t.TempDir()
+	assert.NilError(t, os.MkdirAll(filepath.Join(source, "web"), 0755))
+	assert.NilError(t, os.WriteFile(filepath.Join(source, "web", "package.json"), []byte(scaAutoPackageJSON), permission))
+	assert.NilError(t, os.WriteFile(filepath.Join(source, "requirements.txt"), []byte(scaAutoRequirements), permission))
+	return source
+}
+
+func TestPlanScaAutoFixes(t *testing.T) {
+	fixes := planScaAutoFixes(scaAutoResults(), scaAutoPackages())
+
+	assert.Equal(t, len(fixes), 4)
+	assert.Equal(t, fixes[0].ID, "Maven-log4j-2.14")
+	assert.Equal(t, fixes[0].Reason, "The package isn't in the SCA packages of the scan")
+	assert.Equal(t, fixes[1].Name, "lodash")
+	assert.Equal(t, fixes[1].Reason, "")
+	assert.Equal(t, fixes[2].Name, "minimist")
+	assert.Equal(t, fixes[2].Reason, "Transitive dependency, upgrade the direct dependency that brings it")
+	assert.Equal(t, fixes[3].Name, "requests")
+	assert.Equal(t, fixes[3].CurrentVersion, "2.19.0")
+	assert.Equal(t, fixes[3].RecommendedVersion, "2.31.0")
+	assert.DeepEqual(t, fixes[3].Locations, []string{"/requirements.txt"})
+}
+
+func TestApplyScaAutoFixes(t *testing.T) {
+	source := writeScaAutoSources(t)
+
+	changes, views := applyScaAutoFixes(source, planScaAutoFixes(scaAutoResults(), scaAutoPackages()))
+
+	assert.Equal(t, len(views), 4)
+	assert.Equal(t, views[1].File, "web/package.json")
+	assert.Equal(t, views[1].Status, scaAutoFixed)
+	assert.Equal(t, views[3].File, "requirements.txt")
+	assert.Equal(t, views[3].Status, scaAutoFixed)
+	assert.Equal(t, changes["requirements.txt"].Updated, "# web\nflask==1.0\nrequests==2.31.0  # http\n")
+	assert.DeepEqual(t, sortedChangedFiles(changes), []string{"requirements.txt", "web/package.json"})
+}
+
+func TestApplyScaAutoFixesUnsupportedFile(t *testing.T) {
+	_, views := applyScaAutoFixes(t.TempDir(), []scaAutoFix{
+		{Name: "rails", RecommendedVersion: "7.0.0", Locations: []string{"Gemfile"}},
+	})
+
+	assert.Equal(t, views[0].Status, scaAutoNotFixable)
+	assert.Equal(t, views[0].Reason, "Unsupported package manager file")
+}
+
+func TestApplyScaAutoFixesOutsideSource(t *testing.T) {
+	source := filepath.Join(t.TempDir(), "source")
+	assert.NilError(t, os.MkdirAll(source, 0755))
+	outside := filepath.Join(filepath.Dir(source), "requirements.txt")
+	assert.NilError(t, os.WriteFile(outside, []byte(scaAutoRequirements), permission))
+
+	changes, views := applyScaAutoFixes(source, []scaAutoFix{
+		{Name: "requests", RecommendedVersion: "2.31.0", Locations: []string{"../requirements.txt", "/web/../../requirements.txt"}},
+	})
+
+	assert.Equal(t, len(changes), 0)
+	for _, view := range views {
+		assert.Equal(t, view.Status, scaAutoNotFixable)
+		assert.Equal(t, view.Reason, "The package manager file is outside the source directory")
+	}
+}
+
+func TestPlanScaAutoFixesUnsupportedFile(t *testing.T) {
+	location := "Gemfile"
+	fixes := planScaAutoFixes(
+		[]*wrappers.ScanResult{{Type: "sca", ScanResultData: wrappers.ScanResultData{PackageIdentifier: "Ruby-rails-6.0", RecommendedVersion: "7.0.0"}}},
+		[]wrappers.ScaPackage{{ID: "Ruby-rails-6.0", Name: "rails", IsDirectDependency: true, Locations: []*string{&location}}},
+	)
+
+	assert.Equal(t, fixes[0].Reason, "Declared in a package manager file that isn't supported")
+}
+
+func TestRemediationScaAutoCommandPatch(t *testing.T) {
+	source := writeScaAutoSources(t)
+	cmd := RemediationScaAutoCommand(scaAutoResultsWrapper{}, &scaAutoExportWrapper{}, &mock.FeatureFlagsMockWrapper{})
+	patch := bytes.NewBufferString("")
+	cmd.SetOut(patch)
+	cmd.SetErr(bytes.NewBufferString(""))
+
+	err := executeTestCommand(cmd, "--scan-id", "MOCK", "--source", source)
+
+	assert.NilError(t, err)
+	assert.Assert(t, bytes.Contains(patch.Bytes(), []byte("--- a/requirements.txt\n+++ b/requirements.txt\n")))
+	assert.Assert(t, bytes.Contains(patch.Bytes(), []byte("-requests==2.19.0  # http\n+requests==2.31.0  # http\n")))
+	content, _ := os.ReadFile(filepath.Join(source, "requirements.txt"))
+	assert.Equal(t, string(content), scaAutoRequirements)
+}
+
+func TestRemediationScaAutoCommandApply(t *testing.T) {
+	source := writeScaAutoSources(t)
+	cmd := RemediationScaAutoCommand(scaAutoResultsWrapper{}, &scaAutoExportWrapper{}, &mock.FeatureFlagsMockWrapper{})
+	cmd.SetOut(bytes.NewBufferString(""))
+
+	err := executeTestCommand(cmd, "--scan-id", "MOCK", "--source", source, "--apply", "--format", "json")
+
+	assert.NilError(t, err)
+	content, _ := os.ReadFile(filepath.Join(source, "requirements.txt"))
+	assert.Equal(t, string(content), "# web\nflask==1.0\nrequests==2.31.0  # http\n")
+}
+
+func TestIsNewerVersion(t *testing.T) {
+	assert.Assert(t, isNewerVersion("1.10.0", "1.9.2"))
+	assert.Assert(t, isNewerVersion("2.0.1", "2.0"))
+	assert.Assert(t, !isNewerVersion("1.2.3", "1.2.3"))
+	assert.Assert(t, !isNewerVersion("", "1.0"))
+}
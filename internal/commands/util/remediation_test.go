@@ -1,9 +1,13 @@
 package util
 
 import (
+	"os"
 	"path/filepath"
+	"runtime"
+	"strings"
 	"testing"
 
+	"github.com/checkmarx/ast-cli/internal/wrappers/mock"
 	"gotest.tools/assert"
 )
 
@@ -29,7 +33,7 @@ const (
 )
 
 func TestNewRemediationCommand(t *testing.T) {
-	cmd := NewRemediationCommand()
+	cmd := NewRemediationCommand(&mock.ResultsMockWrapper{}, &mock.ExportMockWrapper{}, &mock.FeatureFlagsMockWrapper{})
 	assert.Assert(t, cmd != nil, "Remediation command must exist")
 }
 
@@ -75,6 +79,25 @@ func TestRemediationScaCommandPackageNotFound(t *testing.T) {
 	assert.Assert(t, err != nil, "Package not found")
 }
 
+func TestRemediationScaCommandPomFile(t *testing.T) {
+	pomFile := filepath.Join(t.TempDir(), "pom.xml")
+	err := os.WriteFile(pomFile, []byte(`<dependency>
+  <groupId>org.yaml</groupId>
+  <artifactId>snakeyaml</artifactId>
+  <version>1.30</version>
+</dependency>`), permission)
+	assert.NilError(t, err)
+	cmd := RemediationScaCommand()
+	err = executeTestCommand(cmd, packageFileFlag, pomFile, packageFlag, "org.yaml:snakeyaml", packageVersionFlag, "2.0")
+	assert.NilError(t, err)
+	content, _ := os.ReadFile(pomFile)
+	assert.Equal(t, string(content), `<dependency>
+  <groupId>org.yaml</groupId>
+  <artifactId>snakeyaml</artifactId>
+  <version>2.0</version>
+</dependency>`)
+}
+
 func TestRemediationKicsCommand(t *testing.T) {
 	cmd := RemediationKicsCommand()
 	abs, _ := filepath.Abs(kicsFileValue)
@@ -117,3 +140,30 @@ func TestRemediationKicsCommandSimilarityFilter(t *testing.T) {
 	)
 	assert.Assert(t, err == nil, "Remediation command must pass")
 }
+
+func TestRemediationKicsCommandBinary(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("the fake KICS binary is a shell script")
+	}
+	dir := t.TempDir()
+	kicsBinary := filepath.Join(dir, "kics")
+	copiedResults := filepath.Join(dir, "results.json")
+	script := "#!/bin/sh\ncp \"$3\" " + copiedResults + "\nprintf 'Available remediation: 2\\nApplied remediation: 1\\n'\n"
+	assert.NilError(t, os.WriteFile(kicsBinary, []byte(script), 0700))
+	abs, _ := filepath.Abs(kicsFileValue)
+
+	cmd := RemediationKicsCommand()
+	err := executeTestCommand(cmd, resultsFileFlag, resultFileValue, kicsFileFlag, abs, "--kics-binary", kicsBinary)
+
+	assert.NilError(t, err)
+	results, err := os.ReadFile(copiedResults)
+	assert.NilError(t, err)
+	assert.Assert(t, strings.Contains(string(results), abs+string(os.PathSeparator)), "file names must point to the kics files")
+}
+
+func TestRemediationKicsCommandMissingBinary(t *testing.T) {
+	cmd := RemediationKicsCommand()
+	abs, _ := filepath.Abs(kicsFileValue)
+	err := executeTestCommand(cmd, resultsFileFlag, resultFileValue, kicsFileFlag, abs, "--kics-binary", filepath.Join(t.TempDir(), "kics"))
+	assert.ErrorContains(t, err, "KICS binary")
+}
@@ -0,0 +1,110 @@
+package util
+
+import (
+	"fmt"
+	"log"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/checkmarx/ast-cli/internal/params"
+	"github.com/checkmarx/ast-cli/internal/wrappers"
+	"github.com/pkg/errors"
+)
+
+const ignoredResultState = "IGNORED"
+
+// ParseThreshold returns the limits of a threshold by <engine>-<severity>, ex: sast-high=10;sca-high=5. Invalid
+// limits are logged and left out.
+func ParseThreshold(threshold string) map[string]int {
+	if strings.TrimSpace(threshold) == "" {
+		return nil
+	}
+	thresholdMap := make(map[string]int)
+	if threshold != "" {
+		threshold = strings.ReplaceAll(strings.ReplaceAll(threshold, " ", ""), ",", ";")
+		thresholdLimits := strings.Split(strings.ToLower(threshold), ";")
+		for _, limits := range thresholdLimits {
+			engineName, intLimit, err := ParseThresholdLimit(limits)
+			if err != nil {
+				log.Printf("%s", err)
+			} else {
+				thresholdMap[engineName] = intLimit
+			}
+		}
+	}
+
+	return thresholdMap
+}
+
+// ValidateThresholds fails when a limit of thresholdMap is lower than 1.
+func ValidateThresholds(thresholdMap map[string]int) error {
+	var errMsgBuilder strings.Builder
+
+	for engineName, limit := range thresholdMap {
+		if limit < 1 {
+			errMsgBuilder.WriteString(errors.Errorf("Invalid value for threshold limit %s. Threshold should be greater or equal to 1.\n", engineName).Error())
+		}
+	}
+
+	errMsg := errMsgBuilder.String()
+	if errMsg != "" {
+		return errors.New(errMsg)
+	}
+	return nil
+}
+
+func ParseThresholdLimit(limit string) (engineName string, intLimit int, err error) {
+	parts := strings.Split(limit, "=")
+	engineName = strings.Replace(parts[0], params.KicsType, params.IacType, 1)
+	if len(parts) <= 1 {
+		return engineName, 0, errors.New("Error parsing threshold limit: missing values\n")
+	}
+	intLimit, err = strconv.Atoi(parts[1])
+	if err != nil {
+		err = errors.Errorf("%s: Error parsing threshold limit: %v\n", engineName, err)
+	}
+	return engineName, intLimit, err
+}
+
+// GetSummaryThresholdMap counts the exploitable results of the scan by <engine>-<severity>, the keys of the threshold
+// limits, including the API security risks when the scan ran that engine.
+func GetSummaryThresholdMap(
+	scan *wrappers.ScanResponseModel,
+	risksOverviewWrapper wrappers.RisksOverviewWrapper,
+	results *wrappers.ScanResultsCollection,
+	featureFlagsWrapper wrappers.FeatureFlagsWrapper,
+	resultsParam map[string]string,
+) (map[string]int, error) {
+	summaryMap := make(map[string]int)
+
+	for _, result := range results.Results {
+		if IsExploitable(result.State) {
+			key := strings.ToLower(fmt.Sprintf("%s-%s", strings.Replace(result.Type, params.KicsType, params.IacType, 1), result.Severity))
+			summaryMap[key]++
+		}
+	}
+
+	if slices.Contains(scan.Engines, params.APISecType) {
+		apiSecFilterRisks, err := GetFilterResultsForAPISecScanner(risksOverviewWrapper, scan.ID, resultsParam)
+		if err != nil {
+			return nil, err
+		}
+		if apiSecFilterRisks != nil && apiSecFilterRisks.SeverityCount != nil {
+			summaryMap["api-security-high"] = apiSecFilterRisks.SeverityCount["high"]
+			summaryMap["api-security-medium"] = apiSecFilterRisks.SeverityCount["medium"]
+			summaryMap["api-security-low"] = apiSecFilterRisks.SeverityCount["low"]
+
+			flagResponse, _ := wrappers.GetSpecificFeatureFlag(featureFlagsWrapper, wrappers.CVSSV3Enabled)
+			criticalEnabled := flagResponse.Status
+			if criticalEnabled {
+				summaryMap["api-security-critical"] = apiSecFilterRisks.SeverityCount["critical"]
+			}
+		}
+	}
+	return summaryMap, nil
+}
+
+func IsExploitable(state string) bool {
+	return !strings.EqualFold(state, params.NotExploitable) && !strings.EqualFold(state, ignoredResultState)
+}
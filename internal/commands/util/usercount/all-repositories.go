@@ -0,0 +1,319 @@
+package usercount
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/checkmarx/ast-cli/internal/logger"
+	"github.com/checkmarx/ast-cli/internal/wrappers"
+	bitbucketServerWrapper "github.com/checkmarx/ast-cli/internal/wrappers/bitbucketserver"
+)
+
+func orgToken(org contributorOrg) string {
+	if org.TokenEnv == "" {
+		return ""
+	}
+	return os.Getenv(org.TokenEnv)
+}
+
+func orgURL(org contributorOrg, defaultURL string) string {
+	if org.URL == "" {
+		return defaultURL
+	}
+	return org.URL
+}
+
+func listGitHubRepositories(gitHubWrapper wrappers.GitHubWrapper, org contributorOrg) ([]contributorRepository, error) {
+	gitHubURL := orgURL(org, githubAPIURL)
+	token := orgToken(org)
+
+	var repositories []wrappers.Repository
+	if len(org.Repos) > 0 {
+		for _, repo := range org.Repos {
+			repository, err := gitHubWrapper.GetRepository(gitHubURL, org.Name, repo, token)
+			if err != nil {
+				return nil, err
+			}
+			repositories = append(repositories, repository)
+		}
+	} else {
+		organization, err := gitHubWrapper.GetOrganization(gitHubURL, org.Name, token)
+		if err != nil {
+			return nil, err
+		}
+		repositories, err = gitHubWrapper.GetRepositories(organization, token)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var contributorRepositories []contributorRepository
+	for _, repository := range repositories {
+		contributorRepositories = append(contributorRepositories, contributorRepository{
+			name: repository.FullName,
+			fetch: func() ([]contributorCommit, error) {
+				commits, err := gitHubWrapper.GetCommits(repository, token, windowQueryParams())
+				if err != nil {
+					return nil, err
+				}
+				var contributorCommits []contributorCommit
+				for _, commit := range commits {
+					if !isNotBot(commit) {
+						continue
+					}
+					contributor := contributorCommit{Name: commit.Commit.CommitAuthor.Name, Email: commit.Commit.CommitAuthor.Email}
+					if commit.Author != nil {
+						contributor.Login = commit.Author.Login
+					}
+					contributorCommits = append(contributorCommits, contributor)
+				}
+				return contributorCommits, nil
+			},
+		})
+	}
+	return contributorRepositories, nil
+}
+
+func listGitLabRepositories(gitLabWrapper wrappers.GitLabWrapper, org contributorOrg) ([]contributorRepository, error) {
+	gitLabURL := orgURL(org, gitLabAPIURL)
+	token := orgToken(org)
+
+	var projects []string
+	if len(org.Repos) > 0 {
+		for _, repo := range org.Repos {
+			projects = append(projects, org.Name+"/"+repo)
+		}
+	} else {
+		gitLabProjects, err := gitLabWrapper.GetGitLabProjects(gitLabURL, org.Name, token, map[string]string{})
+		if err != nil {
+			return nil, err
+		}
+		for _, gitLabProject := range gitLabProjects {
+			if gitLabProject.EmptyRepo ||
+				strings.EqualFold(gitLabProject.RepoAccessLevel, repoAccessLevelDisabled) ||
+				strings.EqualFold(gitLabProject.RepoAccessLevel, repoAccessLevelPrivate) {
+				logger.PrintIfVerbose(fmt.Sprintf("Skipping the project %s because of empty repository.", gitLabProject.PathWithNameSpace))
+				continue
+			}
+			projects = append(projects, gitLabProject.PathWithNameSpace)
+		}
+	}
+
+	var contributorRepositories []contributorRepository
+	for _, project := range projects {
+		contributorRepositories = append(contributorRepositories, contributorRepository{
+			name: project,
+			fetch: func() ([]contributorCommit, error) {
+				commits, err := gitLabWrapper.GetCommits(gitLabURL, project, token, windowQueryParams())
+				if err != nil {
+					return nil, err
+				}
+				var contributorCommits []contributorCommit
+				for _, commit := range commits {
+					if !isNotGitLabBot(commit) {
+						contributorCommits = append(contributorCommits, contributorCommit{Name: commit.Name, Email: commit.Email})
+					}
+				}
+				return contributorCommits, nil
+			},
+		})
+	}
+	return contributorRepositories, nil
+}
+
+func listAzureRepositories(azureWrapper wrappers.AzureWrapper, org contributorOrg) ([]contributorRepository, error) {
+	azureURL := orgURL(org, azureAPIURL)
+	token := orgToken(org)
+
+	projects := org.Projects
+	if len(projects) == 0 {
+		azureProjects, err := azureWrapper.GetProjects(azureURL, org.Name, token)
+		if err != nil {
+			return nil, err
+		}
+		for _, project := range azureProjects.Projects {
+			projects = append(projects, project.Name)
+		}
+	}
+
+	var contributorRepositories []contributorRepository
+	for _, project := range projects {
+		repos := org.Repos
+		if len(repos) == 0 {
+			azureRepos, err := azureWrapper.GetRepositories(azureURL, org.Name, project, token)
+			if err != nil {
+				return nil, err
+			}
+			for _, repo := range azureRepos.GetEnabledRepos().Repos {
+				repos = append(repos, repo.Name)
+			}
+		}
+		for _, repo := range repos {
+			contributorRepositories = append(contributorRepositories, contributorRepository{
+				name: buildCountPath(org.Name, project, repo),
+				fetch: func() ([]contributorCommit, error) {
+					commits, err := azureWrapper.GetCommits(azureURL, org.Name, project, repo, token, window)
+					if err != nil {
+						return nil, err
+					}
+					var contributorCommits []contributorCommit
+					for _, commit := range commits.Commits {
+						if !azureIsNotBot(commit) {
+							contributorCommits = append(contributorCommits, contributorCommit{Name: commit.Author.Name, Email: commit.Author.Email})
+						}
+					}
+					return contributorCommits, nil
+				},
+			})
+		}
+	}
+	return contributorRepositories, nil
+}
+
+func listBitBucketRepositories(bitBucketWrapper wrappers.BitBucketWrapper, org contributorOrg) ([]contributorRepository, error) {
+	bitBucketURL := orgURL(org, bitbucketAPIURL)
+	password := orgToken(org)
+
+	workspace, err := bitBucketWrapper.GetworkspaceUUID(bitBucketURL, org.Name, org.Username, password)
+	if err != nil {
+		return nil, err
+	}
+
+	var repos []wrappers.BitBucketRepo
+	if len(org.Repos) > 0 {
+		for _, repo := range org.Repos {
+			repoObject, err := bitBucketWrapper.GetRepoUUID(bitBucketURL, org.Name, repo, org.Username, password)
+			if err != nil {
+				return nil, err
+			}
+			repos = append(repos, wrappers.BitBucketRepo{Name: buildBitBucketCountPath(org.Name, repo), UUID: repoObject.UUID})
+		}
+	} else {
+		reposList, err := bitBucketWrapper.GetRepositories(bitBucketURL, org.Name, org.Username, password)
+		if err != nil {
+			return nil, err
+		}
+		repos = reposList.Values
+	}
+
+	var contributorRepositories []contributorRepository
+	for _, repo := range repos {
+		contributorRepositories = append(contributorRepositories, contributorRepository{
+			name: repo.Name,
+			fetch: func() ([]contributorCommit, error) {
+				commits, err := bitBucketWrapper.GetCommits(bitBucketURL, workspace.UUID, repo.UUID, org.Username, password, window)
+				if err != nil {
+					return nil, err
+				}
+				var contributorCommits []contributorCommit
+				for _, commit := range commits.Commits {
+					if !bitBucketIsNotBot(commit) {
+						contributorCommits = append(contributorCommits, contributorCommit{
+							Name:  strings.TrimSpace(cleanUsername(commit.Author.Name)),
+							Email: cleanEmail(commit.Author.Name),
+						})
+					}
+				}
+				return contributorCommits, nil
+			},
+		})
+	}
+	return contributorRepositories, nil
+}
+
+func listBitBucketServerRepositories(
+	bitBucketServerWrapper bitbucketServerWrapper.Wrapper,
+	org contributorOrg,
+) ([]contributorRepository, error) {
+	serverURL := org.URL
+	if !strings.HasSuffix(serverURL, "/") {
+		serverURL += "/"
+	}
+	token := orgToken(org)
+
+	repos := org.Repos
+	if len(repos) == 0 {
+		serverRepos, err := bitBucketServerWrapper.GetRepositories(serverURL, org.Name, token)
+		if err != nil {
+			return nil, err
+		}
+		for _, repo := range serverRepos {
+			repos = append(repos, repo.Slug)
+		}
+	}
+
+	var contributorRepositories []contributorRepository
+	for _, repo := range repos {
+		contributorRepositories = append(contributorRepositories, contributorRepository{
+			name: org.Name + "/" + repo,
+			fetch: func() ([]contributorCommit, error) {
+				commits, err := bitBucketServerWrapper.GetCommits(serverURL, org.Name, repo, token, window)
+				if err != nil {
+					return nil, err
+				}
+				var contributorCommits []contributorCommit
+				for _, commit := range commits {
+					if !strings.Contains(commit.Author.Name, bitBucketBot) {
+						contributorCommits = append(contributorCommits, contributorCommit{Name: commit.Author.Name, Email: commit.Author.Email})
+					}
+				}
+				return contributorCommits, nil
+			},
+		})
+	}
+	return contributorRepositories, nil
+}
+
+func listGiteaRepositories(giteaWrapper wrappers.GiteaWrapper, org contributorOrg) ([]contributorRepository, error) {
+	serverURL := orgURL(org, giteaURL)
+	token := orgToken(org)
+
+	var repositories []wrappers.GiteaRepository
+	if len(org.Repos) > 0 {
+		for _, repo := range org.Repos {
+			repository, err := giteaWrapper.GetRepository(serverURL, org.Name, repo, token)
+			if err != nil {
+				return nil, err
+			}
+			repositories = append(repositories, repository)
+		}
+	} else {
+		var err error
+		repositories, err = giteaWrapper.GetRepositories(serverURL, org.Name, token)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var contributorRepositories []contributorRepository
+	for _, repository := range repositories {
+		// Listing the commits of an empty repository fails
+		if repository.Empty {
+			logger.PrintIfVerbose(fmt.Sprintf("Skipping the repository %s because of empty repository.", repository.FullName))
+			continue
+		}
+		contributorRepositories = append(contributorRepositories, contributorRepository{
+			name: repository.FullName,
+			fetch: func() ([]contributorCommit, error) {
+				commits, err := giteaWrapper.GetCommits(serverURL, repository, token, windowQueryParams())
+				if err != nil {
+					return nil, err
+				}
+				var contributorCommits []contributorCommit
+				for _, commit := range commits {
+					if giteaIsBot(commit) {
+						continue
+					}
+					contributor := contributorCommit{Name: commit.Commit.CommitAuthor.Name, Email: commit.Commit.CommitAuthor.Email}
+					if commit.Author != nil {
+						contributor.Login = commit.Author.Login
+					}
+					contributorCommits = append(contributorCommits, contributor)
+				}
+				return contributorCommits, nil
+			},
+		})
+	}
+	return contributorRepositories, nil
+}
@@ -0,0 +1,377 @@
+package usercount
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/checkmarx/ast-cli/internal/commands/util/printer"
+	"github.com/checkmarx/ast-cli/internal/logger"
+	"github.com/checkmarx/ast-cli/internal/params"
+	"github.com/checkmarx/ast-cli/internal/wrappers"
+	bitbucketServerWrapper "github.com/checkmarx/ast-cli/internal/wrappers/bitbucketserver"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"gopkg.in/yaml.v3"
+)
+
+// ContributorView is the activity of a contributor across every counted repository
+type ContributorView struct {
+	Email        string `json:"email"`
+	Name         string `json:"name"`
+	Repositories uint64 `json:"repositories"`
+	Commits      uint64 `json:"commits"`
+}
+
+// contributorSources is the YAML file listing the organizations counted by the all command
+type contributorSources struct {
+	Orgs []contributorOrg `yaml:"orgs"`
+	// Aliases maps an email or login to the email of the contributor it belongs to
+	Aliases map[string]string `yaml:"aliases"`
+}
+
+type contributorOrg struct {
+	Provider string   `yaml:"provider"`
+	Name     string   `yaml:"name"`
+	URL      string   `yaml:"url"`
+	TokenEnv string   `yaml:"token-env"`
+	Username string   `yaml:"username"`
+	Projects []string `yaml:"projects"`
+	Repos    []string `yaml:"repos"`
+}
+
+// contributorCommit is the author of a commit, whatever the SCM it was fetched from
+type contributorCommit struct {
+	Name  string `json:"name"`
+	Email string `json:"email"`
+	Login string `json:"login,omitempty"`
+}
+
+type contributorRepository struct {
+	name  string
+	fetch func() ([]contributorCommit, error)
+}
+
+const (
+	AllCommand                 = "all"
+	allShort                   = "The all command presents the unique contributors across the organizations of several SCMs, merging the identities of each developer"
+	orgsFileFlag               = "orgs-file"
+	orgsFileFlagUsage          = "YAML file listing the organizations to count per provider and the aliases of contributors"
+	cacheDirFlag               = "cache-dir"
+	cacheDirFlagUsage          = "Directory caching the commits fetched per repository and window, so an interrupted count resumes where it stopped"
+	allOutputPathFlagUsage     = "Directory to write the per-repository and per-contributor counts to as CSV files"
+	repositoriesCSVFile        = "contributors-repositories.csv"
+	contributorsCSVFile        = "contributors-users.csv"
+	bitbucketServerProvider    = "bitbucket-server"
+	missingOrgsFile            = "provide the YAML file listing the organizations with --" + orgsFileFlag
+	failedReadingOrgsFile      = "failed reading the organizations file"
+	noOrgsInFile               = "the organizations file doesn't list any organization"
+	unknownProvider            = "unknown provider %s for organization %s"
+	missingProviderOrg         = "a name is required for the %s organization"
+	missingBitbucketServerURL  = "a url is required for the bitbucket-server organization %s"
+	missingBitbucketUsername   = "a username is required for the bitbucket organization %s"
+	failedWritingCommitCache   = "failed writing the commit cache"
+	cachedCommitsLog           = "Using the cached commits of %s"
+	commitCacheDayLayout       = "2006-01-02"
+	cacheDirPermission         = 0o750
+	commitCacheFilePermissions = 0o600
+)
+
+var (
+	orgsFile, cacheDir, allOutputPath string
+	contributorProviders              = []string{
+		GithubCommand, GitLabCommand, AzureCommand, BitBucketCommand, bitbucketServerProvider, GiteaCommand,
+	}
+)
+
+func newUserCountAllCommand(
+	gitHubWrapper wrappers.GitHubWrapper,
+	azureWrapper wrappers.AzureWrapper,
+	bitBucketWrapper wrappers.BitBucketWrapper,
+	bitBucketServerWrapper bitbucketServerWrapper.Wrapper,
+	gitLabWrapper wrappers.GitLabWrapper,
+	giteaWrapper wrappers.GiteaWrapper,
+) *cobra.Command {
+	userCountCmd := &cobra.Command{
+		Use:     AllCommand,
+		Short:   allShort,
+		PreRunE: preRunAllUserCount,
+		RunE: createRunAllUserCountFunc(
+			gitHubWrapper, azureWrapper, bitBucketWrapper, bitBucketServerWrapper, gitLabWrapper, giteaWrapper,
+		),
+	}
+
+	userCountCmd.Flags().StringVar(&orgsFile, orgsFileFlag, "", orgsFileFlagUsage)
+	userCountCmd.Flags().StringVar(&cacheDir, cacheDirFlag, "", cacheDirFlagUsage)
+	userCountCmd.Flags().StringVar(&allOutputPath, params.TargetPathFlag, "", allOutputPathFlagUsage)
+
+	return userCountCmd
+}
+
+func preRunAllUserCount(*cobra.Command, []string) error {
+	if orgsFile == "" {
+		return errors.New(missingOrgsFile)
+	}
+	return nil
+}
+
+func createRunAllUserCountFunc(
+	gitHubWrapper wrappers.GitHubWrapper,
+	azureWrapper wrappers.AzureWrapper,
+	bitBucketWrapper wrappers.BitBucketWrapper,
+	bitBucketServerWrapper bitbucketServerWrapper.Wrapper,
+	gitLabWrapper wrappers.GitLabWrapper,
+	giteaWrapper wrappers.GiteaWrapper,
+) func(cmd *cobra.Command, args []string) error {
+	return func(cmd *cobra.Command, args []string) error {
+		sources, err := readContributorSources(orgsFile)
+		if err != nil {
+			return err
+		}
+
+		var views []RepositoryView
+		contributors := map[string]*ContributorView{}
+		for _, org := range sources.Orgs {
+			var repositories []contributorRepository
+			switch org.Provider {
+			case GithubCommand:
+				repositories, err = listGitHubRepositories(gitHubWrapper, org)
+			case GitLabCommand:
+				repositories, err = listGitLabRepositories(gitLabWrapper, org)
+			case AzureCommand:
+				repositories, err = listAzureRepositories(azureWrapper, org)
+			case BitBucketCommand:
+				repositories, err = listBitBucketRepositories(bitBucketWrapper, org)
+			case bitbucketServerProvider:
+				repositories, err = listBitBucketServerRepositories(bitBucketServerWrapper, org)
+			case GiteaCommand:
+				repositories, err = listGiteaRepositories(giteaWrapper, org)
+			}
+			if err != nil {
+				return err
+			}
+
+			for _, repository := range repositories {
+				commits, err := fetchContributorCommits(org, repository)
+				if err != nil {
+					return err
+				}
+				repositoryContributors := mergeContributors(contributors, commits, sources.Aliases)
+				views = append(
+					views,
+					RepositoryView{
+						Name:               org.Provider + "/" + repository.name,
+						UniqueContributors: uint64(repositoryContributors),
+					},
+				)
+			}
+		}
+
+		viewsContributors := make([]ContributorView, 0, len(contributors))
+		for _, contributor := range contributors {
+			viewsContributors = append(viewsContributors, *contributor)
+		}
+		sort.Slice(viewsContributors, func(i, j int) bool {
+			return viewsContributors[i].Email < viewsContributors[j].Email
+		})
+
+		views = append(
+			views,
+			RepositoryView{
+				Name:               TotalContributorsName,
+				UniqueContributors: uint64(len(viewsContributors)),
+			},
+		)
+
+		if allOutputPath != "" {
+			if err = writeContributorsCSV(allOutputPath, views, viewsContributors); err != nil {
+				return err
+			}
+		}
+
+		err = printer.Print(cmd.OutOrStdout(), views, format)
+
+		// Only print user count information if in debug mode
+		if viper.GetBool(params.DebugFlag) {
+			err = printer.Print(cmd.OutOrStdout(), viewsContributors, format)
+		}
+
+		log.Println(params.BotCount)
+
+		return err
+	}
+}
+
+func readContributorSources(path string) (*contributorSources, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, errors.Wrap(err, failedReadingOrgsFile)
+	}
+	var sources contributorSources
+	if err = yaml.Unmarshal(content, &sources); err != nil {
+		return nil, errors.Wrap(err, failedReadingOrgsFile)
+	}
+	if len(sources.Orgs) == 0 {
+		return nil, errors.New(noOrgsInFile)
+	}
+	for i := range sources.Orgs {
+		org := &sources.Orgs[i]
+		org.Provider = strings.ToLower(strings.TrimSpace(org.Provider))
+		if !slices.Contains(contributorProviders, org.Provider) {
+			return nil, errors.Errorf(unknownProvider, org.Provider, org.Name)
+		}
+		if org.Name == "" {
+			return nil, errors.Errorf(missingProviderOrg, org.Provider)
+		}
+		if org.Provider == bitbucketServerProvider && org.URL == "" {
+			return nil, errors.Errorf(missingBitbucketServerURL, org.Name)
+		}
+		if org.Provider == BitBucketCommand && org.Username == "" {
+			return nil, errors.Errorf(missingBitbucketUsername, org.Name)
+		}
+	}
+	aliases := make(map[string]string, len(sources.Aliases))
+	for alias, email := range sources.Aliases {
+		aliases[strings.ToLower(alias)] = strings.ToLower(email)
+	}
+	sources.Aliases = aliases
+	return &sources, nil
+}
+
+// mergeContributors adds the authors of commits to contributors, identifying each one by its aliased email, and
+// returns the number of distinct contributors of commits.
+func mergeContributors(contributors map[string]*ContributorView, commits []contributorCommit, aliases map[string]string) int {
+	repositoryContributors := map[string]bool{}
+	for _, commit := range commits {
+		identity := contributorIdentity(commit, aliases)
+		contributor, ok := contributors[identity]
+		if !ok {
+			contributor = &ContributorView{Email: identity, Name: commit.Name}
+			contributors[identity] = contributor
+		}
+		contributor.Commits++
+		if !repositoryContributors[identity] {
+			repositoryContributors[identity] = true
+			contributor.Repositories++
+		}
+	}
+	return len(repositoryContributors)
+}
+
+func contributorIdentity(commit contributorCommit, aliases map[string]string) string {
+	email := strings.ToLower(commit.Email)
+	login := strings.ToLower(commit.Login)
+	if identity, ok := aliases[email]; ok && email != "" {
+		return identity
+	}
+	if identity, ok := aliases[login]; ok && login != "" {
+		return identity
+	}
+	if email != "" {
+		return email
+	}
+	if login != "" {
+		return login
+	}
+	return strings.ToLower(commit.Name)
+}
+
+// fetchContributorCommits returns the commits of repository from the cache directory, fetching and caching them when
+// they were not fetched by a previous run with the same window.
+func fetchContributorCommits(org contributorOrg, repository contributorRepository) ([]contributorCommit, error) {
+	if cacheDir == "" {
+		return repository.fetch()
+	}
+
+	key := strings.Join(
+		[]string{
+			org.Provider,
+			org.URL,
+			repository.name,
+			window.Since.Format(commitCacheDayLayout),
+			window.Until.Format(commitCacheDayLayout),
+		},
+		"|",
+	)
+	hash := sha256.Sum256([]byte(key))
+	cachePath := filepath.Join(cacheDir, hex.EncodeToString(hash[:])+".json")
+
+	if content, err := os.ReadFile(cachePath); err == nil {
+		var commits []contributorCommit
+		if err = json.Unmarshal(content, &commits); err == nil {
+			logger.PrintIfVerbose(fmt.Sprintf(cachedCommitsLog, repository.name))
+			return commits, nil
+		}
+	}
+
+	commits, err := repository.fetch()
+	if err != nil {
+		return nil, err
+	}
+	if err = writeCommitCache(cachePath, commits); err != nil {
+		return nil, errors.Wrap(err, failedWritingCommitCache)
+	}
+	return commits, nil
+}
+
+// writeCommitCache writes commits to a temporary file renamed to cachePath, so an interrupted run never leaves a
+// partial cache entry behind.
+func writeCommitCache(cachePath string, commits []contributorCommit) error {
+	if commits == nil {
+		commits = []contributorCommit{}
+	}
+	content, err := json.Marshal(commits)
+	if err != nil {
+		return err
+	}
+	if err = os.MkdirAll(filepath.Dir(cachePath), cacheDirPermission); err != nil {
+		return err
+	}
+	file, err := os.CreateTemp(filepath.Dir(cachePath), filepath.Base(cachePath)+".*")
+	if err != nil {
+		return err
+	}
+	if _, err = file.Write(content); err != nil {
+		_ = file.Close()
+		_ = os.Remove(file.Name())
+		return err
+	}
+	if err = file.Close(); err != nil {
+		_ = os.Remove(file.Name())
+		return err
+	}
+	if err = os.Chmod(file.Name(), commitCacheFilePermissions); err != nil {
+		_ = os.Remove(file.Name())
+		return err
+	}
+	return os.Rename(file.Name(), cachePath)
+}
+
+func writeContributorsCSV(outputPath string, views []RepositoryView, viewsContributors []ContributorView) error {
+	if err := os.MkdirAll(outputPath, cacheDirPermission); err != nil {
+		return err
+	}
+	if err := writeCSV(filepath.Join(outputPath, repositoriesCSVFile), views); err != nil {
+		return err
+	}
+	return writeCSV(filepath.Join(outputPath, contributorsCSVFile), viewsContributors)
+}
+
+func writeCSV(path string, view interface{}) error {
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = file.Close()
+	}()
+	return printer.Print(file, view, printer.FormatCSV)
+}
@@ -0,0 +1,159 @@
+package usercount
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/checkmarx/ast-cli/internal/commands/util/printer"
+	"github.com/checkmarx/ast-cli/internal/params"
+	"github.com/checkmarx/ast-cli/internal/wrappers"
+	"github.com/checkmarx/ast-cli/internal/wrappers/mock"
+	"github.com/pkg/errors"
+	"gotest.tools/assert"
+)
+
+const allOrgsFile = `
+orgs:
+  - provider: azure
+    name: azure-org
+  - provider: gitea
+    name: gitea-org
+aliases:
+  MOCK Email: mock@example.com
+`
+
+type failingGiteaWrapper struct {
+	mock.GiteaMockWrapper
+}
+
+func (g failingGiteaWrapper) GetCommits(string, wrappers.GiteaRepository, string, map[string]string) ([]wrappers.GiteaCommit, error) {
+	return nil, errors.New("rate limit exceeded")
+}
+
+type gitHubOrgSpyWrapper struct {
+	mock.GitHubMockWrapper
+	urls, tokens []string
+}
+
+func (g *gitHubOrgSpyWrapper) GetOrganization(url, organizationName, token string) (wrappers.Organization, error) {
+	g.urls = append(g.urls, url)
+	g.tokens = append(g.tokens, token)
+	return wrappers.Organization{}, nil
+}
+
+func writeOrgsFile(t *testing.T, content string) string {
+	path := filepath.Join(t.TempDir(), "orgs.yaml")
+	assert.NilError(t, os.WriteFile(path, []byte(content), 0o600))
+	return path
+}
+
+func executeAllUserCount(giteaWrapper wrappers.GiteaWrapper, args ...string) (string, error) {
+	cmd := NewUserCountCommand(nil, mock.AzureMockWrapper{}, nil, nil, nil, giteaWrapper)
+	var output bytes.Buffer
+	cmd.SetOut(&output)
+	cmd.SetArgs(append([]string{AllCommand}, args...))
+	err := cmd.Execute()
+	return output.String(), err
+}
+
+func TestAllUserCountMergesAliases(t *testing.T) {
+	output, err := executeAllUserCount(
+		mock.GiteaMockWrapper{},
+		"--"+orgsFileFlag, writeOrgsFile(t, allOrgsFile),
+		"--"+params.FormatFlag, printer.FormatJSON,
+	)
+	assert.NilError(t, err)
+	var views []RepositoryView
+	assert.NilError(t, json.Unmarshal([]byte(output), &views))
+	// The Azure and Gitea authors are the same contributor through the alias of the Azure email
+	assert.DeepEqual(t, views, []RepositoryView{
+		{Name: "azure/azure-org/MOCK/MOCK REPO", UniqueContributors: 1},
+		{Name: "gitea/gitea-org/MOCK", UniqueContributors: 1},
+		{Name: TotalContributorsName, UniqueContributors: 1},
+	})
+}
+
+func TestAllUserCountCSV(t *testing.T) {
+	outputPath := t.TempDir()
+	_, err := executeAllUserCount(
+		mock.GiteaMockWrapper{},
+		"--"+orgsFileFlag, writeOrgsFile(t, allOrgsFile),
+		"--"+params.TargetPathFlag, outputPath,
+	)
+	assert.NilError(t, err)
+
+	repositories, err := os.ReadFile(filepath.Join(outputPath, repositoriesCSVFile))
+	assert.NilError(t, err)
+	assert.Equal(t, string(repositories), "Name,UniqueContributors\n"+
+		"azure/azure-org/MOCK/MOCK REPO,1\n"+
+		"gitea/gitea-org/MOCK,1\n"+
+		TotalContributorsName+",1\n")
+
+	contributors, err := os.ReadFile(filepath.Join(outputPath, contributorsCSVFile))
+	assert.NilError(t, err)
+	assert.Equal(t, string(contributors), "Email,Name,Repositories,Commits\nmock@example.com,MOCK NAME,2,3\n")
+}
+
+func TestAllUserCountResumesFromCache(t *testing.T) {
+	orgsFilePath := writeOrgsFile(t, allOrgsFile)
+	cachePath := t.TempDir()
+
+	_, err := executeAllUserCount(failingGiteaWrapper{}, "--"+orgsFileFlag, orgsFilePath, "--"+cacheDirFlag, cachePath)
+	assert.ErrorContains(t, err, "rate limit exceeded")
+
+	_, err = executeAllUserCount(mock.GiteaMockWrapper{}, "--"+orgsFileFlag, orgsFilePath, "--"+cacheDirFlag, cachePath)
+	assert.NilError(t, err)
+
+	// Once every repository is cached, counting again doesn't fetch commits
+	output, err := executeAllUserCount(
+		failingGiteaWrapper{},
+		"--"+orgsFileFlag, orgsFilePath,
+		"--"+cacheDirFlag, cachePath,
+		"--"+params.FormatFlag, printer.FormatCSV,
+	)
+	assert.NilError(t, err)
+	assert.Equal(t, output, "Name,UniqueContributors\n"+
+		"azure/azure-org/MOCK/MOCK REPO,1\n"+
+		"gitea/gitea-org/MOCK,1\n"+
+		TotalContributorsName+",1\n")
+}
+
+func TestAllUserCountGitHubOrgSettings(t *testing.T) {
+	t.Setenv("GHE_TOKEN", "ghe-token")
+	gitHubWrapper := &gitHubOrgSpyWrapper{}
+
+	_, err := listGitHubRepositories(gitHubWrapper, contributorOrg{Provider: GithubCommand, Name: "a"})
+	assert.NilError(t, err)
+	_, err = listGitHubRepositories(
+		gitHubWrapper,
+		contributorOrg{Provider: GithubCommand, Name: "b", URL: "https://ghe.example.com/api/v3", TokenEnv: "GHE_TOKEN"},
+	)
+	assert.NilError(t, err)
+
+	assert.DeepEqual(t, gitHubWrapper.urls, []string{githubAPIURL, "https://ghe.example.com/api/v3"})
+	assert.DeepEqual(t, gitHubWrapper.tokens, []string{"", "ghe-token"})
+}
+
+func TestAllUserCountInvalidOrgsFile(t *testing.T) {
+	_, err := executeAllUserCount(mock.GiteaMockWrapper{})
+	assert.ErrorContains(t, err, missingOrgsFile)
+
+	_, err = executeAllUserCount(mock.GiteaMockWrapper{}, "--"+orgsFileFlag, writeOrgsFile(t, "orgs:\n  - provider: svn\n    name: a\n"))
+	assert.ErrorContains(t, err, "unknown provider svn for organization a")
+
+	_, err = executeAllUserCount(mock.GiteaMockWrapper{}, "--"+orgsFileFlag, writeOrgsFile(t, "orgs:\n  - provider: bitbucket\n    name: a\n"))
+	assert.ErrorContains(t, err, "a username is required for the bitbucket organization a")
+}
+
+func TestUserCountInvalidWindow(t *testing.T) {
+	_, err := executeAllUserCount(
+		mock.GiteaMockWrapper{},
+		"--"+orgsFileFlag, writeOrgsFile(t, allOrgsFile),
+		"--"+params.SinceFlag, "2026-02-01",
+		"--"+params.UntilFlag, "2026-01-01",
+	)
+	assert.ErrorContains(t, err, "must be before")
+}
@@ -125,7 +125,7 @@ func collectFromAzureRepos(azureWrapper wrappers.AzureWrapper) (
 	for _, org := range AzureOrgs {
 		for _, project := range AzureProject {
 			for _, repo := range AzureRepos {
-				commits, err := azureWrapper.GetCommits(*AzureURL, org, project, repo, *AzureToken)
+				commits, err := azureWrapper.GetCommits(*AzureURL, org, project, repo, *AzureToken, window)
 				if err != nil {
 					return totalCommits, views, viewsUsers, err
 				}
@@ -176,7 +176,7 @@ func collectFromAzureProject(azureWrapper wrappers.AzureWrapper) (
 			}
 			// For each repo within the project fetch the commits
 			for _, repo := range repos.Repos {
-				commits, err := azureWrapper.GetCommits(*AzureURL, org, project, repo.Name, *AzureToken)
+				commits, err := azureWrapper.GetCommits(*AzureURL, org, project, repo.Name, *AzureToken, window)
 				if err != nil {
 					return totalCommits, views, viewsUsers, err
 				}
@@ -228,7 +228,7 @@ func collectFromAzureOrg(azureWrapper wrappers.AzureWrapper) (
 			}
 			// For each repo within the project fetch the commits
 			for _, repo := range repos.Repos {
-				commits, err := azureWrapper.GetCommits(*AzureURL, org, project.Name, repo.Name, *AzureToken)
+				commits, err := azureWrapper.GetCommits(*AzureURL, org, project.Name, repo.Name, *AzureToken, window)
 				if err != nil {
 					return totalCommits, views, viewsUsers, err
 				}
@@ -123,7 +123,7 @@ func collectFromBitBucketRepos(bitBucketWrapper wrappers.BitBucketWrapper) ([]wr
 			if err != nil {
 				return totalCommits, views, viewsUsers, err
 			}
-			commits, err := bitBucketWrapper.GetCommits(*BitBucketURL, workspaceUUID.UUID, repoObject.UUID, *BitBucketUsername, *BitBucketPassword)
+			commits, err := bitBucketWrapper.GetCommits(*BitBucketURL, workspaceUUID.UUID, repoObject.UUID, *BitBucketUsername, *BitBucketPassword, window)
 			if err != nil {
 				return totalCommits, views, viewsUsers, err
 			}
@@ -174,7 +174,7 @@ func collectFromBitBucketWorkspace(bitBucketWrapper wrappers.BitBucketWrapper) (
 		}
 		for _, repo := range reposList.Values {
 			// Get commits for a specific repo
-			commits, err := bitBucketWrapper.GetCommits(*BitBucketURL, workspaceUUID.UUID, repo.UUID, *BitBucketUsername, *BitBucketPassword)
+			commits, err := bitBucketWrapper.GetCommits(*BitBucketURL, workspaceUUID.UUID, repo.UUID, *BitBucketUsername, *BitBucketPassword, window)
 			if err != nil {
 				return totalCommits, views, viewsUsers, err
 			}
@@ -11,6 +11,7 @@ import (
 
 	"github.com/checkmarx/ast-cli/internal/commands/util/printer"
 	"github.com/checkmarx/ast-cli/internal/params"
+	"github.com/checkmarx/ast-cli/internal/wrappers"
 	"github.com/checkmarx/ast-cli/internal/wrappers/bitbucketserver"
 	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
@@ -48,9 +49,14 @@ var (
 	bitbucketServerURL      *string
 	bitbucketServerToken    *string
 	format                  string
+	window                  *wrappers.CommitWindow
 )
 
-func NewUserCountBitBucketServerCommand(bitBucketServerWrapper bitbucketserver.Wrapper) *cobra.Command {
+// NewUserCountBitBucketServerCommand counts the contributors of the commits inside commitWindow, which is set before
+// the command runs.
+func NewUserCountBitBucketServerCommand(bitBucketServerWrapper bitbucketserver.Wrapper, commitWindow *wrappers.CommitWindow) *cobra.Command {
+	window = commitWindow
+
 	userCountCmd := &cobra.Command{
 		Use:     bitBucketServerCommandName,
 		Short:   bitBucketServerCommandShort,
@@ -81,7 +87,7 @@ func NewUserCountBitBucketServerCommand(bitBucketServerWrapper bitbucketserver.W
 		printer.FormatTable,
 		fmt.Sprintf(
 			params.FormatFlagUsageFormat,
-			[]string{printer.FormatTable, printer.FormatJSON, printer.FormatList},
+			[]string{printer.FormatTable, printer.FormatJSON, printer.FormatList, printer.FormatCSV},
 		),
 	)
 
@@ -201,6 +207,7 @@ func searchRepos(
 			project,
 			repo,
 			*bitbucketServerToken,
+			*window,
 		)
 		if err != nil {
 			log.Printf("Skipping repository %s/%s: Repository is corrupted (error: %v)", project, repo, err)
@@ -0,0 +1,169 @@
+package usercount
+
+import (
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/checkmarx/ast-cli/internal/commands/util/printer"
+	"github.com/checkmarx/ast-cli/internal/logger"
+	"github.com/checkmarx/ast-cli/internal/params"
+	"github.com/checkmarx/ast-cli/internal/wrappers"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+const (
+	GiteaCommand = "gitea"
+	giteaShort   = "The gitea command presents the unique contributors for the provided Gitea or Forgejo repositories or organizations"
+	giteaURL     = "https://gitea.com"
+	giteaBot     = "[bot]"
+)
+
+var (
+	giteaRepos, giteaOrgs []string
+	giteaServerURL        *string
+	giteaToken            *string
+)
+
+func newUserCountGiteaCommand(giteaWrapper wrappers.GiteaWrapper) *cobra.Command {
+	userCountCmd := &cobra.Command{
+		Use:     GiteaCommand,
+		Short:   giteaShort,
+		PreRunE: preRunGiteaUserCount,
+		RunE:    createRunGiteaUserCountFunc(giteaWrapper),
+	}
+
+	userCountCmd.Flags().StringSliceVar(&giteaRepos, ReposFlag, []string{}, reposFlagUsage)
+	userCountCmd.Flags().StringSliceVar(&giteaOrgs, OrgsFlag, []string{}, orgsFlagUsage)
+	giteaServerURL = userCountCmd.Flags().String(params.GiteaURLFlag, giteaURL, params.GiteaURLFlagUsage)
+	giteaToken = userCountCmd.Flags().String(params.SCMTokenFlag, "", params.GiteaTokenUsage)
+
+	return userCountCmd
+}
+
+func preRunGiteaUserCount(*cobra.Command, []string) error {
+	if len(giteaRepos) == 0 && len(giteaOrgs) == 0 {
+		return errors.New(missingArgs)
+	}
+
+	if len(giteaRepos) > 0 && len(giteaOrgs) == 0 {
+		return errors.New(missingOrg)
+	}
+
+	if len(giteaRepos) > 0 && len(giteaOrgs) > 1 {
+		return errors.New(tooManyOrgs)
+	}
+
+	return nil
+}
+
+func createRunGiteaUserCountFunc(giteaWrapper wrappers.GiteaWrapper) func(cmd *cobra.Command, args []string) error {
+	return func(cmd *cobra.Command, args []string) error {
+		var repositories []wrappers.GiteaRepository
+
+		if len(giteaRepos) > 0 {
+			for _, repo := range giteaRepos {
+				repository, err := giteaWrapper.GetRepository(*giteaServerURL, giteaOrgs[0], repo, *giteaToken)
+				if err != nil {
+					return err
+				}
+				repositories = append(repositories, repository)
+			}
+		} else {
+			for _, org := range giteaOrgs {
+				orgRepositories, err := giteaWrapper.GetRepositories(*giteaServerURL, org, *giteaToken)
+				if err != nil {
+					return err
+				}
+				repositories = append(repositories, orgRepositories...)
+			}
+		}
+
+		totalCommits, views, viewsUsers, err := collectFromGiteaRepositories(giteaWrapper, repositories)
+		if err != nil {
+			return err
+		}
+
+		views = append(
+			views,
+			RepositoryView{
+				Name:               TotalContributorsName,
+				UniqueContributors: uint64(len(getGiteaUniqueContributors(totalCommits))),
+			},
+		)
+
+		err = printer.Print(cmd.OutOrStdout(), views, format)
+
+		// Only print user count information if in debug mode
+		if viper.GetBool(params.DebugFlag) {
+			err = printer.Print(cmd.OutOrStdout(), viewsUsers, format)
+		}
+
+		log.Println(params.BotCount)
+
+		return err
+	}
+}
+
+func collectFromGiteaRepositories(giteaWrapper wrappers.GiteaWrapper, repositories []wrappers.GiteaRepository) (
+	[]wrappers.GiteaCommit, []RepositoryView, []UserView, error,
+) {
+	var totalCommits []wrappers.GiteaCommit
+	var views []RepositoryView
+	var viewsUsers []UserView
+
+	for _, repository := range repositories {
+		// Listing the commits of an empty repository fails
+		if repository.Empty {
+			logger.PrintIfVerbose(fmt.Sprintf("Skipping the repository %s because of empty repository.", repository.FullName))
+			continue
+		}
+
+		commits, err := giteaWrapper.GetCommits(*giteaServerURL, repository, *giteaToken, windowQueryParams())
+		if err != nil {
+			return totalCommits, views, viewsUsers, err
+		}
+
+		totalCommits = append(totalCommits, commits...)
+
+		uniqueContributorsMap := getGiteaUniqueContributors(commits)
+
+		views = append(
+			views,
+			RepositoryView{
+				Name:               repository.FullName,
+				UniqueContributors: uint64(len(uniqueContributorsMap)),
+			},
+		)
+		for email, name := range uniqueContributorsMap {
+			viewsUsers = append(
+				viewsUsers,
+				UserView{
+					Name:                       repository.FullName,
+					UniqueContributorsUsername: name,
+					UniqueContributorsEmail:    email,
+				},
+			)
+		}
+	}
+	return totalCommits, views, viewsUsers, nil
+}
+
+func getGiteaUniqueContributors(commits []wrappers.GiteaCommit) map[string]string {
+	var contributors = map[string]string{}
+	for _, commit := range commits {
+		name := commit.Commit.CommitAuthor.Name
+		email := strings.ToLower(commit.Commit.CommitAuthor.Email)
+		if _, ok := contributors[email]; !ok && !giteaIsBot(commit) {
+			contributors[email] = name
+		}
+	}
+	return contributors
+}
+
+func giteaIsBot(commit wrappers.GiteaCommit) bool {
+	return strings.Contains(commit.Commit.CommitAuthor.Name, giteaBot) ||
+		(commit.Author != nil && strings.Contains(commit.Author.Login, giteaBot))
+}
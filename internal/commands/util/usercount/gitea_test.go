@@ -0,0 +1,89 @@
+package usercount
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/checkmarx/ast-cli/internal/commands/util/printer"
+	"github.com/checkmarx/ast-cli/internal/params"
+	"github.com/checkmarx/ast-cli/internal/wrappers"
+	"github.com/checkmarx/ast-cli/internal/wrappers/mock"
+	"gotest.tools/assert"
+)
+
+func TestGiteaUserCountOrgs(t *testing.T) {
+	cmd := NewUserCountCommand(nil, nil, nil, nil, nil, mock.GiteaMockWrapper{})
+	assert.Assert(t, cmd != nil, "Gitea user count command must exist")
+	var output bytes.Buffer
+	cmd.SetOut(&output)
+
+	cmd.SetArgs(
+		[]string{
+			GiteaCommand,
+			"--" + OrgsFlag,
+			"a,b",
+			"--" + params.FormatFlag,
+			printer.FormatJSON,
+		},
+	)
+
+	err := cmd.Execute()
+	assert.NilError(t, err)
+	var views []RepositoryView
+	assert.NilError(t, json.Unmarshal(output.Bytes(), &views))
+	// The empty repositories are skipped and the bot isn't counted
+	assert.DeepEqual(t, views, []RepositoryView{
+		{Name: "a/MOCK", UniqueContributors: 1},
+		{Name: "b/MOCK", UniqueContributors: 1},
+		{Name: TotalContributorsName, UniqueContributors: 1},
+	})
+}
+
+func TestGiteaUserCountRepos(t *testing.T) {
+	cmd := NewUserCountCommand(nil, nil, nil, nil, nil, mock.GiteaMockWrapper{})
+	assert.Assert(t, cmd != nil, "Gitea user count command must exist")
+
+	cmd.SetArgs(
+		[]string{
+			GiteaCommand,
+			"--" + OrgsFlag,
+			"a",
+			"--" + ReposFlag,
+			"a,b,c",
+			"--" + params.GiteaURLFlag,
+			"https://gitea.example.com",
+			"--" + params.FormatFlag,
+			printer.FormatJSON,
+		},
+	)
+
+	err := cmd.Execute()
+	assert.NilError(t, err)
+}
+
+func TestGiteaUserCountMissingArgs(t *testing.T) {
+	cmd := NewUserCountCommand(nil, nil, nil, nil, nil, mock.GiteaMockWrapper{})
+
+	cmd.SetArgs([]string{GiteaCommand, "--" + ReposFlag, "repo"})
+
+	err := cmd.Execute()
+	assert.Error(t, err, missingOrg)
+}
+
+func TestGiteaUserCountManyOrgs(t *testing.T) {
+	cmd := NewUserCountCommand(nil, nil, nil, nil, nil, mock.GiteaMockWrapper{})
+
+	cmd.SetArgs([]string{GiteaCommand, "--" + ReposFlag, "repo", "--" + OrgsFlag, "a,b"})
+
+	err := cmd.Execute()
+	assert.Error(t, err, tooManyOrgs)
+}
+
+func TestGiteaIsBot(t *testing.T) {
+	human := wrappers.GiteaCommit{Commit: wrappers.Commit{CommitAuthor: wrappers.CommitAuthor{Name: "Dev"}}}
+	botUser := wrappers.GiteaCommit{Commit: human.Commit, Author: &wrappers.GiteaUser{Login: "ci[bot]"}}
+
+	assert.Equal(t, giteaIsBot(human), false)
+	assert.Equal(t, giteaIsBot(botUser), true)
+}
@@ -31,6 +31,7 @@ const (
 	orgsFlagUsage  = "List of organizations to scan for contributors"
 	githubAPIURL   = "https://api.github.com"
 	sinceParam     = "since"
+	untilParam     = "until"
 	missingArgs    = "provide at least one repository or organization"
 	missingOrg     = "an organization is required for your repositories"
 	tooManyOrgs    = "a single organization should be provided for specific repositories"
@@ -84,11 +85,13 @@ func createRunGitHubUserCountFunc(gitHubWrapper wrappers.GitHubWrapper) func(cmd
 		var viewsUsers []UserView
 
 		_ = viper.BindPFlag(params.SCMTokenFlag, cmd.Flags().Lookup(params.SCMTokenFlag))
+		url := viper.GetString(params.URLFlag)
+		token := viper.GetString(params.SCMTokenFlag)
 
 		if len(repos) > 0 {
-			totalCommits, views, viewsUsers, err = collectFromRepos(gitHubWrapper)
+			totalCommits, views, viewsUsers, err = collectFromRepos(gitHubWrapper, url, token)
 		} else {
-			totalCommits, views, viewsUsers, err = collectFromOrgs(gitHubWrapper)
+			totalCommits, views, viewsUsers, err = collectFromOrgs(gitHubWrapper, url, token)
 		}
 		if err != nil {
 			return err
@@ -117,17 +120,17 @@ func createRunGitHubUserCountFunc(gitHubWrapper wrappers.GitHubWrapper) func(cmd
 	}
 }
 
-func collectFromRepos(gitHubWrapper wrappers.GitHubWrapper) ([]wrappers.CommitRoot, []RepositoryView, []UserView, error) {
+func collectFromRepos(gitHubWrapper wrappers.GitHubWrapper, url, token string) ([]wrappers.CommitRoot, []RepositoryView, []UserView, error) {
 	var totalCommits []wrappers.CommitRoot
 	var views []RepositoryView
 	var viewsUsers []UserView
 	for _, repo := range repos {
-		repository, err := gitHubWrapper.GetRepository(orgs[0], repo)
+		repository, err := gitHubWrapper.GetRepository(url, orgs[0], repo, token)
 		if err != nil {
 			return totalCommits, views, viewsUsers, err
 		}
 
-		commits, err := gitHubWrapper.GetCommits(repository, map[string]string{sinceParam: ninetyDaysDate})
+		commits, err := gitHubWrapper.GetCommits(repository, token, windowQueryParams())
 		if err != nil {
 			return totalCommits, views, viewsUsers, err
 		}
@@ -159,24 +162,24 @@ func collectFromRepos(gitHubWrapper wrappers.GitHubWrapper) ([]wrappers.CommitRo
 	return totalCommits, views, viewsUsers, nil
 }
 
-func collectFromOrgs(gitHubWrapper wrappers.GitHubWrapper) ([]wrappers.CommitRoot, []RepositoryView, []UserView, error) {
+func collectFromOrgs(gitHubWrapper wrappers.GitHubWrapper, url, token string) ([]wrappers.CommitRoot, []RepositoryView, []UserView, error) {
 	var totalCommits []wrappers.CommitRoot
 	var views []RepositoryView
 	var viewsUsers []UserView
 
 	for _, org := range orgs {
-		organization, err := gitHubWrapper.GetOrganization(org)
+		organization, err := gitHubWrapper.GetOrganization(url, org, token)
 		if err != nil {
 			return totalCommits, views, viewsUsers, err
 		}
 
-		repositories, err := gitHubWrapper.GetRepositories(organization)
+		repositories, err := gitHubWrapper.GetRepositories(organization, token)
 		if err != nil {
 			return totalCommits, views, viewsUsers, err
 		}
 
 		for _, repository := range repositories {
-			commits, err := gitHubWrapper.GetCommits(repository, map[string]string{sinceParam: ninetyDaysDate})
+			commits, err := gitHubWrapper.GetCommits(repository, token, windowQueryParams())
 			if err != nil {
 				return totalCommits, views, viewsUsers, err
 			}
@@ -66,16 +66,18 @@ func createRunGitLabUserCountFunc(gitLabWrapper wrappers.GitLabWrapper) func(cmd
 		var viewsUsers []UserView
 
 		_ = viper.BindPFlag(params.SCMTokenFlag, cmd.Flags().Lookup(params.SCMTokenFlag))
+		url := viper.GetString(params.GitLabURLFlag)
+		token := viper.GetString(params.SCMTokenFlag)
 
 		if len(gitLabProjects) > 0 {
 			log.Println("Collecting the commits from GitLab projects only...")
-			totalCommits, views, viewsUsers, err = collectFromGitLabProjects(gitLabWrapper)
+			totalCommits, views, viewsUsers, err = collectFromGitLabProjects(gitLabWrapper, url, token)
 		} else if len(gitLabGroups) > 0 {
 			log.Println("Collecting the commits from GitLab groups only...")
-			totalCommits, views, viewsUsers, err = collectFromGitLabGroups(gitLabWrapper)
+			totalCommits, views, viewsUsers, err = collectFromGitLabGroups(gitLabWrapper, url, token)
 		} else {
 			log.Println("Collecting the commits from User's projects only...")
-			totalCommits, views, viewsUsers, err = collectFromUser(gitLabWrapper)
+			totalCommits, views, viewsUsers, err = collectFromUser(gitLabWrapper, url, token)
 		}
 
 		if err != nil {
@@ -103,7 +105,7 @@ func createRunGitLabUserCountFunc(gitLabWrapper wrappers.GitLabWrapper) func(cmd
 	}
 }
 
-func collectFromGitLabProjects(gitLabWrapper wrappers.GitLabWrapper) (
+func collectFromGitLabProjects(gitLabWrapper wrappers.GitLabWrapper, url, token string) (
 	[]wrappers.GitLabCommit, []RepositoryView, []UserView, error,
 ) {
 	var totalCommits []wrappers.GitLabCommit
@@ -114,7 +116,7 @@ func collectFromGitLabProjects(gitLabWrapper wrappers.GitLabWrapper) (
 		if strings.TrimSpace(gitLabProjectName) == "" {
 			log.Println("Ignoring the blank value for project name.")
 		} else {
-			commits, err := gitLabWrapper.GetCommits(gitLabProjectName, map[string]string{sinceParam: ninetyDaysDate})
+			commits, err := gitLabWrapper.GetCommits(url, gitLabProjectName, token, windowQueryParams())
 			if err != nil {
 				return totalCommits, views, viewsUsers, err
 			}
@@ -127,7 +129,7 @@ func collectFromGitLabProjects(gitLabWrapper wrappers.GitLabWrapper) (
 	return totalCommits, views, viewsUsers, nil
 }
 
-func collectFromGitLabGroups(gitLabWrapper wrappers.GitLabWrapper) (
+func collectFromGitLabGroups(gitLabWrapper wrappers.GitLabWrapper, url, token string) (
 	[]wrappers.GitLabCommit, []RepositoryView, []UserView, error,
 ) {
 	var totalCommits []wrappers.GitLabCommit
@@ -139,7 +141,9 @@ func collectFromGitLabGroups(gitLabWrapper wrappers.GitLabWrapper) (
 			log.Println("Ignoring the blank value for group name.")
 		} else {
 			gitLabProjects, err := gitLabWrapper.GetGitLabProjects(
+				url,
 				strings.TrimSpace(gitLabGroupName),
+				token,
 				map[string]string{})
 
 			if err != nil {
@@ -158,8 +162,10 @@ func collectFromGitLabGroups(gitLabWrapper wrappers.GitLabWrapper) (
 							gitLabProject.PathWithNameSpace))
 				} else {
 					commits, err := gitLabWrapper.GetCommits(
+						url,
 						gitLabProject.PathWithNameSpace,
-						map[string]string{sinceParam: ninetyDaysDate})
+						token,
+						windowQueryParams())
 					if err != nil {
 						return totalCommits, views, viewsUsers, err
 					}
@@ -176,14 +182,14 @@ func collectFromGitLabGroups(gitLabWrapper wrappers.GitLabWrapper) (
 	return totalCommits, views, viewsUsers, nil
 }
 
-func collectFromUser(gitLabWrapper wrappers.GitLabWrapper) (
+func collectFromUser(gitLabWrapper wrappers.GitLabWrapper, url, token string) (
 	[]wrappers.GitLabCommit, []RepositoryView, []UserView, error,
 ) {
 	var totalCommits []wrappers.GitLabCommit
 	var views []RepositoryView
 	var viewsUsers []UserView
 
-	gitLabProjects, err := gitLabWrapper.GetGitLabProjectsForUser()
+	gitLabProjects, err := gitLabWrapper.GetGitLabProjectsForUser(url, token)
 	if err != nil {
 		return totalCommits, views, viewsUsers, err
 	}
@@ -197,8 +203,10 @@ func collectFromUser(gitLabWrapper wrappers.GitLabWrapper) (
 					"Skipping the project %s because of empty repository.", gitLabProject.PathWithNameSpace))
 		} else {
 			commits, err := gitLabWrapper.GetCommits(
+				url,
 				gitLabProject.PathWithNameSpace,
-				map[string]string{sinceParam: ninetyDaysDate})
+				token,
+				windowQueryParams())
 			if err != nil {
 				return totalCommits, views, viewsUsers, err
 			}
@@ -14,13 +14,14 @@ import (
 
 const (
 	UcCommand             = "contributor-count"
-	UcShort               = "The contributor-count command enables the ability to count unique contributors from different SCM repositories, for the past 90 days"
+	UcShort               = "The contributor-count command enables the ability to count unique contributors from different SCM repositories, for the past 90 days by default"
 	TotalContributorsName = "Total unique contributors"
 )
 
 var (
-	ninetyDaysDate, format string
-	hiddenFlags            = []string{
+	since, until, format string
+	window               wrappers.CommitWindow
+	hiddenFlags          = []string{
 		params.AgentFlag,
 		params.AstAPIKeyFlag,
 		params.BaseAuthURIFlag,
@@ -40,6 +41,7 @@ func NewUserCountCommand(
 	bitBucketWrapper wrappers.BitBucketWrapper,
 	bitBucketServerWrapper bitbucketServerWrapper.Wrapper,
 	gitLabWrapper wrappers.GitLabWrapper,
+	giteaWrapper wrappers.GiteaWrapper,
 ) *cobra.Command {
 	userCountCmd := &cobra.Command{
 		Use:   UcCommand,
@@ -64,6 +66,12 @@ func NewUserCountCommand(
 
 	userCountCmd.AddCommand(newUserCountGitLabCommand(gitLabWrapper))
 
+	userCountCmd.AddCommand(newUserCountGiteaCommand(giteaWrapper))
+
+	userCountCmd.AddCommand(
+		newUserCountAllCommand(gitHubWrapper, azureWrapper, bitBucketWrapper, bitBucketServerWrapper, gitLabWrapper, giteaWrapper),
+	)
+
 	for _, cmd := range userCountCmd.Commands() {
 		cmd.Flags().StringVar(
 			&format,
@@ -71,15 +79,41 @@ func NewUserCountCommand(
 			printer.FormatTable,
 			fmt.Sprintf(
 				params.FormatFlagUsageFormat,
-				[]string{printer.FormatTable, printer.FormatJSON, printer.FormatList},
+				[]string{printer.FormatTable, printer.FormatJSON, printer.FormatList, printer.FormatCSV},
 			),
 		)
 	}
 
-	userCountCmd.AddCommand(bitbucketserver.NewUserCountBitBucketServerCommand(bitBucketServerWrapper))
+	userCountCmd.AddCommand(bitbucketserver.NewUserCountBitBucketServerCommand(bitBucketServerWrapper, &window))
 
-	// subtract ninety days from current date
-	ninetyDaysDate = time.Now().UTC().Add(-90 * 24 * time.Hour).Format(time.RFC3339)
+	userCountCmd.PersistentFlags().StringVar(&since, params.SinceFlag, "", params.SinceFlagUsage)
+	userCountCmd.PersistentFlags().StringVar(&until, params.UntilFlag, "", params.UntilFlagUsage)
+	for _, cmd := range userCountCmd.Commands() {
+		cmd.PreRunE = withCommitWindow(cmd.PreRunE)
+	}
 
 	return userCountCmd
 }
+
+// withCommitWindow parses the --since and --until flags into the window before running preRun
+func withCommitWindow(preRun func(*cobra.Command, []string) error) func(*cobra.Command, []string) error {
+	return func(cmd *cobra.Command, args []string) error {
+		var err error
+		window, err = wrappers.NewCommitWindow(since, until)
+		if err != nil {
+			return err
+		}
+		if preRun == nil {
+			return nil
+		}
+		return preRun(cmd, args)
+	}
+}
+
+// windowQueryParams returns the query parameters limiting the listed commits to the window
+func windowQueryParams() map[string]string {
+	return map[string]string{
+		sinceParam: window.Since.Format(time.RFC3339),
+		untilParam: window.Until.Format(time.RFC3339),
+	}
+}
@@ -32,6 +32,7 @@ func NewUtilsCommand(
 	bitBucketWrapper wrappers.BitBucketWrapper,
 	bitBucketServerWrapper bitbucketserver.Wrapper,
 	gitLabWrapper wrappers.GitLabWrapper,
+	giteaWrapper wrappers.GiteaWrapper,
 	prWrapper wrappers.PRWrapper,
 	learnMoreWrapper wrappers.LearnMoreWrapper,
 	tenantWrapper wrappers.TenantConfigurationWrapper,
@@ -46,6 +47,9 @@ func NewUtilsCommand(
 	applicationsWrapper wrappers.ApplicationsWrapper,
 	byorWrapper wrappers.ByorWrapper,
 	featureFlagsWrapper wrappers.FeatureFlagsWrapper,
+	resultsWrapper wrappers.ResultsWrapper,
+	exportWrapper wrappers.ExportWrapper,
+	risksOverviewWrapper wrappers.RisksOverviewWrapper,
 ) *cobra.Command {
 	utilsCmd := &cobra.Command{
 		Use:   "utils",
@@ -71,9 +75,9 @@ func NewUtilsCommand(
 
 	completionCmd := NewCompletionCommand()
 
-	prDecorationCmd := NewPRDecorationCommand(prWrapper, policyWrapper, scansWrapper)
+	prDecorationCmd := NewPRDecorationCommand(prWrapper, policyWrapper, scansWrapper, resultsWrapper, risksOverviewWrapper, featureFlagsWrapper)
 
-	remediationCmd := NewRemediationCommand()
+	remediationCmd := NewRemediationCommand(resultsWrapper, exportWrapper, featureFlagsWrapper)
 
 	learnMoreCmd := NewLearnMoreCommand(learnMoreWrapper)
 
@@ -81,6 +85,8 @@ func NewUtilsCommand(
 
 	maskSecretsCmd := NewMaskSecretsCommand(chatWrapper)
 
+	ciCmd := NewCICommand()
+
 	utilsCmd.AddCommand(
 		completionCmd,
 		envCheckCmd,
@@ -91,12 +97,14 @@ func NewUtilsCommand(
 			bitBucketWrapper,
 			bitBucketServerWrapper,
 			gitLabWrapper,
+			giteaWrapper,
 		),
 		prDecorationCmd,
 		remediationCmd,
 		tenantCmd,
 		maskSecretsCmd,
 		importCmd,
+		ciCmd,
 	)
 
 	return utilsCmd
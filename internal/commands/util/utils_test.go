@@ -19,6 +19,7 @@ func TestNewUtilsCommand(t *testing.T) {
 		mock.BitBucketMockWrapper{},
 		nil,
 		mock.GitLabMockWrapper{},
+		mock.GiteaMockWrapper{},
 		nil,
 		mock.LearnMoreMockWrapper{},
 		mock.TenantConfigurationMockWrapper{},
@@ -32,7 +33,10 @@ func TestNewUtilsCommand(t *testing.T) {
 		mock.AccessManagementMockWrapper{},
 		mock.ApplicationsMockWrapper{},
 		&mock.ByorMockWrapper{},
-		&mock.FeatureFlagsMockWrapper{})
+		&mock.FeatureFlagsMockWrapper{},
+		&mock.ResultsMockWrapper{},
+		&mock.ExportMockWrapper{},
+		mock.RisksOverviewMockWrapper{})
 
 	assert.Assert(t, cmd != nil, "Utils command must exist")
 }
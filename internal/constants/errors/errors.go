@@ -25,6 +25,7 @@ const (
 	FailedUploadFileMsgWithDomain          = "Unable to upload the file to the pre-signed URL. Try adding the domain: %s to your allow list."
 	FailedUploadFileMsgWithURL             = "Unable to upload the file to the pre-signed URL. Try adding the URL: %s to your allow list."
 	NoPermissionToUpdateApplication        = "you do not have permission to update the application"
+	NoPermissionToCreateApplication        = "you do not have permission to create applications"
 	FailedToUpdateApplication              = "failed to update application"
 	ApplicationNotFound                    = "Application not found"
 	ErrMissingAIFeatureLicense             = "User does not have the required license for AI-assisted functionality."
@@ -0,0 +1,85 @@
+package logger
+
+import (
+	"fmt"
+	"os"
+	"sync"
+)
+
+const logFilePermissions = 0666
+
+// RotatingFile is a log file that is renamed to <path>.1 once it grows past maxSize bytes,
+// shifting older backups up to <path>.<maxBackups> and deleting the oldest one.
+type RotatingFile struct {
+	mutex      sync.Mutex
+	path       string
+	maxSize    int64
+	maxBackups int
+	file       *os.File
+	size       int64
+}
+
+// OpenRotatingFile opens path for appending. Rotation is disabled when maxSize is not positive.
+func OpenRotatingFile(path string, maxSize int64, maxBackups int) (*RotatingFile, error) {
+	r := &RotatingFile{path: path, maxSize: maxSize, maxBackups: maxBackups}
+	if err := r.open(); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+func (r *RotatingFile) Write(p []byte) (int, error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	if r.maxSize > 0 && r.size > 0 && r.size+int64(len(p)) > r.maxSize {
+		if err := r.rotate(); err != nil {
+			return 0, err
+		}
+	}
+	n, err := r.file.Write(p)
+	r.size += int64(n)
+	return n, err
+}
+
+func (r *RotatingFile) Close() error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	return r.file.Close()
+}
+
+func (r *RotatingFile) open() error {
+	file, err := os.OpenFile(r.path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, logFilePermissions)
+	if err != nil {
+		return err
+	}
+	info, err := file.Stat()
+	if err != nil {
+		_ = file.Close()
+		return err
+	}
+	r.file = file
+	r.size = info.Size()
+	return nil
+}
+
+func (r *RotatingFile) rotate() error {
+	if err := r.file.Close(); err != nil {
+		return err
+	}
+	if r.maxBackups > 0 {
+		_ = os.Remove(r.backupPath(r.maxBackups))
+		for i := r.maxBackups - 1; i > 0; i-- {
+			_ = os.Rename(r.backupPath(i), r.backupPath(i+1))
+		}
+		if err := os.Rename(r.path, r.backupPath(1)); err != nil {
+			return err
+		}
+	} else if err := os.Remove(r.path); err != nil {
+		return err
+	}
+	return r.open()
+}
+
+func (r *RotatingFile) backupPath(index int) string {
+	return fmt.Sprintf("%s.%d", r.path, index)
+}
@@ -0,0 +1,161 @@
+package logger
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	"github.com/checkmarx/ast-cli/internal/params"
+	"github.com/spf13/viper"
+)
+
+// Level is the severity of a log entry.
+type Level int
+
+const (
+	LevelDebug Level = iota
+	LevelInfo
+	LevelWarn
+	LevelError
+)
+
+const (
+	FormatText = "text"
+	FormatJSON = "json"
+)
+
+// Fields attached to every entry written in the JSON format once set.
+const (
+	FieldCommand   = "command"
+	FieldScanID    = "scanId"
+	FieldProjectID = "projectId"
+)
+
+// RequestIDHeader carries the ID reported as requestId by the logs of an API request and its response.
+const RequestIDHeader = "X-Request-ID"
+
+var levelNames = map[Level]string{
+	LevelDebug: "debug",
+	LevelInfo:  "info",
+	LevelWarn:  "warn",
+	LevelError: "error",
+}
+
+var textPrefixes = map[Level]string{
+	LevelWarn:  "Warning: ",
+	LevelError: "Error: ",
+}
+
+var (
+	fieldsMutex sync.RWMutex
+	fields      = map[string]string{}
+	writeMutex  sync.Mutex
+)
+
+type jsonEntry struct {
+	Time      string `json:"time"`
+	Level     string `json:"level"`
+	Message   string `json:"msg"`
+	Command   string `json:"command,omitempty"`
+	ScanID    string `json:"scanId,omitempty"`
+	ProjectID string `json:"projectId,omitempty"`
+	RequestID string `json:"requestId,omitempty"`
+}
+
+// ParseLevel converts a --log-level value to a Level.
+func ParseLevel(value string) (Level, bool) {
+	for level, name := range levelNames {
+		if strings.EqualFold(strings.TrimSpace(value), name) {
+			return level, true
+		}
+	}
+	if strings.EqualFold(strings.TrimSpace(value), "warning") {
+		return LevelWarn, true
+	}
+	return LevelInfo, false
+}
+
+// SetField sets a field, such as FieldScanID, reported by every following entry. An empty value removes it.
+func SetField(key, value string) {
+	fieldsMutex.Lock()
+	defer fieldsMutex.Unlock()
+	if value == "" {
+		delete(fields, key)
+		return
+	}
+	fields[key] = value
+}
+
+// ResetFields removes every field set with SetField.
+func ResetFields() {
+	fieldsMutex.Lock()
+	defer fieldsMutex.Unlock()
+	fields = map[string]string{}
+}
+
+func Warn(msg string) {
+	logEntry(LevelWarn, msg, "")
+}
+
+func Warnf(msg string, args ...interface{}) {
+	Warn(fmt.Sprintf(msg, args...))
+}
+
+func Error(msg string) {
+	logEntry(LevelError, msg, "")
+}
+
+func Errorf(msg string, args ...interface{}) {
+	Error(fmt.Sprintf(msg, args...))
+}
+
+// minimumLevel returns the lowest level written. Without --log-level, debug entries are written
+// only in verbose mode or when logging to a file, as before levels were introduced.
+func minimumLevel() Level {
+	if level, ok := ParseLevel(viper.GetString(params.LogLevelKey)); ok {
+		return level
+	}
+	if viper.GetBool(params.DebugFlag) || viper.GetString(params.LogFileFlag) != "" || viper.GetString(params.LogFileConsoleFlag) != "" {
+		return LevelDebug
+	}
+	return LevelInfo
+}
+
+func logEntry(level Level, msg, requestID string) {
+	if level < minimumLevel() {
+		return
+	}
+	if utf8.ValidString(msg) {
+		msg = sanitizeLogs(msg)
+	} else {
+		msg = binaryDataMessage
+	}
+	if !strings.EqualFold(viper.GetString(params.LogFormatKey), FormatJSON) {
+		log.Print(textPrefixes[level] + msg)
+		return
+	}
+
+	fieldsMutex.RLock()
+	entry := jsonEntry{
+		Time:      time.Now().UTC().Format(time.RFC3339Nano),
+		Level:     levelNames[level],
+		Message:   strings.TrimRight(msg, "\r\n"),
+		Command:   fields[FieldCommand],
+		ScanID:    fields[FieldScanID],
+		ProjectID: fields[FieldProjectID],
+		RequestID: requestID,
+	}
+	fieldsMutex.RUnlock()
+	line, err := json.Marshal(entry)
+	if err != nil {
+		log.Print(msg)
+		return
+	}
+	writeMutex.Lock()
+	defer writeMutex.Unlock()
+	_, _ = log.Writer().Write(append(line, '\n'))
+}
@@ -0,0 +1,101 @@
+package logger
+
+import (
+	"bytes"
+	"encoding/json"
+	"log"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/checkmarx/ast-cli/internal/params"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+)
+
+func captureLogs(t *testing.T, format, level string) *bytes.Buffer {
+	buffer := &bytes.Buffer{}
+	writer, flags := log.Writer(), log.Flags()
+	log.SetOutput(buffer)
+	viper.Set(params.LogFormatKey, format)
+	viper.Set(params.LogLevelKey, level)
+	t.Cleanup(func() {
+		log.SetOutput(writer)
+		log.SetFlags(flags)
+		viper.Set(params.LogFormatKey, "")
+		viper.Set(params.LogLevelKey, "")
+		ResetFields()
+	})
+	return buffer
+}
+
+func TestJSONFormat_IncludesLevelAndFields(t *testing.T) {
+	buffer := captureLogs(t, FormatJSON, "debug")
+	SetField(FieldCommand, "cx scan create")
+	SetField(FieldScanID, "scan-1")
+	SetField(FieldProjectID, "project-1")
+
+	Warnf("threshold %s reached", "high")
+	req, _ := http.NewRequest(http.MethodGet, "https://example.com/api/scans", http.NoBody)
+	req.Header.Set(RequestIDHeader, "request-1")
+	PrintRequest(req)
+
+	lines := strings.Split(strings.TrimSpace(buffer.String()), "\n")
+	assert.Len(t, lines, 3)
+	entry := jsonEntry{}
+	assert.NoError(t, json.Unmarshal([]byte(lines[0]), &entry))
+	assert.Equal(t, "warn", entry.Level)
+	assert.Equal(t, "threshold high reached", entry.Message)
+	assert.Equal(t, "cx scan create", entry.Command)
+	assert.Equal(t, "scan-1", entry.ScanID)
+	assert.Equal(t, "project-1", entry.ProjectID)
+	assert.Empty(t, entry.RequestID)
+
+	for _, line := range lines[1:] {
+		entry = jsonEntry{}
+		assert.NoError(t, json.Unmarshal([]byte(line), &entry))
+		assert.Equal(t, "debug", entry.Level)
+		assert.Equal(t, "request-1", entry.RequestID)
+	}
+}
+
+func TestLogLevel_FiltersEntries(t *testing.T) {
+	buffer := captureLogs(t, FormatText, "warn")
+	log.SetFlags(0)
+
+	PrintIfVerbose("debug entry")
+	Print("info entry")
+	Warn("warn entry")
+	Error("error entry")
+
+	assert.Equal(t, "Warning: warn entry\nError: error entry\n", buffer.String())
+}
+
+func TestParseLevel(t *testing.T) {
+	level, ok := ParseLevel("WARNING")
+	assert.True(t, ok)
+	assert.Equal(t, LevelWarn, level)
+	_, ok = ParseLevel("verbose")
+	assert.False(t, ok)
+}
+
+func TestRotatingFile_KeepsMaxBackups(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "ast-cli.log")
+	file, err := OpenRotatingFile(path, 10, 2)
+	assert.NoError(t, err)
+	for _, line := range []string{"first-1\n", "second-2\n", "third-3\n", "fourth-4\n"} {
+		_, err = file.Write([]byte(line))
+		assert.NoError(t, err)
+	}
+	assert.NoError(t, file.Close())
+
+	for suffix, expected := range map[string]string{"": "fourth-4\n", ".1": "third-3\n", ".2": "second-2\n"} {
+		content, readErr := os.ReadFile(path + suffix)
+		assert.NoError(t, readErr)
+		assert.Equal(t, expected, string(content))
+	}
+	_, err = os.Stat(path + ".3")
+	assert.True(t, os.IsNotExist(err))
+}
@@ -7,13 +7,15 @@ import (
 	"net/http"
 	"net/http/httputil"
 	"strings"
-	"unicode/utf8"
 
 	"github.com/checkmarx/ast-cli/internal/params"
 	"github.com/spf13/viper"
 )
 
-const ContentLengthLimit = 1000000 // 1mb in bytes
+const (
+	ContentLengthLimit = 1000000 // 1mb in bytes
+	binaryDataMessage  = "Request contains binary data and cannot be printed!"
+)
 
 var sanitizeFlags = []string{
 	params.AstAPIKey, params.AccessKeyIDConfigKey, params.AccessKeySecretConfigKey,
@@ -26,11 +28,7 @@ var sanitizeFlags = []string{
 }
 
 func Print(msg string) {
-	if utf8.Valid([]byte(msg)) {
-		log.Print(sanitizeLogs(msg))
-	} else {
-		log.Print("Request contains binary data and cannot be printed!")
-	}
+	logEntry(LevelInfo, msg, "")
 }
 
 func Printf(msg string, args ...interface{}) {
@@ -38,9 +36,7 @@ func Printf(msg string, args ...interface{}) {
 }
 
 func PrintIfVerbose(msg string) {
-	if viper.GetBool(params.DebugFlag) || viper.GetString(params.LogFileFlag) != "" || viper.GetString(params.LogFileConsoleFlag) != "" {
-		Print(msg)
-	}
+	logEntry(LevelDebug, msg, "")
 }
 
 func PrintfIfVerbose(msg string, args ...interface{}) {
@@ -48,23 +44,33 @@ func PrintfIfVerbose(msg string, args ...interface{}) {
 }
 
 func PrintRequest(r *http.Request) {
-	PrintIfVerbose("Sending API request to:")
+	requestID := r.Header.Get(RequestIDHeader)
+	logEntry(LevelDebug, "Sending API request to:", requestID)
 	requestDump, err := httputil.DumpRequest(r, r.ContentLength < ContentLengthLimit)
 	if err != nil {
 		fmt.Println(err)
 		return
 	}
-	PrintIfVerbose(string(requestDump))
+	logEntry(LevelDebug, string(requestDump), requestID)
 }
 
 func PrintResponse(r *http.Response, body bool) {
-	PrintIfVerbose("Receiving API response:")
+	requestID := ""
+	if r.Request != nil {
+		requestID = r.Request.Header.Get(RequestIDHeader)
+	}
+	logEntry(LevelDebug, "Receiving API response:", requestID)
 	requestDump, err := httputil.DumpResponse(r, body)
 	if err != nil {
 		fmt.Println(err)
 		return
 	}
-	PrintIfVerbose(string(requestDump))
+	logEntry(LevelDebug, string(requestDump), requestID)
+}
+
+// SanitizeLogs masks the values of secret flags and settings in msg.
+func SanitizeLogs(msg string) string {
+	return sanitizeLogs(msg)
 }
 
 func sanitizeLogs(msg string) string {
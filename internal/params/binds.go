@@ -11,6 +11,14 @@ var EnvVarsBinds = []struct {
 	{ProxyKerberosSPNKey, ProxyKerberosSPNEnv, ""},
 	{ProxyKerberosKrb5ConfKey, ProxyKerberosKrb5ConfEnv, ""},
 	{ProxyKerberosCcacheKey, ProxyKerberosCcacheEnv, ""},
+	{CACertKey, CACertEnv, ""},
+	{ClientCertKey, ClientCertEnv, ""},
+	{ClientKeyKey, ClientKeyEnv, ""},
+	{RequestsPerSecondKey, RequestsPerSecondEnv, "0"},
+	{TraceEndpointKey, TraceEndpointEnv, ""},
+	{LogFormatKey, LogFormatEnv, ""},
+	{LogLevelKey, LogLevelEnv, ""},
+	{RetainTagKey, RetainTagEnv, "retain"},
 	{BaseAuthURIKey, BaseAuthURIEnv, ""},
 	{AstAPIKey, AstAPIKeyEnv, ""},
 	{IgnoreProxyKey, IgnoreProxyEnv, ""},
@@ -89,5 +97,6 @@ var EnvVarsBinds = []struct {
 	{DisableASCALatestVersionKey, DisableASCALatestVersionEnv, ""},
 	{DastEnvironmentsPathKey, DastEnvironmentsPathEnv, "api/dast/scans/environments"},
 	{ASCALocationKey, ASCALocationEnv, ""},
+	{KicsBinaryKey, KicsBinaryEnv, ""},
 	{OptionalFlagsKey, OptionalFlagsEnv, ""},
 }
@@ -16,6 +16,14 @@ const (
 	ProxyKerberosSPNEnv                 = "CX_PROXY_KERBEROS_SPN"
 	ProxyKerberosKrb5ConfEnv            = "CX_PROXY_KERBEROS_KRB5_CONF"
 	ProxyKerberosCcacheEnv              = "CX_PROXY_KERBEROS_CCACHE"
+	CACertEnv                           = "CX_CA_CERT"
+	ClientCertEnv                       = "CX_CLIENT_CERT"
+	ClientKeyEnv                        = "CX_CLIENT_KEY"
+	RequestsPerSecondEnv                = "CX_REQUESTS_PER_SECOND"
+	TraceEndpointEnv                    = "CX_TRACE_ENDPOINT"
+	LogFormatEnv                        = "CX_LOG_FORMAT"
+	LogLevelEnv                         = "CX_LOG_LEVEL"
+	RetainTagEnv                        = "CX_RETAIN_TAG"
 	BaseAuthURIEnv                      = "CX_BASE_AUTH_URI"
 	AstAPIKeyEnv                        = "CX_APIKEY"
 	AccessKeyIDEnv                      = "CX_CLIENT_ID"
@@ -92,5 +100,6 @@ const (
 	DisableASCALatestVersionEnv         = "DISABLE_ASCA_UPDATE"
 	DastEnvironmentsPathEnv             = "CX_DAST_ENVIRONMENTS_PATH"
 	ASCALocationEnv                     = "CX_ASCA_LOCATION"
+	KicsBinaryEnv                       = "CX_KICS_BINARY"
 	OptionalFlagsEnv                    = "CX_OPTIONAL_FLAGS"
 )
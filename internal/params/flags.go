@@ -28,6 +28,11 @@ const (
 	OriginFlag                     = "origin"
 	AgentFlagUsage                 = "Scan origin name"
 	ApplicationName                = "application-name"
+	NewApplicationNameFlag         = "new-name"
+	ApplicationDescriptionFlag     = "description"
+	ApplicationRuleFlag            = "rule"
+	AddApplicationRuleFlag         = "add-rule"
+	RemoveApplicationRuleFlag      = "remove-rule"
 	DefaultAgent                   = "ASTCLI"
 	DebugFlag                      = "debug"
 	DebugUsage                     = "Debug mode with detailed logs"
@@ -53,6 +58,25 @@ const (
 	SourceDirFilterFlag            = "file-filter"
 	SourceDirFilterFlagSh          = "f"
 	ImportFilePath                 = "import-file-path"
+	SpecFileFlag                   = "file"
+	SpecFileFlagSh                 = "f"
+	PruneFlag                      = "prune"
+	DryRunFlag                     = "dry-run"
+	SourceProjectIDFlag            = "source-project-id"
+	ConfigKeyFlag                  = "key"
+	ConfigKeyValueFlag             = "key-value"
+	InactiveDaysFlag               = "inactive-days"
+	TagFilterFlag                  = "tag-filter"
+	RetainTagFlag                  = "retain-tag"
+	AuditLogFlag                   = "audit-log"
+	YesFlag                        = "yes"
+	KeepLastFlag                   = "keep-last"
+	OlderThanFlag                  = "older-than"
+	AllPagesFlag                   = "all"
+	PageSizeFlag                   = "page-size"
+	ChangedOnlyFlag                = "changed-only"
+	OnceFlag                       = "once"
+	RefreshIntervalFlag            = "refresh-interval"
 	IncludeFilterFlag              = "file-include"
 	IncludeFilterFlagSh            = "i"
 	ProjectIDFlag                  = "project-id"
@@ -73,6 +97,14 @@ const (
 	AccessKeySecretFlagUsage       = "The OAuth2 client secret"
 	InsecureFlag                   = "insecure"
 	InsecureFlagUsage              = "Ignore TLS certificate validations"
+	CACertFlag                     = "ca-cert"
+	CACertFlagUsage                = "Path to a PEM bundle of additional trusted CA certificates"
+	ClientCertFlag                 = "client-cert"
+	ClientCertFlagUsage            = "Path to a PEM client certificate for mutual TLS"
+	ClientKeyFlag                  = "client-key"
+	ClientKeyFlagUsage             = "Path to the PEM private key of the client certificate (default: read from --client-cert)"
+	RequestsPerSecondFlag          = "requests-per-second"
+	RequestsPerSecondFlagUsage     = "Maximum number of Checkmarx One API requests per second, 0 for unlimited"
 	ScanInfoFormatFlag             = "scan-info-format"
 	FormatFlag                     = "format"
 	FormatFlagUsageFormat          = "Format for the output. One of %s"
@@ -131,6 +163,7 @@ const (
 	KicsRealtimeFile               = "file"
 	KicsRealtimeEngine             = "engine"
 	KicsRealtimeAdditionalParams   = "additional-params"
+	KicsBinaryFlag                 = "kics-binary"
 	ScaRealtimeProjectDir          = "project-dir"
 	ScaRealtimeProjectDirSh        = "p"
 	RemediationFiles               = "package-files"
@@ -139,10 +172,17 @@ const (
 	KicsSimilarityFilter           = "similarity-ids"
 	RemediationPackage             = "package"
 	RemediationPackageVersion      = "package-version"
+	RemediationSourceFlag          = "source"
+	RemediationApplyFlag           = "apply"
 	TagList                        = "tags"
 	GroupList                      = "groups"
 	ProjectGroupList               = "project-groups"
 	ProjectTagList                 = "project-tags"
+	AddTagsFlag                    = "add-tags"
+	RemoveTagsFlag                 = "remove-tags"
+	AddGroupsFlag                  = "add-groups"
+	RemoveGroupsFlag               = "remove-groups"
+	CriticalityFlag                = "criticality"
 	IncrementalSast                = "sast-incremental"
 	PresetName                     = "sast-preset-name"
 	Threshold                      = "threshold"
@@ -166,13 +206,21 @@ const (
 	GithubTokenUsage             = "GitHub OAuth token. Requires “Repo” scope and organization SSO authorization, if enforced by the organization"
 	GitLabTokenUsage             = "GitLab OAuth token"
 	BitbucketTokenUsage          = "Bitbucket OAuth token"
+	GiteaTokenUsage              = "Gitea or Forgejo access token. Requires the “read:repository” scope, and “write:repository” and “write:issue” to decorate pull requests"
 	BotCount                     = "Note: dependabot is not counted but other bots might be considered as contributors."
 	DisabledReposCount           = "Note: Disabled repositories are not counted."
 	URLFlag                      = "url"
 	GitLabURLFlag                = "url-gitlab"
+	GiteaURLFlag                 = "url-gitea"
+	GiteaURLFlagUsage            = "Root URL of the Gitea or Forgejo server"
 	URLFlagUsage                 = "API base URL"
+	SinceFlag                    = "since"
+	SinceFlagUsage               = "Count the commits made since this date, as YYYY-MM-DD or RFC3339 (default: 90 days before --until)"
+	UntilFlag                    = "until"
+	UntilFlagUsage               = "Count the commits made until this date, as YYYY-MM-DD or RFC3339 (default: now)"
 	QueryIDFlag                  = "query-id"
 	SSHKeyFlag                   = "ssh-key"
+	RerunSCMTokenFlag            = "scm-token"
 	RepoURLFlag                  = "repo-url"
 	AstToken                     = "ast-token"
 	SSHValue                     = "ssh-value"
@@ -201,8 +249,26 @@ const (
 	ConfigFilePathFlag           = "config-file-path"
 	LogFileFlag                  = "log-file"
 	LogFileUsage                 = "Saves logs to the specified file path only"
+	RecordHTTPFlag               = "record-http"
+	RecordHTTPUsage              = "Records every HTTP request and response, with secrets redacted, to the specified archive file"
+	ReplayHTTPFlag               = "replay-http"
+	ReplayHTTPUsage              = "Serves HTTP responses from an archive created with --record-http instead of the network"
+	TraceFileFlag                = "trace-file"
+	TraceFileUsage               = "Writes OpenTelemetry spans of the command and its HTTP requests to the specified file as JSON lines"
+	TraceEndpointFlag            = "trace-endpoint"
+	TraceEndpointUsage           = "Exports OpenTelemetry spans of the command and its HTTP requests to an OTLP/HTTP collector, e.g. http://localhost:4318"
 	LogFileConsoleFlag           = "log-file-console"
 	LogFileConsoleUsage          = "Saves logs to the specified file path as well as to the console"
+	LogFormatFlag                = "log-format"
+	LogFormatUsage               = "Format of the log entries, text or json. JSON entries include the command, scan ID, project ID and request ID"
+	LogLevelFlag                 = "log-level"
+	LogLevelUsage                = "Lowest level of the log entries written: debug, info, warn or error"
+	LogMaxSizeFlag               = "log-max-size"
+	LogMaxSizeUsage              = "Size in MB after which the log file is rotated, 0 to disable rotation"
+	LogMaxSizeDefault            = 10
+	LogMaxBackupsFlag            = "log-max-backups"
+	LogMaxBackupsUsage           = "Number of rotated log files to keep"
+	LogMaxBackupsDefault         = 5
 	GitIgnoreFileFilterFlag      = "use-gitignore"
 	GitIgnoreFileFilterUsage     = "Exclude files and directories from the scan based on the patterns defined in the directory's .gitignore file"
 	// INDIVIDUAL FILTER FLAGS
@@ -234,6 +300,25 @@ const (
 	ProjectKeyFlagUsage              = "Key of the project containing the repository"
 	PRBBIDFlag                       = "pr-id"
 	PRBBIDFlagUsage                  = "Bitbucket PR ID"
+	PRInlineCommentsFlag             = "inline-comments"
+	PRInlineCommentsFlagUsage        = "Post the new findings of the scan as review comments on the lines changed by the PR, " +
+		"updating and resolving the comments of previous scans"
+	PRStatusFlag      = "status"
+	PRStatusFlagUsage = "Publish the status of the scanned commit, failed when a violated policy breaks the build " +
+		"or a threshold limit is reached"
+	PRThresholdFlagUsage = "Threshold failing the status published with --status. Format <engine>-<severity>=<limit>. " +
+		"Example: --threshold \"sast-high=10;sca-high=5;iac-security-low=10\""
+	PRCheckRunFlag      = "check-run"
+	PRCheckRunFlagUsage = "Publish the status as a check run with annotations on the findings instead of a commit status. " +
+		"Requires a GitHub App installation token"
+
+	// CI pipeline generation flags
+	CIProviderFlag        = "provider"
+	CIProviderFlagUsage   = "CI provider to generate the pipeline definition for: %s"
+	CIOutputFileFlag      = "output-file"
+	CIOutputFileFlagUsage = "Path of the generated pipeline definition. Defaults to the location the provider reads it from in the source directory"
+	CIForceFlag           = "force"
+	CIForceFlagUsage      = "Overwrite an existing pipeline definition"
 
 	// Chat (General)
 	ChatAPIKey         = "chat-apikey"
@@ -266,6 +351,23 @@ const (
 	GitCommitHistoryFlag            = "git-commit-history"
 	GitCommitHistoryFlagDescription = "Enable or disable commit history scan for Secret Detection"
 
+	// Pre-commit hook flags
+	PreCommitEnginesFlag      = "engines"
+	PreCommitEnginesFlagUsage = "Comma separated realtime engines scanning the staged files: %s. " +
+		"Defaults to the engines of .checkmarx/pre-commit.yaml, or all of them"
+	PreCommitForceFlag      = "force"
+	PreCommitForceFlagUsage = "Replace an existing pre-commit hook that wasn't installed by cx"
+	PreCommitIacEngineFlag  = "iac-engine"
+	PreCommitIacEngineUsage = "Name of the container engine running KICS for the iac engine. (ex. docker, podman)"
+	PreCommitResultIDsFlag  = "resultIds"
+	PreCommitAllFlag        = "all"
+
+	// Diff scan flags
+	DiffBaseFlag         = "diff-base"
+	DiffBaseFlagUsage    = "Git ref to diff HEAD against. Only the files changed since it, with their --diff-context, are scanned incrementally. Requires --scan-types sast, the other engines need the whole source"
+	DiffContextFlag      = "diff-context"
+	DiffContextFlagUsage = "Context packaged with the changed files of --diff-base: %s"
+
 	// Containers Config Flags
 	ContainersFileFolderFilterFlag      = "containers-file-folder-filter"
 	ContainersImageTagFilterFlag        = "containers-image-tag-filter"
@@ -292,6 +394,7 @@ const (
 	StatusesQueryParam         = "statuses"
 	StatusQueryParam           = "status"
 	BranchNameQueryParam       = "branch-name"
+	NameQueryParam             = "name"
 	ProjectIDQueryParam        = "project-id"
 	FromDateQueryParam         = "from-date"
 	ToDateQueryParam           = "to-date"
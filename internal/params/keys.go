@@ -15,6 +15,14 @@ var (
 	ProxyKerberosSPNKey                 = strings.ToLower(ProxyKerberosSPNEnv)
 	ProxyKerberosKrb5ConfKey            = strings.ToLower(ProxyKerberosKrb5ConfEnv)
 	ProxyKerberosCcacheKey              = strings.ToLower(ProxyKerberosCcacheEnv)
+	CACertKey                           = strings.ToLower(CACertEnv)
+	ClientCertKey                       = strings.ToLower(ClientCertEnv)
+	ClientKeyKey                        = strings.ToLower(ClientKeyEnv)
+	RequestsPerSecondKey                = strings.ToLower(RequestsPerSecondEnv)
+	TraceEndpointKey                    = strings.ToLower(TraceEndpointEnv)
+	LogFormatKey                        = strings.ToLower(LogFormatEnv)
+	LogLevelKey                         = strings.ToLower(LogLevelEnv)
+	RetainTagKey                        = strings.ToLower(RetainTagEnv)
 	BaseAuthURIKey                      = strings.ToLower(BaseAuthURIEnv)
 	ClientTimeoutKey                    = strings.ToLower(ClientTimeoutEnv)
 	AstAPIKey                           = strings.ToLower(AstAPIKeyEnv)
@@ -91,5 +99,6 @@ var (
 	DisableASCALatestVersionKey         = strings.ToLower(DisableASCALatestVersionEnv)
 	DastEnvironmentsPathKey             = strings.ToLower(DastEnvironmentsPathEnv)
 	ASCALocationKey                     = strings.ToLower(ASCALocationEnv)
+	KicsBinaryKey                       = strings.ToLower(KicsBinaryEnv)
 	OptionalFlagsKey                    = strings.ToLower(OptionalFlagsEnv)
 )
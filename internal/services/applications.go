@@ -1,6 +1,8 @@
 package services
 
 import (
+	"strings"
+
 	errorConstants "github.com/checkmarx/ast-cli/internal/constants/errors"
 	"github.com/checkmarx/ast-cli/internal/logger"
 	"github.com/checkmarx/ast-cli/internal/wrappers"
@@ -120,6 +122,57 @@ func findApplicationAndUpdate(applicationName string, applicationsWrapper wrappe
 	return nil
 }
 
+// AddProjectToApplication associates an existing project with the named application, if it isn't already.
+func AddProjectToApplication(applicationName, projectName, projectID string, applicationsWrapper wrappers.ApplicationsWrapper,
+	featureFlagsWrapper wrappers.FeatureFlagsWrapper, tenantWrapper wrappers.TenantConfigurationWrapper) error {
+	return findApplicationAndUpdate(applicationName, applicationsWrapper, projectName, projectID, featureFlagsWrapper, tenantWrapper)
+}
+
+// RemoveProjectFromApplication removes the association of a project with an application, either directly or by
+// taking the project out of the project.name.in rules of the application.
+func RemoveProjectFromApplication(application *wrappers.Application, projectName, projectID string, applicationsWrapper wrappers.ApplicationsWrapper,
+	featureFlagsWrapper wrappers.FeatureFlagsWrapper, tenantWrapper wrappers.TenantConfigurationWrapper) error {
+	isEnabled, err := checkDirectAssociationEnabled(featureFlagsWrapper, tenantWrapper)
+	if err != nil {
+		return errors.Wrap(err, "error while checking if direct association is enabled")
+	}
+	if isEnabled {
+		errorModel, deleteErr := applicationsWrapper.DeleteProjectAssociation(application.ID, &wrappers.AssociateProjectModel{ProjectIds: []string{projectID}})
+		return handleApplicationUpdateResponse(errorModel, deleteErr)
+	}
+
+	applicationModel := wrappers.ApplicationConfiguration{
+		Name:        application.Name,
+		Description: application.Description,
+		Criticality: application.Criticality,
+		Type:        application.Type,
+		Tags:        application.Tags,
+	}
+	removed := false
+	for _, rule := range application.Rules {
+		if rule.Type != ApplicationRuleType {
+			applicationModel.Rules = append(applicationModel.Rules, rule)
+			continue
+		}
+		var names []string
+		for _, name := range strings.Split(rule.Value, ";") {
+			if name == projectName {
+				removed = true
+			} else if name != "" {
+				names = append(names, name)
+			}
+		}
+		if len(names) > 0 {
+			rule.Value = strings.Join(names, ";")
+			applicationModel.Rules = append(applicationModel.Rules, rule)
+		}
+	}
+	if !removed {
+		return errors.Errorf("Project %s is not associated with the application %s by name", projectName, application.Name)
+	}
+	return updateApplication(&applicationModel, applicationsWrapper, application.ID)
+}
+
 func checkDirectAssociationEnabled(featureFlagsWrapper wrappers.FeatureFlagsWrapper, tenantWrapper wrappers.TenantConfigurationWrapper) (bool, error) {
 	directAssociationEnabled, _ := wrappers.GetSpecificFeatureFlag(featureFlagsWrapper, wrappers.DirectAssociationEnabled)
 	daMigrationEnabled, _ := wrappers.GetSpecificFeatureFlag(featureFlagsWrapper, wrappers.DaMigrationEnabled)
@@ -96,3 +96,37 @@ func Test_AssociateProjectToApplication_ProjectAlreadyAssociated(t *testing.T) {
 	err := associateProjectToApplication(applicationName, projectID, applicationWrapper)
 	assert.NilError(t, err)
 }
+
+type updateRecordingApplicationsWrapper struct {
+	mock.ApplicationsMockWrapper
+	updated *wrappers.ApplicationConfiguration
+}
+
+func (a *updateRecordingApplicationsWrapper) Update(_ string, applicationBody *wrappers.ApplicationConfiguration) (*wrappers.ErrorModel, error) {
+	a.updated = applicationBody
+	return nil, nil
+}
+
+func Test_RemoveProjectFromApplication_ByNameRules(t *testing.T) {
+	mock.Flag = wrappers.FeatureFlagResponseModel{Name: wrappers.DirectAssociationEnabled, Status: false}
+	applicationWrapper := &updateRecordingApplicationsWrapper{}
+	application := &wrappers.Application{
+		ID:   "app-id",
+		Name: "app",
+		Rules: []wrappers.Rule{
+			{ID: "1", Type: ApplicationRuleType, Value: "payments"},
+			{ID: "2", Type: ApplicationRuleType, Value: "billing;payments"},
+			{ID: "3", Type: "project.tag.key.exists", Value: "team"},
+		},
+	}
+
+	err := RemoveProjectFromApplication(application, "payments", "p1", applicationWrapper, &mock.FeatureFlagsMockWrapper{}, &mock.TenantConfigurationMockWrapper{})
+	assert.NilError(t, err)
+	assert.DeepEqual(t, applicationWrapper.updated.Rules, []wrappers.Rule{
+		{ID: "2", Type: ApplicationRuleType, Value: "billing"},
+		{ID: "3", Type: "project.tag.key.exists", Value: "team"},
+	})
+
+	err = RemoveProjectFromApplication(application, "other", "p2", applicationWrapper, &mock.FeatureFlagsMockWrapper{}, &mock.TenantConfigurationMockWrapper{})
+	assert.ErrorContains(t, err, "is not associated with the application app by name")
+}
@@ -150,7 +150,7 @@ func executeScan(ascaWrapper grpcs.AscaWrapper, filePath, ignoredFilePath string
 	if ignoredFilePath != "" {
 		ignoredFindings, err := loadIgnoredAscaFindings(ignoredFilePath)
 		if err != nil {
-			logger.PrintfIfVerbose("asca: failed to load ignore file %s: %v; continuing without ignore filtering", ignoredFilePath, err)
+			logger.Warnf("asca: failed to load ignore file %s: %v; continuing without ignore filtering", ignoredFilePath, err)
 		} else {
 			ignoreMap := buildAscaIgnoreMap(ignoredFindings)
 			scanResult.ScanDetails = filterIgnoredAscaFindings(scanResult.ScanDetails, ignoreMap)
@@ -219,11 +219,11 @@ func findASCAPort() (int, error) {
 	viper.Set(params.ASCAPortKey, port)
 	configFilePath, err := configuration.GetConfigFilePath()
 	if err != nil {
-		logger.PrintfIfVerbose("Error getting config file path: %v", err)
+		logger.Warnf("Failed getting the config file path: %v", err)
 	}
 	err = configuration.SafeWriteSingleConfigKey(configFilePath, params.ASCAPortKey, port)
 	if err != nil {
-		logger.PrintfIfVerbose("Error writing ASCA port to config file: %v", err)
+		logger.Warnf("Failed writing the ASCA port to the config file: %v", err)
 	}
 	return port, nil
 }
@@ -278,7 +278,7 @@ func checkLicense(isDefaultAgent bool, wrapperParams AscaWrappersParam) error {
 func readSourceCode(filePath string) (string, error) {
 	data, err := os.ReadFile(filePath)
 	if err != nil {
-		logger.Printf("Error reading file %v: %v", filePath, err)
+		logger.Errorf("Failed reading file %v: %v", filePath, err)
 		return "", err
 	}
 	return string(data), nil
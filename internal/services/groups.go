@@ -91,6 +91,37 @@ func AssignGroupsToProjectNewAccessManagement(projectID string, projectName stri
 	return nil
 }
 
+// RemoveGroupsFromProjectNewAccessManagement deletes the assignments of the given groups to the project,
+// under the same feature flag conditions in which AssignGroupsToProjectNewAccessManagement creates them.
+func RemoveGroupsFromProjectNewAccessManagement(projectID string, projectName string, groups []*wrappers.Group,
+	accessManagement wrappers.AccessManagementWrapper, featureFlagsWrapper wrappers.FeatureFlagsWrapper) error {
+
+	amEnabledFlag, _ := wrappers.GetSpecificFeatureFlag(featureFlagsWrapper, featureFlagsConstants.AccessManagementEnabled)
+	groupValidationEnabledFlag, _ := wrappers.GetSpecificFeatureFlag(featureFlagsWrapper, featureFlagsConstants.GroupValidationEnabled)
+	if !amEnabledFlag.Status || (amEnabledFlag.Status && groupValidationEnabledFlag.Status) {
+		return nil
+	}
+
+	groupsAssignedToTheProject, err := accessManagement.GetGroups(projectID)
+	if err != nil {
+		return err
+	}
+	assigned := make(map[string]bool)
+	for _, group := range groupsAssignedToTheProject {
+		assigned[group.ID] = true
+	}
+	var groupsToRemove []*wrappers.Group
+	for _, group := range groups {
+		if assigned[group.ID] {
+			groupsToRemove = append(groupsToRemove, group)
+		}
+	}
+	if len(groupsToRemove) == 0 {
+		return nil
+	}
+	return accessManagement.DeleteGroupsAssignment(projectID, projectName, groupsToRemove)
+}
+
 func GetGroupIds(groups []*wrappers.Group) []string {
 	var groupIds []string
 	for _, group := range groups {
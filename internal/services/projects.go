@@ -132,7 +132,7 @@ func createProject(
 	if projectPrivatePackage != "" {
 		projModel.PrivatePackage, _ = strconv.ParseBool(projectPrivatePackage)
 	}
-	projModel.Tags = createTagMap(projectTags)
+	projModel.Tags = CreateTagMap(projectTags)
 	logger.PrintIfVerbose("Creating new project")
 	resp, errorModel, err := projectsWrapper.Create(&projModel)
 	projectID := ""
@@ -220,7 +220,7 @@ func updateProject(project *wrappers.ProjectResponseModel,
 	projModel.Tags = projModelResp.Tags
 	if projectTags != "" {
 		logger.PrintIfVerbose("Updating project tags")
-		projModel.Tags = createTagMap(projectTags)
+		projModel.Tags = CreateTagMap(projectTags)
 	}
 
 	err = projectsWrapper.Update(projectID, &projModel)
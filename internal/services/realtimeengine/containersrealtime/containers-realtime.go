@@ -106,7 +106,7 @@ func (c *ContainersRealtimeService) RunContainersRealtimeScan(filePath, ignoredF
 	if ignoredFilePath != "" {
 		ignored, err := loadIgnoredContainerFindings(ignoredFilePath)
 		if err != nil {
-			logger.PrintfIfVerbose("containers-realtime: failed to load ignore file %s: %v; continuing without ignore filtering", ignoredFilePath, err)
+			logger.Warnf("containers-realtime: failed to load ignore file %s: %v; continuing without ignore filtering", ignoredFilePath, err)
 		} else {
 			ignoreMap := buildContainerIgnoreMap(ignored)
 			results.Images = filterIgnoredContainers(results.Images, ignoreMap)
@@ -0,0 +1,55 @@
+package iacrealtime
+
+import (
+	"strings"
+
+	"github.com/checkmarx/ast-cli/internal/commands/util"
+	"github.com/checkmarx/ast-cli/internal/logger"
+)
+
+// BinaryManager runs a locally installed KICS binary in place of the KICS container
+type BinaryManager struct {
+	binaryPath string
+	runner     util.KicsRunner
+}
+
+func NewBinaryManager(binaryPath string) IContainerManager {
+	return &BinaryManager{binaryPath: binaryPath, runner: util.NewKicsBinaryRunner(binaryPath)}
+}
+
+// NewKicsManager returns a BinaryManager when util.KicsBinaryPath picks a KICS binary, as util.NewKicsRunner does for
+// the other KICS commands. Otherwise KICS runs in a container.
+func NewKicsManager(kicsBinary, engine string) (IContainerManager, error) {
+	binaryPath, err := util.KicsBinaryPath(kicsBinary, engine)
+	if err != nil {
+		return nil, err
+	}
+	if binaryPath != "" {
+		return NewBinaryManager(binaryPath), nil
+	}
+	return NewContainerManager(), nil
+}
+
+// GenerateContainerID returns an empty name, there is no container to name or to stop on shutdown
+func (bm *BinaryManager) GenerateContainerID() string {
+	return ""
+}
+
+// EnsureImageAvailable returns the binary path, there is no image to pull
+func (bm *BinaryManager) EnsureImageAvailable(_ string) (string, error) {
+	return bm.binaryPath, nil
+}
+
+// RunKicsContainer scans the host folder of volumeMap with the binary, writing the results next to the scanned file
+// as the container does.
+func (bm *BinaryManager) RunKicsContainer(_, volumeMap string) error {
+	volume := util.KicsVolume{HostPath: strings.TrimSuffix(volumeMap, ":"+ContainerPath), ContainerPath: ContainerPath}
+	output, err := bm.runner.Run([]string{
+		"scan",
+		"-p", bm.runner.Path(volume),
+		"-o", bm.runner.Path(volume),
+		"--report-formats", ContainerFormat,
+	}, volume)
+	logger.PrintIfVerbose(string(output))
+	return err
+}
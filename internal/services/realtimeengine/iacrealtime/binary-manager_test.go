@@ -0,0 +1,98 @@
+package iacrealtime
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/checkmarx/ast-cli/internal/params"
+	"github.com/spf13/viper"
+)
+
+const fakeKicsResults = `{"queries":[{"query_name":"Privileged Container","query_id":"q1","severity":"HIGH",` +
+	`"files":[{"file_name":"Dockerfile","similarity_id":"s1","line":1}]}]}`
+
+// writeFakeKics writes a kics script recording its arguments and writing results to the -o folder, exiting with 40 as
+// KICS does when it finds high results.
+func writeFakeKics(t *testing.T) (binaryPath, argsFile string) {
+	t.Helper()
+	if runtime.GOOS == osWindows {
+		t.Skip("the fake KICS binary is a shell script")
+	}
+	dir := t.TempDir()
+	binaryPath = filepath.Join(dir, "kics")
+	argsFile = filepath.Join(dir, "args")
+	script := "#!/bin/sh\necho \"$@\" > " + argsFile + "\n" +
+		"echo '" + fakeKicsResults + "' > \"$5/" + ContainerResultsFileName + "\"\nexit 40\n"
+	if err := os.WriteFile(binaryPath, []byte(script), 0700); err != nil {
+		t.Fatal(err)
+	}
+	return binaryPath, argsFile
+}
+
+func TestBinaryManager_RunScan(t *testing.T) {
+	binaryPath, argsFile := writeFakeKics(t)
+	manager := NewBinaryManager(binaryPath)
+	if name := manager.GenerateContainerID(); name != "" {
+		t.Errorf("Expected no container name, got %s", name)
+	}
+	if resolved, err := manager.EnsureImageAvailable("docker"); err != nil || resolved != binaryPath {
+		t.Errorf("Expected the binary path, got %s, %v", resolved, err)
+	}
+
+	tempDir := t.TempDir()
+	filePath := filepath.Join(tempDir, "Dockerfile")
+	if err := os.WriteFile(filePath, []byte("FROM alpine\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	results, err := NewScanner(manager).RunScan("docker", tempDir+":"+ContainerPath, tempDir, filePath)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(results) != 1 || results[0].Title != "Privileged Container" {
+		t.Errorf("Expected the fake KICS result, got %+v", results)
+	}
+	args, _ := os.ReadFile(argsFile)
+	expected := "scan -p " + tempDir + " -o " + tempDir + " --report-formats json"
+	if strings.TrimSpace(string(args)) != expected {
+		t.Errorf("Expected args %q, got %q", expected, strings.TrimSpace(string(args)))
+	}
+}
+
+func TestNewKicsManager(t *testing.T) {
+	binaryPath, _ := writeFakeKics(t)
+
+	manager, err := NewKicsManager(binaryPath, engineDocker)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if _, ok := manager.(*BinaryManager); !ok {
+		t.Errorf("Expected a BinaryManager for an explicit binary, got %T", manager)
+	}
+
+	t.Setenv("PATH", filepath.Dir(binaryPath))
+	manager, _ = NewKicsManager("", engineDocker)
+	if _, ok := manager.(*BinaryManager); !ok {
+		t.Errorf("Expected a BinaryManager for kics in the PATH without a container engine, got %T", manager)
+	}
+	if err = os.WriteFile(filepath.Join(filepath.Dir(binaryPath), enginePodman), []byte("#!/bin/sh\n"), 0700); err != nil {
+		t.Fatal(err)
+	}
+	manager, _ = NewKicsManager("", enginePodman)
+	if _, ok := manager.(*ContainerManager); !ok {
+		t.Errorf("Expected a ContainerManager when the container engine is installed, got %T", manager)
+	}
+
+	viper.Set(params.KicsBinaryKey, binaryPath)
+	t.Cleanup(func() { viper.Set(params.KicsBinaryKey, "") })
+	manager, _ = NewKicsManager("", enginePodman)
+	if _, ok := manager.(*BinaryManager); !ok {
+		t.Errorf("Expected a BinaryManager for the configured binary, got %T", manager)
+	}
+
+	if _, err = NewKicsManager(filepath.Join(t.TempDir(), "missing"), engineDocker); err == nil {
+		t.Error("Expected an error for a missing binary")
+	}
+}
@@ -70,6 +70,28 @@ func (fh *FileHandler) CopyFileToTempDir(filePath, tempDir string) error {
 	return nil
 }
 
+// CopyRelativeFileToTempDir copies the file at relativePath in dir to the same relative path in tempDir
+func (fh *FileHandler) CopyRelativeFileToTempDir(dir, relativePath, tempDir string) error {
+	data, err := os.ReadFile(filepath.Join(dir, relativePath))
+	if err != nil {
+		return errorconstants.NewRealtimeEngineError("error reading file").Error()
+	}
+
+	destPath := filepath.Join(tempDir, relativePath)
+	if !strings.HasPrefix(filepath.Clean(destPath), filepath.Clean(tempDir)+string(filepath.Separator)) {
+		return errorconstants.NewRealtimeEngineError("error writing file to temporary directory").Error()
+	}
+
+	if err := os.MkdirAll(filepath.Dir(destPath), os.ModePerm); err != nil {
+		return errorconstants.NewRealtimeEngineError("error writing file to temporary directory").Error()
+	}
+	if err := os.WriteFile(destPath, data, os.ModePerm); err != nil {
+		return errorconstants.NewRealtimeEngineError("error writing file to temporary directory").Error()
+	}
+
+	return nil
+}
+
 func (fh *FileHandler) HasSupportedExtension(filePath string) bool {
 	for _, filter := range commonParams.KicsBaseFilters {
 		if filter != "" && strings.Contains(filePath, filter) {
@@ -103,7 +103,7 @@ func (svc *IacRealtimeService) RunIacRealtimeScan(filePath, engine, ignoredFileP
 	if ignoredFilePath != "" {
 		ignored, err := loadIgnoredIacFindings(ignoredFilePath)
 		if err != nil {
-			logger.PrintfIfVerbose("iac-realtime: failed to load ignore file %s: %v; continuing without ignore filtering", ignoredFilePath, err)
+			logger.Warnf("iac-realtime: failed to load ignore file %s: %v; continuing without ignore filtering", ignoredFilePath, err)
 		} else {
 			ignoreMap := buildIgnoreMap(ignored)
 			results = filterIgnoredFindings(results, ignoreMap)
@@ -113,6 +113,61 @@ func (svc *IacRealtimeService) RunIacRealtimeScan(filePath, engine, ignoredFileP
 	return results, nil
 }
 
+// RunIacRealtimeFilesScan scans the supported files of filePaths, relative to dir, in a single KICS run and returns
+// their results by relative path. Files without findings are left out.
+func (svc *IacRealtimeService) RunIacRealtimeFilesScan(dir string, filePaths []string, engine, ignoredFilePath string) (
+	map[string][]IacRealtimeResult, error) {
+	if err := svc.checkFeatureFlag(); err != nil {
+		return nil, err
+	}
+	if err := svc.ensureLicense(); err != nil {
+		return nil, err
+	}
+
+	svc.containerManager.GenerateContainerID()
+
+	tempDir, err := svc.fileHandler.CreateTempDirectory()
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = svc.fileHandler.CleanupTempDirectory(tempDir)
+	}()
+
+	scannedFiles := map[string]string{}
+	for _, filePath := range filePaths {
+		if !svc.fileHandler.HasSupportedExtension(filePath) {
+			continue
+		}
+		if err = svc.fileHandler.CopyRelativeFileToTempDir(dir, filePath, tempDir); err != nil {
+			return nil, err
+		}
+		scannedFiles[filepath.ToSlash(filePath)] = filepath.Join(dir, filePath)
+	}
+	if len(scannedFiles) == 0 {
+		return map[string][]IacRealtimeResult{}, nil
+	}
+
+	results, err := svc.scanner.RunFilesScan(engine, fmt.Sprintf("%s:%s", tempDir, ContainerPath), tempDir, scannedFiles)
+	if err != nil {
+		return nil, err
+	}
+
+	if ignoredFilePath != "" {
+		ignored, err := loadIgnoredIacFindings(ignoredFilePath)
+		if err != nil {
+			logger.Warnf("iac-realtime: failed to load ignore file %s: %v; continuing without ignore filtering", ignoredFilePath, err)
+		} else {
+			ignoreMap := buildIgnoreMap(ignored)
+			for filePath := range results {
+				results[filePath] = filterIgnoredFindings(results[filePath], ignoreMap)
+			}
+		}
+	}
+
+	return results, nil
+}
+
 func (svc *IacRealtimeService) runValidations(filePath string) error {
 	if err := svc.checkFeatureFlag(); err != nil {
 		return err
@@ -1211,3 +1211,65 @@ func TestVerifyEnginePath_ValidSystemExecutable(t *testing.T) {
 		t.Errorf("verifyEnginePath should return true for valid executable: %s", execPath)
 	}
 }
+
+func TestIacRealtimeService_RunIacRealtimeFilesScan(t *testing.T) {
+	if runtime.GOOS == osWindows {
+		t.Skip("the fake KICS binary is a shell script")
+	}
+	mock.Flag = wrappers.FeatureFlagResponseModel{
+		Name:   wrappers.OssRealtimeEnabled,
+		Status: true,
+	}
+
+	// The fake KICS counts its runs and reports a result in each of the scanned files
+	binDir := t.TempDir()
+	binaryPath := filepath.Join(binDir, "kics")
+	runsFile := filepath.Join(binDir, "runs")
+	script := "#!/bin/sh\necho run >> " + runsFile + "\n" +
+		"echo '{\"queries\":[{\"query_name\":\"Privileged Container\",\"severity\":\"HIGH\",\"files\":[" +
+		"{\"file_name\":\"'$3'/k8s/pod.yaml\",\"similarity_id\":\"s1\",\"line\":2}," +
+		"{\"file_name\":\"'$3'/compose.yml\",\"similarity_id\":\"s2\",\"line\":1}]}]}' > \"$5/" + ContainerResultsFileName + "\"\nexit 40\n"
+	if err := os.WriteFile(binaryPath, []byte(script), 0700); err != nil {
+		t.Fatal(err)
+	}
+
+	dir := t.TempDir()
+	files := map[string]string{"k8s/pod.yaml": "kind: Pod\nspec: {}\n", "compose.yml": "services: {}\n", "main.go": "package main\n"}
+	for name, content := range files {
+		if err := os.MkdirAll(filepath.Dir(filepath.Join(dir, name)), 0700); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	service := NewIacRealtimeService(&mock.JWTMockWrapper{}, &mock.FeatureFlagsMockWrapper{}, NewBinaryManager(binaryPath))
+	results, err := service.RunIacRealtimeFilesScan(dir, []string{filepath.Join("k8s", "pod.yaml"), "compose.yml", "main.go"}, "docker", "")
+	if err != nil {
+		t.Fatalf("RunIacRealtimeFilesScan() unexpected error: %v", err)
+	}
+
+	runs, _ := os.ReadFile(runsFile)
+	if strings.Count(string(runs), "run") != 1 {
+		t.Errorf("Expected a single KICS run, got %q", string(runs))
+	}
+	if len(results) != 2 || len(results["k8s/pod.yaml"]) != 1 || len(results["compose.yml"]) != 1 {
+		t.Fatalf("Expected one result in each IaC file, got %+v", results)
+	}
+	if result := results["k8s/pod.yaml"][0]; result.FilePath != filepath.Join(dir, "k8s", "pod.yaml") || result.Locations[0].Line != 1 {
+		t.Errorf("Expected the result on the second line of the original file, got %+v", result)
+	}
+}
+
+func TestScanner_relativeResultPath(t *testing.T) {
+	scanner := NewScanner(NewMockContainerManager())
+	tempDir := filepath.Join(os.TempDir(), "iac-realtime")
+
+	if path := scanner.relativeResultPath(ContainerPath+"/k8s/pod.yaml", tempDir); path != "k8s/pod.yaml" {
+		t.Errorf("Expected the path relative to the container folder, got %s", path)
+	}
+	if path := scanner.relativeResultPath(filepath.Join(tempDir, "k8s", "pod.yaml"), tempDir); path != "k8s/pod.yaml" {
+		t.Errorf("Expected the path relative to the temporary folder, got %s", path)
+	}
+}
@@ -33,27 +33,82 @@ func (s *Scanner) RunScan(engine, volumeMap, tempDir, filePath string) ([]IacRea
 }
 
 func (s *Scanner) HandleScanResult(kicsErrorCode error, tempDir, filePath string) ([]IacRealtimeResult, error) {
+	if err := s.checkKicsError(kicsErrorCode); err != nil {
+		return nil, err
+	}
+	return s.processResults(tempDir, filePath)
+}
+
+// RunFilesScan runs KICS once on the files copied to tempDir, given as their paths relative to tempDir mapped to the
+// paths of the original files, and returns the results of each file by its relative path
+func (s *Scanner) RunFilesScan(engine, volumeMap, tempDir string, filePaths map[string]string) (map[string][]IacRealtimeResult, error) {
+	if err := s.checkKicsError(s.dockerManager.RunKicsContainer(engine, volumeMap)); err != nil {
+		return nil, err
+	}
+	results, err := s.readKicsResultsFile(tempDir)
+	if err != nil {
+		return nil, errors.Errorf("failed to read KICS results: %s", err)
+	}
+
+	fileResults := map[string]*wrappers.KicsResultsCollection{}
+	for i := range results.Results {
+		query := results.Results[i]
+		for _, location := range query.Locations {
+			relativePath := s.relativeResultPath(location.Filename, tempDir)
+			if _, ok := filePaths[relativePath]; !ok {
+				continue
+			}
+			if fileResults[relativePath] == nil {
+				fileResults[relativePath] = &wrappers.KicsResultsCollection{Version: results.Version}
+			}
+			fileQuery := query
+			fileQuery.Locations = []wrappers.KicsFiles{location}
+			fileResults[relativePath].Results = append(fileResults[relativePath].Results, fileQuery)
+		}
+	}
+
+	iacRealtimeResults := map[string][]IacRealtimeResult{}
+	for relativePath, collection := range fileResults {
+		iacRealtimeResults[relativePath] = s.mapper.ConvertKicsToIacResults(collection, filePaths[relativePath])
+	}
+	return iacRealtimeResults, nil
+}
+
+// relativeResultPath returns the path relative to the scanned folder of a file reported by KICS, which sees the
+// folder at ContainerPath in a container and at tempDir as a binary
+func (s *Scanner) relativeResultPath(fileName, tempDir string) string {
+	fileName = filepath.ToSlash(fileName)
+	for _, root := range []string{ContainerPath, filepath.ToSlash(tempDir)} {
+		if relativePath, ok := strings.CutPrefix(fileName, root+"/"); ok {
+			return relativePath
+		}
+	}
+	return fileName
+}
+
+// checkKicsError returns the error of a KICS run that produced no results. KICS exits with one of KicsErrorCodes
+// when it finds results.
+func (s *Scanner) checkKicsError(kicsErrorCode error) error {
 	if kicsErrorCode == nil {
-		// No error, process results
-		return s.processResults(tempDir, filePath)
+		return nil
 	}
 
 	msg := kicsErrorCode.Error()
 	code := s.extractErrorCode(msg)
 
 	if slices.Contains(KicsErrorCodes, code) {
-		return s.processResults(tempDir, filePath)
+		return nil
 	}
 
 	if exitErr, ok := kicsErrorCode.(*exec.ExitError); ok && exitErr.ExitCode() == util.EngineNoRunningCode {
-		return nil, errors.New(util.NotRunningEngineMessage)
+		return errors.New(util.NotRunningEngineMessage)
 	}
 
 	if strings.Contains(msg, util.InvalidEngineError) || strings.Contains(msg, util.InvalidEngineErrorWindows) {
-		return nil, errors.New(util.InvalidEngineMessage)
+		return errors.New(util.InvalidEngineMessage)
 	}
 
-	return nil, errors.Errorf("Check container engine state. Failed: %s", msg)
+	return errors.Errorf("Check container engine state. Failed: %s", msg)
 }
 
 func (s *Scanner) processResults(tempDir, filePath string) ([]IacRealtimeResult, error) {
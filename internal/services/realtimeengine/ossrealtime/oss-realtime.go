@@ -100,7 +100,7 @@ func (o *OssRealtimeService) RunOssRealtimeScan(filePath, ignoredFilePath string
 	if ignoredFilePath != "" {
 		ignoredPkgs, err := loadIgnoredPackages(ignoredFilePath)
 		if err != nil {
-			logger.PrintfIfVerbose("oss-realtime: failed to load ignore file %s: %v; continuing without ignore filtering", ignoredFilePath, err)
+			logger.Warnf("oss-realtime: failed to load ignore file %s: %v; continuing without ignore filtering", ignoredFilePath, err)
 		} else {
 			ignoreMap := buildIgnoreMap(ignoredPkgs)
 			response.Packages = filterIgnoredPackages(response.Packages, ignoreMap)
@@ -323,7 +323,7 @@ func (o *OssRealtimeService) scanAndCache(requestPackages *wrappers.RealtimeScan
 	versionMapping := createVersionMapping(requestPackages, result)
 
 	if err := osscache.AppendToCache(result, versionMapping); err != nil {
-		logger.PrintfIfVerbose("oss-realtime: failed to update cache: %v", err)
+		logger.Warnf("oss-realtime: failed to update cache: %v", err)
 	}
 
 	return result, nil
@@ -115,7 +115,7 @@ func (s *SecretsRealtimeService) RunSecretsRealtimeScan(filePath, ignoredFilePat
 	}
 	ignoredSecrets, err := loadIgnoredSecrets(ignoredFilePath)
 	if err != nil {
-		logger.PrintfIfVerbose("secrets-realtime: failed to load ignore file %s: %v; continuing without ignore filtering", ignoredFilePath, err)
+		logger.Warnf("secrets-realtime: failed to load ignore file %s: %v; continuing without ignore filtering", ignoredFilePath, err)
 		return results, nil
 	}
 	ignoreMap := buildIgnoreMap(ignoredSecrets)
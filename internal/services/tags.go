@@ -2,7 +2,7 @@ package services
 
 import "strings"
 
-func createTagMap(tagListStr string) map[string]string {
+func CreateTagMap(tagListStr string) map[string]string {
 	tagsList := strings.Split(tagListStr, ",")
 	tags := make(map[string]string)
 	for _, tag := range tagsList {
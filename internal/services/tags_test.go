@@ -23,8 +23,8 @@ func Test_createTagMap(t *testing.T) {
 	for _, tt := range tests {
 		ttt := tt
 		t.Run(tt.name, func(t *testing.T) {
-			if got := createTagMap(ttt.args.tagListStr); !reflect.DeepEqual(got, ttt.want) {
-				t.Errorf("createTagMap() = %v, want %v", got, ttt.want)
+			if got := CreateTagMap(ttt.args.tagListStr); !reflect.DeepEqual(got, ttt.want) {
+				t.Errorf("CreateTagMap() = %v, want %v", got, ttt.want)
 			}
 		})
 	}
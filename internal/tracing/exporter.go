@@ -0,0 +1,64 @@
+package tracing
+
+import (
+	"context"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+const (
+	tracesPath    = "/v1/traces"
+	exportTimeout = 10 * time.Second
+	traceFileMode = 0600
+)
+
+// fileExporter writes every span as a JSON object on its own line of a file, closed on shutdown.
+type fileExporter struct {
+	*stdouttrace.Exporter
+	file *os.File
+}
+
+func newFileExporter(path string) (*fileExporter, error) {
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, traceFileMode)
+	if err != nil {
+		return nil, err
+	}
+	exporter, err := stdouttrace.New(stdouttrace.WithWriter(file))
+	if err != nil {
+		_ = file.Close()
+		return nil, err
+	}
+	return &fileExporter{Exporter: exporter, file: file}, nil
+}
+
+func (e *fileExporter) Shutdown(ctx context.Context) error {
+	err := e.Exporter.Shutdown(ctx)
+	if closeErr := e.file.Close(); err == nil {
+		err = closeErr
+	}
+	return err
+}
+
+// newHTTPExporter posts spans as OTLP/HTTP with client, which is expected to carry the proxy and TLS settings
+// of the CLI. Sending through the client directly keeps exports out of the CLI's own HTTP spans, rate limiting
+// and recordings.
+func newHTTPExporter(endpoint string, client *http.Client) (sdktrace.SpanExporter, error) {
+	endpoint = strings.TrimSuffix(endpoint, "/")
+	if !strings.HasSuffix(endpoint, tracesPath) {
+		endpoint += tracesPath
+	}
+	options := []otlptracehttp.Option{
+		otlptracehttp.WithEndpointURL(endpoint),
+		otlptracehttp.WithTimeout(exportTimeout),
+	}
+	if client != nil {
+		options = append(options, otlptracehttp.WithHTTPClient(client))
+	}
+	return otlptracehttp.New(context.Background(), options...)
+}
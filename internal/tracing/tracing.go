@@ -0,0 +1,187 @@
+package tracing
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/checkmarx/ast-cli/internal/logger"
+	"github.com/checkmarx/ast-cli/internal/params"
+	"github.com/pkg/errors"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	tracerName      = "github.com/checkmarx/ast-cli"
+	serviceName     = "cx"
+	shutdownTimeout = 10 * time.Second
+)
+
+var (
+	mutex       sync.Mutex
+	provider    *sdktrace.TracerProvider
+	commandSpan trace.Span
+	current     = context.Background()
+	propagator  = propagation.TraceContext{}
+)
+
+// Configure installs a tracer provider exporting spans over OTLP/HTTP to the collector at endpoint
+// and/or as JSON lines to file. Spans are posted to endpoint with client. Tracing stays disabled, and every span
+// a no-op, when both are empty.
+func Configure(endpoint, file string, client *http.Client) error {
+	endpoint = strings.TrimSpace(endpoint)
+	file = strings.TrimSpace(file)
+	if endpoint == "" && file == "" {
+		return nil
+	}
+
+	var options []sdktrace.TracerProviderOption
+	if file != "" {
+		fileExporter, err := newFileExporter(file)
+		if err != nil {
+			return errors.Wrapf(err, "Failed to create trace file %s", file)
+		}
+		// Spans are written as they end so the file is usable even when the CLI exits early.
+		options = append(options, sdktrace.WithSyncer(fileExporter))
+	}
+	if endpoint != "" {
+		httpExporter, err := newHTTPExporter(endpoint, client)
+		if err != nil {
+			return errors.Wrapf(err, "Failed to create the trace exporter for %s", endpoint)
+		}
+		options = append(options, sdktrace.WithBatcher(httpExporter))
+	}
+	options = append(options, sdktrace.WithResource(resource.NewSchemaless(
+		attribute.String("service.name", serviceName),
+		attribute.String("service.version", params.Version),
+	)))
+
+	mutex.Lock()
+	defer mutex.Unlock()
+	provider = sdktrace.NewTracerProvider(options...)
+	otel.SetTracerProvider(provider)
+	return nil
+}
+
+// StartCommand opens the root span of the CLI command and makes it the parent of every later span.
+func StartCommand(ctx context.Context, name string, attrs ...attribute.KeyValue) context.Context {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	ctx, span := otel.Tracer(tracerName).Start(ctx, name, trace.WithAttributes(attrs...))
+	mutex.Lock()
+	commandSpan = span
+	current = ctx
+	mutex.Unlock()
+	if span.SpanContext().IsValid() {
+		logger.PrintIfVerbose("Trace ID: " + span.SpanContext().TraceID().String())
+	}
+	return ctx
+}
+
+// EndCommand closes the command span, recording err, and flushes every pending span.
+func EndCommand(err error) {
+	mutex.Lock()
+	span, tracerProvider := commandSpan, provider
+	commandSpan = nil
+	current = context.Background()
+	mutex.Unlock()
+
+	if span != nil {
+		endSpan(span, err)
+	}
+	if tracerProvider != nil {
+		ctx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+		defer cancel()
+		if shutdownErr := tracerProvider.Shutdown(ctx); shutdownErr != nil {
+			logger.PrintIfVerbose("Failed to export traces: " + shutdownErr.Error())
+		}
+	}
+}
+
+// SetCommandAttributes adds attributes, such as the scan ID, to the command span.
+func SetCommandAttributes(attrs ...attribute.KeyValue) {
+	mutex.Lock()
+	span := commandSpan
+	mutex.Unlock()
+	if span != nil {
+		span.SetAttributes(attrs...)
+	}
+}
+
+// StartPhase opens a span for one phase of the running command, nested under the current phase.
+// Spans started until the returned func is called, including HTTP calls, become its children.
+func StartPhase(name string, attrs ...attribute.KeyValue) func(err error) {
+	mutex.Lock()
+	parent := current
+	ctx, span := otel.Tracer(tracerName).Start(parent, name, trace.WithAttributes(attrs...))
+	current = ctx
+	mutex.Unlock()
+	return func(err error) {
+		endSpan(span, err)
+		mutex.Lock()
+		if current == ctx {
+			current = parent
+		}
+		mutex.Unlock()
+	}
+}
+
+// StartHTTPSpan opens a client span for an outgoing request. With propagate, the trace is also sent in the
+// W3C traceparent header, so server-side logs can be correlated with the CLI trace.
+func StartHTTPSpan(req *http.Request, sanitizedURL string, propagate bool) (*http.Request, trace.Span) {
+	ctx := req.Context()
+	if !trace.SpanContextFromContext(ctx).IsValid() {
+		mutex.Lock()
+		ctx = mergeSpan(ctx, current)
+		mutex.Unlock()
+	}
+	ctx, span := otel.Tracer(tracerName).Start(ctx, "HTTP "+req.Method,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			attribute.String("http.request.method", req.Method),
+			attribute.String("url.full", sanitizedURL),
+			attribute.String("server.address", req.URL.Hostname()),
+		))
+	req = req.WithContext(ctx)
+	if propagate {
+		propagator.Inject(ctx, propagation.HeaderCarrier(req.Header))
+	}
+	return req, span
+}
+
+// EndHTTPSpan records the outcome of a request started with StartHTTPSpan.
+func EndHTTPSpan(span trace.Span, resp *http.Response, err error) {
+	if resp != nil {
+		span.SetAttributes(attribute.Int("http.response.status_code", resp.StatusCode))
+		if err == nil && resp.StatusCode >= http.StatusBadRequest {
+			span.SetStatus(codes.Error, http.StatusText(resp.StatusCode))
+		}
+	}
+	endSpan(span, err)
+}
+
+func endSpan(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
+
+// mergeSpan returns ctx carrying the span of spanCtx, keeping the values already stored in ctx.
+func mergeSpan(ctx, spanCtx context.Context) context.Context {
+	span := trace.SpanFromContext(spanCtx)
+	if !span.SpanContext().IsValid() {
+		return ctx
+	}
+	return trace.ContextWithSpan(ctx, span)
+}
@@ -0,0 +1,156 @@
+package tracing
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	coltracepb "go.opentelemetry.io/proto/otlp/collector/trace/v1"
+	"google.golang.org/protobuf/proto"
+)
+
+type spanContext struct {
+	TraceID string
+	SpanID  string
+}
+
+type fileSpan struct {
+	Name        string
+	SpanContext spanContext
+	Parent      spanContext
+	SpanKind    int
+	Status      struct {
+		Code        string
+		Description string
+	}
+	Attributes []struct {
+		Key   string
+		Value struct {
+			Value interface{}
+		}
+	}
+}
+
+func readTraceFile(t *testing.T, path string) []fileSpan {
+	file, err := os.Open(path)
+	assert.NoError(t, err)
+	defer file.Close()
+	var spans []fileSpan
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		span := fileSpan{}
+		assert.NoError(t, json.Unmarshal(scanner.Bytes(), &span))
+		spans = append(spans, span)
+	}
+	return spans
+}
+
+func spanByName(spans []fileSpan, name string) *fileSpan {
+	for i := range spans {
+		if spans[i].Name == name {
+			return &spans[i]
+		}
+	}
+	return nil
+}
+
+func TestTraceFile_CommandPhasesAndHTTP(t *testing.T) {
+	var traceparent string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		traceparent = r.Header.Get("traceparent")
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	tracePath := filepath.Join(t.TempDir(), "trace.jsonl")
+	assert.NoError(t, Configure("", tracePath, nil))
+	StartCommand(context.Background(), "cx scan create")
+	endUpload := StartPhase("scan.upload")
+	req, _ := http.NewRequest(http.MethodPut, server.URL+"/upload?token=secret", http.NoBody)
+	req, span := StartHTTPSpan(req, server.URL+"/upload", true)
+	resp, err := http.DefaultClient.Do(req)
+	assert.NoError(t, err)
+	_ = resp.Body.Close()
+	EndHTTPSpan(span, resp, nil)
+	endUpload(errors.New("upload failed"))
+	EndCommand(nil)
+
+	spans := readTraceFile(t, tracePath)
+	assert.Len(t, spans, 3)
+	command := spanByName(spans, "cx scan create")
+	upload := spanByName(spans, "scan.upload")
+	httpSpan := spanByName(spans, "HTTP PUT")
+	assert.NotNil(t, command)
+	assert.NotNil(t, upload)
+	assert.NotNil(t, httpSpan)
+
+	assert.Equal(t, "0000000000000000", command.Parent.SpanID)
+	assert.Equal(t, command.SpanContext.SpanID, upload.Parent.SpanID)
+	assert.Equal(t, upload.SpanContext.SpanID, httpSpan.Parent.SpanID)
+	assert.Equal(t, command.SpanContext.TraceID, httpSpan.SpanContext.TraceID)
+	assert.Equal(t, "00-"+httpSpan.SpanContext.TraceID+"-"+httpSpan.SpanContext.SpanID+"-01", traceparent)
+
+	assert.Equal(t, "Error", upload.Status.Code)
+	assert.Equal(t, "upload failed", upload.Status.Description)
+	assert.Equal(t, "Error", httpSpan.Status.Code)
+	assert.Equal(t, 3, httpSpan.SpanKind)
+	for _, attr := range httpSpan.Attributes {
+		if attr.Key == "http.response.status_code" {
+			assert.Equal(t, float64(http.StatusNotFound), attr.Value.Value)
+		}
+		if attr.Key == "url.full" {
+			assert.Equal(t, server.URL+"/upload", attr.Value.Value)
+		}
+	}
+}
+
+func TestHTTPExporter_PostsOTLP(t *testing.T) {
+	var path, contentType string
+	var body []byte
+	collector := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path = r.URL.Path
+		contentType = r.Header.Get("Content-Type")
+		body, _ = io.ReadAll(r.Body)
+	}))
+	defer collector.Close()
+
+	assert.NoError(t, Configure(collector.URL, "", collector.Client()))
+	StartCommand(context.Background(), "cx project list")
+	EndCommand(nil)
+
+	assert.Equal(t, tracesPath, path)
+	assert.Equal(t, "application/x-protobuf", contentType)
+	traces := &coltracepb.ExportTraceServiceRequest{}
+	assert.NoError(t, proto.Unmarshal(body, traces))
+	assert.Equal(t, "cx project list", traces.ResourceSpans[0].ScopeSpans[0].Spans[0].Name)
+	assert.Equal(t, "service.name", traces.ResourceSpans[0].Resource.Attributes[0].Key)
+}
+
+func TestStartHTTPSpan_WithoutPropagationAddsNoHeader(t *testing.T) {
+	tracePath := filepath.Join(t.TempDir(), "trace.jsonl")
+	assert.NoError(t, Configure("", tracePath, nil))
+	StartCommand(context.Background(), "cx pr github")
+	req, _ := http.NewRequest(http.MethodGet, "https://api.github.com", http.NoBody)
+	req, span := StartHTTPSpan(req, req.URL.String(), false)
+	EndHTTPSpan(span, nil, nil)
+	EndCommand(nil)
+
+	assert.Empty(t, req.Header.Get("traceparent"))
+	assert.NotNil(t, spanByName(readTraceFile(t, tracePath), "HTTP GET"))
+}
+
+func TestStartHTTPSpan_DisabledAddsNoHeader(t *testing.T) {
+	assert.NoError(t, Configure("", "", nil))
+	req, _ := http.NewRequest(http.MethodGet, "https://example.com", http.NoBody)
+	req, span := StartHTTPSpan(req, req.URL.String(), true)
+	EndHTTPSpan(span, nil, nil)
+	assert.Empty(t, req.Header.Get("traceparent"))
+}
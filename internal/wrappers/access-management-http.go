@@ -64,6 +64,23 @@ func (a *AccessManagementHTTPWrapper) CreateGroupsAssignment(projectID, projectN
 	return nil
 }
 
+func (a *AccessManagementHTTPWrapper) DeleteGroupsAssignment(projectID, projectName string, groups []*Group) error {
+	for _, group := range groups {
+		path := fmt.Sprintf("%s/%s?entity-id=%s&resource-id=%s", a.path, createAssignmentPath, group.ID, projectID)
+		resp, err := SendHTTPRequest(http.MethodDelete, path, http.NoBody, true, a.clientTimeout)
+		if err != nil {
+			return errors.Wrapf(err, "Failed to delete groups assignment")
+		}
+		resp.Body.Close()
+		if resp.StatusCode != http.StatusNoContent && resp.StatusCode != http.StatusOK {
+			return errors.Errorf("Failed to delete group '%s' assignment, status code: %d", group.Name, resp.StatusCode)
+		}
+		logger.PrintfIfVerbose("group '%s' assignment for project %s deleted", group.Name, projectName)
+	}
+	logger.PrintIfVerbose("Groups assignment deleted successfully")
+	return nil
+}
+
 func (a *AccessManagementHTTPWrapper) GetGroups(projectID string) ([]*Group, error) {
 	path := fmt.Sprintf("%s/%s?resource-id=%s&resource-type=project", a.path, entitiesForPath, projectID)
 	resp, err := SendHTTPRequest(http.MethodGet, path, nil, true, a.clientTimeout)
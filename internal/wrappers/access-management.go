@@ -13,6 +13,7 @@ type AssignmentResponse struct {
 type AccessManagementWrapper interface {
 	CreateGroupsAssignment(projectID, projectName string, groups []*Group) error
 	GetGroups(projectID string) ([]*Group, error)
+	DeleteGroupsAssignment(projectID, projectName string, groups []*Group) error
 }
 
 type AssignmentPayload struct {
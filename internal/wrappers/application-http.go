@@ -59,6 +59,74 @@ func (a *ApplicationsHTTPWrapper) CreateProjectAssociation(applicationID string,
 	}
 }
 
+func (a *ApplicationsHTTPWrapper) DeleteProjectAssociation(applicationID string, projectAssociationModel *AssociateProjectModel) (*ErrorModel, error) {
+	clientTimeout := viper.GetUint(commonParams.ClientTimeoutKey)
+	jsonBytes, err := json.Marshal(*projectAssociationModel)
+	if err != nil {
+		return nil, err
+	}
+	associationPath := fmt.Sprintf("%s/%s/%s", a.path, applicationID, "projects")
+	resp, err := SendHTTPRequest(http.MethodDelete, associationPath, bytes.NewBuffer(jsonBytes), true, clientTimeout)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+	return handleApplicationResponseWithNoBody(resp, http.StatusNoContent)
+}
+
+func (a *ApplicationsHTTPWrapper) Create(applicationBody *ApplicationConfiguration) (*Application, *ErrorModel, error) {
+	clientTimeout := viper.GetUint(commonParams.ClientTimeoutKey)
+	jsonBytes, err := json.Marshal(applicationBody)
+	if err != nil {
+		return nil, nil, err
+	}
+	resp, err := SendHTTPRequest(http.MethodPost, a.path, bytes.NewBuffer(jsonBytes), true, clientTimeout)
+	if err != nil {
+		return nil, nil, err
+	}
+	decoder := json.NewDecoder(resp.Body)
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	switch resp.StatusCode {
+	case http.StatusBadRequest:
+		errorModel := ErrorModel{}
+		err = decoder.Decode(&errorModel)
+		if err != nil {
+			return nil, nil, errors.Errorf("failed to parse application response: %s ", err)
+		}
+		return nil, &errorModel, nil
+	case http.StatusCreated:
+		model := Application{}
+		err = decoder.Decode(&model)
+		if err != nil {
+			return nil, nil, errors.Errorf("failed to parse application response: %s ", err)
+		}
+		return &model, nil, nil
+	case http.StatusForbidden:
+		return nil, nil, errors.New(errorConstants.NoPermissionToCreateApplication)
+	case http.StatusUnauthorized:
+		return nil, nil, errors.New(errorConstants.StatusUnauthorized)
+	default:
+		return nil, nil, errors.Errorf("response status code %d", resp.StatusCode)
+	}
+}
+
+func (a *ApplicationsHTTPWrapper) Delete(applicationID string) (*ErrorModel, error) {
+	clientTimeout := viper.GetUint(commonParams.ClientTimeoutKey)
+	resp, err := SendHTTPRequest(http.MethodDelete, fmt.Sprintf("%s/%s", a.path, applicationID), http.NoBody, true, clientTimeout)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+	return handleApplicationResponseWithNoBody(resp, http.StatusNoContent)
+}
+
 func (a *ApplicationsHTTPWrapper) Update(applicationID string, applicationBody *ApplicationConfiguration) (*ErrorModel, error) {
 	clientTimeout := viper.GetUint(commonParams.ClientTimeoutKey)
 	jsonBytes, err := json.Marshal(applicationBody)
@@ -135,3 +203,25 @@ func (a *ApplicationsHTTPWrapper) Get(params map[string]string) (*ApplicationsRe
 		return nil, errors.Errorf("response status code %d", resp.StatusCode)
 	}
 }
+
+func handleApplicationResponseWithNoBody(resp *http.Response, successStatusCode int) (*ErrorModel, error) {
+	switch resp.StatusCode {
+	case successStatusCode:
+		return nil, nil
+	case http.StatusBadRequest:
+		errorModel := ErrorModel{}
+		err := json.NewDecoder(resp.Body).Decode(&errorModel)
+		if err != nil {
+			return nil, errors.Errorf("failed to parse application response: %s ", err)
+		}
+		return &errorModel, nil
+	case http.StatusForbidden:
+		return nil, errors.New(errorConstants.NoPermissionToUpdateApplication)
+	case http.StatusUnauthorized:
+		return nil, errors.New(errorConstants.StatusUnauthorized)
+	case http.StatusNotFound:
+		return nil, errors.New(errorConstants.ApplicationDoesntExistOrNoPermission)
+	default:
+		return nil, errors.Errorf("response status code %d", resp.StatusCode)
+	}
+}
@@ -42,6 +42,9 @@ type Rule struct {
 
 type ApplicationsWrapper interface {
 	Get(params map[string]string) (*ApplicationsResponseModel, error)
+	Create(applicationBody *ApplicationConfiguration) (*Application, *ErrorModel, error)
 	Update(applicationID string, applicationBody *ApplicationConfiguration) (*ErrorModel, error)
+	Delete(applicationID string) (*ErrorModel, error)
 	CreateProjectAssociation(applicationID string, requestModel *AssociateProjectModel) (*ErrorModel, error)
+	DeleteProjectAssociation(applicationID string, requestModel *AssociateProjectModel) (*ErrorModel, error)
 }
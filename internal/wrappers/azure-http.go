@@ -22,6 +22,7 @@ type AzureHTTPWrapper struct {
 
 const (
 	azureSearchDate      = "searchCriteria.fromDate"
+	azureSearchToDate    = "searchCriteria.toDate"
 	azureAPIVersion      = "api-version"
 	azureAPIVersionValue = "5.0"
 	azureBaseCommitURL   = "%s%s/%s/_apis/git/repositories/%s/commits"
@@ -29,7 +30,6 @@ const (
 	azureBaseProjectsURL = "%s%s/_apis/projects"
 	azureTop             = "$top"
 	azurePage            = "$skip"
-	azureLayoutTime      = "2006-01-02"
 	failedAuth           = "failed Azure Authentication"
 	unauthorized         = "unauthorized: verify if the organization you provided is correct"
 	azurePageLenValue    = 100
@@ -41,7 +41,7 @@ func NewAzureWrapper() AzureWrapper {
 	}
 }
 
-func (g *AzureHTTPWrapper) GetCommits(url, organizationName, projectName, repositoryName, token string) (
+func (g *AzureHTTPWrapper) GetCommits(url, organizationName, projectName, repositoryName, token string, window CommitWindow) (
 	AzureRootCommit,
 	error,
 ) {
@@ -51,7 +51,8 @@ func (g *AzureHTTPWrapper) GetCommits(url, organizationName, projectName, reposi
 	var queryParams = make(map[string]string)
 
 	commitsURL := fmt.Sprintf(azureBaseCommitURL, url, organizationName, projectName, repositoryName)
-	queryParams[azureSearchDate] = getThreeMonthsTime()
+	queryParams[azureSearchDate] = window.Since.Format(time.RFC3339)
+	queryParams[azureSearchToDate] = window.Until.Format(time.RFC3339)
 	queryParams[azureAPIVersion] = azureAPIVersionValue
 	queryParams[azureTop] = fmt.Sprintf("%d", azurePageLenValue)
 
@@ -177,12 +178,6 @@ func (g *AzureHTTPWrapper) paginateGetter(url, token string, target, slice inter
 	return nil
 }
 
-func getThreeMonthsTime() string {
-	today := time.Now()
-	lastThreeMonths := today.AddDate(0, -3, 0).Format(azureLayoutTime)
-	return lastThreeMonths
-}
-
 func encodeToken(token string) string {
 	return b64.StdEncoding.EncodeToString([]byte(":" + token))
 }
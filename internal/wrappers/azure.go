@@ -43,6 +43,6 @@ type AzureProject struct {
 
 type AzureWrapper interface {
 	GetProjects(url string, organizationName string, token string) (AzureRootProject, error)
-	GetCommits(url, organizationName, projectName, repositoryName, token string) (AzureRootCommit, error)
+	GetCommits(url, organizationName, projectName, repositoryName, token string, window CommitWindow) (AzureRootCommit, error)
 	GetRepositories(url string, organizationName string, projectName string, token string) (AzureRootRepo, error)
 }
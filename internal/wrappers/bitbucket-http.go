@@ -71,7 +71,10 @@ func (g *BitBucketHTTPWrapper) GetRepoUUID(bitBucketURL, workspaceName, repoName
 	return repo, err
 }
 
-func (g *BitBucketHTTPWrapper) GetCommits(bitBucketURL, workspaceUUID, repoUUID, bitBucketUsername, bitBucketPassword string) (
+func (g *BitBucketHTTPWrapper) GetCommits(
+	bitBucketURL, workspaceUUID, repoUUID, bitBucketUsername, bitBucketPassword string,
+	window CommitWindow,
+) (
 	BitBucketRootCommit, error,
 ) {
 	var commits BitBucketRootCommit
@@ -83,6 +86,7 @@ func (g *BitBucketHTTPWrapper) GetCommits(bitBucketURL, workspaceUUID, repoUUID,
 		encodeBitBucketAuth(bitBucketUsername, bitBucketPassword),
 		commitType,
 		queryParams,
+		window.Since,
 	)
 	if err != nil {
 		return commits, err
@@ -99,12 +103,15 @@ func (g *BitBucketHTTPWrapper) GetCommits(bitBucketURL, workspaceUUID, repoUUID,
 			return commits, err
 		}
 		for _, pageCommit := range commitHolder.Commits {
-			// Filter the commits older than three months from the commits list
-			if !verifyDate(pageCommit) {
+			// Commits are listed newest first, so stop at the first commit older than the window
+			if !verifyDate(pageCommit, window.Since) {
 				return commits, nil
 			}
-			// Append the commit to the returned commits list
-			commits.Commits = append(commits.Commits, pageCommit)
+			// Append the commit to the returned commits list unless it is newer than the window
+			commitDate, _ := time.Parse(time.RFC3339, pageCommit.Date)
+			if window.Contains(commitDate) {
+				commits.Commits = append(commits.Commits, pageCommit)
+			}
 		}
 	}
 
@@ -123,6 +130,7 @@ func (g *BitBucketHTTPWrapper) GetRepositories(bitBucketURL, workspaceName, bitB
 		encodeBitBucketAuth(bitBucketUsername, bitBucketPassword),
 		repoType,
 		queryParams,
+		time.Time{},
 	)
 	if err != nil {
 		return repos, err
@@ -196,14 +204,10 @@ func encodeBitBucketAuth(username, password string) string {
 	return b64.StdEncoding.EncodeToString([]byte(username + ":" + password))
 }
 
-func verifyDate(commit BitBucketCommit) bool {
-	// Get last three months in string and cast it to date
-	threeMonthsString := getThreeMonthsTime()
-	threeMonths, _ := time.Parse(azureLayoutTime, threeMonthsString)
-
+func verifyDate(commit BitBucketCommit, since time.Time) bool {
 	commitDate, _ := time.Parse(time.RFC3339, commit.Date)
-	// Check if the commit date occurs after the last three months
-	return commitDate.After(threeMonths)
+	// Check if the commit date occurs after the start of the window
+	return commitDate.After(since)
 }
 
 func getWithPaginationBitBucket(
@@ -212,12 +216,13 @@ func getWithPaginationBitBucket(
 	token,
 	types string,
 	queryParams map[string]string,
+	since time.Time,
 ) ([]interface{}, error) {
 	var pageCollection = make([]interface{}, 0)
 	var currentPage = 1
 	var err error
 	for currentPage != -1 {
-		currentPage, err = collectPageBitBucket(client, token, url, types, currentPage, queryParams, &pageCollection)
+		currentPage, err = collectPageBitBucket(client, token, url, types, currentPage, queryParams, since, &pageCollection)
 		if err != nil {
 			return nil, err
 		}
@@ -232,6 +237,7 @@ func collectPageBitBucket(
 	types string,
 	currentPage int,
 	queryParams map[string]string,
+	since time.Time,
 	pageCollection *[]interface{},
 ) (int, error) {
 	var holder BitBucketPage
@@ -261,7 +267,7 @@ func collectPageBitBucket(
 		}
 		commitHolder := BitBucketRootCommit{}
 		err = json.Unmarshal(marshal, &commitHolder)
-		if err != nil || len(commitHolder.Commits) == 0 || !verifyDate(commitHolder.Commits[len(commitHolder.Commits)-1]) {
+		if err != nil || len(commitHolder.Commits) == 0 || !verifyDate(commitHolder.Commits[len(commitHolder.Commits)-1], since) {
 			return -1, err
 		}
 	}
@@ -42,6 +42,9 @@ type BitBucketPage struct {
 type BitBucketWrapper interface {
 	GetworkspaceUUID(bitBucketURL, workspace, bitBucketUsername, bitBucketPassword string) (BitBucketRootWorkspace, error)
 	GetRepoUUID(bitBucketURL, workspaceName, repo, bitBucketUsername, bitBucketPassword string) (BitBucketRootRepo, error)
-	GetCommits(bitBucketURL, workspaceUUID, repoUUID, bitBucketUsername, bitBucketPassword string) (BitBucketRootCommit, error)
+	GetCommits(bitBucketURL, workspaceUUID, repoUUID, bitBucketUsername, bitBucketPassword string, window CommitWindow) (
+		BitBucketRootCommit,
+		error,
+	)
 	GetRepositories(bitBucketURL, workspaceUUID, bitBucketUsername, bitBucketPassword string) (BitBucketRootRepoList, error)
 }
@@ -41,8 +41,7 @@ const (
 )
 
 var (
-	ErrNotFound    = errors.New("resource not found")
-	ninetyDaysPast = time.Now().AddDate(0, -3, 0)
+	ErrNotFound = errors.New("resource not found")
 )
 
 func NewBitbucketServerWrapper() Wrapper {
@@ -51,7 +50,7 @@ func NewBitbucketServerWrapper() Wrapper {
 	}
 }
 
-func (b HTTPWrapper) GetCommits(bitBucketURL, projectKey, repoSlug, bitBucketPassword string) (
+func (b HTTPWrapper) GetCommits(bitBucketURL, projectKey, repoSlug, bitBucketPassword string, window wrappers.CommitWindow) (
 	[]Commit,
 	error,
 ) {
@@ -62,7 +61,8 @@ func (b HTTPWrapper) GetCommits(bitBucketURL, projectKey, repoSlug, bitBucketPas
 	pageHolder := CommitList{}
 	pageHolder.IsLastPage = false
 	pageHolder.NextPageStart = 0
-	for !pageHolder.IsLastPage {
+	// Commits are listed newest first, so stop paging at the first commit older than the window
+	for olderThanWindow := false; !pageHolder.IsLastPage && !olderThanWindow; {
 		queryParams := buildQueryParams(pageHolder.NextPageStart)
 
 		err := getBitBucketServer(b.client, bitBucketPassword, url, &pageHolder, queryParams)
@@ -75,11 +75,13 @@ func (b HTTPWrapper) GetCommits(bitBucketURL, projectKey, repoSlug, bitBucketPas
 
 		for _, commit := range pageHolder.Commits {
 			timestamp := time.UnixMilli(commit.AuthorTimestamp)
-			if timestamp.After(ninetyDaysPast) {
-				acc = append(acc, commit)
-			} else {
+			if timestamp.Before(window.Since) {
+				olderThanWindow = true
 				break
 			}
+			if window.Contains(timestamp) {
+				acc = append(acc, commit)
+			}
 		}
 	}
 
@@ -198,7 +200,7 @@ func getBitBucketServer(
 			return err
 		}
 		for _, errorModel := range errorResponse.Errors {
-			logger.Printf(bitBucketServerNotFound, errorModel.Message, url)
+			logger.Warnf(bitBucketServerNotFound, errorModel.Message, url)
 		}
 		return ErrNotFound
 		// Case the commit/project does not exist in the organization
@@ -1,5 +1,7 @@
 package bitbucketserver
 
+import "github.com/checkmarx/ast-cli/internal/wrappers"
+
 type CommitList struct {
 	Commits       []Commit `json:"values,omitempty"`
 	IsLastPage    bool     `json:"isLastPage"`
@@ -50,7 +52,7 @@ type ProjectList struct {
 }
 
 type Wrapper interface {
-	GetCommits(bitBucketURL, projectKey, repoSlug, bitBucketPassword string) (
+	GetCommits(bitBucketURL, projectKey, repoSlug, bitBucketPassword string, window wrappers.CommitWindow) (
 		[]Commit,
 		error,
 	)
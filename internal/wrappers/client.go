@@ -3,6 +3,7 @@ package wrappers
 import (
 	"bytes"
 	"crypto/tls"
+	"crypto/x509"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -19,10 +20,14 @@ import (
 
 	applicationErrors "github.com/checkmarx/ast-cli/internal/constants/errors"
 	"github.com/checkmarx/ast-cli/internal/logger"
+	"github.com/checkmarx/ast-cli/internal/tracing"
 	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
 
 	"github.com/pkg/errors"
 	"github.com/spf13/viper"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 
 	commonParams "github.com/checkmarx/ast-cli/internal/params"
 	"github.com/checkmarx/ast-cli/internal/wrappers/kerberos"
@@ -93,16 +98,23 @@ func retryHTTPRequest(requestFunc func() (*http.Response, error), retries int, b
 		if err != nil {
 			return nil, err
 		}
+		delay := baseDelayInMilliSec * (1 << attempt)
 		if resp.StatusCode == http.StatusBadGateway {
 			logger.PrintIfVerbose("Bad Gateway (502), retrying")
 		} else if resp.StatusCode == http.StatusUnauthorized {
 			logger.PrintIfVerbose("Unauthorized request (401), refreshing token")
 			_, _ = configureClientCredentialsAndGetNewToken()
+		} else if isRateLimitedResponse(resp) {
+			delay = ServerRetryAfter(resp.Header, delay)
+			logger.PrintIfVerbose(fmt.Sprintf("Rate limited (%d), retrying in %s", resp.StatusCode, delay))
 		} else {
 			return resp, nil
 		}
+		if attempt == retries-1 {
+			break
+		}
 		_ = resp.Body.Close()
-		time.Sleep(baseDelayInMilliSec * (1 << attempt))
+		time.Sleep(delay)
 	}
 	return resp, nil
 }
@@ -141,6 +153,10 @@ func setAgentNameAndOrigin(req *http.Request, isAuth bool) {
 
 	originStr := viper.GetString(commonParams.OriginKey)
 	req.Header.Set("Cx-Origin", originStr)
+	// Only Checkmarx One requests carry a request ID, it is never sent to SCM or other third-party hosts.
+	if req.Header.Get(logger.RequestIDHeader) == "" {
+		req.Header.Set(logger.RequestIDHeader, uuid.New().String())
+	}
 
 	if !isAuth {
 		uniqueID := GetUniqueID()
@@ -187,24 +203,74 @@ func GetClient(timeout uint) *http.Client {
 }
 
 func basicProxyClient(timeout uint, proxyStr string) *http.Client {
-	insecure := viper.GetBool("insecure")
+	tlsConfig := getTLSConfigOrExit()
 	u, _ := url.Parse(proxyStr)
 	var tr *http.Transport
 	if len(proxyStr) > 0 {
 		logger.PrintIfVerbose("Creating HTTP Client with Proxy: " + proxyStr)
 		tr = &http.Transport{
-			TLSClientConfig: &tls.Config{InsecureSkipVerify: insecure},
+			TLSClientConfig: tlsConfig,
 			Proxy:           http.ProxyURL(u),
 		}
 	} else {
 		logger.PrintIfVerbose("Creating HTTP Client.")
 		tr = &http.Transport{
-			TLSClientConfig: &tls.Config{InsecureSkipVerify: insecure},
+			TLSClientConfig: tlsConfig,
 		}
 	}
 	return &http.Client{Transport: tr, Timeout: time.Duration(timeout) * time.Second}
 }
 
+// NewTLSConfig builds the TLS configuration shared by every transport the CLI creates. It honors
+// --insecure, trusts the CA bundle given by --ca-cert on top of the system pool and presents the
+// --client-cert/--client-key pair for mutual TLS. When --client-key is omitted the private key is
+// read from the --client-cert file.
+func NewTLSConfig() (*tls.Config, error) {
+	tlsConfig := &tls.Config{InsecureSkipVerify: viper.GetBool(commonParams.InsecureFlag)}
+
+	caCertPath := strings.TrimSpace(viper.GetString(commonParams.CACertKey))
+	if caCertPath != "" {
+		caCerts, err := os.ReadFile(caCertPath)
+		if err != nil {
+			return nil, errors.Wrapf(err, "Failed to read CA certificate bundle %s", caCertPath)
+		}
+		rootCAs, err := x509.SystemCertPool()
+		if err != nil || rootCAs == nil {
+			rootCAs = x509.NewCertPool()
+		}
+		if !rootCAs.AppendCertsFromPEM(caCerts) {
+			return nil, errors.Errorf("No PEM encoded certificates found in CA certificate bundle %s", caCertPath)
+		}
+		tlsConfig.RootCAs = rootCAs
+	}
+
+	clientCertPath := strings.TrimSpace(viper.GetString(commonParams.ClientCertKey))
+	clientKeyPath := strings.TrimSpace(viper.GetString(commonParams.ClientKeyKey))
+	if clientCertPath == "" && clientKeyPath != "" {
+		return nil, errors.Errorf("--%s requires --%s", commonParams.ClientKeyFlag, commonParams.ClientCertFlag)
+	}
+	if clientCertPath != "" {
+		if clientKeyPath == "" {
+			clientKeyPath = clientCertPath
+		}
+		clientCert, err := tls.LoadX509KeyPair(clientCertPath, clientKeyPath)
+		if err != nil {
+			return nil, errors.Wrapf(err, "Failed to load client certificate %s", clientCertPath)
+		}
+		tlsConfig.Certificates = []tls.Certificate{clientCert}
+	}
+	return tlsConfig, nil
+}
+
+func getTLSConfigOrExit() *tls.Config {
+	tlsConfig, err := NewTLSConfig()
+	if err != nil {
+		logger.Errorf("Failed to configure TLS: %v", err)
+		os.Exit(1)
+	}
+	return tlsConfig
+}
+
 func ntmlProxyClient(timeout uint, proxyStr string) *http.Client {
 	dialer := &net.Dialer{
 		Timeout:   defaultDialerDuration,
@@ -214,12 +280,14 @@ func ntmlProxyClient(timeout uint, proxyStr string) *http.Client {
 	domainStr := viper.GetString(commonParams.ProxyDomainKey)
 	proxyUser := u.User.Username()
 	proxyPass, _ := u.User.Password()
+	tlsConfig := getTLSConfigOrExit()
 	logger.PrintIfVerbose("Creating HTTP client using NTLM Proxy using: " + proxyStr)
-	ntlmDialContext := ntlm.NewNTLMProxyDialContext(dialer, u, proxyUser, proxyPass, domainStr, nil)
+	ntlmDialContext := ntlm.NewNTLMProxyDialContext(dialer, u, proxyUser, proxyPass, domainStr, tlsConfig)
 	return &http.Client{
 		Transport: &http.Transport{
-			Proxy:       nil,
-			DialContext: ntlmDialContext,
+			Proxy:           nil,
+			DialContext:     ntlmDialContext,
+			TLSClientConfig: tlsConfig,
 		},
 		Timeout: time.Duration(timeout) * time.Second,
 	}
@@ -232,8 +300,7 @@ func kerberosProxyClient(timeout uint, proxyStr string) *http.Client {
 	}
 
 	if proxyStr == "" {
-		logger.PrintIfVerbose("Error: Proxy string is required for Kerberos proxy authentication.")
-		logger.Print("Error: Proxy string is required for Kerberos proxy authentication.")
+		logger.Error("Proxy string is required for Kerberos proxy authentication.")
 		logger.PrintIfVerbose("Please provide Proxy string using: --proxy 'http://proxy.example.com' or set CX_PROXY environment variable")
 		os.Exit(1)
 	}
@@ -245,8 +312,7 @@ func kerberosProxyClient(timeout uint, proxyStr string) *http.Client {
 
 	// Validate required SPN parameter
 	if proxySPN == "" {
-		logger.PrintIfVerbose("Error: Kerberos SPN is required for Kerberos proxy authentication.")
-		logger.Print("Error: Kerberos SPN is required for Kerberos proxy authentication.")
+		logger.Error("Kerberos SPN is required for Kerberos proxy authentication.")
 		logger.PrintIfVerbose("Please provide SPN using: --proxy-kerberos-spn 'HTTP/proxy.example.com' or set CX_PROXY_KERBEROS_SPN environment variable")
 		os.Exit(1)
 	}
@@ -258,8 +324,7 @@ func kerberosProxyClient(timeout uint, proxyStr string) *http.Client {
 // kerberosNativeProxyClient creates an HTTP client using Windows native Kerberos (SSPI)
 func kerberosNativeProxyClient(timeout uint, proxyStr string) *http.Client {
 	if runtime.GOOS != "windows" {
-		logger.PrintIfVerbose("Error: --proxy-auth-type kerberos-native is only supported on Windows")
-		logger.Print("Error: --proxy-auth-type kerberos-native is only supported on Windows")
+		logger.Error("--proxy-auth-type kerberos-native is only supported on Windows")
 		os.Exit(1)
 	}
 
@@ -272,15 +337,14 @@ func kerberosNativeProxyClient(timeout uint, proxyStr string) *http.Client {
 	// Get Kerberos configuration
 	proxySPN := viper.GetString(commonParams.ProxyKerberosSPNKey)
 	if proxySPN == "" {
-		logger.PrintIfVerbose("ERROR: Kerberos SPN is required for windows native kerberos authentication")
-		logger.Print("Error: Kerberos SPN is required for windows native kerberos authentication")
+		logger.Error("Kerberos SPN is required for windows native kerberos authentication")
 		os.Exit(1)
 	}
 
 	// Validate SSPI setup
 	if err := kerberos.ValidateSSPISetup(proxySPN); err != nil {
-		logger.PrintIfVerbose("Error: Failed to generate a token for the specified SPN." + err.Error())
-		logger.Print("Error: Failed to generate a token for the specified SPN.")
+		logger.PrintIfVerbose(err.Error())
+		logger.Error("Failed to generate a token for the specified SPN.")
 		os.Exit(1)
 	}
 
@@ -288,12 +352,14 @@ func kerberosNativeProxyClient(timeout uint, proxyStr string) *http.Client {
 	logger.PrintIfVerbose("Windows SSPI SPN: " + proxySPN)
 
 	// Use Windows SSPI DialContext
-	kerberosDialContext := kerberos.WindowsSSPIDialContext(dialer, u, proxySPN, nil)
+	tlsConfig := getTLSConfigOrExit()
+	kerberosDialContext := kerberos.WindowsSSPIDialContext(dialer, u, proxySPN, tlsConfig)
 
 	return &http.Client{
 		Transport: &http.Transport{
-			Proxy:       nil,
-			DialContext: kerberosDialContext,
+			Proxy:           nil,
+			DialContext:     kerberosDialContext,
+			TLSClientConfig: tlsConfig,
 		},
 		Timeout: time.Duration(timeout) * time.Second,
 	}
@@ -310,8 +376,7 @@ func kerberosGokrb5ProxyClient(timeout uint, proxyStr string, u *url.URL, dialer
 
 	// Early validation: Check gokrb5 Kerberos setup before creating client
 	if err := kerberos.ValidateKerberosSetup(krb5ConfPath, ccachePath, proxySPN); err != nil {
-		logger.PrintIfVerbose("Error: Kerberos proxy authentication setup failed: " + err.Error())
-		logger.Printf("Error: %v", err.Error())
+		logger.Errorf("Kerberos proxy authentication setup failed: %v", err)
 		os.Exit(0)
 	}
 
@@ -325,11 +390,13 @@ func kerberosGokrb5ProxyClient(timeout uint, proxyStr string, u *url.URL, dialer
 		CcachePath:   ccachePath,
 	}
 
-	kerberosDialContext := kerberos.NewKerberosProxyDialContext(dialer, u, kerberosConfig, nil)
+	tlsConfig := getTLSConfigOrExit()
+	kerberosDialContext := kerberos.NewKerberosProxyDialContext(dialer, u, kerberosConfig, tlsConfig)
 	return &http.Client{
 		Transport: &http.Transport{
-			Proxy:       nil,
-			DialContext: kerberosDialContext,
+			Proxy:           nil,
+			DialContext:     kerberosDialContext,
+			TLSClientConfig: tlsConfig,
 		},
 		Timeout: time.Duration(timeout) * time.Second,
 	}
@@ -422,7 +489,7 @@ func addReqMonitor(req *http.Request) *http.Request {
 				if err == nil {
 					logger.Print("Completed TLS handshake")
 				} else {
-					logger.Printf("%s, %s", "Error completing TLS handshake", err)
+					logger.Errorf("Error completing TLS handshake: %v", err)
 				}
 			},
 			GotFirstResponseByte: func() {
@@ -605,6 +672,13 @@ func enrichWithPasswordCredentials(
 }
 
 func configureClientCredentialsAndGetNewToken() (string, error) {
+	if replayedToken, replaying, err := replayedAccessToken(); replaying {
+		if err != nil {
+			return "", err
+		}
+		writeCredentialsToCache(replayedToken)
+		return replayedToken, nil
+	}
 	accessKeyID := viper.GetString(commonParams.AccessKeyIDConfigKey)
 	accessKeySecret := viper.GetString(commonParams.AccessKeySecretConfigKey)
 	astAPIKey := viper.GetString(commonParams.AstAPIKey)
@@ -780,7 +854,7 @@ func getAPIKeyPayload(astToken string) string {
 
 	clientID, err := extractAZPFromToken(astToken)
 	if err != nil {
-		logger.PrintIfVerbose("Failed to extract azp from token, using default client_id")
+		logger.Warn("Failed to extract azp from token, using default client_id")
 		clientID = "ast-app"
 	}
 
@@ -809,11 +883,20 @@ func doRequest(client *http.Client, req *http.Request) (*http.Response, error) {
 }
 
 func request(client *http.Client, req *http.Request, responseBody bool) (*http.Response, error) {
+	// Only Checkmarx One requests, which setAgentNameAndOrigin gives a request ID, carry the trace to the server
+	req, span := tracing.StartHTTPSpan(req, logger.SanitizeLogs(req.URL.String()), req.Header.Get(logger.RequestIDHeader) != "")
+	resp, err := requestWithRetries(client, req, responseBody, span)
+	tracing.EndHTTPSpan(span, resp, err)
+	return resp, err
+}
+
+func requestWithRetries(client *http.Client, req *http.Request, responseBody bool, span trace.Span) (*http.Response, error) {
 	var err error
 	var resp *http.Response
 	var body []byte
 	retryLimit := int(viper.GetUint(commonParams.RetryFlag))
 	retryWaitTimeSeconds := viper.GetUint(commonParams.RetryDelayFlag)
+	client = withHTTPArchive(client)
 	logger.PrintRequest(req)
 	if req.Body != nil {
 		body, err = ioutil.ReadAll(req.Body)
@@ -833,7 +916,12 @@ func request(client *http.Client, req *http.Request, responseBody bool) (*http.R
 				try+tryPrintOffset, retryLimit+retryLimitPrintOffset,
 			),
 		)
+		if err = apiRateLimiter.Wait(req.Context(), req.URL.Host); err != nil {
+			return nil, err
+		}
+		span.SetAttributes(attribute.Int("http.request.attempts", try+tryPrintOffset))
 		resp, err = client.Do(req)
+		apiRateLimiter.Observe(req.URL.Host, resp)
 		Domains = AppendIfNotExists(Domains, req.URL.Host)
 		if err != nil {
 			logger.PrintIfVerbose(err.Error())
@@ -848,10 +936,19 @@ func request(client *http.Client, req *http.Request, responseBody bool) (*http.R
 				req, err = handleRedirect(resp, req, body)
 				continue
 			}
+			if isRateLimitedResponse(resp) && try < retryLimit-1 {
+				// Wait as long as the server asks, the following attempt also waits for the pause of the host
+				delay := ServerRetryAfter(resp.Header, time.Duration(retryWaitTimeSeconds)*time.Second)
+				logger.PrintIfVerbose(fmt.Sprintf("Rate limited (%d) in attempt %d, retrying in %s", resp.StatusCode, try+tryPrintOffset, delay))
+				_, _ = io.Copy(io.Discard, resp.Body)
+				_ = resp.Body.Close()
+				time.Sleep(delay)
+				continue
+			}
 			logger.PrintResponse(resp, responseBody)
 			return resp, nil
 		}
-		logger.PrintIfVerbose(fmt.Sprintf("Request failed in attempt %d", try+tryPrintOffset))
+		logger.Warnf("Request failed in attempt %d", try+tryPrintOffset)
 		time.Sleep(time.Duration(retryWaitTimeSeconds) * time.Second)
 	}
 	return nil, err
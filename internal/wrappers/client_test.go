@@ -1,19 +1,32 @@
 package wrappers
 
 import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
 	"encoding/base64"
 	"encoding/json"
+	"encoding/pem"
 	"errors"
 	"fmt"
+	"math/big"
 	"net/http"
 	"net/http/httptest"
+	"os"
+	"path/filepath"
 	"strconv"
 	"strings"
 	"sync"
 	"testing"
 	"time"
 
+	"github.com/checkmarx/ast-cli/internal/logger"
 	commonParams "github.com/checkmarx/ast-cli/internal/params"
+	"github.com/checkmarx/ast-cli/internal/tracing"
 	"github.com/spf13/viper"
 	"github.com/stretchr/testify/assert"
 )
@@ -259,6 +272,41 @@ func TestSetAgentNameAndOrigin(t *testing.T) {
 	}
 }
 
+func TestSetAgentNameAndOriginRequestID(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "http://example.com", nil)
+	setAgentNameAndOrigin(req, false)
+	assert.NotEmpty(t, req.Header.Get(logger.RequestIDHeader))
+
+	req = httptest.NewRequest(http.MethodGet, "http://example.com", nil)
+	req.Header.Set(logger.RequestIDHeader, "request-1")
+	setAgentNameAndOrigin(req, false)
+	assert.Equal(t, "request-1", req.Header.Get(logger.RequestIDHeader))
+}
+
+func TestRequest_TraceparentOnlyForCheckmarxOne(t *testing.T) {
+	headers := make(chan http.Header, 2)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		headers <- r.Header.Clone()
+	}))
+	defer server.Close()
+	assert.NoError(t, tracing.Configure("", filepath.Join(t.TempDir(), "trace.jsonl"), nil))
+	tracing.StartCommand(context.Background(), "cx test")
+	defer tracing.EndCommand(nil)
+
+	scmReq, _ := http.NewRequest(http.MethodGet, server.URL, http.NoBody)
+	resp, err := request(server.Client(), scmReq, false)
+	assert.NoError(t, err)
+	_ = resp.Body.Close()
+	assert.Empty(t, (<-headers).Get("traceparent"))
+
+	cxReq, _ := http.NewRequest(http.MethodGet, server.URL, http.NoBody)
+	setAgentNameAndOrigin(cxReq, true)
+	resp, err = request(server.Client(), cxReq, false)
+	assert.NoError(t, err)
+	_ = resp.Body.Close()
+	assert.NotEmpty(t, (<-headers).Get("traceparent"))
+}
+
 // unsignedJWT builds a 3-segment JWT with the given claims. ExtractFromTokenClaims
 // uses ParseUnverified, so the signature segment is irrelevant.
 func unsignedJWT(claims map[string]interface{}) string {
@@ -369,3 +417,261 @@ func TestRetryHTTPIAMRequest_Fail(t *testing.T) {
 	assert.Error(t, err)
 	assert.Nil(t, resp)
 }
+
+func writeSelfSignedPair(t *testing.T, dir, name string) (certPath, keyPath string) {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: name},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	assert.NoError(t, err)
+	keyDer, err := x509.MarshalECPrivateKey(key)
+	assert.NoError(t, err)
+	certPath = filepath.Join(dir, name+".crt")
+	keyPath = filepath.Join(dir, name+".key")
+	assert.NoError(t, os.WriteFile(certPath, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0600))
+	assert.NoError(t, os.WriteFile(keyPath, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDer}), 0600))
+	return certPath, keyPath
+}
+
+func resetTLSSettings() {
+	viper.Set(commonParams.InsecureFlag, false)
+	viper.Set(commonParams.CACertKey, "")
+	viper.Set(commonParams.ClientCertKey, "")
+	viper.Set(commonParams.ClientKeyKey, "")
+}
+
+func TestNewTLSConfig_Defaults(t *testing.T) {
+	resetTLSSettings()
+	tlsConfig, err := NewTLSConfig()
+	assert.NoError(t, err)
+	assert.False(t, tlsConfig.InsecureSkipVerify)
+	assert.Nil(t, tlsConfig.RootCAs)
+	assert.Empty(t, tlsConfig.Certificates)
+}
+
+func TestNewTLSConfig_CACertTrustsServer(t *testing.T) {
+	defer resetTLSSettings()
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	caPath := filepath.Join(t.TempDir(), "ca.pem")
+	caPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+	assert.NoError(t, os.WriteFile(caPath, caPEM, 0600))
+	viper.Set(commonParams.CACertKey, caPath)
+
+	resp, err := GetClient(5).Get(server.URL)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	_ = resp.Body.Close()
+}
+
+func TestNewTLSConfig_InvalidCACert(t *testing.T) {
+	defer resetTLSSettings()
+	caPath := filepath.Join(t.TempDir(), "ca.pem")
+	assert.NoError(t, os.WriteFile(caPath, []byte("not a certificate"), 0600))
+	viper.Set(commonParams.CACertKey, caPath)
+
+	_, err := NewTLSConfig()
+	assert.ErrorContains(t, err, "No PEM encoded certificates found")
+
+	viper.Set(commonParams.CACertKey, filepath.Join(t.TempDir(), "missing.pem"))
+	_, err = NewTLSConfig()
+	assert.ErrorContains(t, err, "Failed to read CA certificate bundle")
+}
+
+func TestNewTLSConfig_ClientCertificate(t *testing.T) {
+	defer resetTLSSettings()
+	dir := t.TempDir()
+	certPath, keyPath := writeSelfSignedPair(t, dir, "client")
+
+	viper.Set(commonParams.ClientKeyKey, keyPath)
+	_, err := NewTLSConfig()
+	assert.ErrorContains(t, err, "--client-key requires --client-cert")
+
+	viper.Set(commonParams.ClientCertKey, certPath)
+	tlsConfig, err := NewTLSConfig()
+	assert.NoError(t, err)
+	assert.Len(t, tlsConfig.Certificates, 1)
+
+	combined := filepath.Join(dir, "combined.pem")
+	certPEM, _ := os.ReadFile(certPath)
+	keyPEM, _ := os.ReadFile(keyPath)
+	assert.NoError(t, os.WriteFile(combined, append(certPEM, keyPEM...), 0600))
+	viper.Set(commonParams.ClientCertKey, combined)
+	viper.Set(commonParams.ClientKeyKey, "")
+	tlsConfig, err = NewTLSConfig()
+	assert.NoError(t, err)
+	assert.Len(t, tlsConfig.Certificates, 1)
+}
+
+func TestGetClient_MutualTLS(t *testing.T) {
+	defer resetTLSSettings()
+	certPath, keyPath := writeSelfSignedPair(t, t.TempDir(), "client")
+
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	server.TLS = &tls.Config{ClientAuth: tls.RequireAnyClientCert}
+	server.StartTLS()
+	defer server.Close()
+
+	viper.Set(commonParams.InsecureFlag, true)
+	_, err := GetClient(5).Get(server.URL)
+	assert.Error(t, err, "server must reject connections without a client certificate")
+
+	viper.Set(commonParams.ClientCertKey, certPath)
+	viper.Set(commonParams.ClientKeyKey, keyPath)
+	resp, err := GetClient(5).Get(server.URL)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	_ = resp.Body.Close()
+}
+
+func TestRetryHTTPRequest_HonorsRetryAfter(t *testing.T) {
+	attempts := 0
+	fn := func() (*http.Response, error) {
+		attempts++
+		if attempts == 1 {
+			return &http.Response{
+				StatusCode: http.StatusTooManyRequests,
+				Header:     http.Header{"Retry-After": []string{"1"}},
+				Body:       &mockReadCloser{},
+			}, nil
+		}
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Body:       &mockReadCloser{},
+		}, nil
+	}
+
+	start := time.Now()
+	resp, err := retryHTTPRequest(fn, retryAttempts, time.Millisecond)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, 2, attempts)
+	assert.GreaterOrEqual(t, time.Since(start), time.Second)
+}
+
+func TestRetryHTTPRequest_EndWithServiceUnavailable(t *testing.T) {
+	attempts := 0
+	fn := func() (*http.Response, error) {
+		attempts++
+		return &http.Response{
+			StatusCode: http.StatusServiceUnavailable,
+			Body:       &mockReadCloser{},
+		}, nil
+	}
+
+	resp, err := retryHTTPRequest(fn, retryAttempts, time.Millisecond)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusServiceUnavailable, resp.StatusCode)
+	assert.Equal(t, retryAttempts, attempts)
+}
+
+func TestServerRetryAfter(t *testing.T) {
+	fallback := 2 * time.Second
+	tests := []struct {
+		name    string
+		headers http.Header
+		min     time.Duration
+		max     time.Duration
+	}{
+		{"no headers uses fallback", http.Header{}, fallback, fallback},
+		{"retry-after seconds", http.Header{"Retry-After": []string{"7"}}, 7 * time.Second, 7 * time.Second},
+		{"retry-after http date", http.Header{"Retry-After": []string{time.Now().Add(30 * time.Second).UTC().Format(http.TimeFormat)}},
+			28 * time.Second, 30 * time.Second},
+		{"rate limit reset delta", http.Header{"X-Ratelimit-Reset": []string{"5"}}, 5 * time.Second, 5 * time.Second},
+		{"rate limit reset epoch", http.Header{"X-Ratelimit-Reset": []string{strconv.FormatInt(time.Now().Add(10*time.Second).Unix(), 10)}},
+			8 * time.Second, 10 * time.Second},
+		{"capped", http.Header{"Retry-After": []string{"86400"}}, maxServerRetryAfter, maxServerRetryAfter},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			wait := ServerRetryAfter(tt.headers, fallback)
+			assert.GreaterOrEqual(t, wait, tt.min)
+			assert.LessOrEqual(t, wait, tt.max)
+		})
+	}
+}
+
+func TestAPIRateLimiter_RequestsPerSecondBudget(t *testing.T) {
+	defer viper.Set(commonParams.RequestsPerSecondKey, 0)
+	viper.Set(commonParams.RequestsPerSecondKey, 4)
+	limiter := &APIRateLimiter{}
+
+	start := time.Now()
+	for i := 0; i < 6; i++ {
+		assert.NoError(t, limiter.Wait(context.Background(), "ast.checkmarx.net"))
+	}
+	assert.GreaterOrEqual(t, time.Since(start), 400*time.Millisecond)
+}
+
+func TestAPIRateLimiter_PausesOnExhaustedBudget(t *testing.T) {
+	limiter := &APIRateLimiter{}
+	limiter.Observe("ast.checkmarx.net", &http.Response{
+		StatusCode: http.StatusOK,
+		Header:     http.Header{"X-Ratelimit-Remaining": []string{"0"}, "X-Ratelimit-Reset": []string{"1"}},
+	})
+
+	start := time.Now()
+	assert.NoError(t, limiter.Wait(context.Background(), "ast.checkmarx.net"))
+	assert.GreaterOrEqual(t, time.Since(start), 900*time.Millisecond)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	limiter.Observe("ast.checkmarx.net", &http.Response{StatusCode: http.StatusTooManyRequests, Header: http.Header{"Retry-After": []string{"60"}}})
+	assert.ErrorIs(t, limiter.Wait(ctx, "ast.checkmarx.net"), context.Canceled)
+}
+
+func TestAPIRateLimiter_PausesOnlyTheThrottledHost(t *testing.T) {
+	limiter := &APIRateLimiter{}
+	limiter.Observe("api.github.com", &http.Response{
+		StatusCode: http.StatusOK,
+		Header:     http.Header{"X-Ratelimit-Remaining": []string{"0"}, "X-Ratelimit-Reset": []string{"60"}},
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	assert.NoError(t, limiter.Wait(ctx, "ast.checkmarx.net"))
+	assert.ErrorIs(t, limiter.Wait(ctx, "api.github.com"), context.DeadlineExceeded)
+}
+
+func TestRequestWithRetries_RetriesRateLimitedResponses(t *testing.T) {
+	defer viper.Set(commonParams.RetryFlag, viper.GetUint(commonParams.RetryFlag))
+	defer viper.Set(commonParams.RetryDelayFlag, viper.GetUint(commonParams.RetryDelayFlag))
+	viper.Set(commonParams.RetryFlag, 3)
+	viper.Set(commonParams.RetryDelayFlag, 0)
+	attempts := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		if attempts == 1 {
+			w.Header().Set("Retry-After", "1")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		if attempts == 2 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	req, _ := http.NewRequest(http.MethodGet, server.URL, http.NoBody)
+	start := time.Now()
+	resp, err := request(server.Client(), req, false)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, 3, attempts)
+	assert.GreaterOrEqual(t, time.Since(start), time.Second)
+	_ = resp.Body.Close()
+}
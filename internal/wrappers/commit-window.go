@@ -0,0 +1,65 @@
+package wrappers
+
+import (
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	commitWindowDays      = 90
+	commitWindowDayLayout = "2006-01-02"
+	invalidCommitDate     = "invalid date %s: use YYYY-MM-DD or RFC3339"
+	invalidCommitWindow   = "the since date %s must be before the until date %s"
+)
+
+// CommitWindow bounds the commits taken into account when counting contributors
+type CommitWindow struct {
+	Since time.Time
+	Until time.Time
+}
+
+// NewCommitWindow parses the since and until dates of a window. A date without time covers the whole day, an empty
+// until defaults to now and an empty since to ninety days before until.
+func NewCommitWindow(since, until string) (CommitWindow, error) {
+	var window CommitWindow
+	var err error
+
+	window.Until = time.Now().UTC()
+	if until != "" {
+		window.Until, err = parseCommitDate(until, true)
+		if err != nil {
+			return window, err
+		}
+	}
+	window.Since = window.Until.Add(-commitWindowDays * 24 * time.Hour)
+	if since != "" {
+		window.Since, err = parseCommitDate(since, false)
+		if err != nil {
+			return window, err
+		}
+	}
+	if !window.Since.Before(window.Until) {
+		return window, errors.Errorf(invalidCommitWindow, window.Since.Format(time.RFC3339), window.Until.Format(time.RFC3339))
+	}
+	return window, nil
+}
+
+// Contains reports whether date falls inside the window
+func (w CommitWindow) Contains(date time.Time) bool {
+	return !date.Before(w.Since) && !date.After(w.Until)
+}
+
+func parseCommitDate(date string, endOfDay bool) (time.Time, error) {
+	if parsed, err := time.Parse(time.RFC3339, date); err == nil {
+		return parsed.UTC(), nil
+	}
+	parsed, err := time.Parse(commitWindowDayLayout, date)
+	if err != nil {
+		return parsed, errors.Errorf(invalidCommitDate, date)
+	}
+	if endOfDay {
+		parsed = parsed.Add(24*time.Hour - time.Nanosecond)
+	}
+	return parsed, nil
+}
@@ -0,0 +1,28 @@
+package wrappers
+
+import (
+	"testing"
+	"time"
+
+	"gotest.tools/assert"
+)
+
+func TestNewCommitWindow(t *testing.T) {
+	window, err := NewCommitWindow("2026-01-01", "2026-01-31")
+	assert.NilError(t, err)
+	assert.Equal(t, window.Since, time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC))
+	// A date without time includes the whole day
+	assert.Assert(t, window.Contains(time.Date(2026, 1, 31, 23, 59, 0, 0, time.UTC)))
+	assert.Assert(t, !window.Contains(time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC)))
+	assert.Assert(t, !window.Contains(time.Date(2025, 12, 31, 23, 59, 0, 0, time.UTC)))
+
+	window, err = NewCommitWindow("", "2026-04-01T00:00:00Z")
+	assert.NilError(t, err)
+	assert.Equal(t, window.Since, time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC))
+
+	_, err = NewCommitWindow("2026-02-01", "2026-01-01")
+	assert.ErrorContains(t, err, "must be before")
+
+	_, err = NewCommitWindow("yesterday", "")
+	assert.ErrorContains(t, err, "invalid date yesterday")
+}
@@ -129,7 +129,7 @@ func getSpecificFlagWithRetry(wrapper FeatureFlagsWrapper, flagName string, retr
 		if err == nil {
 			return flag, nil
 		}
-		logger.PrintfIfVerbose("Retry %d/%d for flag %s failed with error: %v", i+1, retries, flagName, err)
+		logger.Warnf("Retry %d/%d for flag %s failed with error: %v", i+1, retries, flagName, err)
 	}
 
 	logger.PrintfIfVerbose("Failed to get feature flag %s after %d retries", flagName, retries)
@@ -162,7 +162,7 @@ func loadFeatureFlagsMap(allFlags FeatureFlagsResponseModel) {
 }
 
 func LoadFeatureFlagsDefaultValues() {
-	logger.PrintIfVerbose("Get feature flags failed. Loading defaults...")
+	logger.Warn("Get feature flags failed. Loading defaults...")
 
 	for _, cmdFlag := range FeatureFlagsBaseMap {
 		for _, flag := range cmdFlag.FeatureFlags {
@@ -0,0 +1,73 @@
+package wrappers
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+const (
+	giteaAPIPath         = "%s/api/v1"
+	giteaRepositoryURL   = "%s/repos/%s/%s"
+	giteaOrgReposURL     = "%s/orgs/%s/repos"
+	giteaLimitParam      = "limit"
+	giteaLimitValue      = "50"
+	giteaStatParam       = "stat"
+	giteaFilesParam      = "files"
+	giteaVerifyParam     = "verification"
+	giteaDisabledDetails = "false"
+)
+
+type GiteaHTTPWrapper struct{}
+
+func NewGiteaWrapper() GiteaWrapper {
+	return &GiteaHTTPWrapper{}
+}
+
+func (g *GiteaHTTPWrapper) GetRepository(serverURL, owner, repositoryName, token string) (GiteaRepository, error) {
+	var repository GiteaRepository
+	repositoryURL := fmt.Sprintf(giteaRepositoryURL, giteaAPIURL(serverURL), url.PathEscape(owner), url.PathEscape(repositoryName))
+	_, _, err := sendSCMRequest(http.MethodGet, repositoryURL, fmt.Sprintf(tokenFormat, token), nil, &repository)
+	return repository, err
+}
+
+func (g *GiteaHTTPWrapper) GetRepositories(serverURL, organizationName, token string) ([]GiteaRepository, error) {
+	reposURL := fmt.Sprintf(giteaOrgReposURL, giteaAPIURL(serverURL), url.PathEscape(organizationName))
+	return getSCMLinkPages[GiteaRepository](withQueryParams(reposURL, map[string]string{giteaLimitParam: giteaLimitValue}),
+		fmt.Sprintf(tokenFormat, token))
+}
+
+// GetCommits returns the commits of the default branch of repository, without their stats and files which are slow to
+// compute on the server.
+func (g *GiteaHTTPWrapper) GetCommits(
+	serverURL string,
+	repository GiteaRepository,
+	token string,
+	queryParams map[string]string,
+) ([]GiteaCommit, error) {
+	commitsURL := fmt.Sprintf(giteaRepositoryURL, giteaAPIURL(serverURL), url.PathEscape(repository.Owner.Login),
+		url.PathEscape(repository.Name)) + "/commits"
+	query := map[string]string{
+		giteaLimitParam:  giteaLimitValue,
+		giteaStatParam:   giteaDisabledDetails,
+		giteaFilesParam:  giteaDisabledDetails,
+		giteaVerifyParam: giteaDisabledDetails,
+	}
+	for key, value := range queryParams {
+		query[key] = value
+	}
+	return getSCMLinkPages[GiteaCommit](withQueryParams(commitsURL, query), fmt.Sprintf(tokenFormat, token))
+}
+
+func giteaAPIURL(serverURL string) string {
+	return fmt.Sprintf(giteaAPIPath, strings.TrimSuffix(serverURL, "/"))
+}
+
+func withQueryParams(requestURL string, queryParams map[string]string) string {
+	values := url.Values{}
+	for key, value := range queryParams {
+		values.Set(key, value)
+	}
+	return requestURL + "?" + values.Encode()
+}
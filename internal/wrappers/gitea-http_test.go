@@ -0,0 +1,59 @@
+package wrappers
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"gotest.tools/assert"
+)
+
+func TestGiteaGetCommits(t *testing.T) {
+	var serverURL string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, r.Header.Get(AuthorizationHeader), "token scm-token")
+		switch r.URL.Path {
+		case "/api/v1/orgs/org/repos":
+			_, _ = w.Write([]byte(`[{"name":"repo","full_name":"org/repo","owner":{"login":"org"}},{"name":"empty","empty":true}]`))
+		case "/api/v1/repos/org/repo/commits":
+			if r.URL.Query().Get("page") == "" {
+				assert.Equal(t, r.URL.Query().Get("since"), "2026-01-01T00:00:00Z")
+				assert.Equal(t, r.URL.Query().Get("stat"), "false")
+				w.Header().Set("Link", fmt.Sprintf(`<%s/api/v1/repos/org/repo/commits?page=2>; rel="next"`, serverURL))
+				_, _ = w.Write([]byte(`[{"commit":{"author":{"name":"Dev","email":"dev@example.com"}},"author":{"login":"dev"}}]`))
+				return
+			}
+			_, _ = w.Write([]byte(`[{"commit":{"author":{"name":"renovate[bot]","email":"bot@example.com"}}}]`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+	serverURL = server.URL
+	wrapper := NewGiteaWrapper()
+
+	repositories, err := wrapper.GetRepositories(server.URL+"/", "org", "scm-token")
+	assert.NilError(t, err)
+	assert.Equal(t, len(repositories), 2)
+	assert.Equal(t, repositories[0].Owner.Login, "org")
+	assert.Equal(t, repositories[1].Empty, true)
+
+	commits, err := wrapper.GetCommits(server.URL, repositories[0], "scm-token", map[string]string{"since": "2026-01-01T00:00:00Z"})
+	assert.NilError(t, err)
+	assert.Equal(t, len(commits), 2)
+	assert.Equal(t, commits[0].Commit.CommitAuthor.Email, "dev@example.com")
+	assert.Equal(t, commits[0].Author.Login, "dev")
+	assert.Assert(t, commits[1].Author == nil)
+}
+
+func TestGiteaGetRepositoryNotFound(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"message":"repository does not exist"}`))
+	}))
+	defer server.Close()
+
+	_, err := NewGiteaWrapper().GetRepository(server.URL, "org", "missing", "scm-token")
+	assert.ErrorContains(t, err, "Code 404")
+}
@@ -0,0 +1,26 @@
+package wrappers
+
+type GiteaUser struct {
+	Login string `json:"login"`
+}
+
+type GiteaRepository struct {
+	Name     string    `json:"name"`
+	FullName string    `json:"full_name"`
+	Owner    GiteaUser `json:"owner"`
+	Empty    bool      `json:"empty"`
+	Archived bool      `json:"archived"`
+}
+
+type GiteaCommit struct {
+	Commit Commit     `json:"commit"`
+	Author *GiteaUser `json:"author,omitempty"`
+}
+
+// GiteaWrapper lists the repositories and commits of a Gitea or Forgejo server through its REST API, url is the root
+// URL of the server.
+type GiteaWrapper interface {
+	GetRepository(url, owner, repositoryName, token string) (GiteaRepository, error)
+	GetRepositories(url, organizationName, token string) ([]GiteaRepository, error)
+	GetCommits(url string, repository GiteaRepository, token string, queryParams map[string]string) ([]GiteaCommit, error)
+}
@@ -18,6 +18,8 @@ type GitHubHTTPWrapper struct {
 	client               *http.Client
 	repositoryTemplate   string
 	organizationTemplate string
+	// templatesURL is the API base URL the templates were fetched from
+	templatesURL string
 }
 
 const (
@@ -41,41 +43,41 @@ func NewGitHubWrapper() GitHubWrapper {
 	}
 }
 
-func (g *GitHubHTTPWrapper) GetOrganization(organizationName string) (Organization, error) {
+func (g *GitHubHTTPWrapper) GetOrganization(url, organizationName, token string) (Organization, error) {
 	var err error
 	var organization Organization
 
-	organizationTemplate, err := g.getOrganizationTemplate()
+	organizationTemplate, err := g.getOrganizationTemplate(url, token)
 	if err != nil {
 		return organization, err
 	}
 	organizationURL := strings.ReplaceAll(organizationTemplate, orgPlaceholder, organizationName)
 
-	err = g.get(organizationURL, &organization)
+	err = g.get(organizationURL, token, &organization)
 
 	return organization, err
 }
 
-func (g *GitHubHTTPWrapper) GetRepository(organizationName, repositoryName string) (Repository, error) {
+func (g *GitHubHTTPWrapper) GetRepository(url, organizationName, repositoryName, token string) (Repository, error) {
 	var err error
 	var repository Repository
 
-	repositoryURL, err := g.getRepositoryTemplate()
+	repositoryURL, err := g.getRepositoryTemplate(url, token)
 	if err != nil {
 		return repository, err
 	}
 	repositoryURL = strings.ReplaceAll(repositoryURL, ownerPlaceholder, organizationName)
 	repositoryURL = strings.ReplaceAll(repositoryURL, repoPlaceholder, repositoryName)
 
-	err = g.get(repositoryURL, &repository)
+	err = g.get(repositoryURL, token, &repository)
 
 	return repository, err
 }
 
-func (g *GitHubHTTPWrapper) GetRepositories(organization Organization) ([]Repository, error) {
+func (g *GitHubHTTPWrapper) GetRepositories(organization Organization, token string) ([]Repository, error) {
 	repositoriesURL := organization.RepositoriesURL
 
-	pages, err := getWithPagination(g.client, repositoriesURL, map[string]string{})
+	pages, err := getWithPagination(g.client, repositoriesURL, token, map[string]string{})
 	if err != nil {
 		return nil, err
 	}
@@ -97,7 +99,7 @@ func (g *GitHubHTTPWrapper) GetRepositories(organization Organization) ([]Reposi
 	return castedPages, nil
 }
 
-func (g *GitHubHTTPWrapper) GetCommits(repository Repository, queryParams map[string]string) ([]CommitRoot, error) {
+func (g *GitHubHTTPWrapper) GetCommits(repository Repository, token string, queryParams map[string]string) ([]CommitRoot, error) {
 	commitsURL := repository.CommitsURL
 	index := strings.Index(commitsURL, "{")
 	if index < 0 {
@@ -105,7 +107,7 @@ func (g *GitHubHTTPWrapper) GetCommits(repository Repository, queryParams map[st
 	}
 	commitsURL = commitsURL[:index]
 
-	pages, err := getWithPagination(g.client, commitsURL, queryParams)
+	pages, err := getWithPagination(g.client, commitsURL, token, queryParams)
 	if err != nil {
 		return nil, err
 	}
@@ -127,41 +129,41 @@ func (g *GitHubHTTPWrapper) GetCommits(repository Repository, queryParams map[st
 	return castedPages, nil
 }
 
-func (g *GitHubHTTPWrapper) getOrganizationTemplate() (string, error) {
+func (g *GitHubHTTPWrapper) getOrganizationTemplate(url, token string) (string, error) {
 	var err error
 
-	if g.organizationTemplate == "" {
-		err = g.getTemplates()
+	if g.organizationTemplate == "" || g.templatesURL != url {
+		err = g.getTemplates(url, token)
 	}
 
 	return g.organizationTemplate, err
 }
 
-func (g *GitHubHTTPWrapper) getRepositoryTemplate() (string, error) {
+func (g *GitHubHTTPWrapper) getRepositoryTemplate(url, token string) (string, error) {
 	var err error
 
-	if g.repositoryTemplate == "" {
-		err = g.getTemplates()
+	if g.repositoryTemplate == "" || g.templatesURL != url {
+		err = g.getTemplates(url, token)
 	}
 
 	return g.repositoryTemplate, err
 }
 
-func (g *GitHubHTTPWrapper) getTemplates() error {
+func (g *GitHubHTTPWrapper) getTemplates(baseURL, token string) error {
 	var err error
 	var rootAPIResponse rootAPI
 
-	baseURL := viper.GetString(params.URLFlag)
-	err = g.get(baseURL, &rootAPIResponse)
+	err = g.get(baseURL, token, &rootAPIResponse)
 
 	g.organizationTemplate = rootAPIResponse.OrganizationURL
 	g.repositoryTemplate = rootAPIResponse.RepositoryURL
+	g.templatesURL = baseURL
 
 	return err
 }
 
-func (g *GitHubHTTPWrapper) get(url string, target interface{}) error {
-	resp, err := get(g.client, url, target, map[string]string{})
+func (g *GitHubHTTPWrapper) get(url, token string, target interface{}) error {
+	resp, err := get(g.client, url, token, target, map[string]string{})
 	if err != nil {
 		defer func() {
 			if err == nil {
@@ -174,20 +176,20 @@ func (g *GitHubHTTPWrapper) get(url string, target interface{}) error {
 
 func getWithPagination(
 	client *http.Client,
-	url string,
+	url, token string,
 	queryParams map[string]string,
 ) ([]interface{}, error) {
 	queryParams[perPageParam] = perPageValue
 
 	var pageCollection = make([]interface{}, 0)
 
-	next, err := collectPage(client, url, queryParams, &pageCollection)
+	next, err := collectPage(client, url, token, queryParams, &pageCollection)
 	if err != nil {
 		return nil, err
 	}
 
 	for next != "" {
-		next, err = collectPage(client, next, map[string]string{}, &pageCollection)
+		next, err = collectPage(client, next, token, map[string]string{}, &pageCollection)
 		if err != nil {
 			return nil, err
 		}
@@ -198,13 +200,13 @@ func getWithPagination(
 
 func collectPage(
 	client *http.Client,
-	url string,
+	url, token string,
 	queryParams map[string]string,
 	pageCollection *[]interface{},
 ) (string, error) {
 	var holder = make([]interface{}, 0)
 
-	resp, err := get(client, url, &holder, queryParams)
+	resp, err := get(client, url, token, &holder, queryParams)
 	if err != nil {
 		return "", err
 	}
@@ -236,13 +238,12 @@ func getNextPageLink(resp *http.Response) string {
 	return ""
 }
 
-func get(client *http.Client, url string, target interface{}, queryParams map[string]string) (*http.Response, error) {
+func get(client *http.Client, url, token string, target interface{}, queryParams map[string]string) (*http.Response, error) {
 	req, err := http.NewRequest(http.MethodGet, url, http.NoBody)
 	if err != nil {
 		return nil, err
 	}
 	req.Header.Add(acceptHeader, apiVersion)
-	token := viper.GetString(params.SCMTokenFlag)
 	logger.PrintRequest(req)
 	resp, err := WithSCMRateLimitRetry(
 		GitHubRateLimitConfig,
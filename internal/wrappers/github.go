@@ -20,7 +20,8 @@ type CommitRoot struct {
 }
 
 type Author struct {
-	Type string `json:"type"`
+	Type  string `json:"type"`
+	Login string `json:"login"`
 }
 
 type Commit struct {
@@ -33,8 +34,8 @@ type CommitAuthor struct {
 }
 
 type GitHubWrapper interface {
-	GetOrganization(organizationName string) (Organization, error)
-	GetRepository(organizationName, repositoryName string) (Repository, error)
-	GetRepositories(organization Organization) ([]Repository, error)
-	GetCommits(repository Repository, queryParams map[string]string) ([]CommitRoot, error)
+	GetOrganization(url, organizationName, token string) (Organization, error)
+	GetRepository(url, organizationName, repositoryName, token string) (Repository, error)
+	GetRepositories(organization Organization, token string) ([]Repository, error)
+	GetCommits(repository Repository, token string, queryParams map[string]string) ([]CommitRoot, error)
 }
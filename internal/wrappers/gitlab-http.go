@@ -38,12 +38,11 @@ func NewGitLabWrapper() GitLabWrapper {
 	}
 }
 
-func (g *GitLabHTTPWrapper) GetGitLabProjectsForUser() ([]GitLabProject, error) {
+func (g *GitLabHTTPWrapper) GetGitLabProjectsForUser(gitLabBaseURL, token string) ([]GitLabProject, error) {
 	var err error
-	gitLabBaseURL := viper.GetString(params.GitLabURLFlag)
 	getUserProjectsURL := fmt.Sprintf(gitLabProjectsURL, gitLabBaseURL, gitLabAPIVersion)
 
-	pages, err := fetchWithPagination(g.client, getUserProjectsURL, map[string]string{})
+	pages, err := fetchWithPagination(g.client, getUserProjectsURL, token, map[string]string{})
 	if err != nil {
 		return nil, err
 	}
@@ -64,17 +63,16 @@ func (g *GitLabHTTPWrapper) GetGitLabProjectsForUser() ([]GitLabProject, error)
 }
 
 func (g *GitLabHTTPWrapper) GetCommits(
-	gitLabProjectPathWithNameSpace string, queryParams map[string]string,
+	gitLabBaseURL, gitLabProjectPathWithNameSpace, token string, queryParams map[string]string,
 ) ([]GitLabCommit, error) {
 	var err error
-	gitLabBaseURL := viper.GetString(params.GitLabURLFlag)
 
 	encodedProjectPath := url.QueryEscape(gitLabProjectPathWithNameSpace)
 	commitsURL := fmt.Sprintf(gitLabCommitURL, gitLabBaseURL, gitLabAPIVersion, encodedProjectPath)
 
 	logger.PrintIfVerbose(fmt.Sprintf("Getting commits for project: %s", gitLabProjectPathWithNameSpace))
 
-	pages, err := fetchWithPagination(g.client, commitsURL, queryParams)
+	pages, err := fetchWithPagination(g.client, commitsURL, token, queryParams)
 	if err != nil {
 		return nil, err
 	}
@@ -94,18 +92,17 @@ func (g *GitLabHTTPWrapper) GetCommits(
 	return castedPages, nil
 }
 
-func (g *GitLabHTTPWrapper) GetGitLabProjects(gitLabGroupName string, queryParams map[string]string) (
+func (g *GitLabHTTPWrapper) GetGitLabProjects(gitLabBaseURL, gitLabGroupName, token string, queryParams map[string]string) (
 	[]GitLabProject, error,
 ) {
 	var err error
 
-	gitLabBaseURL := viper.GetString(params.GitLabURLFlag)
 	encodedGroupName := url.QueryEscape(gitLabGroupName)
 
 	logger.PrintIfVerbose(fmt.Sprintf("Finding the projects for group: %s", gitLabGroupName))
 	projectsURL := fmt.Sprintf(gitLabGroupProjectsURL, gitLabBaseURL, gitLabAPIVersion, encodedGroupName)
 
-	pages, err := fetchWithPagination(g.client, projectsURL, queryParams)
+	pages, err := fetchWithPagination(g.client, projectsURL, token, queryParams)
 	if err != nil {
 		return nil, err
 	}
@@ -126,15 +123,13 @@ func (g *GitLabHTTPWrapper) GetGitLabProjects(gitLabGroupName string, queryParam
 }
 
 func getFromGitLab(
-	client *http.Client, requestURL string, target interface{}, queryParams map[string]string,
+	client *http.Client, requestURL, token string, target interface{}, queryParams map[string]string,
 ) (*http.Response, error) {
 	req, err := http.NewRequest(http.MethodGet, requestURL, http.NoBody)
 	if err != nil {
 		return nil, err
 	}
 
-	token := viper.GetString(params.SCMTokenFlag)
-
 	logger.PrintRequest(req)
 
 	resp, err := WithSCMRateLimitRetry(
@@ -174,20 +169,20 @@ func getFromGitLab(
 
 func fetchWithPagination(
 	client *http.Client,
-	requestURL string,
+	requestURL, token string,
 	queryParams map[string]string,
 ) ([]interface{}, error) {
 	queryParams[perPageParamGitLab] = perPageValueGitLab
 
 	var pageCollection = make([]interface{}, 0)
 
-	next, err := collectPageForGitLab(client, requestURL, queryParams, &pageCollection)
+	next, err := collectPageForGitLab(client, requestURL, token, queryParams, &pageCollection)
 	if err != nil {
 		return nil, err
 	}
 
 	for next != "" {
-		next, err = collectPageForGitLab(client, next, map[string]string{}, &pageCollection)
+		next, err = collectPageForGitLab(client, next, token, map[string]string{}, &pageCollection)
 		if err != nil {
 			return nil, err
 		}
@@ -197,13 +192,13 @@ func fetchWithPagination(
 
 func collectPageForGitLab(
 	client *http.Client,
-	requestURL string,
+	requestURL, token string,
 	queryParams map[string]string,
 	pageCollection *[]interface{},
 ) (string, error) {
 	var holder = make([]interface{}, 0)
 
-	resp, err := getFromGitLab(client, requestURL, &holder, queryParams)
+	resp, err := getFromGitLab(client, requestURL, token, &holder, queryParams)
 	if err != nil {
 		return "", err
 	}
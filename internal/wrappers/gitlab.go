@@ -30,7 +30,7 @@ type GitLabCommit struct {
 }
 
 type GitLabWrapper interface {
-	GetGitLabProjectsForUser() ([]GitLabProject, error)
-	GetGitLabProjects(gitLabGroupName string, queryParams map[string]string) ([]GitLabProject, error)
-	GetCommits(gitLabProjectPathWithNameSpace string, queryParams map[string]string) ([]GitLabCommit, error)
+	GetGitLabProjectsForUser(url, token string) ([]GitLabProject, error)
+	GetGitLabProjects(url, gitLabGroupName, token string, queryParams map[string]string) ([]GitLabProject, error)
+	GetCommits(url, gitLabProjectPathWithNameSpace, token string, queryParams map[string]string) ([]GitLabCommit, error)
 }
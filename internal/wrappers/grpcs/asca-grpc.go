@@ -37,7 +37,7 @@ func NewASCAGrpcWrapper(port int) AscaWrapper {
 func (v *ASCAGrpcWrapper) Scan(fileName, sourceCode string) (*ScanResult, error) {
 	conn, connErr := v.grpcClient.CreateClientConn()
 	if connErr != nil {
-		logger.Printf(ConnErrMsg, v.hostAddress, connErr)
+		logger.Errorf(ConnErrMsg, v.hostAddress, connErr)
 		return nil, connErr
 	}
 
@@ -111,7 +111,7 @@ func (v *ASCAGrpcWrapper) HealthCheck() error {
 func (v *ASCAGrpcWrapper) ShutDown() error {
 	conn, connErr := v.grpcClient.CreateClientConn()
 	if connErr != nil {
-		logger.Printf(ConnErrMsg, v.hostAddress, connErr)
+		logger.Errorf(ConnErrMsg, v.hostAddress, connErr)
 		return connErr
 	}
 	defer func(conn *grpc.ClientConn) {
@@ -0,0 +1,397 @@
+package wrappers
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	"github.com/checkmarx/ast-cli/internal/logger"
+	commonParams "github.com/checkmarx/ast-cli/internal/params"
+	"github.com/pkg/errors"
+)
+
+const (
+	httpArchiveVersion     = "1.2"
+	httpArchiveCreatorName = "cx"
+	httpArchiveRedacted    = "***"
+	httpArchiveFilePerm    = 0600
+	base64Encoding         = "base64"
+	omittedBodyFormat      = "<%d bytes omitted>"
+)
+
+var (
+	httpArchiveMutex    sync.Mutex
+	httpArchiveRecorder *httpArchive
+	httpArchiveReplayer *httpArchive
+
+	redactedHeaders = []string{"Authorization", "Proxy-Authorization", "Cookie", "Set-Cookie"}
+	// redactedFormFields matches credentials sent to the IAM token endpoint as form parameters.
+	redactedFormFields = regexp.MustCompile(`((?:^|&)(?:client_secret|refresh_token|password|code|code_verifier)=)[^&]*`)
+	// redactedJSONFields matches long-lived tokens returned by the IAM token endpoint.
+	redactedJSONFields = regexp.MustCompile(`("(?:refresh_token|id_token)"\s*:\s*")[^"]*(")`)
+	accessTokenField   = regexp.MustCompile(`("access_token"\s*:\s*")([^"]*)(")`)
+)
+
+// HTTPArchive is a HAR-like capture of the HTTP traffic sent through request(), used to reproduce CLI
+// failures offline with --replay-http.
+type HTTPArchive struct {
+	Log HTTPArchiveLog `json:"log"`
+}
+
+type HTTPArchiveLog struct {
+	Version string             `json:"version"`
+	Creator HTTPArchiveCreator `json:"creator"`
+	Entries []HTTPArchiveEntry `json:"entries"`
+}
+
+type HTTPArchiveCreator struct {
+	Name    string `json:"name"`
+	Version string `json:"version"`
+}
+
+type HTTPArchiveEntry struct {
+	StartedDateTime time.Time           `json:"startedDateTime"`
+	Time            int64               `json:"time"`
+	Request         HTTPArchiveRequest  `json:"request"`
+	Response        HTTPArchiveResponse `json:"response"`
+	Error           string              `json:"_error,omitempty"`
+}
+
+type HTTPArchiveRequest struct {
+	Method   string               `json:"method"`
+	URL      string               `json:"url"`
+	Headers  []HTTPArchiveHeader  `json:"headers"`
+	PostData *HTTPArchivePostData `json:"postData,omitempty"`
+}
+
+type HTTPArchiveResponse struct {
+	Status     int                 `json:"status"`
+	StatusText string              `json:"statusText"`
+	Headers    []HTTPArchiveHeader `json:"headers"`
+	Content    HTTPArchiveContent  `json:"content"`
+}
+
+type HTTPArchiveHeader struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+type HTTPArchivePostData struct {
+	MimeType string `json:"mimeType"`
+	Text     string `json:"text"`
+}
+
+type HTTPArchiveContent struct {
+	Size     int64  `json:"size"`
+	MimeType string `json:"mimeType"`
+	Text     string `json:"text"`
+	Encoding string `json:"encoding,omitempty"`
+}
+
+type httpArchive struct {
+	mutex    sync.Mutex
+	path     string
+	archive  HTTPArchive
+	consumed map[string]int
+}
+
+// StartHTTPRecording captures every subsequent request/response passing through request() into path.
+// The archive is rewritten after each exchange so it is complete even when the CLI exits abruptly.
+func StartHTTPRecording(path string) error {
+	archive := &httpArchive{
+		path: path,
+		archive: HTTPArchive{Log: HTTPArchiveLog{
+			Version: httpArchiveVersion,
+			Creator: HTTPArchiveCreator{Name: httpArchiveCreatorName, Version: commonParams.Version},
+			Entries: []HTTPArchiveEntry{},
+		}},
+	}
+	if err := archive.save(); err != nil {
+		return errors.Wrapf(err, "Failed to create HTTP recording %s", path)
+	}
+	httpArchiveMutex.Lock()
+	defer httpArchiveMutex.Unlock()
+	httpArchiveRecorder = archive
+	httpArchiveReplayer = nil
+	return nil
+}
+
+// StartHTTPReplay serves every subsequent request passing through request() from the archive at path
+// instead of the network. Entries are matched by method and URL, in the order they were recorded.
+func StartHTTPReplay(path string) error {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return errors.Wrapf(err, "Failed to read HTTP recording %s", path)
+	}
+	archive := &httpArchive{path: path, consumed: map[string]int{}}
+	if err = json.Unmarshal(content, &archive.archive); err != nil {
+		return errors.Wrapf(err, "Failed to parse HTTP recording %s", path)
+	}
+	httpArchiveMutex.Lock()
+	defer httpArchiveMutex.Unlock()
+	httpArchiveReplayer = archive
+	httpArchiveRecorder = nil
+	return nil
+}
+
+// StopHTTPArchive disables recording and replay.
+func StopHTTPArchive() {
+	httpArchiveMutex.Lock()
+	defer httpArchiveMutex.Unlock()
+	httpArchiveRecorder = nil
+	httpArchiveReplayer = nil
+}
+
+// withHTTPArchive returns a copy of client whose transport records to, or replays from, the active
+// archive. The client is returned untouched when neither mode is enabled.
+func withHTTPArchive(client *http.Client) *http.Client {
+	httpArchiveMutex.Lock()
+	recorder, replayer := httpArchiveRecorder, httpArchiveReplayer
+	httpArchiveMutex.Unlock()
+	if recorder == nil && replayer == nil {
+		return client
+	}
+	archivedClient := *client
+	next := client.Transport
+	if next == nil {
+		next = http.DefaultTransport
+	}
+	if replayer != nil {
+		archivedClient.Transport = &replayTransport{archive: replayer}
+	} else {
+		archivedClient.Transport = &recordTransport{next: next, archive: recorder}
+	}
+	return &archivedClient
+}
+
+// replayedAccessToken returns the access token captured in the replay archive, so replayed commands
+// don't need credentials. ok is false when replay is not active.
+func replayedAccessToken() (token string, ok bool, err error) {
+	httpArchiveMutex.Lock()
+	replayer := httpArchiveReplayer
+	httpArchiveMutex.Unlock()
+	if replayer == nil {
+		return "", false, nil
+	}
+	for i := range replayer.archive.Log.Entries {
+		credentialsInfo := ClientCredentialsInfo{}
+		if json.Unmarshal([]byte(replayer.archive.Log.Entries[i].Response.Content.Text), &credentialsInfo) == nil &&
+			credentialsInfo.AccessToken != "" {
+			return credentialsInfo.AccessToken, true, nil
+		}
+	}
+	return "", true, errors.Errorf("No access token found in HTTP recording %s", replayer.path)
+}
+
+type recordTransport struct {
+	next    http.RoundTripper
+	archive *httpArchive
+}
+
+func (t *recordTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	entry := HTTPArchiveEntry{
+		StartedDateTime: time.Now(),
+		Request: HTTPArchiveRequest{
+			Method:  req.Method,
+			URL:     logger.SanitizeLogs(req.URL.String()),
+			Headers: archiveHeaders(req.Header),
+		},
+	}
+	if req.Body != nil && req.Body != http.NoBody {
+		postData, err := archiveRequestBody(req)
+		if err != nil {
+			return nil, err
+		}
+		entry.Request.PostData = postData
+	}
+
+	resp, err := t.next.RoundTrip(req)
+	entry.Time = time.Since(entry.StartedDateTime).Milliseconds()
+	if err != nil {
+		entry.Error = logger.SanitizeLogs(err.Error())
+		t.archive.add(&entry)
+		return nil, err
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	_ = resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+	entry.Response = HTTPArchiveResponse{
+		Status:     resp.StatusCode,
+		StatusText: http.StatusText(resp.StatusCode),
+		Headers:    archiveHeaders(resp.Header),
+		Content:    archiveContent(resp.Header.Get(contentTypeHeader), body),
+	}
+	t.archive.add(&entry)
+	return resp, nil
+}
+
+type replayTransport struct {
+	archive *httpArchive
+}
+
+func (t *replayTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Body != nil {
+		_, _ = io.Copy(io.Discard, req.Body)
+		_ = req.Body.Close()
+	}
+	entry := t.archive.next(req.Method, logger.SanitizeLogs(req.URL.String()))
+	if entry == nil {
+		return nil, errors.Errorf("No recorded response for %s %s in %s", req.Method, req.URL.String(), t.archive.path)
+	}
+	if entry.Error != "" {
+		return nil, errors.New(entry.Error)
+	}
+
+	body := []byte(entry.Response.Content.Text)
+	if entry.Response.Content.Encoding == base64Encoding {
+		decoded, err := base64.StdEncoding.DecodeString(entry.Response.Content.Text)
+		if err != nil {
+			return nil, errors.Wrapf(err, "Failed to decode recorded response for %s %s", req.Method, req.URL.String())
+		}
+		body = decoded
+	}
+	header := http.Header{}
+	for _, h := range entry.Response.Headers {
+		header.Add(h.Name, h.Value)
+	}
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", entry.Response.Status, entry.Response.StatusText),
+		StatusCode:    entry.Response.Status,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          io.NopCloser(bytes.NewReader(body)),
+		ContentLength: int64(len(body)),
+		Request:       req,
+	}, nil
+}
+
+func (a *httpArchive) add(entry *HTTPArchiveEntry) {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+	a.archive.Log.Entries = append(a.archive.Log.Entries, *entry)
+	if err := a.save(); err != nil {
+		logger.Warnf("Failed to write HTTP recording: %v", err)
+	}
+}
+
+func (a *httpArchive) save() error {
+	content, err := json.MarshalIndent(a.archive, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(a.path, content, httpArchiveFilePerm)
+}
+
+// next returns the next unconsumed entry recorded for method and url. Once every matching entry was
+// served, the last one keeps being returned so polling loops terminate the same way they did live.
+func (a *httpArchive) next(method, url string) *HTTPArchiveEntry {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+	key := method + " " + url
+	var matches []int
+	for i := range a.archive.Log.Entries {
+		if a.archive.Log.Entries[i].Request.Method == method && a.archive.Log.Entries[i].Request.URL == url {
+			matches = append(matches, i)
+		}
+	}
+	if len(matches) == 0 {
+		return nil
+	}
+	served := a.consumed[key]
+	if served >= len(matches) {
+		served = len(matches) - 1
+	}
+	a.consumed[key] = served + 1
+	return &a.archive.Log.Entries[matches[served]]
+}
+
+func archiveHeaders(header http.Header) []HTTPArchiveHeader {
+	headers := []HTTPArchiveHeader{}
+	for name, values := range header {
+		for _, value := range values {
+			headers = append(headers, HTTPArchiveHeader{Name: name, Value: redactHeader(name, value)})
+		}
+	}
+	return headers
+}
+
+func redactHeader(name, value string) string {
+	for _, redacted := range redactedHeaders {
+		if strings.EqualFold(name, redacted) {
+			if scheme, _, found := strings.Cut(value, " "); found {
+				return scheme + " " + httpArchiveRedacted
+			}
+			return httpArchiveRedacted
+		}
+	}
+	return logger.SanitizeLogs(value)
+}
+
+// archiveRequestBody captures the request body and restores it for the real round trip. Large bodies
+// such as source uploads are streamed through untouched and only their size is recorded.
+func archiveRequestBody(req *http.Request) (*HTTPArchivePostData, error) {
+	postData := &HTTPArchivePostData{MimeType: req.Header.Get(contentTypeHeader)}
+	if req.ContentLength > logger.ContentLengthLimit {
+		postData.Text = fmt.Sprintf(omittedBodyFormat, req.ContentLength)
+		return postData, nil
+	}
+	body, err := io.ReadAll(req.Body)
+	_ = req.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	req.Body = io.NopCloser(bytes.NewReader(body))
+	if utf8.Valid(body) {
+		postData.Text = redactSecrets(string(body))
+	} else {
+		postData.Text = fmt.Sprintf(omittedBodyFormat, len(body))
+	}
+	return postData, nil
+}
+
+func archiveContent(mimeType string, body []byte) HTTPArchiveContent {
+	content := HTTPArchiveContent{Size: int64(len(body)), MimeType: mimeType}
+	if utf8.Valid(body) {
+		content.Text = redactSecrets(string(body))
+	} else {
+		content.Text = base64.StdEncoding.EncodeToString(body)
+		content.Encoding = base64Encoding
+	}
+	return content
+}
+
+// redactSecrets masks configured secrets and token endpoint credentials. Access tokens keep their
+// claims, which replay needs to resolve the tenant and base URL, but lose their signature so the
+// recording can't be used to authenticate.
+func redactSecrets(text string) string {
+	text = logger.SanitizeLogs(text)
+	text = redactedFormFields.ReplaceAllString(text, "${1}"+httpArchiveRedacted)
+	text = redactedJSONFields.ReplaceAllString(text, "${1}"+httpArchiveRedacted+"${2}")
+	return accessTokenField.ReplaceAllStringFunc(text, func(match string) string {
+		parts := accessTokenField.FindStringSubmatch(match)
+		return parts[1] + stripJWTSignature(parts[2]) + parts[3]
+	})
+}
+
+func stripJWTSignature(token string) string {
+	segments := strings.Split(token, ".")
+	if len(segments) != 3 {
+		return httpArchiveRedacted
+	}
+	return segments[0] + "." + segments[1] + "."
+}
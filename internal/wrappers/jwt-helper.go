@@ -206,13 +206,13 @@ func GetUniqueID() string {
 	jwtWrapper := NewJwtWrapper()
 	devAssistAllowed, err := jwtWrapper.IsAllowedEngine(commonParams.CheckmarxDevAssistType)
 	if err != nil {
-		logger.PrintIfVerbose("Failed to check engine allowance: " + err.Error())
+		logger.Warnf("Failed to check engine allowance: %v", err)
 		return ""
 	}
 
 	oneAssistAllowed, err := jwtWrapper.IsAllowedEngine(commonParams.CheckmarxOneAssistType)
 	if err != nil {
-		logger.PrintIfVerbose("Failed to check engine allowance: " + err.Error())
+		logger.Warnf("Failed to check engine allowance: %v", err)
 		return ""
 	}
 
@@ -229,7 +229,7 @@ func GetUniqueID() string {
 	// Generate new unique id
 	currentUser, err := user.Current()
 	if err != nil {
-		logger.PrintIfVerbose("Failed to get user: " + err.Error())
+		logger.Warnf("Failed to get user: %v", err)
 		return ""
 	}
 	username := currentUser.Username
@@ -242,7 +242,7 @@ func GetUniqueID() string {
 	configFilePath, _ := configuration.GetConfigFilePath()
 	err = configuration.SafeWriteSingleConfigKeyString(configFilePath, commonParams.UniqueIDConfigKey, uniqueID)
 	if err != nil {
-		logger.PrintIfVerbose("Failed to write config: " + err.Error())
+		logger.Warnf("Failed to write config: %v", err)
 		return ""
 	}
 	return uniqueID
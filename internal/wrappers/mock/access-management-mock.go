@@ -20,6 +20,11 @@ func (a AccessManagementMockWrapper) GetGroups(projectID string) ([]*wrappers.Gr
 	return nil, nil
 }
 
+func (a AccessManagementMockWrapper) DeleteGroupsAssignment(projectID, projectName string, groups []*wrappers.Group) error {
+	fmt.Println("Called DeleteGroupsAssignment in AccessManagementMockWrapper")
+	return nil
+}
+
 func (a AccessManagementMockWrapper) HasEntityAccessToGroups(groupIDs []string) (bool, error) {
 	logger.PrintfIfVerbose("Called HasEntityAccessToGroups in AccessManagementMockWrapper")
 	return true, nil
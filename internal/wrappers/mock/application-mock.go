@@ -101,3 +101,38 @@ func (a ApplicationsMockWrapper) CreateProjectAssociation(applicationID string,
 
 	return nil, nil
 }
+
+func (a ApplicationsMockWrapper) Create(applicationBody *wrappers.ApplicationConfiguration) (*wrappers.Application, *wrappers.ErrorModel, error) {
+	fmt.Println("called Create application")
+	if applicationBody.Name == FakeBadRequest400 {
+		return nil, &wrappers.ErrorModel{
+			Message: "invalid applicationBody",
+			Code:    code,
+			Type:    "validation",
+		}, nil
+	}
+	return &wrappers.Application{
+		ID:          fmt.Sprintf("ID-%s", applicationBody.Name),
+		Name:        applicationBody.Name,
+		Description: applicationBody.Description,
+		Criticality: applicationBody.Criticality,
+		Rules:       applicationBody.Rules,
+		Tags:        applicationBody.Tags,
+	}, nil, nil
+}
+
+func (a ApplicationsMockWrapper) Delete(applicationID string) (*wrappers.ErrorModel, error) {
+	fmt.Println("called Delete application")
+	if applicationID == FakeForbidden403 {
+		return nil, errors.Errorf(errorConstants.NoPermissionToUpdateApplication)
+	}
+	return nil, nil
+}
+
+func (a ApplicationsMockWrapper) DeleteProjectAssociation(applicationID string, requestModel *wrappers.AssociateProjectModel) (*wrappers.ErrorModel, error) {
+	fmt.Println("called Delete project association from application")
+	if applicationID == FakeForbidden403 {
+		return nil, errors.Errorf(errorConstants.NoPermissionToUpdateApplication)
+	}
+	return nil, nil
+}
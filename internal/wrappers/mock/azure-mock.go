@@ -18,7 +18,10 @@ func (g AzureMockWrapper) GetProjects(url, organizationName, token string) (wrap
 	return wrappers.AzureRootProject{}, nil
 }
 
-func (g AzureMockWrapper) GetCommits(url, organizationName, projectName, repositoryName, token string) (wrappers.AzureRootCommit, error) {
+func (g AzureMockWrapper) GetCommits(url, organizationName, projectName, repositoryName, token string, window wrappers.CommitWindow) (
+	wrappers.AzureRootCommit,
+	error,
+) {
 	if len(repositoryName) > 0 {
 		var commits = make([]wrappers.AzureCommit, 1)
 		author := wrappers.AzureAuthor{Name: "MOCK NAME", Email: "MOCK Email"}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"log"
 
+	"github.com/checkmarx/ast-cli/internal/wrappers"
 	"github.com/checkmarx/ast-cli/internal/wrappers/bitbucketserver"
 	"github.com/pkg/errors"
 )
@@ -22,7 +23,10 @@ type UserView struct {
 	UniqueContributorsUsername string `json:"unique_contributors_username"`
 }
 
-func (m WrapperBitbucketServer) GetCommits(bitBucketURL, projectKey, repoSlug, bitBucketPassword string) ([]bitbucketserver.Commit, error) {
+func (m WrapperBitbucketServer) GetCommits(
+	bitBucketURL, projectKey, repoSlug, bitBucketPassword string,
+	window wrappers.CommitWindow,
+) ([]bitbucketserver.Commit, error) {
 	for _, corruptedRepo := range m.CorruptedRepos {
 		if repoSlug == corruptedRepo {
 			return nil, errors.New(fmt.Sprintf("repository %s is corrupted", repoSlug))
@@ -54,7 +58,7 @@ func (m WrapperBitbucketServer) SearchRepos(
 	var views []RepositoryView
 	var viewsUsers []UserView
 	for _, repo := range repos {
-		_, err := m.GetCommits("mock-url", project, repo, bitBucketToken)
+		_, err := m.GetCommits("mock-url", project, repo, bitBucketToken, wrappers.CommitWindow{})
 		if err != nil {
 			log.Printf("Skipping repository %s/%s: Repository is corrupted (error: %v)", project, repo, err)
 			continue
@@ -0,0 +1,27 @@
+package mock
+
+import (
+	"github.com/checkmarx/ast-cli/internal/wrappers"
+)
+
+type GiteaMockWrapper struct {
+}
+
+func (g GiteaMockWrapper) GetRepository(url, owner, repositoryName, token string) (wrappers.GiteaRepository, error) {
+	return wrappers.GiteaRepository{Name: repositoryName, FullName: owner + "/" + repositoryName, Owner: wrappers.GiteaUser{Login: owner}}, nil
+}
+
+func (g GiteaMockWrapper) GetRepositories(url, organizationName, token string) ([]wrappers.GiteaRepository, error) {
+	return []wrappers.GiteaRepository{
+		{Name: "MOCK", FullName: organizationName + "/MOCK", Owner: wrappers.GiteaUser{Login: organizationName}},
+		{Name: "EMPTY", FullName: organizationName + "/EMPTY", Owner: wrappers.GiteaUser{Login: organizationName}, Empty: true},
+	}, nil
+}
+
+func (g GiteaMockWrapper) GetCommits(url string, repository wrappers.GiteaRepository, token string, queryParams map[string]string) (
+	[]wrappers.GiteaCommit, error,
+) {
+	author := wrappers.Commit{CommitAuthor: wrappers.CommitAuthor{Name: "MOCK NAME", Email: "mock@example.com"}}
+	bot := wrappers.Commit{CommitAuthor: wrappers.CommitAuthor{Name: "renovate[bot]", Email: "bot@example.com"}}
+	return []wrappers.GiteaCommit{{Commit: author}, {Commit: author}, {Commit: bot}}, nil
+}
@@ -7,18 +7,18 @@ import (
 type GitHubMockWrapper struct {
 }
 
-func (g GitHubMockWrapper) GetOrganization(string) (wrappers.Organization, error) {
+func (g GitHubMockWrapper) GetOrganization(string, string, string) (wrappers.Organization, error) {
 	return wrappers.Organization{}, nil
 }
 
-func (g GitHubMockWrapper) GetRepository(string, string) (wrappers.Repository, error) {
+func (g GitHubMockWrapper) GetRepository(string, string, string, string) (wrappers.Repository, error) {
 	return wrappers.Repository{}, nil
 }
 
-func (g GitHubMockWrapper) GetRepositories(wrappers.Organization) ([]wrappers.Repository, error) {
+func (g GitHubMockWrapper) GetRepositories(wrappers.Organization, string) ([]wrappers.Repository, error) {
 	return []wrappers.Repository{{}}, nil
 }
 
-func (g GitHubMockWrapper) GetCommits(wrappers.Repository, map[string]string) ([]wrappers.CommitRoot, error) {
+func (g GitHubMockWrapper) GetCommits(wrappers.Repository, string, map[string]string) ([]wrappers.CommitRoot, error) {
 	return []wrappers.CommitRoot{}, nil
 }
@@ -7,18 +7,18 @@ import (
 type GitLabMockWrapper struct {
 }
 
-func (g GitLabMockWrapper) GetGitLabProjectsForUser() ([]wrappers.GitLabProject, error) {
+func (g GitLabMockWrapper) GetGitLabProjectsForUser(url, token string) ([]wrappers.GitLabProject, error) {
 	return []wrappers.GitLabProject{}, nil
 }
 
 func (g GitLabMockWrapper) GetGitLabProjects(
-	gitLabGroupName string, queryParams map[string]string,
+	url, gitLabGroupName, token string, queryParams map[string]string,
 ) ([]wrappers.GitLabProject, error) {
 	return []wrappers.GitLabProject{}, nil
 }
 
 func (g GitLabMockWrapper) GetCommits(
-	gitLabProjectPathWithNameSpace string, queryParams map[string]string,
+	url, gitLabProjectPathWithNameSpace, token string, queryParams map[string]string,
 ) ([]wrappers.GitLabCommit, error) {
 	return []wrappers.GitLabCommit{}, nil
 }
@@ -26,8 +26,34 @@ func (pr *PRMockWrapper) PostPRDecoration(model interface{}) (
 		return "Bitbucket Server PR comment created successfully.", nil, nil
 	case *wrappers.AzurePRModel:
 		return prCommentSuccess, nil, nil
+	case *wrappers.GiteaPRModel:
+		return "Gitea PR decoration posted", nil, nil
 
 	default:
 		return "", nil, errors.New("unsupported model type")
 	}
 }
+
+func (pr *PRMockWrapper) GetPRDiff(interface{}) (*wrappers.PRDiff, error) {
+	return &wrappers.PRDiff{HeadSHA: "MOCK", Files: map[string]*wrappers.PRDiffFile{}}, nil
+}
+
+func (pr *PRMockWrapper) GetPRReviewComments(interface{}, *wrappers.PRDiff) ([]wrappers.PRReviewComment, error) {
+	return nil, nil
+}
+
+func (pr *PRMockWrapper) CreatePRReviewComment(interface{}, *wrappers.PRDiff, *wrappers.PRReviewComment) error {
+	return nil
+}
+
+func (pr *PRMockWrapper) UpdatePRReviewComment(interface{}, *wrappers.PRDiff, *wrappers.PRReviewComment) error {
+	return nil
+}
+
+func (pr *PRMockWrapper) ResolvePRReviewComment(interface{}, *wrappers.PRDiff, *wrappers.PRReviewComment) error {
+	return nil
+}
+
+func (pr *PRMockWrapper) PostPRStatus(interface{}, *wrappers.PRDiff, *wrappers.PRStatus) error {
+	return nil
+}
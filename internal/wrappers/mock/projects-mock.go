@@ -37,6 +37,25 @@ func (p *ProjectsMockWrapper) UpdateConfiguration(projectID string, configuratio
 	return nil, nil
 }
 
+func (p *ProjectsMockWrapper) GetConfiguration(projectID string) ([]wrappers.ProjectConfiguration, *wrappers.ErrorModel, error) {
+	fmt.Println("Called GetConfiguration in ProjectsMockWrapper")
+	if projectID == "ID-mock-some-error-model" {
+		return nil, &wrappers.ErrorModel{Code: 202, Message: "some-message"}, nil
+	}
+	return []wrappers.ProjectConfiguration{
+		{Key: "scan.config.sast.presetName", Name: "presetName", Category: "sast", OriginLevel: "Tenant", Value: "ASA Premium", ValueType: "List", AllowOverride: true},
+		{Key: "scan.config.sast.incremental", Name: "incremental", Category: "sast", OriginLevel: "Project", Value: "true", ValueType: "Bool", AllowOverride: true},
+		{Key: "scan.config.sast.filter", Name: "filter", Category: "sast", OriginLevel: "Tenant", Value: "", ValueType: "String", AllowOverride: true},
+		{Key: "scan.config.sca.ExploitablePath", Name: "ExploitablePath", Category: "sca", OriginLevel: "Tenant", Value: "false", ValueType: "Bool", AllowOverride: false},
+		{Key: "scan.handler.git.sshKey", Name: "sshKey", Category: "git", OriginLevel: "Project", Value: "private-key", ValueType: "Secret", AllowOverride: true},
+	}, nil, nil
+}
+
+func (p *ProjectsMockWrapper) DeleteConfiguration(projectID string, keys []string) (*wrappers.ErrorModel, error) {
+	fmt.Println("Called DeleteConfiguration for project", projectID, "in ProjectsMockWrapper with the keys", keys)
+	return nil, nil
+}
+
 func (p *ProjectsMockWrapper) Get(params map[string]string) (
 	*wrappers.ProjectsCollectionResponseModel,
 	*wrappers.ErrorModel,
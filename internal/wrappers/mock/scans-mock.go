@@ -17,7 +17,17 @@ type ScansMockWrapper struct {
 	Running bool
 }
 
-func (m *ScansMockWrapper) GetWorkflowByID(_ string) ([]*wrappers.ScanTaskResponseModel, *wrappers.ErrorModel, error) {
+func (m *ScansMockWrapper) GetWorkflowByID(scanID string) ([]*wrappers.ScanTaskResponseModel, *wrappers.ErrorModel, error) {
+	if scanID == "scan1" || scanID == "scan2" {
+		sastEnd := "2024-01-01T10:05:00Z"
+		if scanID == "scan2" {
+			sastEnd = "2024-01-01T10:20:00Z"
+		}
+		return []*wrappers.ScanTaskResponseModel{
+			{Source: "sast", Timestamp: "2024-01-01T10:00:00Z", Info: "started"},
+			{Source: "sast", Timestamp: sastEnd, Info: "done"},
+		}, nil, nil
+	}
 	return nil, nil, nil
 }
 
@@ -105,6 +115,8 @@ func (m *ScansMockWrapper) Get(scanParams map[string]string) (
 	var engines []string
 	engines = append(engines, "sast")
 	return &wrappers.ScansCollectionResponseModel{
+		TotalCount:         1,
+		FilteredTotalCount: 1,
 		Scans: []wrappers.ScanResponseModel{
 			{
 				ID:       "MOCK",
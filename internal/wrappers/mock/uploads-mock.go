@@ -2,6 +2,7 @@ package mock
 
 import (
 	"fmt"
+	"os"
 	"strings"
 
 	"github.com/pkg/errors"
@@ -21,6 +22,14 @@ func (u *UploadsMockWrapper) UploadFileInMultipart(filePath string, wrapper wrap
 	return &url, nil
 }
 
+func (u *UploadsMockWrapper) DownloadFile(uploadURL, targetFile string) error {
+	fmt.Println("Called DownloadFile in UploadsMockWrapper")
+	if strings.Contains(uploadURL, "expired") {
+		return errors.New("response status code 403")
+	}
+	return os.WriteFile(targetFile, []byte("sources"), 0600)
+}
+
 func (u *UploadsMockWrapper) UploadFile(filePath string, featureFlagsWrapper wrappers.FeatureFlagsWrapper) (*string, error) {
 	fmt.Println("Called Create in UploadsMockWrapper")
 	if strings.Contains(filePath, "failureCase.zip") {
@@ -158,7 +158,7 @@ func LoginWithPKCE(ctx context.Context, opts PKCELoginOptions) (*PKCETokenRespon
 	fmt.Fprintln(os.Stderr, "If the browser does not open, copy and paste the URL above.")
 	if opts.OpenBrowser {
 		if err := openBrowser(authURL); err != nil {
-			logger.PrintIfVerbose("Failed to open browser automatically: " + err.Error())
+			logger.Warnf("Failed to open browser automatically: %v", err)
 		}
 	}
 	fmt.Fprintln(os.Stderr, "Waiting for authentication...")
@@ -0,0 +1,192 @@
+package wrappers
+
+import (
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/checkmarx/ast-cli/internal/logger"
+	commonParams "github.com/checkmarx/ast-cli/internal/params"
+	"github.com/spf13/viper"
+)
+
+const DefaultPageSize = 100
+
+// PageParams sets on params the query parameters requesting size items starting at offset.
+type PageParams func(params map[string]string, offset, size int)
+
+// PageFetcher requests a page of a list endpoint. total is the number of items matching params, 0 when unknown.
+type PageFetcher[T any] func(params map[string]string) (items []T, total int, errorModel *ErrorModel, err error)
+
+var (
+	// OffsetLimitPaging requests pages through the limit and offset query parameters.
+	OffsetLimitPaging PageParams = func(params map[string]string, offset, size int) {
+		params[commonParams.LimitQueryParam] = strconv.Itoa(size)
+		params[commonParams.OffsetQueryParam] = strconv.Itoa(offset)
+	}
+	// FromToPaging requests pages through the 1-based and inclusive from and to query parameters.
+	FromToPaging PageParams = func(params map[string]string, offset, size int) {
+		params[commonParams.FromQueryParam] = strconv.Itoa(offset + 1)
+		params[commonParams.ToQueryParam] = strconv.Itoa(offset + size)
+	}
+)
+
+// Pager iterates over the pages of a list endpoint. A Pager may be shared between goroutines, every page is handed to
+// a single caller, and a failed page is requested again up to the configured number of retries.
+type Pager[T any] struct {
+	mutex      sync.Mutex
+	fetch      PageFetcher[T]
+	paging     PageParams
+	params     map[string]string
+	pageSize   int
+	offset     int
+	gaps       []pageRange
+	done       bool
+	retries    int
+	retryDelay time.Duration
+}
+
+// pageRange is the offset and the number of items of a page request.
+type pageRange struct {
+	offset int
+	size   int
+}
+
+func NewPager[T any](fetch PageFetcher[T], paging PageParams, params map[string]string, pageSize int) *Pager[T] {
+	if pageSize <= 0 {
+		pageSize = DefaultPageSize
+	}
+	return &Pager[T]{
+		fetch:      fetch,
+		paging:     paging,
+		params:     params,
+		pageSize:   pageSize,
+		retries:    int(viper.GetUint(commonParams.RetryFlag)),
+		retryDelay: time.Duration(viper.GetUint(commonParams.RetryDelayFlag)) * time.Second,
+	}
+}
+
+// WithRetries overrides the number of retries of a failed page and the base delay between them.
+func (p *Pager[T]) WithRetries(retries int, retryDelay time.Duration) *Pager[T] {
+	p.retries = retries
+	p.retryDelay = retryDelay
+	return p
+}
+
+// Next returns the next page, or an empty page once every item was returned. A page shorter than requested, when the
+// server caps the page size, is followed by a request for the rest of it. The last page is the one reaching the total
+// when it's known, otherwise the first empty page.
+func (p *Pager[T]) Next() ([]T, *ErrorModel, error) {
+	p.mutex.Lock()
+	var page pageRange
+	switch {
+	case len(p.gaps) > 0:
+		page, p.gaps = p.gaps[0], p.gaps[1:]
+	case p.done:
+		p.mutex.Unlock()
+		return nil, nil, nil
+	default:
+		page = pageRange{offset: p.offset, size: p.pageSize}
+		p.offset += p.pageSize
+	}
+	p.mutex.Unlock()
+
+	items, total, errorModel, err := p.fetchWithRetries(page)
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	if err != nil || errorModel != nil || len(items) == 0 {
+		p.done = true
+		p.gaps = nil
+		return items, errorModel, err
+	}
+	if total > 0 && p.offset >= total {
+		p.done = true
+	}
+	if len(items) < page.size && (total == 0 || page.offset+len(items) < total) {
+		p.gaps = append(p.gaps, pageRange{offset: page.offset + len(items), size: page.size - len(items)})
+	}
+	return items, nil, nil
+}
+
+// ForEach calls handle with every page until the last one, a failed request or an error returned by handle.
+func (p *Pager[T]) ForEach(handle func(items []T) error) (*ErrorModel, error) {
+	for {
+		items, errorModel, err := p.Next()
+		if err != nil || errorModel != nil {
+			return errorModel, err
+		}
+		if len(items) == 0 {
+			return nil, nil
+		}
+		if err = handle(items); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// All returns the items of every page.
+func (p *Pager[T]) All() ([]T, *ErrorModel, error) {
+	var all []T
+	errorModel, err := p.ForEach(func(items []T) error {
+		all = append(all, items...)
+		return nil
+	})
+	return all, errorModel, err
+}
+
+func (p *Pager[T]) fetchWithRetries(page pageRange) (items []T, total int, errorModel *ErrorModel, err error) {
+	for attempt := 0; ; attempt++ {
+		// Every attempt gets its own copy of the parameters so concurrent pages don't overwrite each other's bounds
+		params := make(map[string]string, len(p.params))
+		for key, value := range p.params {
+			params[key] = value
+		}
+		p.paging(params, page.offset, page.size)
+		items, total, errorModel, err = p.fetch(params)
+		retryable := err != nil || (errorModel != nil && errorModel.Code >= http.StatusInternalServerError)
+		if !retryable || attempt >= p.retries {
+			return items, total, errorModel, err
+		}
+		delay := p.retryDelay * (1 << attempt)
+		logger.Warnf("Failed getting the page at offset %d, retrying in %s", page.offset, delay)
+		time.Sleep(delay)
+	}
+}
+
+// NewProjectsPager pages through the projects matching params.
+func NewProjectsPager(projectsWrapper ProjectsWrapper, params map[string]string, pageSize int) *Pager[ProjectResponseModel] {
+	return NewPager(func(params map[string]string) ([]ProjectResponseModel, int, *ErrorModel, error) {
+		page, errorModel, err := projectsWrapper.Get(params)
+		if err != nil || errorModel != nil || page == nil {
+			return nil, 0, errorModel, err
+		}
+		return page.Projects, int(page.FilteredTotalCount), nil, nil
+	}, OffsetLimitPaging, params, pageSize)
+}
+
+// NewScansPager pages through the scans matching params.
+func NewScansPager(scansWrapper ScansWrapper, params map[string]string, pageSize int) *Pager[ScanResponseModel] {
+	return NewPager(func(params map[string]string) ([]ScanResponseModel, int, *ErrorModel, error) {
+		page, errorModel, err := scansWrapper.Get(params)
+		if err != nil || errorModel != nil || page == nil {
+			return nil, 0, errorModel, err
+		}
+		return page.Scans, int(page.FilteredTotalCount), nil, nil
+	}, OffsetLimitPaging, params, pageSize)
+}
+
+// NewDastEnvironmentsPager pages through the DAST environments matching params.
+func NewDastEnvironmentsPager(
+	dastEnvironmentsWrapper DastEnvironmentsWrapper,
+	params map[string]string,
+	pageSize int,
+) *Pager[DastEnvironmentResponseModel] {
+	return NewPager(func(params map[string]string) ([]DastEnvironmentResponseModel, int, *ErrorModel, error) {
+		page, errorModel, err := dastEnvironmentsWrapper.Get(params)
+		if err != nil || errorModel != nil || page == nil {
+			return nil, 0, errorModel, err
+		}
+		return page.Environments, page.TotalItems, nil, nil
+	}, FromToPaging, params, pageSize)
+}
@@ -0,0 +1,139 @@
+package wrappers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"sync"
+	"testing"
+
+	"gotest.tools/assert"
+)
+
+func fakeItemsFetcher(count int, calls *[]map[string]string, mutex *sync.Mutex) PageFetcher[int] {
+	return func(params map[string]string) ([]int, int, *ErrorModel, error) {
+		mutex.Lock()
+		*calls = append(*calls, params)
+		mutex.Unlock()
+		offset, _ := strconv.Atoi(params["offset"])
+		limit, _ := strconv.Atoi(params["limit"])
+		var items []int
+		for i := offset; i < offset+limit && i < count; i++ {
+			items = append(items, i)
+		}
+		return items, count, nil, nil
+	}
+}
+
+func TestPager_AllPages(t *testing.T) {
+	var calls []map[string]string
+	var mutex sync.Mutex
+	params := map[string]string{"name": "a"}
+	items, errorModel, err := NewPager(fakeItemsFetcher(25, &calls, &mutex), OffsetLimitPaging, params, 10).All()
+	assert.NilError(t, err)
+	assert.Assert(t, errorModel == nil)
+	assert.Equal(t, len(items), 25)
+	assert.Equal(t, len(calls), 3)
+	assert.Equal(t, calls[2]["offset"], "20")
+	assert.Equal(t, calls[2]["name"], "a")
+	_, found := params["offset"]
+	assert.Assert(t, !found, "the caller's parameters must not be modified")
+}
+
+func TestPager_StopsAtTotal(t *testing.T) {
+	var calls []map[string]string
+	var mutex sync.Mutex
+	items, _, err := NewPager(fakeItemsFetcher(20, &calls, &mutex), OffsetLimitPaging, map[string]string{}, 10).All()
+	assert.NilError(t, err)
+	assert.Equal(t, len(items), 20)
+	assert.Equal(t, len(calls), 2)
+}
+
+func TestPager_ShortPagesBeforeTotal(t *testing.T) {
+	var calls []map[string]string
+	var mutex sync.Mutex
+	fetch := fakeItemsFetcher(12, &calls, &mutex)
+	capped := func(params map[string]string) ([]int, int, *ErrorModel, error) {
+		params["limit"] = "5"
+		return fetch(params)
+	}
+	items, _, err := NewPager(capped, OffsetLimitPaging, map[string]string{}, 10).All()
+	assert.NilError(t, err)
+	assert.DeepEqual(t, items, []int{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11})
+	assert.Equal(t, len(calls), 3)
+	assert.Equal(t, calls[1]["offset"], "5")
+}
+
+func TestPager_UnknownTotalStopsOnEmptyPage(t *testing.T) {
+	var calls []map[string]string
+	var mutex sync.Mutex
+	fetch := fakeItemsFetcher(15, &calls, &mutex)
+	unknownTotal := func(params map[string]string) ([]int, int, *ErrorModel, error) {
+		items, _, errorModel, err := fetch(params)
+		return items, 0, errorModel, err
+	}
+	items, _, err := NewPager(unknownTotal, OffsetLimitPaging, map[string]string{}, 10).All()
+	assert.NilError(t, err)
+	assert.Equal(t, len(items), 15)
+	assert.Equal(t, len(calls), 3)
+}
+
+func TestPager_ConcurrentNext(t *testing.T) {
+	var calls []map[string]string
+	var mutex sync.Mutex
+	pager := NewPager(fakeItemsFetcher(95, &calls, &mutex), OffsetLimitPaging, map[string]string{}, 10)
+	var wg sync.WaitGroup
+	var total int
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, _ = pager.ForEach(func(items []int) error {
+				mutex.Lock()
+				total += len(items)
+				mutex.Unlock()
+				return nil
+			})
+		}()
+	}
+	wg.Wait()
+	assert.Equal(t, total, 95)
+}
+
+func TestPager_RetriesFailedPage(t *testing.T) {
+	attempts := 0
+	fetch := func(params map[string]string) ([]int, int, *ErrorModel, error) {
+		attempts++
+		if attempts == 1 {
+			return nil, 0, nil, errors.New("connection reset")
+		}
+		if attempts == 2 {
+			return nil, 0, &ErrorModel{Code: http.StatusServiceUnavailable}, nil
+		}
+		return []int{1}, 1, nil, nil
+	}
+	items, errorModel, err := NewPager(fetch, OffsetLimitPaging, map[string]string{}, 10).WithRetries(2, 0).All()
+	assert.NilError(t, err)
+	assert.Assert(t, errorModel == nil)
+	assert.Equal(t, len(items), 1)
+	assert.Equal(t, attempts, 3)
+}
+
+func TestPager_DoesNotRetryClientErrors(t *testing.T) {
+	attempts := 0
+	fetch := func(params map[string]string) ([]int, int, *ErrorModel, error) {
+		attempts++
+		return nil, 0, &ErrorModel{Code: http.StatusBadRequest, Message: "bad filter"}, nil
+	}
+	_, errorModel, err := NewPager(fetch, OffsetLimitPaging, map[string]string{}, 10).WithRetries(3, 0).All()
+	assert.NilError(t, err)
+	assert.Equal(t, errorModel.Message, "bad filter")
+	assert.Equal(t, attempts, 1)
+}
+
+func TestFromToPaging(t *testing.T) {
+	params := map[string]string{}
+	FromToPaging(params, 20, 10)
+	assert.Equal(t, params["from"], "21")
+	assert.Equal(t, params["to"], "30")
+}
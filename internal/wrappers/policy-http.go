@@ -72,7 +72,7 @@ func (r *PolicyHTTPWrapper) EvaluatePolicy(params map[string]string) (
 		// policyManagement checks if AM1 Feature flag is ON,if yes policy permissions are checked otherwise skipped
 		amPhase1Enabled, _ := r.featureFlagWrapper.GetSpecificFlag(featureFlagsConstants.AccessManagementEnabled)
 		if amPhase1Enabled != nil && amPhase1Enabled.Status {
-			logger.Printf("This user doesn’t have the necessary permissions to view the Policy Management evaluation for this scan.")
+			logger.Warn("This user doesn’t have the necessary permissions to view the Policy Management evaluation for this scan.")
 			return nil, &errorModel, nil
 		}
 		fallthrough
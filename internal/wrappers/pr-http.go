@@ -34,6 +34,11 @@ func NewHTTPPRWrapper(githubPath, gitlabPath, bitbucketCloudPath, bitbucketServe
 }
 
 func (r *PRHTTPWrapper) PostPRDecoration(model interface{}) (string, *WebError, error) {
+	// Gitea isn't supported by the PR decoration service, its decoration is posted directly
+	if giteaModel, ok := model.(*GiteaPRModel); ok {
+		message, err := (&giteaPRReviewer{pr: giteaModel}).postDecoration()
+		return message, nil, err
+	}
 	url, err := r.getPRDecorationURL(model)
 	if err != nil {
 		return "", nil, err
@@ -0,0 +1,212 @@
+package wrappers
+
+import (
+	b64 "encoding/base64"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+const (
+	azureReviewAPIVersion = "api-version=6.0"
+	azureStatusAPIVersion = "api-version=6.0-preview.1"
+	azureStatusSucceeded  = "succeeded"
+	azureStatusFailed     = "failed"
+	azureStatusGenre      = "checkmarx-one"
+	azureActiveThread     = "active"
+	azurePendingThread    = "pending"
+	azureFixedThread      = "fixed"
+	azureDeleteChange     = "delete"
+	azureTextComment      = 1
+)
+
+type azurePRReviewer struct {
+	pr *AzurePRModel
+}
+
+type azurePullRequest struct {
+	Repository struct {
+		ID string `json:"id"`
+	} `json:"repository"`
+	LastMergeSourceCommit struct {
+		CommitID string `json:"commitId"`
+	} `json:"lastMergeSourceCommit"`
+	LastMergeTargetCommit struct {
+		CommitID string `json:"commitId"`
+	} `json:"lastMergeTargetCommit"`
+}
+
+type azureIterations struct {
+	Value []struct {
+		ID int `json:"id"`
+	} `json:"value"`
+}
+
+type azureIterationChanges struct {
+	ChangeEntries []struct {
+		ChangeType string `json:"changeType"`
+		Item       struct {
+			Path string `json:"path"`
+		} `json:"item"`
+	} `json:"changeEntries"`
+}
+
+type azureThreads struct {
+	Value []azureThread `json:"value"`
+}
+
+type azureThread struct {
+	ID            int64               `json:"id,omitempty"`
+	Status        string              `json:"status,omitempty"`
+	ThreadContext *azureThreadContext `json:"threadContext,omitempty"`
+	Comments      []azureComment      `json:"comments,omitempty"`
+}
+
+type azureThreadContext struct {
+	FilePath       string             `json:"filePath"`
+	RightFileStart *azureFilePosition `json:"rightFileStart"`
+	RightFileEnd   *azureFilePosition `json:"rightFileEnd"`
+}
+
+type azureFilePosition struct {
+	Line   int `json:"line"`
+	Offset int `json:"offset"`
+}
+
+type azureComment struct {
+	ID          int64  `json:"id,omitempty"`
+	Content     string `json:"content"`
+	CommentType int    `json:"commentType,omitempty"`
+}
+
+type azurePullRequestStatus struct {
+	State       string `json:"state"`
+	Description string `json:"description"`
+	TargetURL   string `json:"targetUrl,omitempty"`
+	Context     struct {
+		Name  string `json:"name"`
+		Genre string `json:"genre"`
+	} `json:"context"`
+}
+
+func (a *azurePRReviewer) authorization() string {
+	token := a.pr.ScmToken
+	// The token already holds the user name of Azure DevOps Server
+	if strings.Contains(token, ":") {
+		return fmt.Sprintf(basicFormat, b64.StdEncoding.EncodeToString([]byte(token)))
+	}
+	return fmt.Sprintf(basicFormat, encodeToken(token))
+}
+
+func (a *azurePRReviewer) projectURL() string {
+	return fmt.Sprintf("%s/%s/_apis/git", strings.TrimSuffix(a.pr.APIURL, "/"), a.pr.Namespace)
+}
+
+func (a *azurePRReviewer) pullRequestURL(diff *PRDiff) string {
+	return fmt.Sprintf("%s/repositories/%s/pullRequests/%d", a.projectURL(), diff.RepositoryID, a.pr.PrNumber)
+}
+
+// getDiff returns every line of the files changed by the pull request, Azure DevOps doesn't expose their changed lines
+func (a *azurePRReviewer) getDiff() (*PRDiff, error) {
+	var pullRequest azurePullRequest
+	url := fmt.Sprintf("%s/pullrequests/%d?%s", a.projectURL(), a.pr.PrNumber, azureReviewAPIVersion)
+	if _, _, err := sendSCMRequest(http.MethodGet, url, a.authorization(), nil, &pullRequest); err != nil {
+		return nil, err
+	}
+	diff := &PRDiff{
+		BaseSHA:      pullRequest.LastMergeTargetCommit.CommitID,
+		HeadSHA:      pullRequest.LastMergeSourceCommit.CommitID,
+		RepositoryID: pullRequest.Repository.ID,
+		Files:        make(map[string]*PRDiffFile),
+	}
+	var iterations azureIterations
+	url = fmt.Sprintf("%s/iterations?%s", a.pullRequestURL(diff), azureReviewAPIVersion)
+	if _, _, err := sendSCMRequest(http.MethodGet, url, a.authorization(), nil, &iterations); err != nil {
+		return nil, err
+	}
+	if len(iterations.Value) == 0 {
+		return diff, nil
+	}
+	var changes azureIterationChanges
+	lastIteration := iterations.Value[len(iterations.Value)-1].ID
+	url = fmt.Sprintf("%s/iterations/%d/changes?%s", a.pullRequestURL(diff), lastIteration, azureReviewAPIVersion)
+	if _, _, err := sendSCMRequest(http.MethodGet, url, a.authorization(), nil, &changes); err != nil {
+		return nil, err
+	}
+	for _, change := range changes.ChangeEntries {
+		if !strings.Contains(change.ChangeType, azureDeleteChange) {
+			diff.Files[strings.TrimPrefix(change.Item.Path, "/")] = &PRDiffFile{AllLines: true}
+		}
+	}
+	return diff, nil
+}
+
+func (a *azurePRReviewer) getComments(diff *PRDiff) ([]PRReviewComment, error) {
+	var threads azureThreads
+	url := fmt.Sprintf("%s/threads?%s", a.pullRequestURL(diff), azureReviewAPIVersion)
+	if _, _, err := sendSCMRequest(http.MethodGet, url, a.authorization(), nil, &threads); err != nil {
+		return nil, err
+	}
+	var comments []PRReviewComment
+	for _, thread := range threads.Value {
+		if len(thread.Comments) == 0 || thread.ThreadContext == nil || thread.ThreadContext.RightFileStart == nil {
+			continue
+		}
+		comments = append(comments, PRReviewComment{
+			ID:       commentID(thread.Comments[0].ID),
+			ThreadID: commentID(thread.ID),
+			Path:     strings.TrimPrefix(thread.ThreadContext.FilePath, "/"),
+			Line:     thread.ThreadContext.RightFileStart.Line,
+			Body:     thread.Comments[0].Content,
+			Resolved: thread.Status != azureActiveThread && thread.Status != azurePendingThread,
+		})
+	}
+	return comments, nil
+}
+
+func (a *azurePRReviewer) createComment(diff *PRDiff, comment *PRReviewComment) error {
+	position := &azureFilePosition{Line: comment.Line, Offset: 1}
+	body := azureThread{
+		Status:        azureActiveThread,
+		ThreadContext: &azureThreadContext{FilePath: "/" + comment.Path, RightFileStart: position, RightFileEnd: position},
+		Comments:      []azureComment{{Content: comment.Body, CommentType: azureTextComment}},
+	}
+	var created azureThread
+	url := fmt.Sprintf("%s/threads?%s", a.pullRequestURL(diff), azureReviewAPIVersion)
+	if _, _, err := sendSCMRequest(http.MethodPost, url, a.authorization(), body, &created); err != nil {
+		return err
+	}
+	comment.ThreadID = commentID(created.ID)
+	if len(created.Comments) > 0 {
+		comment.ID = commentID(created.Comments[0].ID)
+	}
+	return nil
+}
+
+func (a *azurePRReviewer) updateComment(diff *PRDiff, comment *PRReviewComment) error {
+	url := fmt.Sprintf("%s/threads/%s/comments/%s?%s", a.pullRequestURL(diff), comment.ThreadID, comment.ID, azureReviewAPIVersion)
+	_, _, err := sendSCMRequest(http.MethodPatch, url, a.authorization(), azureComment{Content: comment.Body}, nil)
+	return err
+}
+
+func (a *azurePRReviewer) resolveComment(diff *PRDiff, comment *PRReviewComment) error {
+	if err := a.updateComment(diff, comment); err != nil {
+		return err
+	}
+	url := fmt.Sprintf("%s/threads/%s?%s", a.pullRequestURL(diff), comment.ThreadID, azureReviewAPIVersion)
+	_, _, err := sendSCMRequest(http.MethodPatch, url, a.authorization(), azureThread{Status: azureFixedThread}, nil)
+	return err
+}
+
+// postStatus publishes a pull request status, the status branch policies of Azure DevOps apply to
+func (a *azurePRReviewer) postStatus(diff *PRDiff, status *PRStatus) error {
+	body := azurePullRequestStatus{State: azureStatusFailed, Description: status.Description, TargetURL: status.TargetURL}
+	if status.Passed {
+		body.State = azureStatusSucceeded
+	}
+	body.Context.Name = status.Name
+	body.Context.Genre = azureStatusGenre
+	url := fmt.Sprintf("%s/statuses?%s", a.pullRequestURL(diff), azureStatusAPIVersion)
+	_, _, err := sendSCMRequest(http.MethodPost, url, a.authorization(), body, nil)
+	return err
+}
@@ -0,0 +1,302 @@
+package wrappers
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+const (
+	bitbucketCloudReviewURL     = "https://api.bitbucket.org/2.0/repositories/%s/%s/pullrequests/%d"
+	bitbucketServerReviewURL    = "%s/rest/api/1.0/projects/%s/repos/%s/pull-requests/%d"
+	bitbucketServerCommented    = "COMMENTED"
+	bitbucketServerResolved     = "RESOLVED"
+	bitbucketServerAddedLine    = "ADDED"
+	bitbucketServerNewFile      = "TO"
+	bitbucketServerEffectiveRef = "EFFECTIVE"
+	bitbucketCloudStatusURL     = "https://api.bitbucket.org/2.0/repositories/%s/%s/commit/%s/statuses/build"
+	bitbucketServerStatusURL    = "%s/rest/build-status/1.0/commits/%s"
+	bitbucketStatusSuccessful   = "SUCCESSFUL"
+	bitbucketStatusFailed       = "FAILED"
+)
+
+// bitbucketBuildStatus is the build status of a commit in Bitbucket Cloud and Server
+type bitbucketBuildStatus struct {
+	Key         string `json:"key"`
+	State       string `json:"state"`
+	Name        string `json:"name"`
+	URL         string `json:"url"`
+	Description string `json:"description"`
+}
+
+func newBitbucketBuildStatus(status *PRStatus) *bitbucketBuildStatus {
+	body := &bitbucketBuildStatus{
+		Key:         strings.ToUpper(strings.ReplaceAll(status.Name, " ", "-")),
+		State:       bitbucketStatusFailed,
+		Name:        status.Name,
+		URL:         status.TargetURL,
+		Description: status.Description,
+	}
+	if status.Passed {
+		body.State = bitbucketStatusSuccessful
+	}
+	return body
+}
+
+type bitbucketCloudPRReviewer struct {
+	pr *BitbucketCloudPRModel
+}
+
+type bitbucketCloudPullRequest struct {
+	Source struct {
+		Commit struct {
+			Hash string `json:"hash"`
+		} `json:"commit"`
+	} `json:"source"`
+	Destination struct {
+		Commit struct {
+			Hash string `json:"hash"`
+		} `json:"commit"`
+	} `json:"destination"`
+}
+
+type bitbucketCloudComments struct {
+	Values []bitbucketCloudComment `json:"values"`
+	Next   string                  `json:"next"`
+}
+
+type bitbucketCloudComment struct {
+	ID      int64 `json:"id,omitempty"`
+	Content struct {
+		Raw string `json:"raw"`
+	} `json:"content"`
+	Inline     *bitbucketCloudInline `json:"inline,omitempty"`
+	Resolution interface{}           `json:"resolution,omitempty"`
+	Deleted    bool                  `json:"deleted,omitempty"`
+}
+
+type bitbucketCloudInline struct {
+	Path string `json:"path"`
+	To   int    `json:"to"`
+}
+
+func (b *bitbucketCloudPRReviewer) authorization() string {
+	return fmt.Sprintf(bearerFormat, b.pr.ScmToken)
+}
+
+func (b *bitbucketCloudPRReviewer) pullRequestURL() string {
+	return fmt.Sprintf(bitbucketCloudReviewURL, b.pr.Namespace, b.pr.RepoName, b.pr.PRID)
+}
+
+func (b *bitbucketCloudPRReviewer) getDiff() (*PRDiff, error) {
+	var pullRequest bitbucketCloudPullRequest
+	if _, _, err := sendSCMRequest(http.MethodGet, b.pullRequestURL(), b.authorization(), nil, &pullRequest); err != nil {
+		return nil, err
+	}
+	content, _, err := sendSCMRequest(http.MethodGet, b.pullRequestURL()+"/diff", b.authorization(), nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &PRDiff{
+		BaseSHA: pullRequest.Destination.Commit.Hash,
+		HeadSHA: pullRequest.Source.Commit.Hash,
+		Files:   ParseUnifiedDiff(string(content)),
+	}, nil
+}
+
+func (b *bitbucketCloudPRReviewer) getComments(_ *PRDiff) ([]PRReviewComment, error) {
+	var comments []PRReviewComment
+	url := fmt.Sprintf("%s/comments?%s=%s", b.pullRequestURL(), pageLen, pageLenValue)
+	for url != "" {
+		var page bitbucketCloudComments
+		if _, _, err := sendSCMRequest(http.MethodGet, url, b.authorization(), nil, &page); err != nil {
+			return nil, err
+		}
+		for _, comment := range page.Values {
+			if comment.Inline == nil || comment.Deleted {
+				continue
+			}
+			comments = append(comments, PRReviewComment{
+				ID:       commentID(comment.ID),
+				Path:     comment.Inline.Path,
+				Line:     comment.Inline.To,
+				Body:     comment.Content.Raw,
+				Resolved: comment.Resolution != nil,
+			})
+		}
+		url = page.Next
+	}
+	return comments, nil
+}
+
+func (b *bitbucketCloudPRReviewer) createComment(_ *PRDiff, comment *PRReviewComment) error {
+	body := b.newComment(comment)
+	body.Inline = &bitbucketCloudInline{Path: comment.Path, To: comment.Line}
+	var created bitbucketCloudComment
+	if _, _, err := sendSCMRequest(http.MethodPost, b.pullRequestURL()+"/comments", b.authorization(), body, &created); err != nil {
+		return err
+	}
+	comment.ID = commentID(created.ID)
+	return nil
+}
+
+func (b *bitbucketCloudPRReviewer) updateComment(_ *PRDiff, comment *PRReviewComment) error {
+	url := fmt.Sprintf("%s/comments/%s", b.pullRequestURL(), comment.ID)
+	_, _, err := sendSCMRequest(http.MethodPut, url, b.authorization(), b.newComment(comment), nil)
+	return err
+}
+
+func (b *bitbucketCloudPRReviewer) resolveComment(diff *PRDiff, comment *PRReviewComment) error {
+	if err := b.updateComment(diff, comment); err != nil {
+		return err
+	}
+	url := fmt.Sprintf("%s/comments/%s/resolve", b.pullRequestURL(), comment.ID)
+	_, _, err := sendSCMRequest(http.MethodPost, url, b.authorization(), nil, nil)
+	return err
+}
+
+func (b *bitbucketCloudPRReviewer) newComment(comment *PRReviewComment) *bitbucketCloudComment {
+	body := &bitbucketCloudComment{}
+	body.Content.Raw = comment.Body
+	return body
+}
+
+func (b *bitbucketCloudPRReviewer) postStatus(_ *PRDiff, status *PRStatus) error {
+	url := fmt.Sprintf(bitbucketCloudStatusURL, b.pr.Namespace, b.pr.RepoName, status.CommitSHA)
+	_, _, err := sendSCMRequest(http.MethodPost, url, b.authorization(), newBitbucketBuildStatus(status), nil)
+	return err
+}
+
+type bitbucketServerPRReviewer struct {
+	pr *BitbucketServerPRModel
+}
+
+type bitbucketServerPullRequest struct {
+	FromRef struct {
+		LatestCommit string `json:"latestCommit"`
+	} `json:"fromRef"`
+	ToRef struct {
+		LatestCommit string `json:"latestCommit"`
+	} `json:"toRef"`
+}
+
+type bitbucketServerActivities struct {
+	Values []struct {
+		Action        string                  `json:"action"`
+		Comment       *bitbucketServerComment `json:"comment"`
+		CommentAnchor *bitbucketServerAnchor  `json:"commentAnchor"`
+	} `json:"values"`
+	IsLastPage    bool `json:"isLastPage"`
+	NextPageStart int  `json:"nextPageStart"`
+}
+
+type bitbucketServerComment struct {
+	ID      int64                  `json:"id,omitempty"`
+	Version int                    `json:"version"`
+	Text    string                 `json:"text"`
+	State   string                 `json:"state,omitempty"`
+	Anchor  *bitbucketServerAnchor `json:"anchor,omitempty"`
+}
+
+type bitbucketServerAnchor struct {
+	Path     string `json:"path"`
+	Line     int    `json:"line"`
+	LineType string `json:"lineType,omitempty"`
+	FileType string `json:"fileType,omitempty"`
+	DiffType string `json:"diffType,omitempty"`
+}
+
+func (b *bitbucketServerPRReviewer) authorization() string {
+	return fmt.Sprintf(bearerFormat, b.pr.ScmToken)
+}
+
+func (b *bitbucketServerPRReviewer) pullRequestURL() string {
+	return fmt.Sprintf(bitbucketServerReviewURL, strings.TrimSuffix(b.pr.ServerURL, "/"), b.pr.ProjectKey, b.pr.RepoName, b.pr.PRID)
+}
+
+func (b *bitbucketServerPRReviewer) getDiff() (*PRDiff, error) {
+	var pullRequest bitbucketServerPullRequest
+	if _, _, err := sendSCMRequest(http.MethodGet, b.pullRequestURL(), b.authorization(), nil, &pullRequest); err != nil {
+		return nil, err
+	}
+	content, _, err := sendSCMRequest(http.MethodGet, b.pullRequestURL()+".diff", b.authorization(), nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &PRDiff{
+		BaseSHA: pullRequest.ToRef.LatestCommit,
+		HeadSHA: pullRequest.FromRef.LatestCommit,
+		Files:   ParseUnifiedDiff(string(content)),
+	}, nil
+}
+
+func (b *bitbucketServerPRReviewer) getComments(_ *PRDiff) ([]PRReviewComment, error) {
+	var comments []PRReviewComment
+	start, isLastPage := 0, false
+	for !isLastPage {
+		var page bitbucketServerActivities
+		url := fmt.Sprintf("%s/activities?limit=%s&start=%d", b.pullRequestURL(), perPageValue, start)
+		if _, _, err := sendSCMRequest(http.MethodGet, url, b.authorization(), nil, &page); err != nil {
+			return nil, err
+		}
+		for _, activity := range page.Values {
+			if activity.Action != bitbucketServerCommented || activity.Comment == nil || activity.CommentAnchor == nil {
+				continue
+			}
+			comments = append(comments, PRReviewComment{
+				ID:       commentID(activity.Comment.ID),
+				Version:  activity.Comment.Version,
+				Path:     activity.CommentAnchor.Path,
+				Line:     activity.CommentAnchor.Line,
+				Body:     activity.Comment.Text,
+				Resolved: activity.Comment.State == bitbucketServerResolved,
+			})
+		}
+		start, isLastPage = page.NextPageStart, page.IsLastPage
+	}
+	return comments, nil
+}
+
+func (b *bitbucketServerPRReviewer) createComment(_ *PRDiff, comment *PRReviewComment) error {
+	body := bitbucketServerComment{
+		Text: comment.Body,
+		Anchor: &bitbucketServerAnchor{
+			Path:     comment.Path,
+			Line:     comment.Line,
+			LineType: bitbucketServerAddedLine,
+			FileType: bitbucketServerNewFile,
+			DiffType: bitbucketServerEffectiveRef,
+		},
+	}
+	var created bitbucketServerComment
+	if _, _, err := sendSCMRequest(http.MethodPost, b.pullRequestURL()+"/comments", b.authorization(), body, &created); err != nil {
+		return err
+	}
+	comment.ID = commentID(created.ID)
+	comment.Version = created.Version
+	return nil
+}
+
+func (b *bitbucketServerPRReviewer) updateComment(_ *PRDiff, comment *PRReviewComment) error {
+	return b.putComment(&bitbucketServerComment{Text: comment.Body, Version: comment.Version}, comment)
+}
+
+func (b *bitbucketServerPRReviewer) resolveComment(_ *PRDiff, comment *PRReviewComment) error {
+	return b.putComment(&bitbucketServerComment{Text: comment.Body, Version: comment.Version, State: bitbucketServerResolved}, comment)
+}
+
+func (b *bitbucketServerPRReviewer) putComment(body *bitbucketServerComment, comment *PRReviewComment) error {
+	var updated bitbucketServerComment
+	url := fmt.Sprintf("%s/comments/%s", b.pullRequestURL(), comment.ID)
+	if _, _, err := sendSCMRequest(http.MethodPut, url, b.authorization(), body, &updated); err != nil {
+		return err
+	}
+	comment.Version = updated.Version
+	return nil
+}
+
+func (b *bitbucketServerPRReviewer) postStatus(_ *PRDiff, status *PRStatus) error {
+	url := fmt.Sprintf(bitbucketServerStatusURL, strings.TrimSuffix(b.pr.ServerURL, "/"), status.CommitSHA)
+	_, _, err := sendSCMRequest(http.MethodPost, url, b.authorization(), newBitbucketBuildStatus(status), nil)
+	return err
+}
@@ -0,0 +1,187 @@
+package wrappers
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+const (
+	giteaCommentReview    = "COMMENT"
+	giteaStatusSuccess    = "success"
+	giteaStatusFailure    = "failure"
+	giteaDecorationPosted = "Gitea PR decoration posted"
+)
+
+type giteaPRReviewer struct {
+	pr *GiteaPRModel
+}
+
+type giteaPullRequest struct {
+	Head struct {
+		SHA string `json:"sha"`
+	} `json:"head"`
+	Base struct {
+		SHA string `json:"sha"`
+	} `json:"base"`
+}
+
+type giteaReview struct {
+	ID            int64 `json:"id"`
+	CommentsCount int   `json:"comments_count"`
+}
+
+type giteaReviewComment struct {
+	ID       int64      `json:"id,omitempty"`
+	Body     string     `json:"body"`
+	Path     string     `json:"path,omitempty"`
+	Position int        `json:"position,omitempty"`
+	Resolver *GiteaUser `json:"resolver,omitempty"`
+}
+
+type giteaNewReview struct {
+	CommitID string                  `json:"commit_id"`
+	Event    string                  `json:"event"`
+	Comments []giteaNewReviewComment `json:"comments"`
+}
+
+type giteaNewReviewComment struct {
+	Path        string `json:"path"`
+	Body        string `json:"body"`
+	NewPosition int    `json:"new_position"`
+}
+
+type giteaIssueComment struct {
+	ID   int64  `json:"id,omitempty"`
+	Body string `json:"body"`
+}
+
+type giteaCommitStatus struct {
+	State       string `json:"state"`
+	TargetURL   string `json:"target_url,omitempty"`
+	Description string `json:"description"`
+	Context     string `json:"context"`
+}
+
+func (g *giteaPRReviewer) authorization() string {
+	return fmt.Sprintf(tokenFormat, g.pr.ScmToken)
+}
+
+func (g *giteaPRReviewer) repositoryURL() string {
+	return fmt.Sprintf("%s/repos/%s/%s", strings.TrimSuffix(g.pr.APIURL, "/"), g.pr.Namespace, g.pr.RepoName)
+}
+
+func (g *giteaPRReviewer) pullRequestURL() string {
+	return fmt.Sprintf("%s/pulls/%d", g.repositoryURL(), g.pr.PrNumber)
+}
+
+func (g *giteaPRReviewer) getDiff() (*PRDiff, error) {
+	var pullRequest giteaPullRequest
+	if _, _, err := sendSCMRequest(http.MethodGet, g.pullRequestURL(), g.authorization(), nil, &pullRequest); err != nil {
+		return nil, err
+	}
+	content, _, err := sendSCMRequest(http.MethodGet, g.pullRequestURL()+".diff", g.authorization(), nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &PRDiff{BaseSHA: pullRequest.Base.SHA, HeadSHA: pullRequest.Head.SHA, Files: ParseUnifiedDiff(string(content))}, nil
+}
+
+func (g *giteaPRReviewer) getComments(_ *PRDiff) ([]PRReviewComment, error) {
+	reviews, err := getSCMLinkPages[giteaReview](g.pullRequestURL()+"/reviews?limit="+giteaLimitValue, g.authorization())
+	if err != nil {
+		return nil, err
+	}
+	var comments []PRReviewComment
+	for _, review := range reviews {
+		if review.CommentsCount == 0 {
+			continue
+		}
+		reviewComments, err := g.getReviewComments(review.ID)
+		if err != nil {
+			return nil, err
+		}
+		for _, comment := range reviewComments {
+			comments = append(comments, PRReviewComment{
+				ID:       commentID(comment.ID),
+				Path:     comment.Path,
+				Line:     comment.Position,
+				Body:     comment.Body,
+				Resolved: comment.Resolver != nil,
+			})
+		}
+	}
+	return comments, nil
+}
+
+func (g *giteaPRReviewer) getReviewComments(reviewID int64) ([]giteaReviewComment, error) {
+	var comments []giteaReviewComment
+	url := fmt.Sprintf("%s/reviews/%d/comments", g.pullRequestURL(), reviewID)
+	_, _, err := sendSCMRequest(http.MethodGet, url, g.authorization(), nil, &comments)
+	return comments, err
+}
+
+// createComment posts a review holding the comment, the ID of the comment is read from the comments of the review
+func (g *giteaPRReviewer) createComment(diff *PRDiff, comment *PRReviewComment) error {
+	body := giteaNewReview{
+		CommitID: diff.HeadSHA,
+		Event:    giteaCommentReview,
+		Comments: []giteaNewReviewComment{{Path: comment.Path, Body: comment.Body, NewPosition: comment.Line}},
+	}
+	var created giteaReview
+	if _, _, err := sendSCMRequest(http.MethodPost, g.pullRequestURL()+"/reviews", g.authorization(), body, &created); err != nil {
+		return err
+	}
+	comments, err := g.getReviewComments(created.ID)
+	if err != nil {
+		return err
+	}
+	if len(comments) > 0 {
+		comment.ID = commentID(comments[0].ID)
+	}
+	return nil
+}
+
+func (g *giteaPRReviewer) updateComment(_ *PRDiff, comment *PRReviewComment) error {
+	url := fmt.Sprintf("%s/issues/comments/%s", g.repositoryURL(), comment.ID)
+	_, _, err := sendSCMRequest(http.MethodPatch, url, g.authorization(), giteaIssueComment{Body: comment.Body}, nil)
+	return err
+}
+
+// resolveComment only updates the comment, conversations can't be resolved through the Gitea API
+func (g *giteaPRReviewer) resolveComment(diff *PRDiff, comment *PRReviewComment) error {
+	return g.updateComment(diff, comment)
+}
+
+func (g *giteaPRReviewer) postStatus(_ *PRDiff, status *PRStatus) error {
+	body := giteaCommitStatus{State: giteaStatusFailure, TargetURL: status.TargetURL, Description: status.Description, Context: status.Name}
+	if status.Passed {
+		body.State = giteaStatusSuccess
+	}
+	url := fmt.Sprintf("%s/statuses/%s", g.repositoryURL(), status.CommitSHA)
+	_, _, err := sendSCMRequest(http.MethodPost, url, g.authorization(), body, nil)
+	return err
+}
+
+// postDecoration updates the decoration comment of a previous scan, otherwise creates it.
+func (g *giteaPRReviewer) postDecoration() (string, error) {
+	issueCommentsURL := fmt.Sprintf("%s/issues/%d/comments", g.repositoryURL(), g.pr.PrNumber)
+	comments, err := getSCMLinkPages[giteaIssueComment](issueCommentsURL, g.authorization())
+	if err != nil {
+		return "", err
+	}
+	body := giteaIssueComment{Body: g.pr.Comment}
+	for _, comment := range comments {
+		if strings.Contains(comment.Body, PRDecorationMarker) {
+			url := fmt.Sprintf("%s/issues/comments/%d", g.repositoryURL(), comment.ID)
+			if _, _, err = sendSCMRequest(http.MethodPatch, url, g.authorization(), body, nil); err != nil {
+				return "", err
+			}
+			return giteaDecorationPosted, nil
+		}
+	}
+	if _, _, err = sendSCMRequest(http.MethodPost, issueCommentsURL, g.authorization(), body, nil); err != nil {
+		return "", err
+	}
+	return giteaDecorationPosted, nil
+}
@@ -0,0 +1,207 @@
+package wrappers
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+const (
+	githubRemovedFile       = "removed"
+	githubStatusSuccess     = "success"
+	githubStatusFailure     = "failure"
+	githubCheckRunCompleted = "completed"
+	githubAnnotationFailure = "failure"
+	githubAnnotationWarning = "warning"
+	githubAnnotationNotice  = "notice"
+	// githubAnnotationsLimit is the number of annotations a check run accepts per request
+	githubAnnotationsLimit = 50
+)
+
+type githubPRReviewer struct {
+	pr *PRModel
+}
+
+type githubPullRequest struct {
+	Head struct {
+		SHA string `json:"sha"`
+	} `json:"head"`
+	Base struct {
+		SHA string `json:"sha"`
+	} `json:"base"`
+}
+
+type githubPullRequestFile struct {
+	Filename string `json:"filename"`
+	Status   string `json:"status"`
+	Patch    string `json:"patch"`
+}
+
+type githubReviewComment struct {
+	ID       int64  `json:"id,omitempty"`
+	Body     string `json:"body"`
+	CommitID string `json:"commit_id,omitempty"`
+	Path     string `json:"path,omitempty"`
+	Line     int    `json:"line,omitempty"`
+	Side     string `json:"side,omitempty"`
+}
+
+type githubCommitStatus struct {
+	State       string `json:"state"`
+	TargetURL   string `json:"target_url,omitempty"`
+	Description string `json:"description"`
+	Context     string `json:"context"`
+}
+
+type githubCheckRun struct {
+	ID         int64                `json:"id,omitempty"`
+	Name       string               `json:"name,omitempty"`
+	HeadSHA    string               `json:"head_sha,omitempty"`
+	Status     string               `json:"status,omitempty"`
+	Conclusion string               `json:"conclusion,omitempty"`
+	DetailsURL string               `json:"details_url,omitempty"`
+	Output     githubCheckRunOutput `json:"output"`
+}
+
+type githubCheckRunOutput struct {
+	Title       string             `json:"title"`
+	Summary     string             `json:"summary"`
+	Annotations []githubAnnotation `json:"annotations,omitempty"`
+}
+
+type githubAnnotation struct {
+	Path            string `json:"path"`
+	StartLine       int    `json:"start_line"`
+	EndLine         int    `json:"end_line"`
+	AnnotationLevel string `json:"annotation_level"`
+	Title           string `json:"title"`
+	Message         string `json:"message"`
+}
+
+func (g *githubPRReviewer) authorization() string {
+	return fmt.Sprintf(tokenFormat, g.pr.ScmToken)
+}
+
+func (g *githubPRReviewer) repositoryURL() string {
+	return fmt.Sprintf("%s%s/%s", g.pr.APIURL, g.pr.Namespace, g.pr.RepoName)
+}
+
+func (g *githubPRReviewer) pullRequestURL() string {
+	return fmt.Sprintf("%s/pulls/%d", g.repositoryURL(), g.pr.PrNumber)
+}
+
+func (g *githubPRReviewer) getDiff() (*PRDiff, error) {
+	var pullRequest githubPullRequest
+	if _, _, err := sendSCMRequest(http.MethodGet, g.pullRequestURL(), g.authorization(), nil, &pullRequest); err != nil {
+		return nil, err
+	}
+	files, err := getSCMLinkPages[githubPullRequestFile](g.pullRequestURL()+"/files?per_page="+perPageValue, g.authorization())
+	if err != nil {
+		return nil, err
+	}
+	diff := &PRDiff{BaseSHA: pullRequest.Base.SHA, HeadSHA: pullRequest.Head.SHA, Files: make(map[string]*PRDiffFile)}
+	for _, file := range files {
+		if file.Status != githubRemovedFile {
+			diff.Files[file.Filename] = ParsePatchHunks(file.Patch)
+		}
+	}
+	return diff, nil
+}
+
+func (g *githubPRReviewer) getComments(_ *PRDiff) ([]PRReviewComment, error) {
+	comments, err := getSCMLinkPages[githubReviewComment](g.pullRequestURL()+"/comments?per_page="+perPageValue, g.authorization())
+	if err != nil {
+		return nil, err
+	}
+	reviewComments := make([]PRReviewComment, 0, len(comments))
+	for _, comment := range comments {
+		reviewComments = append(reviewComments, PRReviewComment{
+			ID:   commentID(comment.ID),
+			Path: comment.Path,
+			Line: comment.Line,
+			Body: comment.Body,
+		})
+	}
+	return reviewComments, nil
+}
+
+func (g *githubPRReviewer) createComment(diff *PRDiff, comment *PRReviewComment) error {
+	body := githubReviewComment{Body: comment.Body, CommitID: diff.HeadSHA, Path: comment.Path, Line: comment.Line, Side: "RIGHT"}
+	var created githubReviewComment
+	if _, _, err := sendSCMRequest(http.MethodPost, g.pullRequestURL()+"/comments", g.authorization(), body, &created); err != nil {
+		return err
+	}
+	comment.ID = commentID(created.ID)
+	return nil
+}
+
+func (g *githubPRReviewer) updateComment(_ *PRDiff, comment *PRReviewComment) error {
+	url := fmt.Sprintf("%s/pulls/comments/%s", g.repositoryURL(), comment.ID)
+	_, _, err := sendSCMRequest(http.MethodPatch, url, g.authorization(), githubReviewComment{Body: comment.Body}, nil)
+	return err
+}
+
+// resolveComment only updates the comment, review threads can't be resolved through the GitHub REST API
+func (g *githubPRReviewer) resolveComment(diff *PRDiff, comment *PRReviewComment) error {
+	return g.updateComment(diff, comment)
+}
+
+func (g *githubPRReviewer) postStatus(_ *PRDiff, status *PRStatus) error {
+	state := githubStatusFailure
+	if status.Passed {
+		state = githubStatusSuccess
+	}
+	if !status.CheckRun {
+		url := fmt.Sprintf("%s/statuses/%s", g.repositoryURL(), status.CommitSHA)
+		body := githubCommitStatus{State: state, TargetURL: status.TargetURL, Description: status.Description, Context: status.Name}
+		_, _, err := sendSCMRequest(http.MethodPost, url, g.authorization(), body, nil)
+		return err
+	}
+
+	annotations := githubAnnotations(status.Annotations)
+	output := githubCheckRunOutput{Title: status.Name, Summary: status.Description}
+	output.Annotations = annotations[:min(len(annotations), githubAnnotationsLimit)]
+	checkRun := githubCheckRun{
+		Name:       status.Name,
+		HeadSHA:    status.CommitSHA,
+		Status:     githubCheckRunCompleted,
+		Conclusion: state,
+		DetailsURL: status.TargetURL,
+		Output:     output,
+	}
+	var created githubCheckRun
+	if _, _, err := sendSCMRequest(http.MethodPost, g.repositoryURL()+"/check-runs", g.authorization(), checkRun, &created); err != nil {
+		return err
+	}
+	// The annotations over the limit of a request are added by updating the check run
+	for start := githubAnnotationsLimit; start < len(annotations); start += githubAnnotationsLimit {
+		output.Annotations = annotations[start:min(len(annotations), start+githubAnnotationsLimit)]
+		url := fmt.Sprintf("%s/check-runs/%d", g.repositoryURL(), created.ID)
+		if _, _, err := sendSCMRequest(http.MethodPatch, url, g.authorization(), githubCheckRun{Output: output}, nil); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func githubAnnotations(annotations []PRAnnotation) []githubAnnotation {
+	githubAnnotations := make([]githubAnnotation, 0, len(annotations))
+	for _, annotation := range annotations {
+		level := githubAnnotationNotice
+		switch strings.ToUpper(annotation.Severity) {
+		case "CRITICAL", "HIGH":
+			level = githubAnnotationFailure
+		case "MEDIUM":
+			level = githubAnnotationWarning
+		}
+		githubAnnotations = append(githubAnnotations, githubAnnotation{
+			Path:            annotation.Path,
+			StartLine:       annotation.Line,
+			EndLine:         annotation.Line,
+			AnnotationLevel: level,
+			Title:           annotation.Title,
+			Message:         annotation.Message,
+		})
+	}
+	return githubAnnotations
+}
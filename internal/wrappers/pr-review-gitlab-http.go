@@ -0,0 +1,165 @@
+package wrappers
+
+import (
+	"fmt"
+	"net/http"
+)
+
+const (
+	gitLabTextPosition  = "text"
+	gitLabStatusSuccess = "success"
+	gitLabStatusFailed  = "failed"
+)
+
+type gitlabPRReviewer struct {
+	pr *GitlabPRModel
+}
+
+type gitlabMergeRequest struct {
+	DiffRefs struct {
+		BaseSHA  string `json:"base_sha"`
+		HeadSHA  string `json:"head_sha"`
+		StartSHA string `json:"start_sha"`
+	} `json:"diff_refs"`
+}
+
+type gitlabMergeRequestDiff struct {
+	NewPath     string `json:"new_path"`
+	DeletedFile bool   `json:"deleted_file"`
+	Diff        string `json:"diff"`
+}
+
+type gitlabDiscussion struct {
+	ID    string       `json:"id"`
+	Notes []gitlabNote `json:"notes"`
+}
+
+type gitlabNote struct {
+	ID       int64           `json:"id"`
+	Body     string          `json:"body"`
+	Resolved bool            `json:"resolved"`
+	Position *gitlabPosition `json:"position"`
+}
+
+type gitlabPosition struct {
+	PositionType string `json:"position_type"`
+	BaseSHA      string `json:"base_sha"`
+	StartSHA     string `json:"start_sha"`
+	HeadSHA      string `json:"head_sha"`
+	NewPath      string `json:"new_path"`
+	NewLine      int    `json:"new_line"`
+}
+
+type gitlabNewDiscussion struct {
+	Body     string         `json:"body"`
+	Position gitlabPosition `json:"position"`
+}
+
+type gitlabCommitStatus struct {
+	State       string `json:"state"`
+	Name        string `json:"name"`
+	TargetURL   string `json:"target_url,omitempty"`
+	Description string `json:"description"`
+}
+
+func (g *gitlabPRReviewer) authorization() string {
+	return fmt.Sprintf(gitLabTokenFormat, g.pr.ScmToken)
+}
+
+func (g *gitlabPRReviewer) mergeRequestURL() string {
+	return fmt.Sprintf("%sprojects/%d/merge_requests/%d", g.pr.APIURL, g.pr.GitlabProjectID, g.pr.IiD)
+}
+
+func (g *gitlabPRReviewer) getDiff() (*PRDiff, error) {
+	var mergeRequest gitlabMergeRequest
+	if _, _, err := sendSCMRequest(http.MethodGet, g.mergeRequestURL(), g.authorization(), nil, &mergeRequest); err != nil {
+		return nil, err
+	}
+	diffs, err := getSCMLinkPages[gitlabMergeRequestDiff](g.mergeRequestURL()+"/diffs?per_page="+perPageValueGitLab, g.authorization())
+	if err != nil {
+		return nil, err
+	}
+	diff := &PRDiff{
+		BaseSHA:  mergeRequest.DiffRefs.BaseSHA,
+		StartSHA: mergeRequest.DiffRefs.StartSHA,
+		HeadSHA:  mergeRequest.DiffRefs.HeadSHA,
+		Files:    make(map[string]*PRDiffFile),
+	}
+	for _, fileDiff := range diffs {
+		if !fileDiff.DeletedFile {
+			diff.Files[fileDiff.NewPath] = ParsePatchHunks(fileDiff.Diff)
+		}
+	}
+	return diff, nil
+}
+
+func (g *gitlabPRReviewer) getComments(_ *PRDiff) ([]PRReviewComment, error) {
+	discussions, err := getSCMLinkPages[gitlabDiscussion](g.mergeRequestURL()+"/discussions?per_page="+perPageValueGitLab, g.authorization())
+	if err != nil {
+		return nil, err
+	}
+	var comments []PRReviewComment
+	for _, discussion := range discussions {
+		if len(discussion.Notes) == 0 || discussion.Notes[0].Position == nil {
+			continue
+		}
+		note := discussion.Notes[0]
+		comments = append(comments, PRReviewComment{
+			ID:       commentID(note.ID),
+			ThreadID: discussion.ID,
+			Path:     note.Position.NewPath,
+			Line:     note.Position.NewLine,
+			Body:     note.Body,
+			Resolved: note.Resolved,
+		})
+	}
+	return comments, nil
+}
+
+func (g *gitlabPRReviewer) createComment(diff *PRDiff, comment *PRReviewComment) error {
+	body := gitlabNewDiscussion{
+		Body: comment.Body,
+		Position: gitlabPosition{
+			PositionType: gitLabTextPosition,
+			BaseSHA:      diff.BaseSHA,
+			StartSHA:     diff.StartSHA,
+			HeadSHA:      diff.HeadSHA,
+			NewPath:      comment.Path,
+			NewLine:      comment.Line,
+		},
+	}
+	var created gitlabDiscussion
+	if _, _, err := sendSCMRequest(http.MethodPost, g.mergeRequestURL()+"/discussions", g.authorization(), body, &created); err != nil {
+		return err
+	}
+	comment.ThreadID = created.ID
+	if len(created.Notes) > 0 {
+		comment.ID = commentID(created.Notes[0].ID)
+	}
+	return nil
+}
+
+func (g *gitlabPRReviewer) updateComment(_ *PRDiff, comment *PRReviewComment) error {
+	url := fmt.Sprintf("%s/discussions/%s/notes/%s", g.mergeRequestURL(), comment.ThreadID, comment.ID)
+	_, _, err := sendSCMRequest(http.MethodPut, url, g.authorization(), map[string]string{"body": comment.Body}, nil)
+	return err
+}
+
+func (g *gitlabPRReviewer) resolveComment(diff *PRDiff, comment *PRReviewComment) error {
+	if err := g.updateComment(diff, comment); err != nil {
+		return err
+	}
+	url := fmt.Sprintf("%s/discussions/%s", g.mergeRequestURL(), comment.ThreadID)
+	_, _, err := sendSCMRequest(http.MethodPut, url, g.authorization(), map[string]bool{"resolved": true}, nil)
+	return err
+}
+
+func (g *gitlabPRReviewer) postStatus(_ *PRDiff, status *PRStatus) error {
+	body := gitlabCommitStatus{State: gitLabStatusFailed, Name: status.Name, TargetURL: status.TargetURL, Description: status.Description}
+	if status.Passed {
+		body.State = gitLabStatusSuccess
+	}
+	url := fmt.Sprintf("%sprojects/%d/statuses/%s", g.pr.APIURL, g.pr.GitlabProjectID, status.CommitSHA)
+	_, _, err := sendSCMRequest(http.MethodPost, url, g.authorization(), body, nil)
+	return err
+}
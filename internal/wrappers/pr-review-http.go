@@ -0,0 +1,151 @@
+package wrappers
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/checkmarx/ast-cli/internal/params"
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+)
+
+// prReviewer posts the inline review comments of a pull request through the REST API of its SCM.
+type prReviewer interface {
+	getDiff() (*PRDiff, error)
+	getComments(diff *PRDiff) ([]PRReviewComment, error)
+	createComment(diff *PRDiff, comment *PRReviewComment) error
+	updateComment(diff *PRDiff, comment *PRReviewComment) error
+	resolveComment(diff *PRDiff, comment *PRReviewComment) error
+	postStatus(diff *PRDiff, status *PRStatus) error
+}
+
+func newPRReviewer(model interface{}) (prReviewer, error) {
+	switch pr := model.(type) {
+	case *PRModel:
+		return &githubPRReviewer{pr: pr}, nil
+	case *GitlabPRModel:
+		return &gitlabPRReviewer{pr: pr}, nil
+	case *AzurePRModel:
+		return &azurePRReviewer{pr: pr}, nil
+	case *BitbucketCloudPRModel:
+		return &bitbucketCloudPRReviewer{pr: pr}, nil
+	case *BitbucketServerPRModel:
+		return &bitbucketServerPRReviewer{pr: pr}, nil
+	case *GiteaPRModel:
+		return &giteaPRReviewer{pr: pr}, nil
+	default:
+		return nil, errors.New("unsupported model type")
+	}
+}
+
+func (r *PRHTTPWrapper) GetPRDiff(model interface{}) (*PRDiff, error) {
+	reviewer, err := newPRReviewer(model)
+	if err != nil {
+		return nil, err
+	}
+	return reviewer.getDiff()
+}
+
+func (r *PRHTTPWrapper) GetPRReviewComments(model interface{}, diff *PRDiff) ([]PRReviewComment, error) {
+	reviewer, err := newPRReviewer(model)
+	if err != nil {
+		return nil, err
+	}
+	return reviewer.getComments(diff)
+}
+
+func (r *PRHTTPWrapper) CreatePRReviewComment(model interface{}, diff *PRDiff, comment *PRReviewComment) error {
+	reviewer, err := newPRReviewer(model)
+	if err != nil {
+		return err
+	}
+	return reviewer.createComment(diff, comment)
+}
+
+func (r *PRHTTPWrapper) UpdatePRReviewComment(model interface{}, diff *PRDiff, comment *PRReviewComment) error {
+	reviewer, err := newPRReviewer(model)
+	if err != nil {
+		return err
+	}
+	return reviewer.updateComment(diff, comment)
+}
+
+func (r *PRHTTPWrapper) ResolvePRReviewComment(model interface{}, diff *PRDiff, comment *PRReviewComment) error {
+	reviewer, err := newPRReviewer(model)
+	if err != nil {
+		return err
+	}
+	return reviewer.resolveComment(diff, comment)
+}
+
+func (r *PRHTTPWrapper) PostPRStatus(model interface{}, diff *PRDiff, status *PRStatus) error {
+	reviewer, err := newPRReviewer(model)
+	if err != nil {
+		return err
+	}
+	return reviewer.postStatus(diff, status)
+}
+
+// sendSCMRequest sends a request to the REST API of an SCM, with body encoded in JSON when not nil, and decodes the
+// JSON response in target when not nil. It returns the raw response with the URL of the next page from the Link
+// header, empty on the last page.
+func sendSCMRequest(method, url, authorization string, body, target interface{}) (content []byte, next string, err error) {
+	var reader io.Reader = http.NoBody
+	if body != nil {
+		jsonBytes, err := json.Marshal(body)
+		if err != nil {
+			return nil, "", err
+		}
+		reader = bytes.NewBuffer(jsonBytes)
+	}
+	req, err := http.NewRequest(method, url, reader)
+	if err != nil {
+		return nil, "", err
+	}
+	req.Header.Set(AuthorizationHeader, authorization)
+	if body != nil {
+		req.Header.Set(contentTypeHeader, jsonContentType)
+	}
+	resp, err := request(GetClient(viper.GetUint(params.ClientTimeoutKey)), req, true)
+	if err != nil {
+		return nil, "", err
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+	content, err = io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, "", err
+	}
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		return nil, "", errors.Errorf("%s %s: Code %d %s", method, url, resp.StatusCode, string(content))
+	}
+	if target != nil {
+		if err = json.Unmarshal(content, target); err != nil {
+			return nil, "", errors.Wrapf(err, "Failed to parse the response of %s", url)
+		}
+	}
+	return content, getNextPageLink(resp), nil
+}
+
+// getSCMLinkPages returns the items of every page of a list, following the Link headers of the responses.
+func getSCMLinkPages[T any](url, authorization string) ([]T, error) {
+	var items []T
+	for url != "" {
+		var page []T
+		var err error
+		_, url, err = sendSCMRequest(http.MethodGet, url, authorization, nil, &page)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, page...)
+	}
+	return items, nil
+}
+
+func commentID(id int64) string {
+	return fmt.Sprint(id)
+}
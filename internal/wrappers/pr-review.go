@@ -0,0 +1,160 @@
+package wrappers
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// PRDiff is what a review needs from a pull request: the commits it compares and the lines it adds, by file path.
+// RepositoryID is only set for Azure DevOps, where the threads of a pull request are under its repository.
+type PRDiff struct {
+	BaseSHA      string
+	StartSHA     string
+	HeadSHA      string
+	RepositoryID string
+	Files        map[string]*PRDiffFile
+}
+
+// PRDiffFile is a file changed by a pull request. AllLines is set when the SCM doesn't expose the changed lines of the
+// file, every line of the file can then be commented.
+type PRDiffFile struct {
+	AddedLines map[int]bool
+	AllLines   bool
+}
+
+// PRReviewComment is an inline comment on a line of a pull request. ThreadID is the discussion or thread holding the
+// comment for the SCMs resolving threads instead of comments, Version is the comment version Bitbucket Server requires
+// to edit it.
+type PRReviewComment struct {
+	ID       string
+	ThreadID string
+	Version  int
+	Path     string
+	Line     int
+	Body     string
+	Resolved bool
+}
+
+// PRStatus is the status of a commit of a pull request. CheckRun publishes a GitHub check run with the annotations
+// instead of a commit status, the other SCMs publish a commit or pull request status without annotations.
+type PRStatus struct {
+	CommitSHA   string
+	Name        string
+	Passed      bool
+	Description string
+	TargetURL   string
+	CheckRun    bool
+	Annotations []PRAnnotation
+}
+
+// PRAnnotation is a finding on a line of a check run.
+type PRAnnotation struct {
+	Path     string
+	Line     int
+	Severity string
+	Title    string
+	Message  string
+}
+
+var hunkHeaderRegex = regexp.MustCompile(`^@@ -\d+(?:,(\d+))? \+(\d+)(?:,(\d+))? @@`)
+
+// HasLine tells if a review comment can be posted on the line of the file.
+func (f *PRDiffFile) HasLine(line int) bool {
+	return f != nil && (f.AllLines || f.AddedLines[line])
+}
+
+// ParseUnifiedDiff returns the files of a unified diff, as returned by git diff, with their added lines. Deleted files
+// are left out.
+func ParseUnifiedDiff(diff string) map[string]*PRDiffFile {
+	files := make(map[string]*PRDiffFile)
+	var file *PRDiffFile
+	var hunk diffHunk
+	for _, line := range strings.Split(diff, "\n") {
+		switch {
+		case hunk.inProgress():
+			hunk.add(line, file)
+		case strings.HasPrefix(line, "diff "):
+			file = nil
+		case strings.HasPrefix(line, "+++ "):
+			file = nil
+			path := strings.TrimSpace(strings.TrimPrefix(line, "+++ "))
+			if path != "/dev/null" {
+				file = &PRDiffFile{AddedLines: make(map[int]bool)}
+				files[diffFilePath(path)] = file
+			}
+		case file != nil:
+			hunk = newDiffHunk(line)
+		}
+	}
+	return files
+}
+
+// diffFilePath removes the prefix of the new file path of a diff, b/ for git and dst:// for Bitbucket Server.
+func diffFilePath(path string) string {
+	for _, prefix := range []string{"b/", "dst://"} {
+		if strings.HasPrefix(path, prefix) {
+			return strings.TrimPrefix(path, prefix)
+		}
+	}
+	return path
+}
+
+// ParsePatchHunks returns the added lines of the hunks of a single file, as returned by the GitHub and GitLab APIs.
+func ParsePatchHunks(patch string) *PRDiffFile {
+	file := &PRDiffFile{AddedLines: make(map[int]bool)}
+	var hunk diffHunk
+	for _, line := range strings.Split(patch, "\n") {
+		if hunk.inProgress() {
+			hunk.add(line, file)
+		} else {
+			hunk = newDiffHunk(line)
+		}
+	}
+	return file
+}
+
+// diffHunk follows the lines of a hunk, newLine is the number in the new file of the next line of the hunk.
+type diffHunk struct {
+	newLine int
+	oldLeft int
+	newLeft int
+}
+
+// newDiffHunk starts the hunk of header, an empty hunk when the line isn't a hunk header.
+func newDiffHunk(header string) diffHunk {
+	matches := hunkHeaderRegex.FindStringSubmatch(header)
+	if matches == nil {
+		return diffHunk{}
+	}
+	hunk := diffHunk{oldLeft: 1, newLeft: 1}
+	hunk.newLine, _ = strconv.Atoi(matches[2])
+	if matches[1] != "" {
+		hunk.oldLeft, _ = strconv.Atoi(matches[1])
+	}
+	if matches[3] != "" {
+		hunk.newLeft, _ = strconv.Atoi(matches[3])
+	}
+	return hunk
+}
+
+func (h *diffHunk) inProgress() bool {
+	return h.oldLeft > 0 || h.newLeft > 0
+}
+
+func (h *diffHunk) add(line string, file *PRDiffFile) {
+	switch {
+	case strings.HasPrefix(line, "+"):
+		file.AddedLines[h.newLine] = true
+		h.newLine++
+		h.newLeft--
+	case strings.HasPrefix(line, "-"):
+		h.oldLeft--
+	case strings.HasPrefix(line, `\`):
+		// No newline at end of file
+	default:
+		h.newLine++
+		h.newLeft--
+		h.oldLeft--
+	}
+}
@@ -0,0 +1,266 @@
+package wrappers
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"gotest.tools/assert"
+)
+
+const prReviewDiff = `diff --git a/main.go b/main.go
+index 1111111..2222222 100644
+--- a/main.go
++++ b/main.go
+@@ -1,3 +1,4 @@
+ package main
+-import "fmt"
++import "os"
++import "fmt"
+ func main() {}
+@@ -10,2 +11,2 @@ func helper() {
+ 	a := 1
+-	b := 2
++	b := os.Args
+diff --git a/old.go b/old.go
+deleted file mode 100644
+--- a/old.go
++++ /dev/null
+@@ -1,2 +0,0 @@
+-package main
+-+++ b/not-a-file.go
+`
+
+func TestParseUnifiedDiff(t *testing.T) {
+	files := ParseUnifiedDiff(prReviewDiff)
+
+	assert.Equal(t, len(files), 1)
+	assert.DeepEqual(t, files["main.go"].AddedLines, map[int]bool{2: true, 3: true, 12: true})
+	assert.Assert(t, files["main.go"].HasLine(3))
+	assert.Assert(t, !files["main.go"].HasLine(4))
+	assert.Assert(t, !files["old.go"].HasLine(1))
+}
+
+func TestParseUnifiedDiffBitbucketServerPaths(t *testing.T) {
+	files := ParseUnifiedDiff("--- src://app.py\n+++ dst://app.py\n@@ -0,0 +1 @@\n+import os\n")
+
+	assert.DeepEqual(t, files["app.py"].AddedLines, map[int]bool{1: true})
+}
+
+func TestParsePatchHunks(t *testing.T) {
+	file := ParsePatchHunks("@@ -5,2 +5,3 @@\n context\n+added\n context\n\\ No newline at end of file")
+
+	assert.DeepEqual(t, file.AddedLines, map[int]bool{6: true})
+}
+
+func TestGithubPRReview(t *testing.T) {
+	var created, updated map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, r.Header.Get(AuthorizationHeader), "token scm-token")
+		body, _ := io.ReadAll(r.Body)
+		switch r.Method + " " + r.URL.Path {
+		case "GET /org/repo/pulls/7":
+			_, _ = w.Write([]byte(`{"head":{"sha":"head-sha"},"base":{"sha":"base-sha"}}`))
+		case "GET /org/repo/pulls/7/files":
+			_, _ = w.Write([]byte(`[{"filename":"main.go","status":"modified","patch":"@@ -1 +1,2 @@\n x\n+y"},` +
+				`{"filename":"old.go","status":"removed","patch":"@@ -1 +0,0 @@\n-x"}]`))
+		case "GET /org/repo/pulls/7/comments":
+			_, _ = w.Write([]byte(`[{"id":11,"path":"main.go","line":2,"body":"previous"},{"id":12,"path":"main.go","line":null,"body":"outdated"}]`))
+		case "POST /org/repo/pulls/7/comments":
+			_ = json.Unmarshal(body, &created)
+			_, _ = w.Write([]byte(`{"id":13}`))
+		case "PATCH /org/repo/pulls/comments/11":
+			_ = json.Unmarshal(body, &updated)
+			_, _ = w.Write([]byte(`{"id":11}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+	wrapper := NewHTTPPRWrapper("", "", "", "", "")
+	model := &PRModel{ScmToken: "scm-token", Namespace: "org", RepoName: "repo", PrNumber: 7, APIURL: server.URL + "/"}
+
+	diff, err := wrapper.GetPRDiff(model)
+	assert.NilError(t, err)
+	assert.Equal(t, diff.HeadSHA, "head-sha")
+	assert.Equal(t, len(diff.Files), 1)
+	assert.Assert(t, diff.Files["main.go"].HasLine(2))
+
+	comments, err := wrapper.GetPRReviewComments(model, diff)
+	assert.NilError(t, err)
+	assert.DeepEqual(t, comments, []PRReviewComment{
+		{ID: "11", Path: "main.go", Line: 2, Body: "previous"},
+		{ID: "12", Path: "main.go", Body: "outdated"},
+	})
+
+	comment := &PRReviewComment{Path: "main.go", Line: 2, Body: "finding"}
+	assert.NilError(t, wrapper.CreatePRReviewComment(model, diff, comment))
+	assert.Equal(t, comment.ID, "13")
+	assert.DeepEqual(t, created, map[string]interface{}{
+		"body": "finding", "commit_id": "head-sha", "path": "main.go", "line": float64(2), "side": "RIGHT",
+	})
+
+	assert.NilError(t, wrapper.ResolvePRReviewComment(model, diff, &PRReviewComment{ID: "11", Body: "resolved"}))
+	assert.DeepEqual(t, updated, map[string]interface{}{"body": "resolved"})
+}
+
+func TestGithubPRReviewError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+		_, _ = w.Write([]byte(`{"message":"Bad credentials"}`))
+	}))
+	defer server.Close()
+	model := &PRModel{Namespace: "org", RepoName: "repo", PrNumber: 7, APIURL: server.URL + "/"}
+
+	_, err := NewHTTPPRWrapper("", "", "", "", "").GetPRDiff(model)
+
+	assert.ErrorContains(t, err, "Code 401")
+}
+
+func TestGithubPRStatus(t *testing.T) {
+	var status map[string]interface{}
+	var checkRuns []githubCheckRun
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		switch r.Method + " " + r.URL.Path {
+		case "POST /org/repo/statuses/sha":
+			_ = json.Unmarshal(body, &status)
+			_, _ = w.Write([]byte(`{}`))
+		case "POST /org/repo/check-runs", "PATCH /org/repo/check-runs/21":
+			var checkRun githubCheckRun
+			_ = json.Unmarshal(body, &checkRun)
+			checkRuns = append(checkRuns, checkRun)
+			_, _ = w.Write([]byte(`{"id":21}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+	wrapper := NewHTTPPRWrapper("", "", "", "", "")
+	model := &PRModel{ScmToken: "scm-token", Namespace: "org", RepoName: "repo", PrNumber: 7, APIURL: server.URL + "/"}
+	prStatus := &PRStatus{CommitSHA: "sha", Name: "Checkmarx One", Description: "Failed: sast-high 1 >= 1", TargetURL: "https://ast"}
+
+	assert.NilError(t, wrapper.PostPRStatus(model, &PRDiff{}, prStatus))
+	assert.DeepEqual(t, status, map[string]interface{}{
+		"state": "failure", "target_url": "https://ast", "description": "Failed: sast-high 1 >= 1", "context": "Checkmarx One",
+	})
+
+	prStatus.Passed, prStatus.CheckRun = true, true
+	for i := 0; i < githubAnnotationsLimit+1; i++ {
+		prStatus.Annotations = append(prStatus.Annotations, PRAnnotation{Path: "main.go", Line: i + 1, Severity: "HIGH"})
+	}
+	assert.NilError(t, wrapper.PostPRStatus(model, &PRDiff{}, prStatus))
+	assert.Equal(t, len(checkRuns), 2)
+	assert.Equal(t, checkRuns[0].HeadSHA, "sha")
+	assert.Equal(t, checkRuns[0].Conclusion, "success")
+	assert.Equal(t, len(checkRuns[0].Output.Annotations), githubAnnotationsLimit)
+	assert.Equal(t, checkRuns[0].Output.Annotations[0].AnnotationLevel, "failure")
+	assert.Equal(t, len(checkRuns[1].Output.Annotations), 1)
+	assert.Equal(t, checkRuns[1].Output.Annotations[0].StartLine, githubAnnotationsLimit+1)
+}
+
+func TestGiteaPRReview(t *testing.T) {
+	var review giteaNewReview
+	var updated, status map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, r.Header.Get(AuthorizationHeader), "token scm-token")
+		body, _ := io.ReadAll(r.Body)
+		switch r.Method + " " + r.URL.Path {
+		case "GET /api/v1/repos/org/repo/pulls/3":
+			_, _ = w.Write([]byte(`{"head":{"sha":"head-sha"},"base":{"sha":"base-sha"}}`))
+		case "GET /api/v1/repos/org/repo/pulls/3.diff":
+			_, _ = w.Write([]byte(prReviewDiff))
+		case "GET /api/v1/repos/org/repo/pulls/3/reviews":
+			_, _ = w.Write([]byte(`[{"id":1,"comments_count":1},{"id":2,"comments_count":0}]`))
+		case "GET /api/v1/repos/org/repo/pulls/3/reviews/1/comments":
+			_, _ = w.Write([]byte(`[{"id":11,"path":"main.go","position":2,"body":"previous","resolver":{"login":"dev"}}]`))
+		case "POST /api/v1/repos/org/repo/pulls/3/reviews":
+			_ = json.Unmarshal(body, &review)
+			_, _ = w.Write([]byte(`{"id":5}`))
+		case "GET /api/v1/repos/org/repo/pulls/3/reviews/5/comments":
+			_, _ = w.Write([]byte(`[{"id":13,"path":"main.go","position":3,"body":"finding"}]`))
+		case "PATCH /api/v1/repos/org/repo/issues/comments/11":
+			_ = json.Unmarshal(body, &updated)
+			_, _ = w.Write([]byte(`{"id":11}`))
+		case "POST /api/v1/repos/org/repo/statuses/head-sha":
+			_ = json.Unmarshal(body, &status)
+			w.WriteHeader(http.StatusCreated)
+			_, _ = w.Write([]byte(`{}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+	wrapper := NewHTTPPRWrapper("", "", "", "", "")
+	model := &GiteaPRModel{ScmToken: "scm-token", Namespace: "org", RepoName: "repo", PrNumber: 3, APIURL: server.URL + "/api/v1/"}
+
+	diff, err := wrapper.GetPRDiff(model)
+	assert.NilError(t, err)
+	assert.Equal(t, diff.HeadSHA, "head-sha")
+	assert.Assert(t, diff.Files["main.go"].HasLine(2))
+
+	comments, err := wrapper.GetPRReviewComments(model, diff)
+	assert.NilError(t, err)
+	assert.DeepEqual(t, comments, []PRReviewComment{{ID: "11", Path: "main.go", Line: 2, Body: "previous", Resolved: true}})
+
+	comment := &PRReviewComment{Path: "main.go", Line: 3, Body: "finding"}
+	assert.NilError(t, wrapper.CreatePRReviewComment(model, diff, comment))
+	assert.Equal(t, comment.ID, "13")
+	assert.DeepEqual(t, review, giteaNewReview{
+		CommitID: "head-sha",
+		Event:    "COMMENT",
+		Comments: []giteaNewReviewComment{{Path: "main.go", Body: "finding", NewPosition: 3}},
+	})
+
+	assert.NilError(t, wrapper.ResolvePRReviewComment(model, diff, &PRReviewComment{ID: "11", Body: "resolved"}))
+	assert.DeepEqual(t, updated, map[string]interface{}{"body": "resolved"})
+
+	assert.NilError(t, wrapper.PostPRStatus(model, diff, &PRStatus{CommitSHA: "head-sha", Name: "Checkmarx One", Passed: true}))
+	assert.Equal(t, status["state"], "success")
+	assert.Equal(t, status["context"], "Checkmarx One")
+}
+
+func TestGiteaPRDecoration(t *testing.T) {
+	var requests []string
+	var posted map[string]interface{}
+	previous := ""
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		requests = append(requests, r.Method+" "+r.URL.Path)
+		switch r.Method + " " + r.URL.Path {
+		case "GET /api/v1/repos/org/repo/issues/3/comments":
+			_, _ = w.Write([]byte(`[{"id":7,"body":"Looks good"}` + previous + `]`))
+		case "POST /api/v1/repos/org/repo/issues/3/comments", "PATCH /api/v1/repos/org/repo/issues/comments/8":
+			_ = json.Unmarshal(body, &posted)
+			w.WriteHeader(http.StatusCreated)
+			_, _ = w.Write([]byte(`{"id":8}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+	wrapper := NewHTTPPRWrapper("", "", "", "", "")
+	model := &GiteaPRModel{Namespace: "org", RepoName: "repo", PrNumber: 3, APIURL: server.URL + "/api/v1/", Comment: "results"}
+
+	message, webErr, err := wrapper.PostPRDecoration(model)
+	assert.NilError(t, err)
+	assert.Assert(t, webErr == nil)
+	assert.Equal(t, message, giteaDecorationPosted)
+	assert.Equal(t, requests[1], "POST /api/v1/repos/org/repo/issues/3/comments")
+	assert.DeepEqual(t, posted, map[string]interface{}{"body": "results"})
+
+	// The decoration of the previous scan is updated
+	previous = `,{"id":8,"body":"old\n\n` + PRDecorationMarker + `"}`
+	requests = nil
+	_, _, err = wrapper.PostPRDecoration(model)
+	assert.NilError(t, err)
+	assert.DeepEqual(t, requests, []string{"GET /api/v1/repos/org/repo/issues/3/comments", "PATCH /api/v1/repos/org/repo/issues/comments/8"})
+}
+
+func TestPRReviewUnsupportedModel(t *testing.T) {
+	_, err := NewHTTPPRWrapper("", "", "", "", "").GetPRDiff("model")
+
+	assert.ErrorContains(t, err, "unsupported model type")
+}
@@ -52,6 +52,31 @@ type BitbucketServerPRModel struct {
 	PRID       int        `json:"prNumber"`
 	Policies   []PrPolicy `json:"violatedPolicyList"`
 }
+
+// GiteaPRModel is decorated by the CLI through the API of the Gitea or Forgejo server, Comment is the decoration
+// posted on the pull request.
+type GiteaPRModel struct {
+	ScanID    string     `json:"scanId"`
+	ScmToken  string     `json:"scmToken"`
+	Namespace string     `json:"namespace"`
+	RepoName  string     `json:"repoName"`
+	PrNumber  int        `json:"prNumber"`
+	Policies  []PrPolicy `json:"violatedPolicyList"`
+	APIURL    string     `json:"apiUrl"`
+	Comment   string     `json:"comment"`
+}
+
+// PRDecorationMarker identifies the decoration comment the CLI posts, to update it on the next scans.
+const PRDecorationMarker = "[//]: # (checkmarx-one-decoration)"
+
 type PRWrapper interface {
 	PostPRDecoration(model interface{}) (string, *WebError, error)
+	// GetPRDiff and the review comment functions call the SCM API of the pull request of model with its SCM token, the
+	// review comment functions take the diff returned by GetPRDiff.
+	GetPRDiff(model interface{}) (*PRDiff, error)
+	GetPRReviewComments(model interface{}, diff *PRDiff) ([]PRReviewComment, error)
+	CreatePRReviewComment(model interface{}, diff *PRDiff, comment *PRReviewComment) error
+	UpdatePRReviewComment(model interface{}, diff *PRDiff, comment *PRReviewComment) error
+	ResolvePRReviewComment(model interface{}, diff *PRDiff, comment *PRReviewComment) error
+	PostPRStatus(model interface{}, diff *PRDiff, status *PRStatus) error
 }
@@ -174,7 +174,7 @@ func checkMandatoryCommentError(body io.ReadCloser, scanType string) error {
 	responseMap := make(map[string]interface{})
 	if err := json.NewDecoder(body).Decode(&responseMap); err != nil {
 		if scanType != params.ScaType {
-			logger.PrintIfVerbose(fmt.Sprintf("failed to read the response, %v", err.Error()))
+			logger.Warnf("Failed to read the response: %v", err)
 		}
 		return nil
 	}
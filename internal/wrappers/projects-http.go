@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"strings"
 	"time"
 
 	"github.com/pkg/errors"
@@ -14,6 +15,11 @@ import (
 	commonParams "github.com/checkmarx/ast-cli/internal/params"
 )
 
+const (
+	projectConfigurationPath = "api/configuration/project"
+	configKeysQueryParam     = "config-keys"
+)
+
 type ProjectsHTTPWrapper struct {
 	path string
 }
@@ -87,7 +93,71 @@ func (p *ProjectsHTTPWrapper) UpdateConfiguration(projectID string, configuratio
 	}
 
 	fn := func() (*http.Response, error) {
-		return SendHTTPRequestWithQueryParams(http.MethodPatch, "api/configuration/project", params, bytes.NewBuffer(jsonBytes), clientTimeout)
+		return SendHTTPRequestWithQueryParams(http.MethodPatch, projectConfigurationPath, params, bytes.NewBuffer(jsonBytes), clientTimeout)
+	}
+	resp, err := retryHTTPRequest(fn, retryAttempts, retryDelay*time.Millisecond)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err == nil {
+			_ = resp.Body.Close()
+		}
+	}()
+	return handleProjectResponseWithNoBody(resp, err, http.StatusNoContent)
+}
+
+// GetConfiguration returns the effective configuration of a project, including the values inherited from the tenant.
+func (p *ProjectsHTTPWrapper) GetConfiguration(projectID string) ([]ProjectConfiguration, *ErrorModel, error) {
+	clientTimeout := viper.GetUint(commonParams.ClientTimeoutKey)
+	params := map[string]string{
+		commonParams.ProjectIDFlag: projectID,
+	}
+
+	fn := func() (*http.Response, error) {
+		return SendHTTPRequestWithQueryParams(http.MethodGet, projectConfigurationPath, params, nil, clientTimeout)
+	}
+	resp, err := retryHTTPRequest(fn, retryAttempts, retryDelay*time.Millisecond)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer func() {
+		if err == nil {
+			_ = resp.Body.Close()
+		}
+	}()
+
+	decoder := json.NewDecoder(resp.Body)
+	switch resp.StatusCode {
+	case http.StatusBadRequest, http.StatusInternalServerError:
+		errorModel := ErrorModel{}
+		err = decoder.Decode(&errorModel)
+		if err != nil {
+			return nil, nil, errors.Wrapf(err, failedToParseErr)
+		}
+		return nil, &errorModel, nil
+	case http.StatusOK:
+		var configuration []ProjectConfiguration
+		err = decoder.Decode(&configuration)
+		if err != nil {
+			return nil, nil, errors.Wrapf(err, "Failed to parse project configuration")
+		}
+		return configuration, nil, nil
+	default:
+		return nil, nil, errors.Errorf("response status code %d", resp.StatusCode)
+	}
+}
+
+// DeleteConfiguration removes the project level values of the given keys, restoring the tenant defaults.
+func (p *ProjectsHTTPWrapper) DeleteConfiguration(projectID string, keys []string) (*ErrorModel, error) {
+	clientTimeout := viper.GetUint(commonParams.ClientTimeoutKey)
+	params := map[string]string{
+		commonParams.ProjectIDFlag: projectID,
+		configKeysQueryParam:       strings.Join(keys, ","),
+	}
+
+	fn := func() (*http.Response, error) {
+		return SendHTTPRequestWithQueryParams(http.MethodDelete, projectConfigurationPath, params, nil, clientTimeout)
 	}
 	resp, err := retryHTTPRequest(fn, retryAttempts, retryDelay*time.Millisecond)
 	if err != nil {
@@ -14,6 +14,7 @@ type Project struct {
 	Groups         []string          `json:"groups,omitempty"`
 	PrivatePackage bool              `json:"privatePackage,omitempty"`
 	ApplicationIds []string          `json:"applicationIds,omitempty"`
+	Criticality    int               `json:"criticality,omitempty"`
 }
 
 type ProjectsCollectionResponseModel struct {
@@ -34,6 +35,7 @@ type ProjectResponseModel struct {
 	Origin         string            `json:"origin,omitempty"`
 	ScmRepoID      string            `json:"scmRepoId,omitempty"`
 	ApplicationIds []string          `json:"applicationIds"`
+	Criticality    int               `json:"criticality"`
 }
 
 type ProjectConfiguration struct {
@@ -56,4 +58,6 @@ type ProjectsWrapper interface {
 	Delete(projectID string) (*ErrorModel, error)
 	Tags() (map[string][]string, *ErrorModel, error)
 	UpdateConfiguration(projectID string, configuration []ProjectConfiguration) (*ErrorModel, error)
+	GetConfiguration(projectID string) ([]ProjectConfiguration, *ErrorModel, error)
+	DeleteConfiguration(projectID string, keys []string) (*ErrorModel, error)
 }
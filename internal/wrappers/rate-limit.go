@@ -1,16 +1,28 @@
 package wrappers
 
 import (
+	"context"
 	"log"
 	"net/http"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
+	"github.com/checkmarx/ast-cli/internal/logger"
+	commonParams "github.com/checkmarx/ast-cli/internal/params"
 	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+	"golang.org/x/time/rate"
 )
 
-const defaultRateLimitWaitSeconds = 60
+const (
+	defaultRateLimitWaitSeconds = 60
+	maxServerRetryAfter         = 5 * time.Minute
+	// rateLimitResetEpochThreshold separates X-RateLimit-Reset values sent as a unix timestamp from
+	// values sent as a number of seconds to wait.
+	rateLimitResetEpochThreshold = 1000000000
+)
 
 // SCMRateLimitConfig holds rate limit configuration for different SCM providers
 type SCMRateLimitConfig struct {
@@ -163,3 +175,125 @@ func isRateLimitStatusCode(statusCode int, config *SCMRateLimitConfig) bool {
 func resetAuthorizationHeader(req *http.Request) {
 	req.Header.Del("Authorization")
 }
+
+// APIRateLimiter throttles the API calls made through request(). It is shared by every wrapper and
+// combines the configured requests-per-second budget with the pauses each host asks for through
+// Retry-After and X-RateLimit-* headers, so a throttled SCM token doesn't stall the Checkmarx One calls.
+type APIRateLimiter struct {
+	mutex             sync.Mutex
+	limiter           *rate.Limiter
+	requestsPerSecond float64
+	pausedUntil       map[string]time.Time
+}
+
+var apiRateLimiter = &APIRateLimiter{}
+
+// Wait blocks until the pause requested by host is over and the requests-per-second budget allows
+// another call.
+func (l *APIRateLimiter) Wait(ctx context.Context, host string) error {
+	l.mutex.Lock()
+	l.configure(viper.GetFloat64(commonParams.RequestsPerSecondKey))
+	limiter := l.limiter
+	pause := time.Until(l.pausedUntil[host])
+	l.mutex.Unlock()
+
+	if pause > 0 {
+		logger.PrintIfVerbose(host + " API rate limit reached, waiting " + pause.Round(time.Second).String())
+		timer := time.NewTimer(pause)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+	if limiter == nil {
+		return nil
+	}
+	return limiter.Wait(ctx)
+}
+
+// Observe pauses the following calls to host when its response reports an exhausted rate limit budget.
+func (l *APIRateLimiter) Observe(host string, resp *http.Response) {
+	if resp == nil {
+		return
+	}
+	var wait time.Duration
+	if isRateLimitedResponse(resp) {
+		wait = ServerRetryAfter(resp.Header, 0)
+	} else if strings.TrimSpace(getHeaderValue(resp.Header, "X-RateLimit-Remaining")) == "0" {
+		wait = rateLimitReset(resp.Header)
+	}
+	if wait <= 0 {
+		return
+	}
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	if l.pausedUntil == nil {
+		l.pausedUntil = make(map[string]time.Time)
+	}
+	if until := time.Now().Add(wait); until.After(l.pausedUntil[host]) {
+		l.pausedUntil[host] = until
+	}
+}
+
+func (l *APIRateLimiter) configure(requestsPerSecond float64) {
+	if requestsPerSecond == l.requestsPerSecond {
+		return
+	}
+	l.requestsPerSecond = requestsPerSecond
+	if requestsPerSecond <= 0 {
+		l.limiter = nil
+		return
+	}
+	burst := int(requestsPerSecond)
+	if burst < 1 {
+		burst = 1
+	}
+	l.limiter = rate.NewLimiter(rate.Limit(requestsPerSecond), burst)
+}
+
+// isRateLimitedResponse reports whether the server refused a call until the client slows down
+func isRateLimitedResponse(resp *http.Response) bool {
+	return resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode == http.StatusServiceUnavailable
+}
+
+// ServerRetryAfter returns how long the server asked the client to wait, from the Retry-After header
+// (delay in seconds or HTTP date) or the X-RateLimit-Reset/RateLimit-Reset headers. fallback is
+// returned when no header is present. The wait is capped at maxServerRetryAfter.
+func ServerRetryAfter(headers http.Header, fallback time.Duration) time.Duration {
+	wait := fallback
+	if retryAfter := strings.TrimSpace(headers.Get("Retry-After")); retryAfter != "" {
+		if seconds, err := strconv.Atoi(retryAfter); err == nil {
+			wait = time.Duration(seconds) * time.Second
+		} else if date, err := http.ParseTime(retryAfter); err == nil {
+			wait = time.Until(date)
+		}
+	} else if reset := rateLimitReset(headers); reset > 0 {
+		wait = reset
+	}
+	if wait < 0 {
+		return 0
+	}
+	if wait > maxServerRetryAfter {
+		return maxServerRetryAfter
+	}
+	return wait
+}
+
+// rateLimitReset reads X-RateLimit-Reset or RateLimit-Reset, which servers send either as a unix
+// timestamp or as the number of seconds until the budget resets.
+func rateLimitReset(headers http.Header) time.Duration {
+	reset := getHeaderValue(headers, "X-RateLimit-Reset")
+	if reset == "" {
+		reset = getHeaderValue(headers, "RateLimit-Reset")
+	}
+	value, err := strconv.ParseInt(strings.TrimSpace(reset), 10, 64)
+	if err != nil || value <= 0 {
+		return 0
+	}
+	if value >= rateLimitResetEpochThreshold {
+		return time.Until(time.Unix(value, 0))
+	}
+	return time.Duration(value) * time.Second
+}
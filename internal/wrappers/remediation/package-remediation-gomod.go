@@ -0,0 +1,56 @@
+package remediation
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/checkmarx/ast-cli/internal/logger"
+)
+
+// PackageContentGoMod remediates a go.mod. PackageIdentifier is the module path.
+type PackageContentGoMod PackageContentJSON
+
+var (
+	goModLineRegex       = regexp.MustCompile(`(?m)^.*$`)
+	goModBlockStartRegex = regexp.MustCompile(`^\s*(\w+)\s*\(\s*(?://.*)?$`)
+	goModBlockEndRegex   = regexp.MustCompile(`^\s*\)`)
+)
+
+// Parser replaces the version of the module in the require directives, the replace and exclude directives are left
+// as they are.
+func (r PackageContentGoMod) Parser() (string, error) {
+	version := r.PackageVersion
+	if !strings.HasPrefix(version, "v") {
+		version = "v" + version
+	}
+	var edits []edit
+	block := ""
+	for _, line := range goModLineRegex.FindAllStringIndex(r.FileContent, -1) {
+		text := r.FileContent[line[0]:line[1]]
+		if match := goModBlockStartRegex.FindStringSubmatch(text); match != nil {
+			block = match[1]
+			continue
+		}
+		if block != "" && goModBlockEndRegex.MatchString(text) {
+			block = ""
+			continue
+		}
+		fields := strings.Fields(text)
+		if block == "" && len(fields) > 0 && fields[0] == "require" {
+			fields = fields[1:]
+		} else if block != "require" {
+			continue
+		}
+		if len(fields) < 2 || fields[0] != r.PackageIdentifier {
+			continue
+		}
+		logger.PrintIfVerbose("Found package " + r.PackageIdentifier + " with version " + fields[1] + ", replacing it with " + version + ".")
+		start := line[0] + strings.Index(text, fields[0]) + len(fields[0])
+		start += strings.Index(r.FileContent[start:line[1]], fields[1])
+		edits = append(edits, edit{start: start, end: start + len(fields[1]), text: version})
+	}
+	if len(edits) == 0 {
+		return "", packageNotFound(r.PackageIdentifier)
+	}
+	return applyEdits(r.FileContent, edits), nil
+}
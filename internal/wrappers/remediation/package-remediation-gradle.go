@@ -0,0 +1,59 @@
+package remediation
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/checkmarx/ast-cli/internal/logger"
+	"github.com/pkg/errors"
+)
+
+// PackageContentGradle remediates a build.gradle, a build.gradle.kts or a version catalog. PackageIdentifier is
+// group:artifact.
+type PackageContentGradle PackageContentJSON
+
+// Parser replaces the version of the matching dependencies declared as "group:artifact:version", with group, name and
+// version arguments or as a version catalog module. Versions set through a variable or a catalog version.ref are
+// replaced where the variable or the catalog version is defined.
+func (r PackageContentGradle) Parser() (string, error) {
+	group, artifact := splitMavenIdentifier(r.PackageIdentifier)
+	quotedGroup := regexp.QuoteMeta(group)
+	quotedArtifact := regexp.QuoteMeta(artifact)
+	notations := []*regexp.Regexp{
+		regexp.MustCompile(`['"]` + quotedGroup + `:` + quotedArtifact + `:(?P<version>[^'"@:]+)`),
+		regexp.MustCompile(`group\s*[:=]\s*['"]` + quotedGroup + `['"]\s*,\s*name\s*[:=]\s*['"]` + quotedArtifact +
+			`['"]\s*,\s*version(?P<ref>\.ref)?\s*[:=]\s*['"](?P<version>[^'"]+)['"]`),
+		regexp.MustCompile(`module\s*=\s*"` + quotedGroup + `:` + quotedArtifact +
+			`"\s*,\s*version(?P<ref>\.ref)?\s*=\s*"(?P<version>[^"]+)"`),
+	}
+	var edits []edit
+	for _, notation := range notations {
+		versionIndex := notation.SubexpIndex("version")
+		refIndex := notation.SubexpIndex("ref")
+		for _, match := range notation.FindAllStringSubmatchIndex(r.FileContent, -1) {
+			start, end := match[2*versionIndex], match[2*versionIndex+1]
+			current := r.FileContent[start:end]
+			logger.PrintIfVerbose("Found package " + r.PackageIdentifier + " with version " + current + ", replacing it with " + r.PackageVersion + ".")
+			var definition *regexp.Regexp
+			switch {
+			case refIndex >= 0 && match[2*refIndex] >= 0:
+				definition = regexp.MustCompile(`(?m)^\s*` + regexp.QuoteMeta(current) + `\s*=\s*"([^"]+)"`)
+			case strings.HasPrefix(current, "$"):
+				current = strings.Trim(current, "${}")
+				definition = regexp.MustCompile(`\b` + regexp.QuoteMeta(current) + `\s*=\s*['"]([^'"$]+)['"]`)
+			default:
+				edits = append(edits, edit{start: start, end: end, text: r.PackageVersion})
+				continue
+			}
+			value := definition.FindStringSubmatchIndex(r.FileContent)
+			if value == nil {
+				return "", errors.Errorf("Version %s of package %s not found", current, r.PackageIdentifier)
+			}
+			edits = append(edits, edit{start: value[2], end: value[3], text: r.PackageVersion})
+		}
+	}
+	if len(edits) == 0 {
+		return "", packageNotFound(r.PackageIdentifier)
+	}
+	return applyEdits(r.FileContent, edits), nil
+}
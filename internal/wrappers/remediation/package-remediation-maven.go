@@ -0,0 +1,87 @@
+package remediation
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/checkmarx/ast-cli/internal/logger"
+	"github.com/pkg/errors"
+)
+
+// PackageContentMaven remediates a pom.xml. PackageIdentifier is groupId:artifactId, or only the artifactId.
+type PackageContentMaven PackageContentJSON
+
+var (
+	mavenDependencyRegex  = regexp.MustCompile(`(?s)<dependency>(.*?)</dependency>`)
+	mavenPropertiesRegex  = regexp.MustCompile(`(?s)<properties>(.*?)</properties>`)
+	mavenPropertyRefRegex = regexp.MustCompile(`^\$\{([^}]+)}$`)
+	mavenGroupIDRegex     = xmlElementRegex("groupId")
+	mavenArtifactIDRegex  = xmlElementRegex("artifactId")
+	mavenVersionRegex     = xmlElementRegex("version")
+)
+
+// Parser replaces the version of the matching dependencies. When the version is a ${property}, the property is
+// replaced instead so every dependency sharing it stays aligned.
+func (r PackageContentMaven) Parser() (string, error) {
+	groupID, artifactID := splitMavenIdentifier(r.PackageIdentifier)
+	var edits []edit
+	for _, match := range mavenDependencyRegex.FindAllStringSubmatchIndex(r.FileContent, -1) {
+		blockStart := match[2]
+		block := r.FileContent[blockStart:match[3]]
+		if elementValue(mavenArtifactIDRegex, block) != artifactID ||
+			(groupID != "" && elementValue(mavenGroupIDRegex, block) != groupID) {
+			continue
+		}
+		version := mavenVersionRegex.FindStringSubmatchIndex(block)
+		if version == nil {
+			logger.PrintIfVerbose("Found package " + r.PackageIdentifier + " without version, it is managed by a parent or BOM.")
+			continue
+		}
+		current := block[version[2]:version[3]]
+		logger.PrintIfVerbose("Found package " + r.PackageIdentifier + " with version " + current + ", replacing it with " + r.PackageVersion + ".")
+		if property := mavenPropertyRefRegex.FindStringSubmatch(current); property != nil {
+			propertyEdit, found := mavenPropertyEdit(r.FileContent, property[1], r.PackageVersion)
+			if !found {
+				return "", errors.Errorf("Property %s of package %s not found", property[1], r.PackageIdentifier)
+			}
+			edits = append(edits, propertyEdit)
+			continue
+		}
+		edits = append(edits, edit{start: blockStart + version[2], end: blockStart + version[3], text: r.PackageVersion})
+	}
+	if len(edits) == 0 {
+		return "", packageNotFound(r.PackageIdentifier)
+	}
+	return applyEdits(r.FileContent, edits), nil
+}
+
+func splitMavenIdentifier(packageIdentifier string) (groupID, artifactID string) {
+	if index := strings.LastIndex(packageIdentifier, ":"); index >= 0 {
+		return packageIdentifier[:index], packageIdentifier[index+1:]
+	}
+	return "", packageIdentifier
+}
+
+func mavenPropertyEdit(content, property, version string) (edit, bool) {
+	properties := mavenPropertiesRegex.FindStringSubmatchIndex(content)
+	if properties == nil {
+		return edit{}, false
+	}
+	value := xmlElementRegex(regexp.QuoteMeta(property)).FindStringSubmatchIndex(content[properties[2]:properties[3]])
+	if value == nil {
+		return edit{}, false
+	}
+	return edit{start: properties[2] + value[2], end: properties[2] + value[3], text: version}, true
+}
+
+// xmlElementRegex matches an XML element named name and captures its value without the surrounding whitespace.
+func xmlElementRegex(name string) *regexp.Regexp {
+	return regexp.MustCompile(`<` + name + `>\s*([^<]*?)\s*</` + name + `>`)
+}
+
+func elementValue(element *regexp.Regexp, content string) string {
+	if match := element.FindStringSubmatch(content); match != nil {
+		return match[1]
+	}
+	return ""
+}
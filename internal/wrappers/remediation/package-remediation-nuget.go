@@ -0,0 +1,63 @@
+package remediation
+
+import (
+	"regexp"
+
+	"github.com/checkmarx/ast-cli/internal/logger"
+	"github.com/pkg/errors"
+)
+
+// PackageContentNuget remediates a .csproj, .fsproj or .vbproj project or a Directory.Packages.props.
+type PackageContentNuget PackageContentJSON
+
+var (
+	nugetReferenceRegex    = regexp.MustCompile(`(?is)<(PackageReference|PackageVersion)\b([^>]*?)(/?)>`)
+	nugetVersionAttrRegex  = regexp.MustCompile(`(?i)\b(?:Version|VersionOverride)\s*=\s*"([^"]*)"`)
+	nugetVersionChildRegex = regexp.MustCompile(`(?is)^(.*?)<Version>\s*([^<]*?)\s*</Version>`)
+	nugetPropertyRefRegex  = regexp.MustCompile(`^\$\(([^)]+)\)$`)
+)
+
+// Parser replaces the version of the matching PackageReference and PackageVersion items, set as an attribute or as a
+// Version element. Versions set through a $(Property) are replaced where the property is defined.
+func (r PackageContentNuget) Parser() (string, error) {
+	// NuGet package IDs are case insensitive
+	include := regexp.MustCompile(`(?i)\b(?:Include|Update)\s*=\s*"\s*` + regexp.QuoteMeta(r.PackageIdentifier) + `\s*"`)
+	var edits []edit
+	for _, match := range nugetReferenceRegex.FindAllStringSubmatchIndex(r.FileContent, -1) {
+		attributes := r.FileContent[match[4]:match[5]]
+		if !include.MatchString(attributes) {
+			continue
+		}
+		start, end := -1, -1
+		if version := nugetVersionAttrRegex.FindStringSubmatchIndex(attributes); version != nil {
+			start, end = match[4]+version[2], match[4]+version[3]
+		} else if match[7] == match[6] {
+			// The item isn't self-closing, its version may be a child element
+			item := r.FileContent[match[1]:]
+			closing := regexp.MustCompile(`(?i)</` + r.FileContent[match[2]:match[3]] + `\s*>`).FindStringIndex(item)
+			if closing != nil {
+				if version := nugetVersionChildRegex.FindStringSubmatchIndex(item[:closing[0]]); version != nil {
+					start, end = match[1]+version[4], match[1]+version[5]
+				}
+			}
+		}
+		if start < 0 {
+			logger.PrintIfVerbose("Found package " + r.PackageIdentifier + " without version, it is managed centrally.")
+			continue
+		}
+		current := r.FileContent[start:end]
+		logger.PrintIfVerbose("Found package " + r.PackageIdentifier + " with version " + current + ", replacing it with " + r.PackageVersion + ".")
+		if property := nugetPropertyRefRegex.FindStringSubmatch(current); property != nil {
+			value := xmlElementRegex(regexp.QuoteMeta(property[1])).FindStringSubmatchIndex(r.FileContent)
+			if value == nil {
+				return "", errors.Errorf("Property %s of package %s not found", property[1], r.PackageIdentifier)
+			}
+			start, end = value[2], value[3]
+		}
+		edits = append(edits, edit{start: start, end: end, text: r.PackageVersion})
+	}
+	if len(edits) == 0 {
+		return "", packageNotFound(r.PackageIdentifier)
+	}
+	return applyEdits(r.FileContent, edits), nil
+}
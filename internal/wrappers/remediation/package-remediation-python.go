@@ -0,0 +1,173 @@
+package remediation
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/checkmarx/ast-cli/internal/logger"
+)
+
+// PackageContentRequirements remediates a pip requirements file.
+type PackageContentRequirements PackageContentJSON
+
+// PackageContentPyproject remediates the PEP 621 and Poetry dependencies of a pyproject.toml.
+type PackageContentPyproject PackageContentJSON
+
+const pythonVersionSpecifier = `[<>=!~]`
+
+var (
+	pythonNameSeparatorRegex = regexp.MustCompile(`[-_.]+`)
+	tomlTableRegex           = regexp.MustCompile(`(?m)^[ \t]*\[\[?([^\]\r\n]+)\]\]?[ \t]*(?:#[^\r\n]*)?\r?$`)
+	tomlDependencyKeyRegex   = regexp.MustCompile(`(?m)^[ \t]*["']?(dependencies|optional-dependencies|requires)["']?[ \t]*=[ \t]*`)
+	poetryDependenciesRegex  = regexp.MustCompile(`^tool\.poetry\.(?:dependencies|dev-dependencies|group\.[^.]+\.dependencies)$`)
+)
+
+// Parser pins the matching requirement lines to the version, keeping their extras, environment markers and comments.
+func (r PackageContentRequirements) Parser() (string, error) {
+	requirement := regexp.MustCompile(`(?im)^([ \t]*` + pythonNameRegex(r.PackageIdentifier) + `[ \t]*(?:\[[^\]\r\n]*\])?[ \t]*)(` +
+		pythonVersionSpecifier + `[^;#\r\n]*?)?([ \t]*(?:[;#][^\r\n]*)?\r?)$`)
+	wholeFile := []textRange{{0, len(r.FileContent)}}
+	return replacePythonRequirements(PackageContentJSON(r), []pythonRequirement{{requirement, "==", wholeFile}})
+}
+
+// Parser pins the matching requirements of the [project] dependencies, its optional dependencies and the
+// [build-system] requires, and sets the version of the matching Poetry dependencies.
+func (r PackageContentPyproject) Parser() (string, error) {
+	name := pythonNameRegex(r.PackageIdentifier)
+	requirement := regexp.MustCompile(`(?i)([\[,]\s*["']` + name + `\s*(?:\[[^\]"']*\])?\s*)(` +
+		pythonVersionSpecifier + `[^;"']*?)?(\s*(?:;[^"']*)?["'])`)
+	poetryDependency := regexp.MustCompile(`(?im)^([ \t]*["']?` + name +
+		`["']?[ \t]*=[ \t]*(?:\{[^}\r\n]*\bversion[ \t]*=[ \t]*)?["'])([^"'\r\n]*)(["'])`)
+	requirementRanges, poetryRanges := pyprojectDependencyRanges(r.FileContent)
+	return replacePythonRequirements(PackageContentJSON(r), []pythonRequirement{
+		{requirement, "==", requirementRanges},
+		{poetryDependency, "", poetryRanges},
+	})
+}
+
+// pythonRequirement matches a dependency declaration whose second group is its version, replaced by the version
+// prefixed with operator. Only the matches within ranges of the file are replaced.
+type pythonRequirement struct {
+	regex    *regexp.Regexp
+	operator string
+	ranges   []textRange
+}
+
+type textRange struct {
+	start, end int
+}
+
+// replacePythonRequirements replaces the version of every match of requirements. An empty version group, ex: an
+// unpinned requirement, gets the version inserted.
+func replacePythonRequirements(r PackageContentJSON, requirements []pythonRequirement) (string, error) {
+	var edits []edit
+	for _, requirement := range requirements {
+		for _, match := range findAllInRanges(requirement.regex, r.FileContent, requirement.ranges) {
+			start, end := match[4], match[5]
+			current := ""
+			if start < 0 {
+				start, end = match[3], match[3]
+			} else {
+				current = r.FileContent[start:end]
+			}
+			logger.PrintIfVerbose("Found package " + r.PackageIdentifier + " with version " + current + ", replacing it with " + r.PackageVersion + ".")
+			edits = append(edits, edit{start: start, end: end, text: requirement.operator + r.PackageVersion})
+		}
+	}
+	if len(edits) == 0 {
+		return "", packageNotFound(r.PackageIdentifier)
+	}
+	return applyEdits(r.FileContent, edits), nil
+}
+
+// findAllInRanges returns the submatch indexes, relative to content, of the matches of regex within each range.
+func findAllInRanges(regex *regexp.Regexp, content string, ranges []textRange) [][]int {
+	var matches [][]int
+	for _, textRange := range ranges {
+		for _, match := range regex.FindAllStringSubmatchIndex(content[textRange.start:textRange.end], -1) {
+			for i := range match {
+				if match[i] >= 0 {
+					match[i] += textRange.start
+				}
+			}
+			matches = append(matches, match)
+		}
+	}
+	return matches
+}
+
+// pyprojectDependencyRanges returns the ranges of a pyproject.toml holding PEP 508 requirements, the values of the
+// [project] dependencies and optional-dependencies and of the [build-system] requires, and the bodies of the Poetry
+// dependency tables.
+func pyprojectDependencyRanges(content string) (requirements, poetry []textRange) {
+	tables := tomlTableRegex.FindAllStringSubmatchIndex(content, -1)
+	for i, table := range tables {
+		body := textRange{table[1], len(content)}
+		if i+1 < len(tables) {
+			body.end = tables[i+1][0]
+		}
+		name := strings.NewReplacer(" ", "", "\t", "", `"`, "", "'", "").Replace(content[table[2]:table[3]])
+		switch {
+		case name == "project" || name == "build-system":
+			for _, key := range tomlDependencyKeyRegex.FindAllStringSubmatchIndex(content[body.start:body.end], -1) {
+				keyName := content[body.start+key[2] : body.start+key[3]]
+				if (name == "project") == (keyName == "requires") {
+					continue
+				}
+				valueStart := body.start + key[1]
+				requirements = append(requirements, textRange{valueStart, tomlValueEnd(content, valueStart)})
+			}
+		case name == "project.optional-dependencies":
+			requirements = append(requirements, body)
+		case poetryDependenciesRegex.MatchString(name):
+			poetry = append(poetry, body)
+		}
+	}
+	return requirements, poetry
+}
+
+// tomlValueEnd returns the end of the TOML value starting at start, the closing bracket of an array or inline table,
+// which may span several lines, or the end of the line of any other value.
+func tomlValueEnd(content string, start int) int {
+	depth := 0
+	var quote byte
+	for i := start; i < len(content); i++ {
+		c := content[i]
+		switch {
+		case quote != 0:
+			if c == '\\' && quote == '"' {
+				i++
+			} else if c == quote {
+				quote = 0
+			}
+		case c == '"' || c == '\'':
+			quote = c
+		case c == '#':
+			for i < len(content) && content[i] != '\n' {
+				i++
+			}
+			if depth == 0 {
+				return i
+			}
+		case c == '[' || c == '{':
+			depth++
+		case c == ']' || c == '}':
+			depth--
+			if depth == 0 {
+				return i + 1
+			}
+		case c == '\n' && depth == 0:
+			return i
+		}
+	}
+	return len(content)
+}
+
+// pythonNameRegex matches the package name as pip compares them, case insensitive and with -, _ and . being equivalent.
+func pythonNameRegex(packageName string) string {
+	parts := pythonNameSeparatorRegex.Split(strings.TrimSpace(packageName), -1)
+	for i, part := range parts {
+		parts[i] = regexp.QuoteMeta(part)
+	}
+	return strings.Join(parts, `[-_.]+`)
+}
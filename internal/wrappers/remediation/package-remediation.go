@@ -1,5 +1,14 @@
 package remediation
 
+import (
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
 type Package interface {
 	Parser() (string, error)
 }
@@ -9,3 +18,83 @@ type PackageContentJSON struct {
 	PackageIdentifier string
 	PackageVersion    string
 }
+
+const (
+	npmPackageFile       = "package.json"
+	mavenPackageFile     = "pom.xml"
+	goModPackageFile     = "go.mod"
+	pyprojectPackageFile = "pyproject.toml"
+	nugetCentralFile     = "Directory.Packages.props"
+)
+
+var requirementsFileRegex = regexp.MustCompile(`(?i)^requirements.*\.txt$`)
+
+// NewPackage returns the parser of the package manager file filename, selected from its name. The package managers
+// other than npm edit the file in place, so its formatting and comments are kept. It returns nil for unsupported files.
+func NewPackage(filename, fileContent, packageIdentifier, packageVersion string) Package {
+	content := PackageContentJSON{
+		FileContent:       fileContent,
+		PackageIdentifier: packageIdentifier,
+		PackageVersion:    packageVersion,
+	}
+	base := filepath.Base(filename)
+	switch {
+	case base == npmPackageFile:
+		return content
+	case base == mavenPackageFile:
+		return PackageContentMaven(content)
+	case base == "build.gradle" || base == "build.gradle.kts" || strings.HasSuffix(base, ".versions.toml"):
+		return PackageContentGradle(content)
+	case requirementsFileRegex.MatchString(base):
+		return PackageContentRequirements(content)
+	case base == pyprojectPackageFile:
+		return PackageContentPyproject(content)
+	case base == goModPackageFile:
+		return PackageContentGoMod(content)
+	case base == nugetCentralFile || isNugetProjectFile(base):
+		return PackageContentNuget(content)
+	default:
+		return nil
+	}
+}
+
+// IsPackageFileSupported reports whether NewPackage has a parser for filename.
+func IsPackageFileSupported(filename string) bool {
+	return NewPackage(filename, "", "", "") != nil
+}
+
+func isNugetProjectFile(filename string) bool {
+	switch filepath.Ext(filename) {
+	case ".csproj", ".fsproj", ".vbproj":
+		return true
+	default:
+		return false
+	}
+}
+
+// edit replaces the bytes between start and end of a file with text.
+type edit struct {
+	start int
+	end   int
+	text  string
+}
+
+// applyEdits applies edits to content from the end of the file, leaving everything else as it was. Edits of the same
+// bytes, ex: a version property shared by several dependencies, are applied once, and an edit overlapping one already
+// applied is skipped, since its end no longer points to the original content.
+func applyEdits(content string, edits []edit) string {
+	sort.Slice(edits, func(i, j int) bool { return edits[i].start > edits[j].start })
+	appliedStart := len(content)
+	for i, e := range edits {
+		if i > 0 && (e.start == appliedStart || e.end > appliedStart) {
+			continue
+		}
+		content = content[:e.start] + e.text + content[e.end:]
+		appliedStart = e.start
+	}
+	return content
+}
+
+func packageNotFound(packageIdentifier string) error {
+	return errors.Errorf("Package %s not found", packageIdentifier)
+}
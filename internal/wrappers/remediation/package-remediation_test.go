@@ -0,0 +1,344 @@
+package remediation
+
+import (
+	"testing"
+
+	"gotest.tools/assert"
+)
+
+func TestNewPackage(t *testing.T) {
+	tests := []struct {
+		filename string
+		expected Package
+	}{
+		{"app/package.json", PackageContentJSON{}},
+		{"app/pom.xml", PackageContentMaven{}},
+		{"build.gradle", PackageContentGradle{}},
+		{"build.gradle.kts", PackageContentGradle{}},
+		{"gradle/libs.versions.toml", PackageContentGradle{}},
+		{"requirements.txt", PackageContentRequirements{}},
+		{"requirements-dev.txt", PackageContentRequirements{}},
+		{"pyproject.toml", PackageContentPyproject{}},
+		{"go.mod", PackageContentGoMod{}},
+		{"src/App.csproj", PackageContentNuget{}},
+		{"Directory.Packages.props", PackageContentNuget{}},
+		{"package.jso", nil},
+		{"go.sum", nil},
+	}
+	for _, test := range tests {
+		t.Run(test.filename, func(t *testing.T) {
+			assert.DeepEqual(t, NewPackage(test.filename, "", "", ""), test.expected)
+			assert.Equal(t, IsPackageFileSupported(test.filename), test.expected != nil)
+		})
+	}
+}
+
+func TestPackageParsers(t *testing.T) {
+	tests := []struct {
+		name       string
+		filename   string
+		identifier string
+		content    string
+		expected   string
+	}{
+		{
+			name:       "maven version",
+			filename:   "pom.xml",
+			identifier: "org.yaml:snakeyaml",
+			content: `<project>
+  <dependencies>
+    <!-- parsing -->
+    <dependency>
+      <groupId>org.yaml</groupId>
+      <artifactId>snakeyaml</artifactId>
+      <version> 1.30 </version>
+    </dependency>
+    <dependency>
+      <groupId>org.other</groupId>
+      <artifactId>snakeyaml</artifactId>
+      <version>1.30</version>
+    </dependency>
+  </dependencies>
+</project>`,
+			expected: `<project>
+  <dependencies>
+    <!-- parsing -->
+    <dependency>
+      <groupId>org.yaml</groupId>
+      <artifactId>snakeyaml</artifactId>
+      <version> 2.0 </version>
+    </dependency>
+    <dependency>
+      <groupId>org.other</groupId>
+      <artifactId>snakeyaml</artifactId>
+      <version>1.30</version>
+    </dependency>
+  </dependencies>
+</project>`,
+		},
+		{
+			name:       "maven property",
+			filename:   "pom.xml",
+			identifier: "com.fasterxml.jackson.core:jackson-databind",
+			content: `<project>
+  <properties>
+    <jackson.version>2.13.0</jackson.version>
+  </properties>
+  <dependency>
+    <groupId>com.fasterxml.jackson.core</groupId>
+    <artifactId>jackson-databind</artifactId>
+    <version>${jackson.version}</version>
+  </dependency>
+</project>`,
+			expected: `<project>
+  <properties>
+    <jackson.version>2.0</jackson.version>
+  </properties>
+  <dependency>
+    <groupId>com.fasterxml.jackson.core</groupId>
+    <artifactId>jackson-databind</artifactId>
+    <version>${jackson.version}</version>
+  </dependency>
+</project>`,
+		},
+		{
+			name:       "gradle string and map notations",
+			filename:   "build.gradle",
+			identifier: "org.yaml:snakeyaml",
+			content: `dependencies {
+    // parsing
+    implementation 'org.yaml:snakeyaml:1.30'
+    testImplementation group: 'org.yaml', name: 'snakeyaml', version: '1.29'
+}`,
+			expected: `dependencies {
+    // parsing
+    implementation 'org.yaml:snakeyaml:2.0'
+    testImplementation group: 'org.yaml', name: 'snakeyaml', version: '2.0'
+}`,
+		},
+		{
+			name:       "gradle variable",
+			filename:   "build.gradle.kts",
+			identifier: "org.yaml:snakeyaml",
+			content: `val snakeyamlVersion = "1.30"
+dependencies {
+    implementation("org.yaml:snakeyaml:$snakeyamlVersion")
+}`,
+			expected: `val snakeyamlVersion = "2.0"
+dependencies {
+    implementation("org.yaml:snakeyaml:$snakeyamlVersion")
+}`,
+		},
+		{
+			name:       "gradle version catalog",
+			filename:   "libs.versions.toml",
+			identifier: "org.yaml:snakeyaml",
+			content: `[versions]
+snakeyaml = "1.30"
+
+[libraries]
+snakeyaml = { module = "org.yaml:snakeyaml", version.ref = "snakeyaml" }`,
+			expected: `[versions]
+snakeyaml = "2.0"
+
+[libraries]
+snakeyaml = { module = "org.yaml:snakeyaml", version.ref = "snakeyaml" }`,
+		},
+		{
+			name:       "requirements",
+			filename:   "requirements.txt",
+			identifier: "PyYAML",
+			content: `# parsing
+pyyaml[libyaml]>=5.1,<6 ; python_version >= "3.6"  # yaml
+pyyaml-include==1.3
+requests
+`,
+			expected: `# parsing
+pyyaml[libyaml]==2.0 ; python_version >= "3.6"  # yaml
+pyyaml-include==1.3
+requests
+`,
+		},
+		{
+			name:       "requirements unpinned",
+			filename:   "requirements.txt",
+			identifier: "requests",
+			content:    "flask==2.0\r\nrequests\r\n",
+			expected:   "flask==2.0\r\nrequests==2.0\r\n",
+		},
+		{
+			name:       "pyproject",
+			filename:   "pyproject.toml",
+			identifier: "requests",
+			content: `[project]
+name = "app"
+dependencies = [
+    "requests>=2.0",  # http
+    "flask",
+]
+
+[tool.poetry.dependencies]
+requests = { version = "^2.0", extras = ["socks"] }`,
+			expected: `[project]
+name = "app"
+dependencies = [
+    "requests==2.0",  # http
+    "flask",
+]
+
+[tool.poetry.dependencies]
+requests = { version = "2.0", extras = ["socks"] }`,
+		},
+		{
+			name:       "pyproject dependency arrays only",
+			filename:   "pyproject.toml",
+			identifier: "requests",
+			content: `[build-system]
+requires = ["setuptools", "requests"]
+
+[project]
+name = "requests"
+keywords = ["requests", "http"]
+optional-dependencies = { http = ["requests[socks]>=2.0"] }
+
+[project.optional-dependencies]
+test = [
+    "requests<3",  # mocked ]
+]
+
+[tool.mypy]
+modules = ["requests"]
+
+[tool.poetry]
+name = "requests"
+
+[tool.poetry.group.dev.dependencies]
+requests = "^2.0"`,
+			expected: `[build-system]
+requires = ["setuptools", "requests==2.0"]
+
+[project]
+name = "requests"
+keywords = ["requests", "http"]
+optional-dependencies = { http = ["requests[socks]==2.0"] }
+
+[project.optional-dependencies]
+test = [
+    "requests==2.0",  # mocked ]
+]
+
+[tool.mypy]
+modules = ["requests"]
+
+[tool.poetry]
+name = "requests"
+
+[tool.poetry.group.dev.dependencies]
+requests = "2.0"`,
+		},
+		{
+			name:       "go.mod",
+			filename:   "go.mod",
+			identifier: "golang.org/x/net",
+			content: `module example.com/app
+
+require golang.org/x/net v0.1.0
+
+require (
+	golang.org/x/net v0.1.0 // indirect
+)
+
+exclude golang.org/x/net v0.0.1
+
+replace (
+	golang.org/x/net v0.1.0 => ../net
+)`,
+			expected: `module example.com/app
+
+require golang.org/x/net v2.0
+
+require (
+	golang.org/x/net v2.0 // indirect
+)
+
+exclude golang.org/x/net v0.0.1
+
+replace (
+	golang.org/x/net v0.1.0 => ../net
+)`,
+		},
+		{
+			name:       "csproj",
+			filename:   "App.csproj",
+			identifier: "Newtonsoft.Json",
+			content: `<Project>
+  <ItemGroup>
+    <PackageReference Include="newtonsoft.json" Version="12.0.1" />
+    <PackageReference Include="Newtonsoft.Json">
+      <Version>12.0.1</Version>
+    </PackageReference>
+    <PackageReference Include="Newtonsoft.Json.Bson" Version="1.0.1" />
+  </ItemGroup>
+</Project>`,
+			expected: `<Project>
+  <ItemGroup>
+    <PackageReference Include="newtonsoft.json" Version="2.0" />
+    <PackageReference Include="Newtonsoft.Json">
+      <Version>2.0</Version>
+    </PackageReference>
+    <PackageReference Include="Newtonsoft.Json.Bson" Version="1.0.1" />
+  </ItemGroup>
+</Project>`,
+		},
+		{
+			name:       "central package management",
+			filename:   "Directory.Packages.props",
+			identifier: "Newtonsoft.Json",
+			content: `<Project>
+  <PropertyGroup>
+    <JsonVersion>12.0.1</JsonVersion>
+  </PropertyGroup>
+  <ItemGroup>
+    <PackageVersion Include="Newtonsoft.Json" Version="$(JsonVersion)" />
+  </ItemGroup>
+</Project>`,
+			expected: `<Project>
+  <PropertyGroup>
+    <JsonVersion>2.0</JsonVersion>
+  </PropertyGroup>
+  <ItemGroup>
+    <PackageVersion Include="Newtonsoft.Json" Version="$(JsonVersion)" />
+  </ItemGroup>
+</Project>`,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			output, err := NewPackage(test.filename, test.content, test.identifier, "2.0").Parser()
+			assert.NilError(t, err)
+			assert.Equal(t, output, test.expected)
+		})
+	}
+}
+
+func TestPackageParsersNotFound(t *testing.T) {
+	for _, filename := range []string{"pom.xml", "build.gradle", "requirements.txt", "pyproject.toml", "go.mod", "App.csproj"} {
+		t.Run(filename, func(t *testing.T) {
+			_, err := NewPackage(filename, "", "org.missing:missing", "2.0").Parser()
+			assert.Error(t, err, "Package org.missing:missing not found")
+		})
+	}
+}
+
+func TestApplyEdits(t *testing.T) {
+	content := "version=1.0 other=1.0"
+	edits := []edit{
+		{start: 8, end: 11, text: "2.0"},
+		{start: 8, end: 11, text: "2.0"},
+		{start: 18, end: 21, text: "3.0"},
+		// Overlaps the edit of the other version, which starts later in the file and is applied first
+		{start: 12, end: 20, text: "broken"},
+	}
+
+	assert.Equal(t, applyEdits(content, edits), "version=2.0 other=3.0")
+}
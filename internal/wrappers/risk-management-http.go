@@ -66,11 +66,11 @@ func setRMDefaultPath(path string) string {
 	if path != riskManagementDefaultPath {
 		configFilePath, err := configuration.GetConfigFilePath()
 		if err != nil {
-			logger.PrintfIfVerbose("Error getting config file path: %v", err)
+			logger.Warnf("Failed getting the config file path: %v", err)
 		}
 		err = configuration.SafeWriteSingleConfigKeyString(configFilePath, commonParams.RiskManagementPathKey, riskManagementDefaultPath)
 		if err != nil {
-			logger.PrintfIfVerbose("Error writing Risk Management path to config file: %v", err)
+			logger.Warnf("Failed writing the Risk Management path to the config file: %v", err)
 		}
 	}
 	return riskManagementDefaultPath
@@ -70,11 +70,11 @@ func setDefaultPath(path string) string {
 	if path != defaultPath {
 		configFilePath, err := configuration.GetConfigFilePath()
 		if err != nil {
-			logger.PrintfIfVerbose("Error getting config file path: %v", err)
+			logger.Warnf("Failed getting the config file path: %v", err)
 		}
 		err = configuration.SafeWriteSingleConfigKeyString(configFilePath, commonParams.ScsScanOverviewPathKey, defaultPath)
 		if err != nil {
-			logger.PrintfIfVerbose("Error writing Scan Overview path to config file: %v", err)
+			logger.Warnf("Failed writing the Scan Overview path to the config file: %v", err)
 		}
 	}
 	return defaultPath
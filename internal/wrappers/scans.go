@@ -85,7 +85,15 @@ type ScanResponseModel struct {
 }
 
 type ScanResponseModelMetadata struct {
-	Configs []Config `json:"configs"`
+	Type    string               `json:"type,omitempty"`
+	Handler *ScanMetadataHandler `json:"handler,omitempty"`
+	Configs []Config             `json:"configs"`
+}
+
+// ScanMetadataHandler is the source a scan was created from, only one of the handlers is set.
+type ScanMetadataHandler struct {
+	GitHandler    *GitProjectHandler `json:"gitHandler,omitempty"`
+	UploadHandler *ScanHandler       `json:"uploadHandler,omitempty"`
 }
 
 type ScansCollectionResponseModel struct {
@@ -164,6 +164,30 @@ func NewUploadsHTTPWrapper(path string) UploadsWrapper {
 	}
 }
 
+// DownloadFile saves to targetFile the sources archive found at uploadURL, the pre-signed URL of a previous upload.
+func (u *UploadsHTTPWrapper) DownloadFile(uploadURL, targetFile string) error {
+	clientTimeout := viper.GetUint(commonParams.ClientTimeoutKey)
+	resp, err := SendHTTPRequestByFullURL(http.MethodGet, uploadURL, http.NoBody, false, clientTimeout, "", false)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+	if resp.StatusCode != http.StatusOK {
+		return errors.Errorf("response status code %d", resp.StatusCode)
+	}
+	file, err := os.Create(targetFile)
+	if err != nil {
+		return errors.Errorf("Failed to create file %s: %s", targetFile, err.Error())
+	}
+	defer func() {
+		_ = file.Close()
+	}()
+	_, err = io.Copy(file, resp.Body)
+	return err
+}
+
 func (u *UploadsHTTPWrapper) UploadFileInMultipart(sourcesFile string, featureFlagsWrapper FeatureFlagsWrapper) (*string, error) {
 	fileInfo, _ := os.Stat(sourcesFile)
 
@@ -416,7 +440,7 @@ func getPartSizeBytes() int64 {
 	partChunkSizeStr := viper.GetString(commonParams.MultipartFileSizeKey)
 	partChunkSizeFloat, err := strconv.ParseFloat(partChunkSizeStr, 64)
 	if err != nil {
-		logger.PrintIfVerbose(fmt.Sprintf("Configured part size '%s' is invalid or empty. Defaulting to 2 GB.", partChunkSizeStr))
+		logger.Warnf("Configured part size '%s' is invalid or empty. Defaulting to 2 GB.", partChunkSizeStr)
 		partChunkSizeFloat = 2
 	}
 	truncatedSize := int64(partChunkSizeFloat)
@@ -492,7 +516,7 @@ func createParts(f *os.File, partSizes []int64) ([]string, error) {
 
 func closeFileVerbose(f *os.File) {
 	if err := f.Close(); err != nil {
-		logger.PrintfIfVerbose("Warning: failed to close input file - %v", err)
+		logger.Warnf("Failed to close input file - %v", err)
 	}
 }
 
@@ -524,7 +548,7 @@ func cleanUpTempParts(partList []string) {
 				}
 			}
 			if tries == 0 {
-				logger.PrintIfVerbose(fmt.Sprintf("Failed to remove temporary part%d - %s", i+1, partPath))
+				logger.Warnf("Failed to remove temporary part%d - %s", i+1, partPath)
 			}
 		} else {
 			logger.PrintIfVerbose(fmt.Sprintf("No temporary part%d to clean", i+1))
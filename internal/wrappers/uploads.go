@@ -3,4 +3,5 @@ package wrappers
 type UploadsWrapper interface {
 	UploadFile(sourcesFile string, featureFlagsWrapper FeatureFlagsWrapper) (*string, error)
 	UploadFileInMultipart(path string, wrapper FeatureFlagsWrapper) (*string, error)
+	DownloadFile(uploadURL, targetFile string) error
 }
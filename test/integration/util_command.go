@@ -112,6 +112,7 @@ func createASTIntegrationTestCommand(t *testing.T) *cobra.Command {
 	codeBashingWrapper := wrappers.NewCodeBashingHTTPWrapper(codebashing)
 	gitHubWrapper := wrappers.NewGitHubWrapper()
 	gitLabWrapper := wrappers.NewGitLabWrapper()
+	giteaWrapper := wrappers.NewGiteaWrapper()
 	azureWrapper := wrappers.NewAzureWrapper()
 	bitBucketWrapper := wrappers.NewBitbucketWrapper()
 	bflWrapper := wrappers.NewBflHTTPWrapper(bfl)
@@ -155,6 +156,7 @@ func createASTIntegrationTestCommand(t *testing.T) *cobra.Command {
 		bitBucketWrapper,
 		nil,
 		gitLabWrapper,
+		giteaWrapper,
 		bflWrapper,
 		prWrapper,
 		learnMoreWrapper,